@@ -0,0 +1,21 @@
+package main
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service"
+)
+
+// initEncryption migrates the secrets still encrypted with any of the
+// previous encryption keys to the current one, so everything that runs after
+// it can decrypt them with PBW_ENCRYPTION_KEY.
+func initEncryption(servs *service.Service) {
+	_, err := servs.EncryptionService.MigratePreviousKeys(context.Background())
+	if err != nil {
+		logger.FatalError(
+			"error migrating secrets from previous encryption keys",
+			logger.KV{"error": err},
+		)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+)
+
+// initProvisioning reconciles the configuration file at startup and every
+// time the process receives a SIGHUP signal.
+func initProvisioning(servs *service.Service) {
+	if !servs.ProvisioningService.IsEnabled() {
+		return
+	}
+
+	ctx := users.WithSystem(context.Background())
+	_, err := servs.ProvisioningService.Reconcile(ctx, false)
+	if err != nil {
+		logger.FatalError(
+			"error reconciling configuration file", logger.KV{"error": err},
+		)
+	}
+
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			logger.Info("reloading configuration file")
+			_, err := servs.ProvisioningService.Reconcile(ctx, false)
+			if err != nil {
+				logger.Error(
+					"error reconciling configuration file", logger.KV{"error": err},
+				)
+			}
+		}
+	}()
+}
@@ -14,12 +14,16 @@ func initSchedule(cr *cron.Cron, servs *service.Service) {
 
 	servs.ExecutionsService.SoftDeleteExpiredExecutions()
 	servs.AuthService.DeleteOldSessions()
+	servs.AuditService.DeleteOldEntries()
+	servs.HealthService.DeleteOldChecks()
 
 	// Run initial health checks in the background so slow/unreachable
 	// dependencies do not block HTTP server startup.
 	go func() {
-		servs.DatabasesService.TestAllDatabases()
-		servs.DestinationsService.TestAllDestinations()
+		servs.DatabasesService.TestDueDatabases()
+		servs.DestinationsService.TestDueDestinations()
+		servs.BackupsService.CheckStaleBackups()
+		servs.WebhooksService.ProcessDeliveryQueue()
 	}()
 
 	/*
@@ -45,8 +49,30 @@ func initSchedule(cr *cron.Cron, servs *service.Service) {
 		)
 	}
 
-	err = cr.UpsertJob(uuid.New(), "UTC", "*/10 * * * *", func() {
-		servs.DatabasesService.TestAllDatabases()
+	err = cr.UpsertJob(uuid.New(), "UTC", "0 * * * *", func() {
+		servs.AuditService.DeleteOldEntries()
+	})
+	if err != nil {
+		logger.FatalError(
+			"error scheduling deletion of old audit log entries",
+			logger.KV{"error": err},
+		)
+	}
+
+	err = cr.UpsertJob(uuid.New(), "UTC", "0 * * * *", func() {
+		servs.HealthService.DeleteOldChecks()
+	})
+	if err != nil {
+		logger.FatalError(
+			"error scheduling deletion of old health checks",
+			logger.KV{"error": err},
+		)
+	}
+
+	// The databases and destinations are tested every minute, only the ones
+	// whose health check interval has passed.
+	err = cr.UpsertJob(uuid.New(), "UTC", "* * * * *", func() {
+		servs.DatabasesService.TestDueDatabases()
 	})
 	if err != nil {
 		logger.FatalError(
@@ -54,8 +80,8 @@ func initSchedule(cr *cron.Cron, servs *service.Service) {
 		)
 	}
 
-	err = cr.UpsertJob(uuid.New(), "UTC", "*/10 * * * *", func() {
-		servs.DestinationsService.TestAllDestinations()
+	err = cr.UpsertJob(uuid.New(), "UTC", "* * * * *", func() {
+		servs.DestinationsService.TestDueDestinations()
 	})
 	if err != nil {
 		logger.FatalError(
@@ -63,5 +89,24 @@ func initSchedule(cr *cron.Cron, servs *service.Service) {
 		)
 	}
 
+	err = cr.UpsertJob(uuid.New(), "UTC", "*/10 * * * *", func() {
+		servs.BackupsService.CheckStaleBackups()
+	})
+	if err != nil {
+		logger.FatalError(
+			"error scheduling stale backups check", logger.KV{"error": err},
+		)
+	}
+
+	err = cr.UpsertJob(uuid.New(), "UTC", "* * * * *", func() {
+		servs.WebhooksService.ProcessDeliveryQueue()
+	})
+	if err != nil {
+		logger.FatalError(
+			"error scheduling webhook delivery retries", logger.KV{"error": err},
+		)
+	}
+
 	servs.BackupsService.ScheduleAll()
+	servs.ReportsService.ScheduleAll()
 }
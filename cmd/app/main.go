@@ -42,8 +42,10 @@ func main() {
 	}()
 	dbgen := dbgen.New(db)
 
-	ints := integration.New()
-	servs := service.New(env, dbgen, cr, ints)
+	ints := integration.New(env)
+	servs := service.New(env, db, dbgen, cr, ints)
+	initEncryption(servs)
+	initProvisioning(servs)
 	initSchedule(cr, servs)
 
 	app := echo.New()
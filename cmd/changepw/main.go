@@ -67,10 +67,20 @@ func main() {
 		panic(err)
 	}
 
+	// Whoever can run this command can also log in without the second factor,
+	// so it's reset too in case the user lost the authenticator.
+	if _, err := dbg.UsersServiceResetTwoFactor(context.Background(), userID); err != nil {
+		panic(err)
+	}
+	if err := dbg.UsersServiceDeleteUserRecoveryCodes(context.Background(), userID); err != nil {
+		panic(err)
+	}
+
 	fmt.Println()
 	fmt.Println("Password reset successfully")
 	fmt.Println("New password: ", newPassword)
 	fmt.Println()
 	fmt.Println("You can change your password after login")
+	fmt.Println("Two-factor authentication was disabled, set it up again after login")
 	fmt.Println()
 }
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/backups"
+	"github.com/google/uuid"
+)
+
+func listBackupsCmd(
+	ctx context.Context, servs *service.Service, args []string,
+) int {
+	fs := newFlagSet("backups list")
+	page, limit := paginationFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	pagination, items, err := servs.BackupsService.PaginateBackups(
+		ctx, backups.PaginateBackupsParams{Page: *page, Limit: *limit},
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing backups: %s\n", err)
+		return exitFailed
+	}
+
+	rows := [][]string{}
+	for _, item := range items {
+		destination := "local"
+		if !item.IsLocal {
+			destination = formatNullString(item.DestinationName)
+		}
+		rows = append(rows, []string{
+			item.ID.String(), item.Name, item.DatabaseName, destination,
+			item.CronExpression, strconv.FormatBool(item.IsActive),
+			strconv.FormatBool(item.IsStale),
+		})
+	}
+	printTable([]string{
+		"ID", "NAME", "DATABASE", "DESTINATION", "SCHEDULE", "ACTIVE", "STALE",
+	}, rows)
+	printPagination(pagination)
+
+	return exitOK
+}
+
+func runBackupCmd(
+	ctx context.Context, servs *service.Service, args []string,
+) int {
+	backupID, err := parseWithID(newFlagSet("backups run"), args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+
+	if _, err := servs.BackupsService.GetBackup(ctx, backupID); err != nil {
+		fmt.Fprintf(os.Stderr, "error getting backup: %s\n", err)
+		return exitFailed
+	}
+
+	// The executions are ordered by start time, so the newest one after the
+	// run is the one created by it.
+	previousID, err := latestExecutionID(ctx, servs, backupID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing backup executions: %s\n", err)
+		return exitFailed
+	}
+
+	if err := servs.ExecutionsService.RunExecution(ctx, backupID); err != nil {
+		fmt.Fprintf(os.Stderr, "error running backup: %s\n", err)
+		return exitFailed
+	}
+
+	executions, err := servs.ExecutionsService.ListBackupExecutions(ctx, backupID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing backup executions: %s\n", err)
+		return exitFailed
+	}
+	if len(executions) == 0 || executions[0].ID == previousID {
+		fmt.Fprintln(os.Stderr, "the backup execution was not created")
+		return exitFailed
+	}
+	execution := executions[0]
+
+	fmt.Printf(
+		"execution %s finished with status %s: %s\n",
+		execution.ID, execution.Status, formatNullString(execution.Message),
+	)
+	if execution.Status != "success" {
+		return exitFailed
+	}
+	return exitOK
+}
+
+// latestExecutionID returns the ID of the newest execution of the backup or
+// uuid.Nil if it has none.
+func latestExecutionID(
+	ctx context.Context, servs *service.Service, backupID uuid.UUID,
+) (uuid.UUID, error) {
+	executions, err := servs.ExecutionsService.ListBackupExecutions(ctx, backupID)
+	if err != nil || len(executions) == 0 {
+		return uuid.Nil, err
+	}
+	return executions[0].ID, nil
+}
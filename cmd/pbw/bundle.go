@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/bundles"
+)
+
+// passphraseEnv is the environment variable read when -passphrase-file is
+// not set.
+const passphraseEnv = "PBW_BUNDLE_PASSPHRASE"
+
+func exportBundleCmd(
+	ctx context.Context, servs *service.Service, args []string,
+) int {
+	fs := newFlagSet("bundle export")
+	includeExecutions := fs.Bool(
+		"executions", false, "include references to the successful executions",
+	)
+	passphraseFile := fs.String(
+		"passphrase-file", "", "file with the passphrase (default $"+passphraseEnv+")",
+	)
+	output := fs.String("o", "", "output file (default stdout)")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	passphrase, err := readSecret(*passphraseFile, passphraseEnv)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+
+	bundle, err := servs.BundlesService.ExportBundle(
+		ctx, passphrase, *includeExecutions,
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error exporting bundle: %s\n", err)
+		return exitFailed
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.OpenFile(*output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error creating output file: %s\n", err)
+			return exitFailed
+		}
+		defer file.Close()
+		w = file
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(bundle); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing bundle: %s\n", err)
+		return exitFailed
+	}
+
+	return exitOK
+}
+
+func importBundleCmd(
+	ctx context.Context, servs *service.Service, args []string,
+) int {
+	fs := newFlagSet("bundle import")
+	strategy := fs.String(
+		"strategy", string(bundles.ConflictSkip),
+		"what to do when the name already exists: skip, overwrite or rename",
+	)
+	passphraseFile := fs.String(
+		"passphrase-file", "", "file with the passphrase (default $"+passphraseEnv+")",
+	)
+
+	var path string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		path, args = args[0], args[1:]
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if path == "" && fs.NArg() > 0 {
+		path = fs.Arg(0)
+	}
+	if path == "" {
+		fmt.Fprintln(os.Stderr, "missing bundle file argument")
+		return exitUsage
+	}
+
+	passphrase, err := readSecret(*passphraseFile, passphraseEnv)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error opening bundle file: %s\n", err)
+		return exitUsage
+	}
+	defer file.Close()
+
+	bundle, err := bundles.ParseBundle(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+
+	result, err := servs.BundlesService.ImportBundle(
+		ctx, bundle, passphrase, bundles.ConflictStrategy(*strategy),
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error importing bundle: %s\n", err)
+		return exitFailed
+	}
+
+	fmt.Print(result.String())
+	return exitOK
+}
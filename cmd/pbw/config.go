@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/eduardolat/pgbackweb/internal/service"
+)
+
+func diffConfigCmd(
+	ctx context.Context, servs *service.Service, args []string,
+) int {
+	if err := newFlagSet("config diff").Parse(args); err != nil {
+		return exitUsage
+	}
+
+	plan, err := servs.ProvisioningService.Reconcile(ctx, true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error computing configuration diff: %s\n", err)
+		return exitFailed
+	}
+
+	fmt.Print(plan.String())
+	return exitOK
+}
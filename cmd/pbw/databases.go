@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/databases"
+)
+
+func listDatabasesCmd(
+	ctx context.Context, servs *service.Service, args []string,
+) int {
+	fs := newFlagSet("databases list")
+	page, limit := paginationFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	pagination, items, err := servs.DatabasesService.PaginateDatabases(
+		ctx, databases.PaginateDatabasesParams{Page: *page, Limit: *limit},
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing databases: %s\n", err)
+		return exitFailed
+	}
+
+	rows := [][]string{}
+	for _, item := range items {
+		rows = append(rows, []string{
+			item.ID.String(), item.Name, item.PgVersion,
+			formatTestOk(item.TestOk), formatNullTime(item.LastTestAt),
+		})
+	}
+	printTable([]string{"ID", "NAME", "PG VERSION", "HEALTH", "LAST TEST"}, rows)
+	printPagination(pagination)
+
+	return exitOK
+}
+
+func testDatabaseCmd(
+	ctx context.Context, servs *service.Service, args []string,
+) int {
+	databaseID, err := parseWithID(newFlagSet("databases test"), args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+
+	err = servs.DatabasesService.TestDatabaseAndStoreResult(ctx, databaseID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "database test failed: %s\n", err)
+		return exitFailed
+	}
+
+	fmt.Println("database connection ok")
+	return exitOK
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/destinations"
+)
+
+func listDestinationsCmd(
+	ctx context.Context, servs *service.Service, args []string,
+) int {
+	fs := newFlagSet("destinations list")
+	page, limit := paginationFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	pagination, items, err := servs.DestinationsService.PaginateDestinations(
+		ctx, destinations.PaginateDestinationsParams{Page: *page, Limit: *limit},
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing destinations: %s\n", err)
+		return exitFailed
+	}
+
+	rows := [][]string{}
+	for _, item := range items {
+		rows = append(rows, []string{
+			item.ID.String(), item.Name, item.BucketName, item.Endpoint,
+			formatTestOk(item.TestOk), formatNullTime(item.LastTestAt),
+		})
+	}
+	printTable(
+		[]string{"ID", "NAME", "BUCKET", "ENDPOINT", "HEALTH", "LAST TEST"}, rows,
+	)
+	printPagination(pagination)
+
+	return exitOK
+}
+
+func testDestinationCmd(
+	ctx context.Context, servs *service.Service, args []string,
+) int {
+	destinationID, err := parseWithID(newFlagSet("destinations test"), args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+
+	err = servs.DestinationsService.TestDestinationAndStoreResult(
+		ctx, destinationID,
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "destination test failed: %s\n", err)
+		return exitFailed
+	}
+
+	fmt.Println("destination connection ok")
+	return exitOK
+}
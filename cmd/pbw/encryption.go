@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/eduardolat/pgbackweb/internal/service"
+)
+
+// newKeyEnv is the environment variable read when -new-key-file is not set.
+const newKeyEnv = "PBW_NEW_ENCRYPTION_KEY"
+
+func rotateKeyCmd(
+	ctx context.Context, servs *service.Service, args []string,
+) int {
+	fs := newFlagSet("encryption rotate-key")
+	dryRun := fs.Bool(
+		"dry-run", false, "verify the rotation and roll it back without changes",
+	)
+	newKeyFile := fs.String(
+		"new-key-file", "", "file with the new key (default $"+newKeyEnv+")",
+	)
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	newKey, err := readSecret(*newKeyFile, newKeyEnv)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+
+	result, err := servs.EncryptionService.RotateKey(ctx, newKey, *dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error rotating encryption key: %s\n", err)
+		return exitFailed
+	}
+
+	fmt.Println(result.String())
+	if *dryRun {
+		fmt.Println("dry run, nothing was changed")
+		return exitOK
+	}
+	fmt.Println(
+		"set PBW_ENCRYPTION_KEY to the new key and restart PG Back Web now",
+	)
+	return exitOK
+}
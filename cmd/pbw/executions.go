@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/executions"
+	"github.com/eduardolat/pgbackweb/internal/service/restorations"
+	"github.com/eduardolat/pgbackweb/internal/util/strutil"
+	"github.com/google/uuid"
+)
+
+func listExecutionsCmd(
+	ctx context.Context, servs *service.Service, args []string,
+) int {
+	fs := newFlagSet("executions list")
+	page, limit := paginationFlags(fs)
+	var backupID, databaseID, destinationID optionalUUID
+	fs.Var(&backupID, "backup", "filter by backup ID")
+	fs.Var(&databaseID, "database", "filter by database ID")
+	fs.Var(&destinationID, "destination", "filter by destination ID")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	pagination, items, err := servs.ExecutionsService.PaginateExecutions(
+		ctx, executions.PaginateExecutionsParams{
+			Page:              *page,
+			Limit:             *limit,
+			BackupFilter:      backupID.NullUUID,
+			DatabaseFilter:    databaseID.NullUUID,
+			DestinationFilter: destinationID.NullUUID,
+		},
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing executions: %s\n", err)
+		return exitFailed
+	}
+
+	rows := [][]string{}
+	for _, item := range items {
+		size := "-"
+		if item.FileSize.Valid {
+			size = strutil.FormatFileSize(item.FileSize.Int64)
+		}
+		rows = append(rows, []string{
+			item.ID.String(), item.BackupName, item.DatabaseName, item.Status,
+			strconv.FormatBool(item.IsSuspicious), size,
+			formatNullTime(item.FinishedAt),
+		})
+	}
+	printTable([]string{
+		"ID", "BACKUP", "DATABASE", "STATUS", "SUSPICIOUS", "SIZE", "FINISHED AT",
+	}, rows)
+	printPagination(pagination)
+
+	return exitOK
+}
+
+func downloadExecutionCmd(
+	ctx context.Context, servs *service.Service, args []string,
+) int {
+	fs := newFlagSet("executions download")
+	outDir := fs.String("o", ".", "directory where the files are saved")
+	executionID, err := parseWithID(fs, args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+
+	isLocal, links, err := servs.ExecutionsService.GetAllExecutionLinksOrPaths(
+		ctx, executionID,
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error getting execution files: %s\n", err)
+		return exitFailed
+	}
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "error creating output directory: %s\n", err)
+		return exitFailed
+	}
+
+	for _, link := range links {
+		dest, err := downloadFile(ctx, isLocal, link, *outDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error downloading execution file: %s\n", err)
+			return exitFailed
+		}
+		fmt.Println(dest)
+	}
+
+	return exitOK
+}
+
+// downloadFile copies a local file or downloads a remote one into the given
+// directory and returns the path of the new file.
+func downloadFile(
+	ctx context.Context, isLocal bool, linkOrPath, outDir string,
+) (string, error) {
+	var src io.ReadCloser
+	var fileName string
+
+	if isLocal {
+		f, err := os.Open(linkOrPath)
+		if err != nil {
+			return "", err
+		}
+		src, fileName = f, filepath.Base(linkOrPath)
+	} else {
+		u, err := url.Parse(linkOrPath)
+		if err != nil {
+			return "", err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, linkOrPath, nil)
+		if err != nil {
+			return "", err
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return "", err
+		}
+		if res.StatusCode != http.StatusOK {
+			res.Body.Close()
+			return "", fmt.Errorf("unexpected status downloading file: %s", res.Status)
+		}
+		src, fileName = res.Body, path.Base(u.Path)
+	}
+	defer src.Close()
+
+	dest := filepath.Join(outDir, fileName)
+	f, err := os.Create(dest)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, src); err != nil {
+		return "", err
+	}
+	return dest, f.Close()
+}
+
+func restoreExecutionCmd(
+	ctx context.Context, servs *service.Service, args []string,
+) int {
+	fs := newFlagSet("executions restore")
+	var databaseID optionalUUID
+	fs.Var(&databaseID, "database", "ID of the database to restore into")
+	connString := fs.String("conn-string", "", "connection string of the database to restore into")
+	executionID, err := parseWithID(fs, args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+
+	if databaseID.Valid == (*connString != "") {
+		fmt.Fprintln(os.Stderr, "exactly one of -database or -conn-string is required")
+		return exitUsage
+	}
+
+	execution, err := servs.ExecutionsService.GetExecution(ctx, executionID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error getting execution: %s\n", err)
+		return exitFailed
+	}
+
+	if *connString != "" {
+		err := servs.DatabasesService.TestDatabase(
+			ctx, execution.DatabasePgVersion, *connString,
+		)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error testing connection string: %s\n", err)
+			return exitFailed
+		}
+	}
+
+	// The restorations are ordered by start time, so the newest one after the
+	// run is the one created by it.
+	previousID, err := latestRestorationID(ctx, servs, executionID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing restorations: %s\n", err)
+		return exitFailed
+	}
+
+	err = servs.RestorationsService.RunRestoration(
+		ctx, executionID, databaseID.NullUUID, *connString,
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error running restoration: %s\n", err)
+		return exitFailed
+	}
+
+	_, items, err := servs.RestorationsService.PaginateRestorations(
+		ctx, restorations.PaginateRestorationsParams{
+			Page:            1,
+			Limit:           1,
+			ExecutionFilter: uuid.NullUUID{UUID: executionID, Valid: true},
+		},
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing restorations: %s\n", err)
+		return exitFailed
+	}
+	if len(items) == 0 || items[0].ID == previousID {
+		fmt.Fprintln(os.Stderr, "the restoration was not created")
+		return exitFailed
+	}
+	restoration := items[0]
+
+	fmt.Printf(
+		"restoration %s finished with status %s: %s\n",
+		restoration.ID, restoration.Status, formatNullString(restoration.Message),
+	)
+	if restoration.Status != "success" {
+		return exitFailed
+	}
+	return exitOK
+}
+
+// latestRestorationID returns the ID of the newest restoration of the
+// execution or uuid.Nil if it has none.
+func latestRestorationID(
+	ctx context.Context, servs *service.Service, executionID uuid.UUID,
+) (uuid.UUID, error) {
+	_, items, err := servs.RestorationsService.PaginateRestorations(
+		ctx, restorations.PaginateRestorationsParams{
+			Page:            1,
+			Limit:           1,
+			ExecutionFilter: uuid.NullUUID{UUID: executionID, Valid: true},
+		},
+	)
+	if err != nil || len(items) == 0 {
+		return uuid.Nil, err
+	}
+	return items[0].ID, nil
+}
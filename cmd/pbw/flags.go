@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// newFlagSet creates a flag set that prints its errors to stderr and
+// doesn't exit on error, so the command can return exitUsage.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// parseWithID parses the flags of a command that receives an ID as its
+// first argument. The ID can be placed before or after the flags.
+func parseWithID(fs *flag.FlagSet, args []string) (uuid.UUID, error) {
+	var rawID string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		rawID, args = args[0], args[1:]
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return uuid.Nil, err
+	}
+	if rawID == "" && fs.NArg() > 0 {
+		rawID = fs.Arg(0)
+	}
+	if rawID == "" {
+		return uuid.Nil, fmt.Errorf("missing ID argument")
+	}
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid ID %q: %w", rawID, err)
+	}
+	return id, nil
+}
+
+// optionalUUID is a flag.Value for optional UUID flags.
+type optionalUUID struct {
+	uuid.NullUUID
+}
+
+func (v *optionalUUID) String() string {
+	if !v.Valid {
+		return ""
+	}
+	return v.UUID.String()
+}
+
+func (v *optionalUUID) Set(s string) error {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return err
+	}
+	v.NullUUID = uuid.NullUUID{UUID: id, Valid: true}
+	return nil
+}
+
+// paginationFlags adds the -page and -limit flags to the flag set.
+func paginationFlags(fs *flag.FlagSet) (page, limit *int) {
+	page = fs.Int("page", 1, "page number")
+	limit = fs.Int("limit", 20, "items per page (max 100)")
+	return page, limit
+}
+
+// readSecret returns the content of the file, or the value of the
+// environment variable when file is empty. Secrets are never accepted as
+// flags so they don't end up in the shell history or the process list.
+func readSecret(file, envName string) (string, error) {
+	if file == "" {
+		secret := os.Getenv(envName)
+		if secret == "" {
+			return "", fmt.Errorf("set %s or use the file flag", envName)
+		}
+		return secret, nil
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("error reading secret file: %w", err)
+	}
+	secret := strings.TrimRight(string(content), "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("the secret file %s is empty", file)
+	}
+	return secret, nil
+}
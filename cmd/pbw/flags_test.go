@@ -0,0 +1,111 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWithID(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantDir string
+		wantErr bool
+	}{
+		{
+			name:    "ID before flags",
+			args:    []string{id.String(), "-o", "/tmp"},
+			wantDir: "/tmp",
+		},
+		{
+			name:    "ID after flags",
+			args:    []string{"-o", "/tmp", id.String()},
+			wantDir: "/tmp",
+		},
+		{
+			name:    "ID without flags",
+			args:    []string{id.String()},
+			wantDir: ".",
+		},
+		{
+			name:    "missing ID",
+			args:    []string{"-o", "/tmp"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid ID",
+			args:    []string{"not-an-id"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{id.String(), "-unknown"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newFlagSet("test")
+			fs.SetOutput(io.Discard)
+			dir := fs.String("o", ".", "")
+
+			got, err := parseWithID(fs, tt.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, id, got)
+			assert.Equal(t, tt.wantDir, *dir)
+		})
+	}
+}
+
+func TestOptionalUUID(t *testing.T) {
+	var v optionalUUID
+	assert.False(t, v.Valid)
+	assert.Equal(t, "", v.String())
+
+	id := uuid.New()
+	assert.NoError(t, v.Set(id.String()))
+	assert.True(t, v.Valid)
+	assert.Equal(t, id.String(), v.String())
+
+	assert.Error(t, v.Set("invalid"))
+}
+
+func TestReadSecret(t *testing.T) {
+	t.Setenv("TEST_PBW_SECRET", "from-env")
+
+	file := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(file, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	emptyFile := filepath.Join(t.TempDir(), "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	secret, err := readSecret("", "TEST_PBW_SECRET")
+	assert.NoError(t, err)
+	assert.Equal(t, "from-env", secret)
+
+	secret, err = readSecret(file, "TEST_PBW_SECRET")
+	assert.NoError(t, err)
+	assert.Equal(t, "from-file", secret)
+
+	_, err = readSecret("", "TEST_PBW_MISSING")
+	assert.Error(t, err)
+
+	_, err = readSecret(emptyFile, "TEST_PBW_SECRET")
+	assert.Error(t, err)
+}
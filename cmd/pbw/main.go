@@ -0,0 +1,183 @@
+// pbw is a headless CLI to manage PG Back Web from the terminal.
+//
+// It uses the service layer directly against PBW_POSTGRES_CONN_STRING, so it
+// works even when the web server is down and only needs the same environment
+// variables as the server. Logs are written to stderr so stdout can be parsed
+// by scripts.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/eduardolat/pgbackweb/internal/config"
+	"github.com/eduardolat/pgbackweb/internal/cron"
+	"github.com/eduardolat/pgbackweb/internal/database"
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/integration"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+)
+
+// Exit codes of the CLI, scripts can rely on them.
+const (
+	exitOK     = 0 // the command succeeded
+	exitFailed = 1 // the command ran but the operation failed
+	exitUsage  = 2 // the command or its arguments are invalid
+)
+
+type command struct {
+	group       string
+	name        string
+	args        string
+	description string
+	run         func(ctx context.Context, servs *service.Service, args []string) int
+}
+
+var commands = []command{
+	{
+		group: "databases", name: "list", args: "[-page n] [-limit n]",
+		description: "List databases",
+		run:         listDatabasesCmd,
+	},
+	{
+		group: "databases", name: "test", args: "<database-id>",
+		description: "Test the connection to a database",
+		run:         testDatabaseCmd,
+	},
+	{
+		group: "destinations", name: "list", args: "[-page n] [-limit n]",
+		description: "List destinations",
+		run:         listDestinationsCmd,
+	},
+	{
+		group: "destinations", name: "test", args: "<destination-id>",
+		description: "Test the connection to a destination",
+		run:         testDestinationCmd,
+	},
+	{
+		group: "backups", name: "list", args: "[-page n] [-limit n]",
+		description: "List backups",
+		run:         listBackupsCmd,
+	},
+	{
+		group: "backups", name: "run", args: "<backup-id>",
+		description: "Run a backup and wait until it finishes",
+		run:         runBackupCmd,
+	},
+	{
+		group: "executions", name: "list",
+		args:        "[-backup id] [-database id] [-destination id] [-page n] [-limit n]",
+		description: "List executions",
+		run:         listExecutionsCmd,
+	},
+	{
+		group: "executions", name: "download", args: "<execution-id> [-o dir]",
+		description: "Download the files of an execution",
+		run:         downloadExecutionCmd,
+	},
+	{
+		group: "executions", name: "restore",
+		args:        "<execution-id> (-database id | -conn-string str)",
+		description: "Restore an execution and wait until it finishes",
+		run:         restoreExecutionCmd,
+	},
+	{
+		group: "config", name: "diff",
+		description: "Show the changes needed to match the configuration file",
+		run:         diffConfigCmd,
+	},
+	{
+		group: "bundle", name: "export",
+		args:        "[-executions] [-passphrase-file file] [-o file]",
+		description: "Export all the configuration as a passphrase protected bundle",
+		run:         exportBundleCmd,
+	},
+	{
+		group: "bundle", name: "import",
+		args:        "<file> [-strategy skip|overwrite|rename] [-passphrase-file file]",
+		description: "Import a bundle created with bundle export",
+		run:         importBundleCmd,
+	},
+	{
+		group: "encryption", name: "rotate-key",
+		args:        "[-dry-run] [-new-key-file file]",
+		description: "Re-encrypt all the secrets with a new encryption key",
+		run:         rotateKeyCmd,
+	},
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "PG Back Web CLI")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Usage: pbw <group> <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, cmd := range commands {
+		usage := strings.TrimSpace(cmd.group + " " + cmd.name + " " + cmd.args)
+		fmt.Fprintf(os.Stderr, "  %s\n      %s\n", usage, cmd.description)
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintf(
+		os.Stderr, "Exit codes: %d success, %d operation failed, %d invalid usage\n",
+		exitOK, exitFailed, exitUsage,
+	)
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) < 2 {
+		printUsage()
+		return exitUsage
+	}
+
+	var cmd *command
+	for i := range commands {
+		if commands[i].group == args[0] && commands[i].name == args[1] {
+			cmd = &commands[i]
+			break
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", strings.Join(args[:2], " "))
+		printUsage()
+		return exitUsage
+	}
+
+	logger.SetOutput(os.Stderr)
+
+	env, err := config.GetEnv()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error getting environment variables: %s\n", err)
+		return exitUsage
+	}
+
+	// The scheduler is required by the services but never started, the
+	// scheduled backups are run only by the server.
+	cr, err := cron.New()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error initializing cron scheduler: %s\n", err)
+		return exitFailed
+	}
+
+	db := database.Connect(env)
+	defer db.Close()
+
+	servs := service.New(env, db, dbgen.New(db), cr, integration.New(env))
+	defer servs.WebhooksService.Wait()
+
+	ctx, stop := signal.NotifyContext(
+		users.WithSystem(context.Background()), os.Interrupt, syscall.SIGTERM,
+	)
+	defer stop()
+
+	return cmd.run(ctx, servs, args[2:])
+}
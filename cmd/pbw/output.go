@@ -0,0 +1,55 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
+	"github.com/eduardolat/pgbackweb/internal/util/timeutil"
+)
+
+// printTable prints the rows to stdout as aligned columns.
+func printTable(headers []string, rows [][]string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	_ = w.Flush()
+}
+
+// printPagination prints the pagination info to stderr so it doesn't mix
+// with the table rows.
+func printPagination(pagination paginateutil.PaginateResponse) {
+	fmt.Fprintf(
+		os.Stderr, "page %d of %d (%d items)\n",
+		pagination.CurrentPage, pagination.TotalPages, pagination.TotalItems,
+	)
+}
+
+func formatTestOk(testOk sql.NullBool) string {
+	if !testOk.Valid {
+		return "untested"
+	}
+	if testOk.Bool {
+		return "healthy"
+	}
+	return "unhealthy"
+}
+
+func formatNullTime(t sql.NullTime) string {
+	if !t.Valid {
+		return "-"
+	}
+	return t.Time.Format(timeutil.LayoutRFC3339)
+}
+
+func formatNullString(s sql.NullString) string {
+	if !s.Valid || s.String == "" {
+		return "-"
+	}
+	return s.String
+}
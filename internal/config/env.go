@@ -2,6 +2,7 @@ package config
 
 import (
 	"sync"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
@@ -13,6 +14,63 @@ type Env struct {
 	PBW_LISTEN_HOST          string `env:"PBW_LISTEN_HOST" envDefault:"0.0.0.0"`
 	PBW_LISTEN_PORT          string `env:"PBW_LISTEN_PORT" envDefault:"8085"`
 	PBW_PATH_PREFIX          string `env:"PBW_PATH_PREFIX" envDefault:""`
+	PBW_METRICS_TOKEN        string `env:"PBW_METRICS_TOKEN" envDefault:""`
+	PBW_CONFIG_FILE          string `env:"PBW_CONFIG_FILE" envDefault:""`
+	PBW_VAULT_ADDR           string `env:"PBW_VAULT_ADDR" envDefault:""`
+	PBW_VAULT_TOKEN          string `env:"PBW_VAULT_TOKEN" envDefault:""`
+
+	// The environment variables and files that the env:// and file:// secret
+	// references can read, as comma separated name prefixes and directories.
+	PBW_SECRETS_ENV_PREFIXES []string `env:"PBW_SECRETS_ENV_PREFIXES" envSeparator:","`
+	PBW_SECRETS_FILE_DIRS    []string `env:"PBW_SECRETS_FILE_DIRS" envSeparator:"," envDefault:"/run/secrets"`
+
+	// PBW_PREVIOUS_ENCRYPTION_KEYS are comma separated keys that were used as
+	// PBW_ENCRYPTION_KEY before, the secrets encrypted with them are migrated
+	// to the current key at startup.
+	PBW_PREVIOUS_ENCRYPTION_KEYS []string `env:"PBW_PREVIOUS_ENCRYPTION_KEYS" envSeparator:","`
+
+	// OpenID Connect single sign-on, it is enabled when the discovery URL is
+	// set. PBW_OIDC_ROLE_MAPPING maps the values of the groups claim to roles,
+	// e.g. "pbw-admins=admin,pbw-ops=operator".
+	PBW_OIDC_DISCOVERY_URL     string            `env:"PBW_OIDC_DISCOVERY_URL" envDefault:""`
+	PBW_OIDC_CLIENT_ID         string            `env:"PBW_OIDC_CLIENT_ID" envDefault:""`
+	PBW_OIDC_CLIENT_SECRET     string            `env:"PBW_OIDC_CLIENT_SECRET" envDefault:""`
+	PBW_OIDC_SCOPES            []string          `env:"PBW_OIDC_SCOPES" envSeparator:"," envDefault:"openid,profile,email"`
+	PBW_OIDC_REDIRECT_URL      string            `env:"PBW_OIDC_REDIRECT_URL" envDefault:""`
+	PBW_OIDC_PROVIDER_NAME     string            `env:"PBW_OIDC_PROVIDER_NAME" envDefault:"SSO"`
+	PBW_OIDC_GROUPS_CLAIM      string            `env:"PBW_OIDC_GROUPS_CLAIM" envDefault:"groups"`
+	PBW_OIDC_ROLE_MAPPING      map[string]string `env:"PBW_OIDC_ROLE_MAPPING" envSeparator:"," envKeyValSeparator:"="`
+	PBW_OIDC_DEFAULT_ROLE      string            `env:"PBW_OIDC_DEFAULT_ROLE" envDefault:"viewer"`
+	PBW_DISABLE_PASSWORD_LOGIN bool              `env:"PBW_DISABLE_PASSWORD_LOGIN" envDefault:"false"`
+
+	// PBW_AUDIT_LOG_RETENTION_DAYS is how long the audit log entries are
+	// kept, zero keeps them forever.
+	PBW_AUDIT_LOG_RETENTION_DAYS int `env:"PBW_AUDIT_LOG_RETENTION_DAYS" envDefault:"365"`
+
+	// PBW_HEALTH_HISTORY_RETENTION_DAYS is how long the health checks of the
+	// databases and destinations are kept, zero keeps them forever.
+	PBW_HEALTH_HISTORY_RETENTION_DAYS int `env:"PBW_HEALTH_HISTORY_RETENTION_DAYS" envDefault:"30"`
+
+	// PBW_PUBLIC_URL is the URL the dashboard is reached at without the path
+	// prefix, e.g. https://pgbackweb.example.com, it is used to link to the
+	// dashboard from the webhooks.
+	PBW_PUBLIC_URL string `env:"PBW_PUBLIC_URL" envDefault:""`
+
+	// PBW_WEBHOOK_MAX_ATTEMPTS is how many times a webhook delivery is tried
+	// before it is marked as failed. The wait between the attempts starts at
+	// PBW_WEBHOOK_RETRY_BACKOFF and doubles every attempt, up to an hour.
+	PBW_WEBHOOK_MAX_ATTEMPTS  int           `env:"PBW_WEBHOOK_MAX_ATTEMPTS" envDefault:"5"`
+	PBW_WEBHOOK_RETRY_BACKOFF time.Duration `env:"PBW_WEBHOOK_RETRY_BACKOFF" envDefault:"1m"`
+
+	// PBW_SLOW_UPLOAD_THRESHOLD is how long the upload of a backup to a
+	// destination can take before the destination_upload_slow webhooks run,
+	// zero disables them.
+	PBW_SLOW_UPLOAD_THRESHOLD time.Duration `env:"PBW_SLOW_UPLOAD_THRESHOLD" envDefault:"30m"`
+}
+
+// OIDCEnabled reports whether the OpenID Connect login is configured.
+func (e Env) OIDCEnabled() bool {
+	return e.PBW_OIDC_DISCOVERY_URL != ""
 }
 
 var (
@@ -2,6 +2,10 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/eduardolat/pgbackweb/internal/validate"
 )
@@ -20,5 +24,70 @@ func validateEnv(env Env) error {
 		return fmt.Errorf("invalid path prefix %s, must start with / and not end with / (or be empty)", env.PBW_PATH_PREFIX)
 	}
 
+	if env.OIDCEnabled() && env.PBW_OIDC_CLIENT_ID == "" {
+		return fmt.Errorf("PBW_OIDC_CLIENT_ID is required when PBW_OIDC_DISCOVERY_URL is set")
+	}
+
+	if env.PBW_DISABLE_PASSWORD_LOGIN && !env.OIDCEnabled() {
+		return fmt.Errorf("PBW_DISABLE_PASSWORD_LOGIN requires the OpenID Connect login to be configured")
+	}
+
+	for group, role := range env.PBW_OIDC_ROLE_MAPPING {
+		if !slices.Contains(oidcRoles, role) {
+			return fmt.Errorf("invalid role %q for the group %q in PBW_OIDC_ROLE_MAPPING", role, group)
+		}
+	}
+
+	if env.PBW_OIDC_DEFAULT_ROLE != OIDCDefaultRoleNone && !slices.Contains(oidcRoles, env.PBW_OIDC_DEFAULT_ROLE) {
+		return fmt.Errorf("invalid PBW_OIDC_DEFAULT_ROLE %q, use %q to reject the users without a mapped group", env.PBW_OIDC_DEFAULT_ROLE, OIDCDefaultRoleNone)
+	}
+
+	if env.PBW_AUDIT_LOG_RETENTION_DAYS < 0 {
+		return fmt.Errorf("invalid PBW_AUDIT_LOG_RETENTION_DAYS %d, use 0 to keep the entries forever", env.PBW_AUDIT_LOG_RETENTION_DAYS)
+	}
+
+	if env.PBW_HEALTH_HISTORY_RETENTION_DAYS < 0 {
+		return fmt.Errorf("invalid PBW_HEALTH_HISTORY_RETENTION_DAYS %d, use 0 to keep the checks forever", env.PBW_HEALTH_HISTORY_RETENTION_DAYS)
+	}
+
+	if env.PBW_WEBHOOK_MAX_ATTEMPTS < 1 {
+		return fmt.Errorf("invalid PBW_WEBHOOK_MAX_ATTEMPTS %d, must be at least 1", env.PBW_WEBHOOK_MAX_ATTEMPTS)
+	}
+
+	if env.PBW_WEBHOOK_RETRY_BACKOFF <= 0 {
+		return fmt.Errorf("invalid PBW_WEBHOOK_RETRY_BACKOFF %s, must be positive", env.PBW_WEBHOOK_RETRY_BACKOFF)
+	}
+
+	if env.PBW_SLOW_UPLOAD_THRESHOLD < 0 {
+		return fmt.Errorf("invalid PBW_SLOW_UPLOAD_THRESHOLD %s, must not be negative", env.PBW_SLOW_UPLOAD_THRESHOLD)
+	}
+
+	for _, prefix := range env.PBW_SECRETS_ENV_PREFIXES {
+		if prefix == "" || strings.HasPrefix("PBW_", prefix) || strings.HasPrefix(prefix, "PBW_") {
+			return fmt.Errorf("invalid PBW_SECRETS_ENV_PREFIXES prefix %q, it must not be empty nor match the PBW_ settings", prefix)
+		}
+	}
+
+	for _, dir := range env.PBW_SECRETS_FILE_DIRS {
+		if !filepath.IsAbs(dir) || filepath.Clean(dir) == "/" {
+			return fmt.Errorf("invalid PBW_SECRETS_FILE_DIRS directory %q, it must be an absolute path other than /", dir)
+		}
+	}
+
+	if env.PBW_PUBLIC_URL != "" {
+		publicURL, err := url.Parse(env.PBW_PUBLIC_URL)
+		if err != nil || publicURL.Host == "" || (publicURL.Scheme != "http" && publicURL.Scheme != "https") {
+			return fmt.Errorf("invalid PBW_PUBLIC_URL %s, must be an http or https URL", env.PBW_PUBLIC_URL)
+		}
+	}
+
 	return nil
 }
+
+// OIDCDefaultRoleNone as PBW_OIDC_DEFAULT_ROLE rejects the users whose groups
+// are not in PBW_OIDC_ROLE_MAPPING.
+const OIDCDefaultRoleNone = "none"
+
+// oidcRoles are the roles that can be given to the users that log in with
+// OpenID Connect, they must match the roles of the users service.
+var oidcRoles = []string{"admin", "operator", "viewer"}
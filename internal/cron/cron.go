@@ -61,6 +61,11 @@ func (c *Cron) RemoveJob(id uuid.UUID) error {
 	return nil
 }
 
+// JobsQty returns the number of jobs registered in the scheduler.
+func (c *Cron) JobsQty() int {
+	return len(c.scheduler.Jobs())
+}
+
 // Start starts the scheduler.
 func (c *Cron) Start() {
 	c.scheduler.Start()
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE backups
+  ADD COLUMN size_deviation_threshold SMALLINT NOT NULL DEFAULT 0
+  CHECK (size_deviation_threshold >= 0 AND size_deviation_threshold <= 1000);
+
+ALTER TABLE executions
+  ADD COLUMN is_suspicious BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE webhooks
+  DROP CONSTRAINT IF EXISTS webhooks_event_type_check,
+  ADD CONSTRAINT webhooks_event_type_check CHECK (event_type IN (
+    'database_healthy', 'database_unhealthy',
+    'destination_healthy', 'destination_unhealthy',
+    'execution_success', 'execution_failed', 'execution_suspicious'
+  ));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM webhooks WHERE event_type = 'execution_suspicious';
+
+ALTER TABLE webhooks
+  DROP CONSTRAINT IF EXISTS webhooks_event_type_check,
+  ADD CONSTRAINT webhooks_event_type_check CHECK (event_type IN (
+    'database_healthy', 'database_unhealthy',
+    'destination_healthy', 'destination_unhealthy',
+    'execution_success', 'execution_failed'
+  ));
+
+ALTER TABLE executions DROP COLUMN IF EXISTS is_suspicious;
+ALTER TABLE backups DROP COLUMN IF EXISTS size_deviation_threshold;
+-- +goose StatementEnd
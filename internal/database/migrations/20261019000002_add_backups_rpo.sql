@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE backups
+  ADD COLUMN rpo_hours SMALLINT
+  CHECK (rpo_hours IS NULL OR (rpo_hours > 0 AND rpo_hours <= 8760)),
+  ADD COLUMN is_stale BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE webhooks
+  DROP CONSTRAINT IF EXISTS webhooks_event_type_check,
+  ADD CONSTRAINT webhooks_event_type_check CHECK (event_type IN (
+    'database_healthy', 'database_unhealthy',
+    'destination_healthy', 'destination_unhealthy',
+    'execution_success', 'execution_failed', 'execution_suspicious',
+    'backup_stale'
+  ));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM webhooks WHERE event_type = 'backup_stale';
+
+ALTER TABLE webhooks
+  DROP CONSTRAINT IF EXISTS webhooks_event_type_check,
+  ADD CONSTRAINT webhooks_event_type_check CHECK (event_type IN (
+    'database_healthy', 'database_unhealthy',
+    'destination_healthy', 'destination_unhealthy',
+    'execution_success', 'execution_failed', 'execution_suspicious'
+  ));
+
+ALTER TABLE backups DROP COLUMN IF EXISTS is_stale;
+ALTER TABLE backups DROP COLUMN IF EXISTS rpo_hours;
+-- +goose StatementEnd
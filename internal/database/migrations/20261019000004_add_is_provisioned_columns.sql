@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE databases
+  ADD COLUMN is_provisioned BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE destinations
+  ADD COLUMN is_provisioned BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE backups
+  ADD COLUMN is_provisioned BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE webhooks
+  ADD COLUMN is_provisioned BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE webhooks DROP COLUMN IF EXISTS is_provisioned;
+ALTER TABLE backups DROP COLUMN IF EXISTS is_provisioned;
+ALTER TABLE destinations DROP COLUMN IF EXISTS is_provisioned;
+ALTER TABLE databases DROP COLUMN IF EXISTS is_provisioned;
+-- +goose StatementEnd
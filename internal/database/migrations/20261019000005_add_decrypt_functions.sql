@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+-- pbw_try_decrypt returns NULL instead of failing when the value can't be
+-- decrypted with the key.
+CREATE OR REPLACE FUNCTION pbw_try_decrypt(data BYTEA, key TEXT) RETURNS TEXT AS $$
+  BEGIN
+    RETURN pgp_sym_decrypt(data, key);
+  EXCEPTION WHEN OTHERS THEN
+    RETURN NULL;
+  END;
+$$ language 'plpgsql';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- pbw_decrypt_any decrypts the value with the first of the keys that works,
+-- it is used to re-encrypt values that were encrypted with previous keys.
+CREATE OR REPLACE FUNCTION pbw_decrypt_any(data BYTEA, keys TEXT[]) RETURNS TEXT AS $$
+  DECLARE
+    decrypted TEXT;
+    key TEXT;
+  BEGIN
+    FOREACH key IN ARRAY keys LOOP
+      decrypted := pbw_try_decrypt(data, key);
+      IF decrypted IS NOT NULL THEN
+        RETURN decrypted;
+      END IF;
+    END LOOP;
+    RAISE EXCEPTION 'the value can''t be decrypted with any of the encryption keys';
+  END;
+$$ language 'plpgsql';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS pbw_decrypt_any(BYTEA, TEXT[]);
+DROP FUNCTION IF EXISTS pbw_try_decrypt(BYTEA, TEXT);
+-- +goose StatementEnd
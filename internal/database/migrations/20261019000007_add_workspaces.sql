@@ -0,0 +1,82 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS workspaces (
+  id UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+
+  name TEXT NOT NULL UNIQUE,
+  is_default BOOLEAN NOT NULL DEFAULT FALSE,
+
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workspaces_is_default
+ON workspaces (is_default) WHERE is_default;
+
+CREATE TRIGGER workspaces_change_updated_at
+BEFORE UPDATE ON workspaces FOR EACH ROW EXECUTE FUNCTION change_updated_at();
+
+INSERT INTO workspaces (name, is_default) VALUES ('Default', TRUE);
+
+CREATE TABLE IF NOT EXISTS workspace_members (
+  workspace_id UUID NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  role TEXT NOT NULL CHECK (role IN ('admin', 'operator', 'viewer')),
+
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (workspace_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_workspace_members_user_id
+ON workspace_members (user_id);
+
+-- The users that are not admins keep their access as members of the default
+-- workspace.
+INSERT INTO workspace_members (workspace_id, user_id, role)
+SELECT workspaces.id, users.id, users.role
+FROM users, workspaces
+WHERE workspaces.is_default AND users.role <> 'admin';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+-- pbw_default_workspace_id is the default value of the workspace_id columns,
+-- so the rows created without a workspace belong to the default one.
+CREATE OR REPLACE FUNCTION pbw_default_workspace_id() RETURNS UUID AS $$
+  SELECT id FROM workspaces WHERE is_default LIMIT 1;
+$$ language 'sql' STABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE databases
+  ADD COLUMN workspace_id UUID NOT NULL DEFAULT pbw_default_workspace_id()
+    REFERENCES workspaces(id) ON DELETE RESTRICT;
+
+ALTER TABLE destinations
+  ADD COLUMN workspace_id UUID NOT NULL DEFAULT pbw_default_workspace_id()
+    REFERENCES workspaces(id) ON DELETE RESTRICT;
+
+ALTER TABLE backups
+  ADD COLUMN workspace_id UUID NOT NULL DEFAULT pbw_default_workspace_id()
+    REFERENCES workspaces(id) ON DELETE RESTRICT;
+
+ALTER TABLE webhooks
+  ADD COLUMN workspace_id UUID NOT NULL DEFAULT pbw_default_workspace_id()
+    REFERENCES workspaces(id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS idx_databases_workspace_id ON databases (workspace_id);
+CREATE INDEX IF NOT EXISTS idx_destinations_workspace_id ON destinations (workspace_id);
+CREATE INDEX IF NOT EXISTS idx_backups_workspace_id ON backups (workspace_id);
+CREATE INDEX IF NOT EXISTS idx_webhooks_workspace_id ON webhooks (workspace_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE webhooks DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE backups DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE destinations DROP COLUMN IF EXISTS workspace_id;
+ALTER TABLE databases DROP COLUMN IF EXISTS workspace_id;
+DROP FUNCTION IF EXISTS pbw_default_workspace_id();
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
+-- +goose StatementEnd
@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+  ADD COLUMN totp_secret BYTEA, -- encrypted with pgp_sym_encrypt
+  ADD COLUMN totp_enabled_at TIMESTAMPTZ,
+  -- last time step used to log in, so a code can't be used twice
+  ADD COLUMN totp_last_counter BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+  id UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+  code_hash TEXT NOT NULL, -- SHA256 of the code, the code itself is never stored
+
+  used_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS
+idx_user_recovery_codes_user_id ON user_recovery_codes(user_id);
+
+-- Logins that passed the first step and are waiting for the second factor.
+CREATE TABLE IF NOT EXISTS login_challenges (
+  id UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+  token_hash TEXT NOT NULL UNIQUE, -- SHA256 of the token sent in the cookie
+  attempts INTEGER NOT NULL DEFAULT 0,
+
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS
+idx_login_challenges_user_id ON login_challenges(user_id);
+
+-- Settings of the whole instance, the table always has a single row.
+CREATE TABLE IF NOT EXISTS instance_settings (
+  id BOOLEAN NOT NULL DEFAULT TRUE PRIMARY KEY CHECK (id),
+  require_two_factor BOOLEAN NOT NULL DEFAULT FALSE,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO instance_settings (id) VALUES (TRUE) ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS instance_settings;
+DROP TABLE IF EXISTS login_challenges;
+DROP TABLE IF EXISTS user_recovery_codes;
+
+ALTER TABLE users DROP COLUMN IF EXISTS totp_last_counter;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_enabled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_secret;
+-- +goose StatementEnd
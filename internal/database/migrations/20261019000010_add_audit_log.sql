@@ -0,0 +1,52 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS audit_log (
+  id UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+
+  -- The user is copied instead of referenced so the entries are kept when
+  -- the user is deleted, both are null for the actions of the system.
+  user_id UUID,
+  user_email TEXT,
+  ip TEXT,
+  user_agent TEXT,
+
+  action TEXT NOT NULL,
+  entity_type TEXT NOT NULL,
+  entity_id UUID,
+  entity_name TEXT,
+  diff JSONB NOT NULL DEFAULT '{}', -- changed fields, secrets are redacted
+
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS
+idx_audit_log_created_at ON audit_log(created_at);
+
+CREATE INDEX IF NOT EXISTS
+idx_audit_log_entity ON audit_log(entity_type, entity_id);
+
+CREATE INDEX IF NOT EXISTS
+idx_audit_log_user_id ON audit_log(user_id);
+
+-- The audit log is append-only, the entries can only be deleted by the
+-- retention cleanup, which enables pbw.audit_log_cleanup for its statement.
+CREATE OR REPLACE FUNCTION pbw_audit_log_append_only() RETURNS TRIGGER AS $$
+BEGIN
+  IF TG_OP = 'DELETE'
+  AND current_setting('pbw.audit_log_cleanup', TRUE) = 'on' THEN
+    RETURN OLD;
+  END IF;
+  RAISE EXCEPTION 'the audit log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+BEFORE UPDATE OR DELETE ON audit_log
+FOR EACH ROW EXECUTE FUNCTION pbw_audit_log_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_log;
+DROP FUNCTION IF EXISTS pbw_audit_log_append_only();
+-- +goose StatementEnd
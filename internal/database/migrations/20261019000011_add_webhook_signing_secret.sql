@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE webhooks
+  ADD COLUMN signing_secret BYTEA; -- encrypted with pgp_sym_encrypt
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE webhooks DROP COLUMN IF EXISTS signing_secret;
+-- +goose StatementEnd
@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Each event sent to a webhook is a delivery, it is retried until it
+-- succeeds or runs out of attempts.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  id UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+  webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+
+  event JSONB NOT NULL, -- the event the templates are rendered with
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN (
+    'pending', 'delivered', 'failed'
+  )),
+  attempts INTEGER NOT NULL DEFAULT 0,
+  -- when a pending delivery is due, it is moved forward while an attempt is
+  -- running so other workers don't pick it up
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_error TEXT,
+
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ
+);
+
+CREATE TRIGGER webhook_deliveries_change_updated_at
+BEFORE UPDATE ON webhook_deliveries FOR EACH ROW EXECUTE FUNCTION change_updated_at();
+
+CREATE INDEX IF NOT EXISTS
+idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id);
+
+CREATE INDEX IF NOT EXISTS
+idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at)
+WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS
+idx_webhook_deliveries_failed ON webhook_deliveries(created_at)
+WHERE status = 'failed';
+
+ALTER TABLE webhook_executions
+  ADD COLUMN delivery_id UUID REFERENCES webhook_deliveries(id) ON DELETE SET NULL,
+  ADD COLUMN attempt INTEGER NOT NULL DEFAULT 1,
+  ADD COLUMN error TEXT; -- why the attempt failed, if it did
+
+CREATE INDEX IF NOT EXISTS
+idx_webhook_executions_delivery_id ON webhook_executions(delivery_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE webhook_executions
+  DROP COLUMN IF EXISTS error,
+  DROP COLUMN IF EXISTS attempt,
+  DROP COLUMN IF EXISTS delivery_id;
+
+DROP TABLE IF EXISTS webhook_deliveries;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The chat channels build the body of the request themselves, the webhook
+-- channel renders the body template.
+ALTER TABLE webhooks
+  ADD COLUMN channel TEXT NOT NULL DEFAULT 'webhook'
+  CONSTRAINT webhooks_channel_check CHECK (channel IN (
+    'webhook', 'slack', 'discord', 'teams', 'telegram'
+  ));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE webhooks DROP COLUMN IF EXISTS channel;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The SMTP server the email channel sends the emails through.
+ALTER TABLE instance_settings
+  ADD COLUMN smtp_host TEXT,
+  ADD COLUMN smtp_port INTEGER NOT NULL DEFAULT 587,
+  ADD COLUMN smtp_tls_mode TEXT NOT NULL DEFAULT 'starttls'
+  CONSTRAINT instance_settings_smtp_tls_mode_check CHECK (
+    smtp_tls_mode IN ('none', 'starttls', 'tls')
+  ),
+  ADD COLUMN smtp_username TEXT,
+  ADD COLUMN smtp_password BYTEA, -- encrypted with pgp_sym_encrypt
+  ADD COLUMN smtp_from TEXT;
+
+ALTER TABLE webhooks DROP CONSTRAINT webhooks_channel_check;
+ALTER TABLE webhooks ADD CONSTRAINT webhooks_channel_check CHECK (channel IN (
+  'webhook', 'slack', 'discord', 'teams', 'telegram', 'email'
+));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM webhooks WHERE channel = 'email';
+ALTER TABLE webhooks DROP CONSTRAINT webhooks_channel_check;
+ALTER TABLE webhooks ADD CONSTRAINT webhooks_channel_check CHECK (channel IN (
+  'webhook', 'slack', 'discord', 'teams', 'telegram'
+));
+
+ALTER TABLE instance_settings
+  DROP COLUMN IF EXISTS smtp_from,
+  DROP COLUMN IF EXISTS smtp_password,
+  DROP COLUMN IF EXISTS smtp_username,
+  DROP COLUMN IF EXISTS smtp_tls_mode,
+  DROP COLUMN IF EXISTS smtp_port,
+  DROP COLUMN IF EXISTS smtp_host;
+-- +goose StatementEnd
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE webhooks
+  DROP CONSTRAINT IF EXISTS webhooks_event_type_check,
+  ADD CONSTRAINT webhooks_event_type_check CHECK (event_type IN (
+    'database_healthy', 'database_unhealthy',
+    'destination_healthy', 'destination_unhealthy', 'destination_upload_slow',
+    'execution_started', 'execution_success', 'execution_failed',
+    'execution_suspicious', 'execution_deleted_by_retention',
+    'backup_stale',
+    'restoration_started', 'restoration_success', 'restoration_failed'
+  ));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM webhooks WHERE event_type IN (
+  'destination_upload_slow', 'execution_started',
+  'execution_deleted_by_retention', 'restoration_started',
+  'restoration_success', 'restoration_failed'
+);
+
+ALTER TABLE webhooks
+  DROP CONSTRAINT IF EXISTS webhooks_event_type_check,
+  ADD CONSTRAINT webhooks_event_type_check CHECK (event_type IN (
+    'database_healthy', 'database_unhealthy',
+    'destination_healthy', 'destination_unhealthy',
+    'execution_success', 'execution_failed', 'execution_suspicious',
+    'backup_stale'
+  ));
+-- +goose StatementEnd
@@ -0,0 +1,72 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The health of a database or destination only changes after the given
+-- number of checks in a row fail or succeed, the counters track the checks
+-- in a row that agree with the last one.
+ALTER TABLE databases
+  ADD COLUMN health_check_interval_minutes INTEGER NOT NULL DEFAULT 10
+    CHECK (health_check_interval_minutes >= 1),
+  ADD COLUMN health_failure_threshold INTEGER NOT NULL DEFAULT 1
+    CHECK (health_failure_threshold >= 1),
+  ADD COLUMN health_success_threshold INTEGER NOT NULL DEFAULT 1
+    CHECK (health_success_threshold >= 1),
+  ADD COLUMN health_consecutive_failures INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN health_consecutive_successes INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE destinations
+  ADD COLUMN health_check_interval_minutes INTEGER NOT NULL DEFAULT 10
+    CHECK (health_check_interval_minutes >= 1),
+  ADD COLUMN health_failure_threshold INTEGER NOT NULL DEFAULT 1
+    CHECK (health_failure_threshold >= 1),
+  ADD COLUMN health_success_threshold INTEGER NOT NULL DEFAULT 1
+    CHECK (health_success_threshold >= 1),
+  ADD COLUMN health_consecutive_failures INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN health_consecutive_successes INTEGER NOT NULL DEFAULT 0;
+
+-- Every health check of a database or destination, for the uptime and the
+-- latency trends.
+CREATE TABLE IF NOT EXISTS health_checks (
+  id UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+  database_id UUID REFERENCES databases(id) ON DELETE CASCADE,
+  destination_id UUID REFERENCES destinations(id) ON DELETE CASCADE,
+
+  ok BOOLEAN NOT NULL,
+  error TEXT,
+  latency_ms INTEGER NOT NULL,
+  changed BOOLEAN NOT NULL DEFAULT FALSE, -- the check changed the health
+
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  CHECK ((database_id IS NULL) <> (destination_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS
+idx_health_checks_database_id ON health_checks(database_id, created_at)
+WHERE database_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS
+idx_health_checks_destination_id ON health_checks(destination_id, created_at)
+WHERE destination_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS
+idx_health_checks_created_at ON health_checks(created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS health_checks;
+
+ALTER TABLE destinations
+  DROP COLUMN IF EXISTS health_consecutive_successes,
+  DROP COLUMN IF EXISTS health_consecutive_failures,
+  DROP COLUMN IF EXISTS health_success_threshold,
+  DROP COLUMN IF EXISTS health_failure_threshold,
+  DROP COLUMN IF EXISTS health_check_interval_minutes;
+
+ALTER TABLE databases
+  DROP COLUMN IF EXISTS health_consecutive_successes,
+  DROP COLUMN IF EXISTS health_consecutive_failures,
+  DROP COLUMN IF EXISTS health_success_threshold,
+  DROP COLUMN IF EXISTS health_failure_threshold,
+  DROP COLUMN IF EXISTS health_check_interval_minutes;
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE webhooks DROP CONSTRAINT webhooks_method_check;
+ALTER TABLE webhooks ADD CONSTRAINT webhooks_method_check CHECK (method IN (
+  'GET', 'POST', 'PUT', 'PATCH', 'DELETE'
+));
+
+ALTER TABLE webhook_executions DROP CONSTRAINT webhook_executions_req_method_check;
+ALTER TABLE webhook_executions ADD CONSTRAINT webhook_executions_req_method_check
+CHECK (req_method IN ('GET', 'POST', 'PUT', 'PATCH', 'DELETE'));
+
+-- The HTTP client settings of the webhook channel, the CA certificate is PEM
+-- encoded and is trusted on top of the system certificates.
+ALTER TABLE webhooks
+  ADD COLUMN timeout_seconds INTEGER NOT NULL DEFAULT 30
+    CHECK (timeout_seconds BETWEEN 1 AND 300),
+  ADD COLUMN tls_skip_verify BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN ca_certificate TEXT,
+  ADD COLUMN proxy_url TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE webhooks
+  DROP COLUMN IF EXISTS proxy_url,
+  DROP COLUMN IF EXISTS ca_certificate,
+  DROP COLUMN IF EXISTS tls_skip_verify,
+  DROP COLUMN IF EXISTS timeout_seconds;
+
+UPDATE webhook_executions SET req_method = 'POST'
+WHERE req_method IN ('PUT', 'PATCH', 'DELETE');
+ALTER TABLE webhook_executions DROP CONSTRAINT webhook_executions_req_method_check;
+ALTER TABLE webhook_executions ADD CONSTRAINT webhook_executions_req_method_check
+CHECK (req_method IN ('GET', 'POST'));
+
+UPDATE webhooks SET method = 'POST' WHERE method IN ('PUT', 'PATCH', 'DELETE');
+ALTER TABLE webhooks DROP CONSTRAINT webhooks_method_check;
+ALTER TABLE webhooks ADD CONSTRAINT webhooks_method_check CHECK (method IN (
+  'GET', 'POST'
+));
+-- +goose StatementEnd
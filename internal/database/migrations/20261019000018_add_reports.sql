@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Reports send a digest of the backup activity of their workspace on a
+-- schedule, as JSON to a URL or as an HTML email to the recipients of a
+-- mailto: URL.
+CREATE TABLE IF NOT EXISTS reports (
+  id UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+  workspace_id UUID NOT NULL DEFAULT pbw_default_workspace_id()
+    REFERENCES workspaces(id) ON DELETE RESTRICT,
+
+  name TEXT NOT NULL,
+  is_active BOOLEAN NOT NULL DEFAULT TRUE,
+
+  period TEXT NOT NULL CHECK (period IN ('daily', 'weekly')),
+  cron_expression TEXT NOT NULL,
+  time_zone TEXT NOT NULL,
+
+  channel TEXT NOT NULL CHECK (channel IN ('webhook', 'email')),
+  url TEXT NOT NULL,
+
+  last_sent_at TIMESTAMPTZ,
+  last_error TEXT, -- error of the last attempt, null if it was sent
+
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ
+);
+
+CREATE TRIGGER reports_change_updated_at
+BEFORE UPDATE ON reports FOR EACH ROW EXECUTE FUNCTION change_updated_at();
+
+CREATE INDEX IF NOT EXISTS idx_reports_workspace_id ON reports (workspace_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS reports;
+-- +goose StatementEnd
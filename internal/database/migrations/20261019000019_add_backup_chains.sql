@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A backup that runs after another one is started every time the other one
+-- succeeds, instead of on its own schedule.
+ALTER TABLE backups
+ADD COLUMN IF NOT EXISTS run_after_backup_id UUID
+  REFERENCES backups(id) ON DELETE SET NULL,
+ADD CONSTRAINT backups_run_after_backup_id_check
+  CHECK (run_after_backup_id <> id);
+
+CREATE INDEX IF NOT EXISTS
+idx_backups_run_after_backup_id ON backups (run_after_backup_id);
+
+-- Backup groups run their backups one after the other, in the order of
+-- backup_ids, on a single schedule. The group stops at the first backup that
+-- fails.
+CREATE TABLE IF NOT EXISTS backup_groups (
+  id UUID NOT NULL DEFAULT uuid_generate_v4() PRIMARY KEY,
+  workspace_id UUID NOT NULL DEFAULT pbw_default_workspace_id()
+    REFERENCES workspaces(id) ON DELETE RESTRICT,
+
+  name TEXT NOT NULL,
+  is_active BOOLEAN NOT NULL DEFAULT TRUE,
+  cron_expression TEXT NOT NULL,
+  time_zone TEXT NOT NULL,
+  backup_ids UUID[] NOT NULL,
+
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ
+);
+
+CREATE TRIGGER backup_groups_change_updated_at
+BEFORE UPDATE ON backup_groups FOR EACH ROW EXECUTE FUNCTION change_updated_at();
+
+CREATE INDEX IF NOT EXISTS
+idx_backup_groups_workspace_id ON backup_groups (workspace_id);
+
+CREATE INDEX IF NOT EXISTS
+idx_backup_groups_backup_ids ON backup_groups USING GIN (backup_ids);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS backup_groups;
+
+ALTER TABLE backups
+DROP CONSTRAINT IF EXISTS backups_run_after_backup_id_check,
+DROP COLUMN IF EXISTS run_after_backup_id;
+-- +goose StatementEnd
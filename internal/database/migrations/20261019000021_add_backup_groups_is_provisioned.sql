@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE backup_groups
+  ADD COLUMN is_provisioned BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE backup_groups DROP COLUMN IF EXISTS is_provisioned;
+-- +goose StatementEnd
@@ -0,0 +1,213 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// TLSModeNone sends the emails in plain text.
+	TLSModeNone = "none"
+	// TLSModeStartTLS upgrades the connection with STARTTLS, usually port 587.
+	TLSModeStartTLS = "starttls"
+	// TLSModeTLS connects with implicit TLS, usually port 465.
+	TLSModeTLS = "tls"
+)
+
+// Config is the SMTP server the emails are sent through. Username and
+// password are optional, without them the emails are sent without auth.
+type Config struct {
+	Host     string
+	Port     int
+	TLSMode  string
+	Username string
+	Password string
+	From     string
+}
+
+// Message is an email with a text and an HTML version of the body.
+type Message struct {
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type Client struct {
+	timeout time.Duration
+}
+
+func New() *Client {
+	return &Client{timeout: 30 * time.Second}
+}
+
+// Send sends the message through the SMTP server of the config.
+func (c *Client) Send(ctx context.Context, cfg Config, msg Message) error {
+	if cfg.Host == "" || cfg.From == "" {
+		return errors.New("SMTP is not configured")
+	}
+	if len(msg.To) == 0 {
+		return errors.New("the email has no recipients")
+	}
+
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid from address: %w", err)
+	}
+
+	data, err := buildMessage(from, msg)
+	if err != nil {
+		return fmt.Errorf("error building email: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	client, err := dial(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if cfg.Username != "" {
+		auth := smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("error authenticating: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("error setting sender: %w", err)
+	}
+	for _, to := range msg.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("error adding recipient %s: %w", to, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("error starting data: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("error writing data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("error sending data: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// dial connects to the SMTP server with the TLS mode of the config.
+func dial(ctx context.Context, cfg Config) (*smtp.Client, error) {
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	tlsConfig := &tls.Config{ServerName: cfg.Host}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if cfg.TLSMode == TLSModeTLS {
+		dialer := &tls.Dialer{Config: tlsConfig}
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	} else {
+		dialer := &net.Dialer{}
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error connecting to %s: %w", addr, err)
+	}
+
+	if cfg.TLSMode == TLSModeStartTLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("error starting TLS: %w", err)
+		}
+	}
+
+	return client, nil
+}
+
+// buildMessage returns the message as a multipart/alternative MIME email.
+func buildMessage(from *mail.Address, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	headers := []string{
+		"From: " + from.String(),
+		"To: " + strings.Join(msg.To, ", "),
+		"Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"Message-ID: " + messageID(from.Address),
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + mw.Boundary(),
+	}
+	buf.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	}
+	for _, part := range parts {
+		if part.content == "" {
+			continue
+		}
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qw := quotedprintable.NewWriter(pw)
+		if _, err := qw.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func messageID(fromAddress string) string {
+	domain := "localhost"
+	if _, after, ok := strings.Cut(fromAddress, "@"); ok {
+		domain = after
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
@@ -0,0 +1,144 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sinkMessage is an email received by the SMTP sink.
+type sinkMessage struct {
+	From string
+	To   []string
+	Data string
+}
+
+// startSink starts a minimal SMTP server on localhost that accepts every
+// email and sends it to the returned channel.
+func startSink(t *testing.T) (string, int, <-chan sinkMessage) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	messages := make(chan sinkMessage, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveSink(conn, messages)
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+	return host, portNum, messages
+}
+
+func serveSink(conn net.Conn, messages chan<- sinkMessage) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 sink ready")
+
+	msg := sinkMessage{}
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			_ = tp.PrintfLine("250 sink")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			msg.From = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+			_ = tp.PrintfLine("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			msg.To = append(msg.To, strings.Trim(line[len("RCPT TO:"):], "<> "))
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "DATA":
+			_ = tp.PrintfLine("354 Go ahead")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.Data = string(data)
+			messages <- msg
+			msg = sinkMessage{}
+			_ = tp.PrintfLine("250 OK")
+		case cmd == "QUIT":
+			_ = tp.PrintfLine("221 Bye")
+			return
+		default:
+			_ = tp.PrintfLine("502 Not implemented")
+		}
+	}
+}
+
+func TestSend(t *testing.T) {
+	host, port, messages := startSink(t)
+	client := New()
+
+	cfg := Config{
+		Host:    host,
+		Port:    port,
+		TLSMode: TLSModeNone,
+		From:    "PG Back Web <pbw@example.com>",
+	}
+
+	t.Run("Multipart message", func(t *testing.T) {
+		err := client.Send(context.Background(), cfg, Message{
+			To:      []string{"a@example.com", "b@example.com"},
+			Subject: "Execution failed: My target",
+			Text:    "The backup failed",
+			HTML:    "<p>The backup failed</p>",
+		})
+		require.NoError(t, err)
+
+		msg := <-messages
+		assert.Equal(t, "pbw@example.com", msg.From)
+		assert.Equal(t, []string{"a@example.com", "b@example.com"}, msg.To)
+
+		headers, err := textproto.NewReader(
+			bufio.NewReader(strings.NewReader(msg.Data)),
+		).ReadMIMEHeader()
+		require.NoError(t, err)
+		assert.Equal(t, "Execution failed: My target", headers.Get("Subject"))
+		assert.Equal(t, "a@example.com, b@example.com", headers.Get("To"))
+		assert.Contains(t, headers.Get("Content-Type"), "multipart/alternative")
+		assert.Contains(t, msg.Data, "text/plain")
+		assert.Contains(t, msg.Data, "The backup failed")
+		assert.Contains(t, msg.Data, "<p>The backup failed</p>")
+	})
+
+	t.Run("Not configured", func(t *testing.T) {
+		err := client.Send(context.Background(), Config{}, Message{
+			To: []string{"a@example.com"},
+		})
+		assert.EqualError(t, err, "SMTP is not configured")
+	})
+
+	t.Run("No recipients", func(t *testing.T) {
+		err := client.Send(context.Background(), cfg, Message{})
+		assert.Error(t, err)
+	})
+
+	t.Run("Unreachable server", func(t *testing.T) {
+		unreachable := cfg
+		unreachable.Port = 1
+		err := client.Send(context.Background(), unreachable, Message{
+			To: []string{"a@example.com"},
+		})
+		assert.Error(t, err)
+	})
+}
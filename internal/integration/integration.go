@@ -1,21 +1,33 @@
 package integration
 
 import (
+	"github.com/eduardolat/pgbackweb/internal/config"
+	"github.com/eduardolat/pgbackweb/internal/integration/email"
 	"github.com/eduardolat/pgbackweb/internal/integration/postgres"
+	"github.com/eduardolat/pgbackweb/internal/integration/secrets"
 	"github.com/eduardolat/pgbackweb/internal/integration/storage"
 )
 
 type Integration struct {
+	EmailClient   *email.Client
 	PGClient      *postgres.Client
+	SecretsClient *secrets.Client
 	StorageClient *storage.Client
 }
 
-func New() *Integration {
+func New(env config.Env) *Integration {
 	pgClient := postgres.New()
+	secretsClient := secrets.New(
+		env.PBW_VAULT_ADDR, env.PBW_VAULT_TOKEN,
+		env.PBW_SECRETS_ENV_PREFIXES, env.PBW_SECRETS_FILE_DIRS,
+	)
 	storageClient := storage.New()
+	emailClient := email.New()
 
 	return &Integration{
 		PGClient:      pgClient,
+		SecretsClient: secretsClient,
 		StorageClient: storageClient,
+		EmailClient:   emailClient,
 	}
 }
@@ -0,0 +1,190 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Prefixes of the secret references. A stored connection string or S3 key
+// starting with one of them is resolved when it is used instead of being
+// used as is, so the actual secret is never stored by PG Back Web.
+const (
+	// PrefixEnv references an environment variable, e.g. env://PG_PROD_URL
+	PrefixEnv = "env://"
+	// PrefixFile references a file, e.g. file:///run/secrets/pg_prod_url
+	PrefixFile = "file://"
+	// PrefixVault references a field of a Vault KV v2 secret, e.g.
+	// vault://secret/pgbackweb/prod#connection_string
+	PrefixVault = "vault://"
+)
+
+// reservedEnvPrefix is the prefix of the settings of PG Back Web, they can
+// never be referenced even if an allowed prefix matches them.
+const reservedEnvPrefix = "PBW_"
+
+type Client struct {
+	vaultAddr   string
+	vaultToken  string
+	envPrefixes []string
+	fileDirs    []string
+	httpClient  *http.Client
+}
+
+// New creates a client that resolves Vault references against the Vault
+// server at vaultAddr using vaultToken. Both can be empty when Vault is not
+// used.
+//
+// References can be entered by any user that tests a connection or restores
+// a backup, so only the environment variables starting with one of
+// envPrefixes and the files inside one of fileDirs can be referenced.
+func New(vaultAddr, vaultToken string, envPrefixes, fileDirs []string) *Client {
+	return &Client{
+		vaultAddr:   strings.TrimRight(vaultAddr, "/"),
+		vaultToken:  vaultToken,
+		envPrefixes: envPrefixes,
+		fileDirs:    fileDirs,
+		httpClient:  &http.Client{Timeout: time.Second * 10},
+	}
+}
+
+// IsReference returns true when the value is a secret reference.
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, PrefixEnv) ||
+		strings.HasPrefix(value, PrefixFile) ||
+		strings.HasPrefix(value, PrefixVault)
+}
+
+// Resolve returns the secret the value references, or the value itself when
+// it is not a reference.
+func (c *Client) Resolve(ctx context.Context, value string) (string, error) {
+	var (
+		secret string
+		err    error
+	)
+
+	switch {
+	case strings.HasPrefix(value, PrefixEnv):
+		secret, err = c.readEnv(strings.TrimPrefix(value, PrefixEnv))
+
+	case strings.HasPrefix(value, PrefixFile):
+		secret, err = c.readFile(strings.TrimPrefix(value, PrefixFile))
+
+	case strings.HasPrefix(value, PrefixVault):
+		secret, err = c.readVault(ctx, strings.TrimPrefix(value, PrefixVault))
+
+	default:
+		return value, nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("error resolving secret reference %s: %w", value, err)
+	}
+	if secret == "" {
+		return "", fmt.Errorf("secret reference %s resolved to an empty value", value)
+	}
+	return secret, nil
+}
+
+// readEnv reads an environment variable whose name starts with one of the
+// allowed prefixes.
+func (c *Client) readEnv(name string) (string, error) {
+	allowed := false
+	for _, prefix := range c.envPrefixes {
+		if prefix != "" && strings.HasPrefix(name, prefix) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed || strings.HasPrefix(name, reservedEnvPrefix) {
+		return "", fmt.Errorf(
+			"environment variable %s is not allowed by PBW_SECRETS_ENV_PREFIXES", name,
+		)
+	}
+
+	secret, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return secret, nil
+}
+
+// readFile reads a file inside one of the allowed directories. The path is
+// checked before and after following its symlinks, so neither .. nor a
+// symlink can point outside of them.
+func (c *Client) readFile(path string) (string, error) {
+	notAllowed := fmt.Errorf("file %s is not allowed by PBW_SECRETS_FILE_DIRS", path)
+	if !filepath.IsAbs(path) || !c.isInFileDirs(filepath.Clean(path)) {
+		return "", notAllowed
+	}
+
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	if !c.isInFileDirs(realPath) {
+		return "", notAllowed
+	}
+
+	content, err := os.ReadFile(realPath)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// isInFileDirs reports whether the clean absolute path is inside one of the
+// allowed directories, as they are configured or with their symlinks
+// followed.
+func (c *Client) isInFileDirs(path string) bool {
+	for _, dir := range c.fileDirs {
+		if dir == "" || !filepath.IsAbs(dir) {
+			continue
+		}
+
+		dirs := []string{filepath.Clean(dir)}
+		if realDir, err := filepath.EvalSymlinks(dir); err == nil {
+			dirs = append(dirs, realDir)
+		}
+
+		for _, d := range dirs {
+			rel, err := filepath.Rel(d, path)
+			if err == nil && rel != "." && rel != ".." &&
+				!strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HideOutput returns a generic error instead of err when value is a secret
+// reference. The output of psql and pg_restore can include the connection
+// string they were given, which would disclose the resolved secret, so it is
+// only meant for the server logs.
+func HideOutput(value string, err error) error {
+	if err == nil || !IsReference(value) {
+		return err
+	}
+	return fmt.Errorf(
+		"the connection with the secret reference %s failed, the details are in the server logs",
+		value,
+	)
+}
+
+// ResolveAll replaces every value with the secret it references, values that
+// are not references are left as they are.
+func (c *Client) ResolveAll(ctx context.Context, values ...*string) error {
+	for _, value := range values {
+		secret, err := c.Resolve(ctx, *value)
+		if err != nil {
+			return err
+		}
+		*value = secret
+	}
+	return nil
+}
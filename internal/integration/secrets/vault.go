@@ -0,0 +1,65 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// readVault reads a field of a KV v2 secret. The reference has the form
+// <mount>/<path>#<field>, e.g. secret/pgbackweb/prod#connection_string,
+// which is compatible with the secret engine of the Vault dev server.
+func (c *Client) readVault(ctx context.Context, reference string) (string, error) {
+	if c.vaultAddr == "" || c.vaultToken == "" {
+		return "", fmt.Errorf(
+			"PBW_VAULT_ADDR and PBW_VAULT_TOKEN are required to read Vault secrets",
+		)
+	}
+
+	secretPath, field, ok := strings.Cut(reference, "#")
+	if !ok || field == "" {
+		return "", fmt.Errorf("the reference must end with #<field>")
+	}
+	mount, path, ok := strings.Cut(secretPath, "/")
+	if !ok || mount == "" || path == "" {
+		return "", fmt.Errorf("the reference must have the form <mount>/<path>#<field>")
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/data/%s", c.vaultAddr, mount, path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("error creating Vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", c.vaultToken)
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error requesting Vault: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Vault responded with status %d", res.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("error decoding Vault response: %w", err)
+	}
+
+	value, ok := body.Data.Data[field]
+	if !ok {
+		return "", fmt.Errorf("field %s not found in Vault secret", field)
+	}
+	secret, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("field %s of Vault secret is not a string", field)
+	}
+	return secret, nil
+}
@@ -1,6 +1,18 @@
 package logger
 
-import "os"
+import (
+	"io"
+	"os"
+)
+
+// output is where the logWriter writes the logs, os.Stdout by default
+var output io.Writer = os.Stdout
+
+// SetOutput changes where the logs are written. It is meant to be called
+// once at startup, e.g. by CLI commands that use stdout for their own output
+func SetOutput(w io.Writer) {
+	output = w
+}
 
 // logWriter is a simple io.Writer that can redirect logs to os.Stdout
 // or any other required place
@@ -8,5 +20,5 @@ type logWriter struct{}
 
 // Write writes the log message to os.Stdout or any other required place
 func (w *logWriter) Write(p []byte) (n int, err error) {
-	return os.Stdout.Write(p)
+	return output.Write(p)
 }
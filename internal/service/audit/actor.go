@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Actor is who started the request, it's recorded in every entry of the
+// audit log written with the context.
+type Actor struct {
+	UserID    uuid.NullUUID
+	UserEmail string
+	IP        string
+	UserAgent string
+}
+
+type actorCtxKey struct{}
+
+// WithActor returns a copy of ctx that carries the actor of the request.
+// Contexts without an actor belong to the system, like the scheduler.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorCtxKey{}, actor)
+}
+
+// ActorFromContext returns the actor carried by ctx.
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorCtxKey{}).(Actor)
+	return actor, ok
+}
@@ -0,0 +1,69 @@
+package audit
+
+import (
+	"github.com/eduardolat/pgbackweb/internal/config"
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+)
+
+// The entity types of the audit log entries.
+const (
+	EntityAPIToken    = "api_token"
+	EntityBackup      = "backup"
+	EntityBackupGroup = "backup_group"
+	EntityBundle      = "bundle"
+	EntityDatabase    = "database"
+	EntityDestination = "destination"
+	EntityExecution   = "execution"
+	EntityInstance    = "instance"
+	EntityReport      = "report"
+	EntityRestoration = "restoration"
+	EntitySession     = "session"
+	EntityUser        = "user"
+	EntityWebhook     = "webhook"
+	EntityWorkspace   = "workspace"
+)
+
+// The actions of the audit log entries, the entity type gives them context.
+const (
+	ActionCreate      = "create"
+	ActionUpdate      = "update"
+	ActionDelete      = "delete"
+	ActionDuplicate   = "duplicate"
+	ActionRun         = "run"
+	ActionExport      = "export"
+	ActionImport      = "import"
+	ActionLogin       = "login"
+	ActionLoginFailed = "login_failed"
+	ActionLogout      = "logout"
+)
+
+// EntityTypes and Actions are the values that can be used to filter the
+// audit log.
+var (
+	EntityTypes = []string{
+		EntityAPIToken, EntityBackup, EntityBackupGroup, EntityBundle, EntityDatabase,
+		EntityDestination, EntityExecution, EntityInstance, EntityReport,
+		EntityRestoration, EntitySession, EntityUser, EntityWebhook,
+		EntityWorkspace,
+	}
+	Actions = []string{
+		ActionCreate, ActionUpdate, ActionDelete, ActionDuplicate, ActionRun,
+		ActionExport, ActionImport, ActionLogin, ActionLoginFailed, ActionLogout,
+	}
+)
+
+// Service reads and cleans the audit log. It doesn't check the role of the
+// user because every service records its actions with Record, so the users
+// service can't be imported here; the routes that use it require the
+// PermissionManageInstance permission instead.
+type Service struct {
+	env   config.Env
+	dbgen *dbgen.Queries
+}
+
+func New(env config.Env, dbgen *dbgen.Queries) *Service {
+	return &Service{
+		env:   env,
+		dbgen: dbgen,
+	}
+}
@@ -0,0 +1,29 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/logger"
+)
+
+// DeleteOldEntries deletes the entries older than the retention set in
+// PBW_AUDIT_LOG_RETENTION_DAYS, zero keeps them forever.
+func (s *Service) DeleteOldEntries() {
+	if s.env.PBW_AUDIT_LOG_RETENTION_DAYS <= 0 {
+		return
+	}
+
+	ctx := context.Background()
+	createdBefore := time.Now().AddDate(0, 0, -s.env.PBW_AUDIT_LOG_RETENTION_DAYS)
+
+	deleted, err := s.dbgen.AuditServiceDeleteOldEntries(ctx, createdBefore)
+	if err != nil {
+		logger.Error(
+			"error deleting old audit log entries", logger.KV{"error": err},
+		)
+		return
+	}
+
+	logger.Info("old audit log entries deleted", logger.KV{"deleted": deleted})
+}
@@ -0,0 +1,6 @@
+-- name: AuditServiceDeleteOldEntries :execrows
+-- The cleanup setting only lasts for this statement, the trigger rejects
+-- the deletions without it.
+DELETE FROM audit_log
+USING (SELECT set_config('pbw.audit_log_cleanup', 'on', TRUE)) AS cleanup
+WHERE audit_log.created_at < @created_before;
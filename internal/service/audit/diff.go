@@ -0,0 +1,167 @@
+package audit
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Redacted replaces the values of the secret fields in the diffs.
+const Redacted = "[redacted]"
+
+// Change is the value of a field before and after an action.
+type Change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// ignoredFields change on every action or are updated by the system, so
+// they are left out of the diffs.
+var ignoredFields = map[string]bool{
+	"created_at":   true,
+	"updated_at":   true,
+	"test_ok":      true,
+	"test_error":   true,
+	"last_test_at": true,
+	"is_stale":     true,
+	// Updated on every health check of the databases and destinations.
+	"health_consecutive_failures":  true,
+	"health_consecutive_successes": true,
+	// Updated every time a report is sent.
+	"last_sent_at": true,
+	"last_error":   true,
+	// Updated on every login with two-factor authentication.
+	"totp_last_counter": true,
+}
+
+// secretFields are the parts of the field names whose values are redacted.
+// The URLs are included because the ones of the chat channels hold a token.
+var secretFields = []string{
+	"password", "connection_string", "access_key", "secret", "token",
+	"encryption_key", "headers", "passphrase", "url",
+}
+
+// Diff returns the fields of before and after, which are structs of the same
+// type or nil, that have different values. Nil before or after means that
+// the entity was created or deleted, so all its fields are returned.
+//
+// The field names are converted to snake case and the Decrypted prefix is
+// removed, the []byte fields hold encrypted values and are left out, use the
+// decrypted fields to record their changes. The values of the secret fields
+// are redacted.
+func Diff(before, after any) map[string]Change {
+	beforeFields := structFields(before)
+	afterFields := structFields(after)
+
+	diff := map[string]Change{}
+	for name, beforeValue := range beforeFields {
+		afterValue, ok := afterFields[name]
+		if ok && reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+		diff[name] = Change{Before: beforeValue, After: afterValue}
+	}
+	for name, afterValue := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			diff[name] = Change{After: afterValue}
+		}
+	}
+
+	for name, change := range diff {
+		if !isSecretField(name) {
+			continue
+		}
+		if change.Before != nil {
+			change.Before = Redacted
+		}
+		if change.After != nil {
+			change.After = Redacted
+		}
+		diff[name] = change
+	}
+
+	return diff
+}
+
+func structFields(v any) map[string]any {
+	fields := map[string]any{}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return fields
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fields
+	}
+
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		field := rt.Field(i)
+		if !field.IsExported() || field.Type == reflect.TypeOf([]byte{}) {
+			continue
+		}
+
+		name := snakeCase(strings.TrimPrefix(field.Name, "Decrypted"))
+		if ignoredFields[name] {
+			continue
+		}
+		fields[name] = normalizeValue(rv.Field(i).Interface())
+	}
+
+	return fields
+}
+
+// normalizeValue converts the nullable and time values to plain values, so
+// they can be compared and look good in JSON.
+func normalizeValue(v any) any {
+	if valuer, ok := v.(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil {
+			return nil
+		}
+		v = value
+	}
+
+	switch value := v.(type) {
+	case time.Time:
+		return value.UTC().Format(time.RFC3339)
+	case []byte:
+		return string(bytes.Clone(value))
+	default:
+		return value
+	}
+}
+
+func isSecretField(name string) bool {
+	for _, secret := range secretFields {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// snakeCase converts a Go field name like DestinationID to destination_id.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && !unicode.IsUpper(runes[i-1])
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
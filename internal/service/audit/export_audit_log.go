@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+)
+
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+
+	// maxExportEntries limits the size of the exports, narrow the filter to
+	// export older entries.
+	maxExportEntries = 100_000
+)
+
+// exportEntry is an audit log entry as it's exported.
+type exportEntry struct {
+	ID         string          `json:"id"`
+	CreatedAt  string          `json:"created_at"`
+	UserID     string          `json:"user_id"`
+	UserEmail  string          `json:"user_email"`
+	IP         string          `json:"ip"`
+	UserAgent  string          `json:"user_agent"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityID   string          `json:"entity_id"`
+	EntityName string          `json:"entity_name"`
+	Diff       json.RawMessage `json:"diff"`
+}
+
+func newExportEntry(entry dbgen.AuditLog) exportEntry {
+	e := exportEntry{
+		ID:         entry.ID.String(),
+		CreatedAt:  entry.CreatedAt.UTC().Format(time.RFC3339),
+		UserEmail:  entry.UserEmail.String,
+		IP:         entry.Ip.String,
+		UserAgent:  entry.UserAgent.String,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		EntityName: entry.EntityName.String,
+		Diff:       entry.Diff,
+	}
+	if entry.UserID.Valid {
+		e.UserID = entry.UserID.UUID.String()
+	}
+	if entry.EntityID.Valid {
+		e.EntityID = entry.EntityID.UUID.String()
+	}
+	if len(e.Diff) == 0 {
+		e.Diff = json.RawMessage("{}")
+	}
+	return e
+}
+
+// ExportAuditLog writes the entries of the audit log that match the filter
+// to w in the given format, the newest first.
+func (s *Service) ExportAuditLog(
+	ctx context.Context, w io.Writer, format string, filter Filter,
+) error {
+	if format != ExportFormatCSV && format != ExportFormatJSON {
+		return fmt.Errorf("invalid export format %q", format)
+	}
+
+	entries, err := s.dbgen.AuditServiceExportAuditLog(
+		ctx, dbgen.AuditServiceExportAuditLogParams{
+			Search:     filter.search(),
+			EntityType: nullString(filter.EntityType),
+			Action:     nullString(filter.Action),
+			From:       filter.from(),
+			To:         filter.to(),
+			Limit:      maxExportEntries,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if format == ExportFormatCSV {
+		return writeCSV(w, entries)
+	}
+	return writeJSON(w, entries)
+}
+
+func writeCSV(w io.Writer, entries []dbgen.AuditLog) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write([]string{
+		"id", "created_at", "user_id", "user_email", "ip", "user_agent",
+		"action", "entity_type", "entity_id", "entity_name", "diff",
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		e := newExportEntry(entry)
+		err := cw.Write([]string{
+			e.ID, e.CreatedAt, e.UserID, e.UserEmail, e.IP, e.UserAgent,
+			e.Action, e.EntityType, e.EntityID, e.EntityName, string(e.Diff),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeJSON(w io.Writer, entries []dbgen.AuditLog) error {
+	exported := make([]exportEntry, 0, len(entries))
+	for _, entry := range entries {
+		exported = append(exported, newExportEntry(entry))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(exported)
+}
@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAuditLog() []dbgen.AuditLog {
+	return []dbgen.AuditLog{
+		{
+			ID:         uuid.MustParse("3b241101-e2bb-4255-8caf-4136c566a962"),
+			UserID:     uuid.NullUUID{UUID: uuid.MustParse("7d444840-9dc0-11d1-b245-5ffdce74fad2"), Valid: true},
+			UserEmail:  sql.NullString{String: "john@example.com", Valid: true},
+			Ip:         sql.NullString{String: "10.0.0.1", Valid: true},
+			UserAgent:  sql.NullString{String: "Mozilla/5.0, Firefox", Valid: true},
+			Action:     ActionUpdate,
+			EntityType: EntityDatabase,
+			EntityID:   uuid.NullUUID{UUID: uuid.MustParse("e4eaaaf2-d142-11e1-b3e4-080027620cdd"), Valid: true},
+			EntityName: sql.NullString{String: "prod", Valid: true},
+			Diff:       json.RawMessage(`{"name":{"before":"a","after":"prod"}}`),
+			CreatedAt:  time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:         uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"),
+			Action:     ActionRun,
+			EntityType: EntityBackup,
+			CreatedAt:  time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeCSV(&buf, testAuditLog()))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	assert.Equal(t, "user_email", records[0][3])
+	assert.Equal(t, []string{
+		"3b241101-e2bb-4255-8caf-4136c566a962", "2026-10-19T12:00:00Z",
+		"7d444840-9dc0-11d1-b245-5ffdce74fad2", "john@example.com", "10.0.0.1",
+		"Mozilla/5.0, Firefox", "update", "database",
+		"e4eaaaf2-d142-11e1-b3e4-080027620cdd", "prod",
+		`{"name":{"before":"a","after":"prod"}}`,
+	}, records[1])
+	assert.Equal(t, []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8", "2026-10-19T11:00:00Z",
+		"", "", "", "", "run", "backup", "", "", "{}",
+	}, records[2])
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeJSON(&buf, testAuditLog()))
+
+	var entries []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entries))
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "john@example.com", entries[0]["user_email"])
+	assert.Equal(t, map[string]any{
+		"name": map[string]any{"before": "a", "after": "prod"},
+	}, entries[0]["diff"])
+	assert.Equal(t, "", entries[1]["user_id"])
+	assert.Equal(t, map[string]any{}, entries[1]["diff"])
+
+	buf.Reset()
+	require.NoError(t, writeJSON(&buf, nil))
+	assert.Equal(t, "[]\n", buf.String())
+}
@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// Filter selects the audit log entries, the empty fields don't filter.
+type Filter struct {
+	// Search matches part of the user email or entity name, or the exact
+	// entity id or IP address.
+	Search     string
+	EntityType string
+	Action     string
+	From       time.Time
+	To         time.Time
+}
+
+func (f Filter) search() sql.NullString {
+	return nullString(strings.TrimSpace(f.Search))
+}
+
+func (f Filter) from() sql.NullTime {
+	return sql.NullTime{Time: f.From, Valid: !f.From.IsZero()}
+}
+
+func (f Filter) to() sql.NullTime {
+	return sql.NullTime{Time: f.To, Valid: !f.To.IsZero()}
+}
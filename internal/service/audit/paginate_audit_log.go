@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
+)
+
+type PaginateAuditLogParams struct {
+	Filter Filter
+	Page   int
+	Limit  int
+}
+
+// PaginateAuditLog returns the entries of the audit log that match the
+// filter, the newest first.
+func (s *Service) PaginateAuditLog(
+	ctx context.Context, params PaginateAuditLogParams,
+) (paginateutil.PaginateResponse, []dbgen.AuditLog, error) {
+	page := max(params.Page, 1)
+	limit := min(max(params.Limit, 1), 100)
+
+	count, err := s.dbgen.AuditServicePaginateAuditLogCount(
+		ctx, dbgen.AuditServicePaginateAuditLogCountParams{
+			Search:     params.Filter.search(),
+			EntityType: nullString(params.Filter.EntityType),
+			Action:     nullString(params.Filter.Action),
+			From:       params.Filter.from(),
+			To:         params.Filter.to(),
+		},
+	)
+	if err != nil {
+		return paginateutil.PaginateResponse{}, nil, err
+	}
+
+	paginateParams := paginateutil.PaginateParams{
+		Page:  page,
+		Limit: limit,
+	}
+	offset := paginateutil.CreateOffsetFromParams(paginateParams)
+	paginateResponse := paginateutil.CreatePaginateResponse(paginateParams, int(count))
+
+	entries, err := s.dbgen.AuditServicePaginateAuditLog(
+		ctx, dbgen.AuditServicePaginateAuditLogParams{
+			Search:     params.Filter.search(),
+			EntityType: nullString(params.Filter.EntityType),
+			Action:     nullString(params.Filter.Action),
+			From:       params.Filter.from(),
+			To:         params.Filter.to(),
+			Limit:      int32(limit),
+			Offset:     int32(offset),
+		},
+	)
+	if err != nil {
+		return paginateutil.PaginateResponse{}, nil, err
+	}
+
+	return paginateResponse, entries, nil
+}
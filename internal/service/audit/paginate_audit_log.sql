@@ -0,0 +1,61 @@
+-- name: AuditServicePaginateAuditLogCount :one
+SELECT COUNT(*) FROM audit_log
+WHERE (
+  sqlc.narg('search')::TEXT IS NULL
+  OR user_email ILIKE '%' || sqlc.narg('search')::TEXT || '%'
+  OR entity_name ILIKE '%' || sqlc.narg('search')::TEXT || '%'
+  OR entity_id::TEXT = sqlc.narg('search')::TEXT
+  OR ip = sqlc.narg('search')::TEXT
+)
+AND (
+  sqlc.narg('entity_type')::TEXT IS NULL
+  OR
+  entity_type = sqlc.narg('entity_type')::TEXT
+)
+AND (
+  sqlc.narg('action')::TEXT IS NULL
+  OR
+  action = sqlc.narg('action')::TEXT
+)
+AND (
+  sqlc.narg('from')::TIMESTAMPTZ IS NULL
+  OR
+  created_at >= sqlc.narg('from')::TIMESTAMPTZ
+)
+AND (
+  sqlc.narg('to')::TIMESTAMPTZ IS NULL
+  OR
+  created_at < sqlc.narg('to')::TIMESTAMPTZ
+);
+
+-- name: AuditServicePaginateAuditLog :many
+SELECT * FROM audit_log
+WHERE (
+  sqlc.narg('search')::TEXT IS NULL
+  OR user_email ILIKE '%' || sqlc.narg('search')::TEXT || '%'
+  OR entity_name ILIKE '%' || sqlc.narg('search')::TEXT || '%'
+  OR entity_id::TEXT = sqlc.narg('search')::TEXT
+  OR ip = sqlc.narg('search')::TEXT
+)
+AND (
+  sqlc.narg('entity_type')::TEXT IS NULL
+  OR
+  entity_type = sqlc.narg('entity_type')::TEXT
+)
+AND (
+  sqlc.narg('action')::TEXT IS NULL
+  OR
+  action = sqlc.narg('action')::TEXT
+)
+AND (
+  sqlc.narg('from')::TIMESTAMPTZ IS NULL
+  OR
+  created_at >= sqlc.narg('from')::TIMESTAMPTZ
+)
+AND (
+  sqlc.narg('to')::TIMESTAMPTZ IS NULL
+  OR
+  created_at < sqlc.narg('to')::TIMESTAMPTZ
+)
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
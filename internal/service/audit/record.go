@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/google/uuid"
+)
+
+// Entry is an action to record in the audit log.
+type Entry struct {
+	Action     string
+	EntityType string
+	EntityID   uuid.UUID
+	EntityName string
+	// Before and After are the entity before and after the action, they are
+	// compared with Diff so only the changed fields are recorded.
+	Before any
+	After  any
+	// Actor overrides the actor of the context, for the actions done before
+	// the user is known like the logins.
+	Actor *Actor
+}
+
+// Record appends the entry to the audit log with the actor of ctx. It uses
+// the given queries so the entry is part of their transaction, if any. A
+// failure to record is logged instead of failing the action.
+func Record(ctx context.Context, q *dbgen.Queries, entry Entry) {
+	actor, _ := ActorFromContext(ctx)
+	if entry.Actor != nil {
+		actor = *entry.Actor
+	}
+
+	diff, err := json.Marshal(Diff(entry.Before, entry.After))
+	if err != nil {
+		diff = []byte("{}")
+	}
+
+	err = q.AuditServiceRecord(
+		context.WithoutCancel(ctx), dbgen.AuditServiceRecordParams{
+			UserID:     actor.UserID,
+			UserEmail:  nullString(actor.UserEmail),
+			Ip:         nullString(actor.IP),
+			UserAgent:  nullString(actor.UserAgent),
+			Action:     entry.Action,
+			EntityType: entry.EntityType,
+			EntityID:   uuid.NullUUID{UUID: entry.EntityID, Valid: entry.EntityID != uuid.Nil},
+			EntityName: nullString(entry.EntityName),
+			Diff:       diff,
+		},
+	)
+	if err != nil {
+		logger.Error("failed to record audit log entry", logger.KV{
+			"action":      entry.Action,
+			"entity_type": entry.EntityType,
+			"entity_id":   entry.EntityID,
+			"error":       err,
+		})
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
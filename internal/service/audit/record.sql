@@ -0,0 +1,9 @@
+-- name: AuditServiceRecord :exec
+INSERT INTO audit_log (
+  user_id, user_email, ip, user_agent,
+  action, entity_type, entity_id, entity_name, diff
+) VALUES (
+  sqlc.narg('user_id'), sqlc.narg('user_email'), sqlc.narg('ip'),
+  sqlc.narg('user_agent'), @action, @entity_type, sqlc.narg('entity_id'),
+  sqlc.narg('entity_name'), @diff
+);
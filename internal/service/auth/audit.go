@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/google/uuid"
+)
+
+// auditLogin records a login, or a failed login, of the user. The request
+// isn't authenticated yet so the actor is built from the user.
+func (s *Service) auditLogin(
+	ctx context.Context, action string, userID uuid.UUID,
+	email, ip, userAgent string,
+) {
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     action,
+		EntityType: audit.EntityUser,
+		EntityID:   userID,
+		EntityName: email,
+		Actor: &audit.Actor{
+			UserID:    uuid.NullUUID{UUID: userID, Valid: userID != uuid.Nil},
+			UserEmail: email,
+			IP:        ip,
+			UserAgent: userAgent,
+		},
+	})
+}
+
+// twoFactorState is recorded in the audit log when the user changes its
+// two-factor authentication, the secret and the codes are never recorded.
+type twoFactorState struct {
+	TwoFactorEnabled bool
+	RecoveryCodes    int64
+}
+
+func (s *Service) auditTwoFactor(
+	ctx context.Context, userID uuid.UUID, before, after twoFactorState,
+) {
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionUpdate,
+		EntityType: audit.EntityUser,
+		EntityID:   userID,
+		Before:     before,
+		After:      after,
+	})
+}
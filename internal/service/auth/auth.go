@@ -9,11 +9,20 @@ import (
 
 const (
 	maxSessionAge = time.Hour * 12
+
+	// APITokenScopeRead allows only read (GET) requests to the REST API.
+	APITokenScopeRead = "read"
+	// APITokenScopeWrite allows all requests to the REST API.
+	APITokenScopeWrite = "write"
+
+	apiTokenPrefix    = "pbw_"
+	apiTokenPrefixLen = 12
 )
 
 type Service struct {
 	env   config.Env
 	dbgen *dbgen.Queries
+	oidc  oidcProvider
 }
 
 func New(env config.Env, dbgen *dbgen.Queries) *Service {
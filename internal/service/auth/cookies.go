@@ -1,15 +1,22 @@
 package auth
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
 	"github.com/labstack/echo/v4"
 )
 
 const (
-	sessionCookieName = "pbw_session"
+	sessionCookieName   = "pbw_session"
+	oidcStateCookieName = "pbw_oidc_state"
+	oidcStateMaxAge     = time.Minute * 10
+
+	loginChallengeCookieName = "pbw_login_challenge"
 )
 
 func (s *Service) SetSessionCookie(c echo.Context, token string) {
@@ -53,3 +60,91 @@ func (s *Service) GetUserFromSessionCookie(c echo.Context) (
 
 	return s.GetUserByToken(ctx, cookie.Value)
 }
+
+// SetLoginChallengeCookie keeps the token of the login challenge until the
+// user enters the two-factor code.
+func (s *Service) SetLoginChallengeCookie(c echo.Context, token string) {
+	cookie := http.Cookie{
+		Name:     loginChallengeCookieName,
+		Value:    token,
+		MaxAge:   int(loginChallengeMaxAge.Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Path:     "/",
+	}
+	c.SetCookie(&cookie)
+}
+
+func (s *Service) ClearLoginChallengeCookie(c echo.Context) {
+	cookie := http.Cookie{
+		Name:     loginChallengeCookieName,
+		Value:    "",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Path:     "/",
+	}
+	c.SetCookie(&cookie)
+}
+
+// GetLoginChallengeCookie returns the token of the login challenge, empty if
+// there is none.
+func (s *Service) GetLoginChallengeCookie(c echo.Context) string {
+	cookie, err := c.Cookie(loginChallengeCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// SetOIDCStateCookie keeps the state of the OpenID Connect login until the
+// provider redirects back to the callback.
+func (s *Service) SetOIDCStateCookie(
+	c echo.Context, state OIDCLoginState,
+) error {
+	value, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	cookie := http.Cookie{
+		Name:     oidcStateCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString(value),
+		MaxAge:   int(oidcStateMaxAge.Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Path:     "/",
+	}
+	c.SetCookie(&cookie)
+	return nil
+}
+
+// PopOIDCStateCookie returns the state of the OpenID Connect login and
+// clears the cookie so it can't be used twice.
+func (s *Service) PopOIDCStateCookie(c echo.Context) (OIDCLoginState, error) {
+	cookie, err := c.Cookie(oidcStateCookieName)
+	if err != nil {
+		return OIDCLoginState{}, errors.New("the login state is missing or expired")
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     oidcStateCookieName,
+		Value:    "",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+		Path:     "/",
+	})
+
+	value, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return OIDCLoginState{}, errors.New("invalid login state")
+	}
+
+	var state OIDCLoginState
+	if err := json.Unmarshal(value, &state); err != nil {
+		return OIDCLoginState{}, errors.New("invalid login state")
+	}
+
+	return state, nil
+}
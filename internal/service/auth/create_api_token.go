@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/util/cryptoutil"
+	"github.com/google/uuid"
+)
+
+// CreateAPIToken creates a new API token for the given user and returns it
+// along with the plain token. The plain token is not stored so it can't be
+// recovered later.
+func (s *Service) CreateAPIToken(
+	ctx context.Context, userID uuid.UUID, name, scope string,
+	expiresAt sql.NullTime,
+) (dbgen.ApiToken, string, error) {
+	if scope != APITokenScopeRead && scope != APITokenScopeWrite {
+		return dbgen.ApiToken{}, "", fmt.Errorf("invalid API token scope %s", scope)
+	}
+
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return dbgen.ApiToken{}, "", fmt.Errorf("error generating API token: %w", err)
+	}
+	token := apiTokenPrefix + hex.EncodeToString(randomBytes)
+
+	apiToken, err := s.dbgen.AuthServiceCreateAPIToken(
+		ctx, dbgen.AuthServiceCreateAPITokenParams{
+			UserID:      userID,
+			Name:        name,
+			TokenHash:   cryptoutil.GetSHA256FromString(token),
+			TokenPrefix: token[:apiTokenPrefixLen],
+			Scope:       scope,
+			ExpiresAt:   expiresAt,
+		},
+	)
+	if err != nil {
+		return dbgen.ApiToken{}, "", err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionCreate,
+		EntityType: audit.EntityAPIToken,
+		EntityID:   apiToken.ID,
+		EntityName: apiToken.Name,
+		After:      apiToken,
+	})
+
+	return apiToken, token, nil
+}
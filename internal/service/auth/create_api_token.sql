@@ -0,0 +1,6 @@
+-- name: AuthServiceCreateAPIToken :one
+INSERT INTO api_tokens (
+  user_id, name, token_hash, token_prefix, scope, expires_at
+) VALUES (
+  @user_id, @name, @token_hash, @token_prefix, @scope, sqlc.narg('expires_at')
+) RETURNING *;
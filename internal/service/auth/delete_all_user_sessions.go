@@ -3,11 +3,22 @@ package auth
 import (
 	"context"
 
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
 	"github.com/google/uuid"
 )
 
 func (s *Service) DeleteAllUserSessions(
 	ctx context.Context, userID uuid.UUID,
 ) error {
-	return s.dbgen.AuthServiceDeleteAllUserSessions(ctx, userID)
+	if err := s.dbgen.AuthServiceDeleteAllUserSessions(ctx, userID); err != nil {
+		return err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionLogout,
+		EntityType: audit.EntityUser,
+		EntityID:   userID,
+	})
+
+	return nil
 }
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/google/uuid"
+)
+
+// DeleteAPIToken deletes an API token only if it belongs to the given user.
+func (s *Service) DeleteAPIToken(
+	ctx context.Context, userID, apiTokenID uuid.UUID,
+) error {
+	err := s.dbgen.AuthServiceDeleteAPIToken(
+		ctx, dbgen.AuthServiceDeleteAPITokenParams{
+			ID:     apiTokenID,
+			UserID: userID,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionDelete,
+		EntityType: audit.EntityAPIToken,
+		EntityID:   apiTokenID,
+	})
+
+	return nil
+}
@@ -3,11 +3,22 @@ package auth
 import (
 	"context"
 
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
 	"github.com/google/uuid"
 )
 
 func (s *Service) DeleteSession(
 	ctx context.Context, sessionID uuid.UUID,
 ) error {
-	return s.dbgen.AuthServiceDeleteSession(ctx, sessionID)
+	if err := s.dbgen.AuthServiceDeleteSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionLogout,
+		EntityType: audit.EntitySession,
+		EntityID:   sessionID,
+	})
+
+	return nil
 }
@@ -0,0 +1,14 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+func (s *Service) GetUserAPITokens(
+	ctx context.Context, userID uuid.UUID,
+) ([]dbgen.ApiToken, error) {
+	return s.dbgen.AuthServiceGetUserAPITokens(ctx, userID)
+}
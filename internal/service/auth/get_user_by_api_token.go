@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/util/cryptoutil"
+)
+
+// GetUserByAPIToken returns the user that owns the given API token if it
+// exists and is not expired.
+func (s *Service) GetUserByAPIToken(
+	ctx context.Context, token string,
+) (bool, dbgen.AuthServiceGetUserByAPITokenRow, error) {
+	if !strings.HasPrefix(token, apiTokenPrefix) {
+		return false, dbgen.AuthServiceGetUserByAPITokenRow{}, nil
+	}
+
+	user, err := s.dbgen.AuthServiceGetUserByAPIToken(
+		ctx, cryptoutil.GetSHA256FromString(token),
+	)
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		return false, user, nil
+	}
+	if err != nil {
+		return false, user, err
+	}
+
+	err = s.dbgen.AuthServiceSetAPITokenLastUsed(ctx, user.ApiTokenID)
+	if err != nil {
+		logger.Error("error setting API token last used", logger.KV{
+			"api_token_id": user.ApiTokenID,
+			"error":        err,
+		})
+	}
+
+	return true, user, nil
+}
@@ -0,0 +1,15 @@
+-- name: AuthServiceGetUserByAPIToken :one
+SELECT
+  users.*,
+  api_tokens.id AS api_token_id,
+  api_tokens.scope AS api_token_scope,
+  instance_settings.require_two_factor
+FROM api_tokens
+JOIN users ON users.id = api_tokens.user_id
+CROSS JOIN instance_settings
+WHERE api_tokens.token_hash = @token_hash
+AND (api_tokens.expires_at IS NULL OR api_tokens.expires_at > NOW())
+AND users.disabled_at IS NULL;
+
+-- name: AuthServiceSetAPITokenLastUsed :exec
+UPDATE api_tokens SET last_used_at = NOW() WHERE id = @id;
@@ -1,7 +1,10 @@
 -- name: AuthServiceGetUserByToken :one
 SELECT
   users.*,
-  sessions.id as session_id
+  sessions.id as session_id,
+  instance_settings.require_two_factor
 FROM sessions
 JOIN users ON users.id = sessions.user_id
-WHERE pgp_sym_decrypt(sessions.token, @encryption_key) = @token::TEXT;
+CROSS JOIN instance_settings
+WHERE pgp_sym_decrypt(sessions.token, @encryption_key) = @token::TEXT
+AND users.disabled_at IS NULL;
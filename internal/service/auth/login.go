@@ -4,35 +4,30 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
 	"github.com/eduardolat/pgbackweb/internal/util/cryptoutil"
 	"github.com/google/uuid"
 )
 
 func (s *Service) Login(
 	ctx context.Context, email, password, ip, userAgent string,
-) (dbgen.AuthServiceLoginCreateSessionRow, error) {
+) (LoginResult, error) {
+	if s.env.PBW_DISABLE_PASSWORD_LOGIN {
+		return LoginResult{}, fmt.Errorf("the password login is disabled")
+	}
+
 	user, err := s.dbgen.AuthServiceLoginGetUserByEmail(ctx, email)
 	if err != nil {
-		return dbgen.AuthServiceLoginCreateSessionRow{}, err
+		s.auditLogin(ctx, audit.ActionLoginFailed, uuid.Nil, email, ip, userAgent)
+		return LoginResult{}, err
 	}
 
 	if err := cryptoutil.VerifyBcryptHash(password, user.Password); err != nil {
-		return dbgen.AuthServiceLoginCreateSessionRow{}, fmt.Errorf("invalid password")
-	}
-
-	session, err := s.dbgen.AuthServiceLoginCreateSession(
-		ctx, dbgen.AuthServiceLoginCreateSessionParams{
-			UserID:        user.ID,
-			Ip:            ip,
-			UserAgent:     userAgent,
-			Token:         uuid.NewString(),
-			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
-		},
-	)
-	if err != nil {
-		return dbgen.AuthServiceLoginCreateSessionRow{}, err
+		s.auditLogin(
+			ctx, audit.ActionLoginFailed, user.ID, user.Email, ip, userAgent,
+		)
+		return LoginResult{}, fmt.Errorf("invalid password")
 	}
 
-	return session, nil
+	return s.finishLogin(ctx, user, ip, userAgent)
 }
@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+)
+
+// OIDCLoginState are the values generated when the login starts that must be
+// kept, usually in a cookie, until the provider redirects back.
+type OIDCLoginState struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+// oidcIdentity is the user authenticated by the OpenID Connect provider.
+type oidcIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified *bool
+	Name          string
+	Groups        []string
+}
+
+// oidcProvider discovers the provider the first time it is used and caches
+// it, a failed discovery is retried on the next login.
+type oidcProvider struct {
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+// OIDCEnabled reports whether the OpenID Connect login is configured.
+func (s *Service) OIDCEnabled() bool {
+	return s.env.OIDCEnabled()
+}
+
+// OIDCProviderName is the name of the provider shown in the login page.
+func (s *Service) OIDCProviderName() string {
+	return s.env.PBW_OIDC_PROVIDER_NAME
+}
+
+// OIDCRedirectURL is the configured callback URL, empty if it has to be
+// built from the request.
+func (s *Service) OIDCRedirectURL() string {
+	return s.env.PBW_OIDC_REDIRECT_URL
+}
+
+// PasswordLoginEnabled reports whether the users can log in with their email
+// and password.
+func (s *Service) PasswordLoginEnabled() bool {
+	return !s.env.PBW_DISABLE_PASSWORD_LOGIN
+}
+
+func (s *Service) getOIDCProvider(ctx context.Context) (*oidc.Provider, error) {
+	if !s.env.OIDCEnabled() {
+		return nil, errors.New("the OpenID Connect login is not configured")
+	}
+
+	s.oidc.mu.Lock()
+	defer s.oidc.mu.Unlock()
+
+	if s.oidc.provider != nil {
+		return s.oidc.provider, nil
+	}
+
+	issuer := strings.TrimSuffix(
+		strings.TrimSuffix(s.env.PBW_OIDC_DISCOVERY_URL, "/"),
+		"/.well-known/openid-configuration",
+	)
+	provider, err := oidc.NewProvider(ctx, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("error discovering the OpenID Connect provider: %w", err)
+	}
+
+	s.oidc.provider = provider
+	return provider, nil
+}
+
+func (s *Service) oauth2Config(
+	provider *oidc.Provider, redirectURL string,
+) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     s.env.PBW_OIDC_CLIENT_ID,
+		ClientSecret: s.env.PBW_OIDC_CLIENT_SECRET,
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  redirectURL,
+		Scopes:       s.env.PBW_OIDC_SCOPES,
+	}
+}
+
+// StartOIDCLogin returns the URL of the provider where the user has to log in
+// and the state to keep until the provider redirects to redirectURL.
+func (s *Service) StartOIDCLogin(
+	ctx context.Context, redirectURL string,
+) (string, OIDCLoginState, error) {
+	provider, err := s.getOIDCProvider(ctx)
+	if err != nil {
+		return "", OIDCLoginState{}, err
+	}
+
+	state := OIDCLoginState{
+		State:    uuid.NewString(),
+		Nonce:    uuid.NewString(),
+		Verifier: oauth2.GenerateVerifier(),
+	}
+
+	authURL := s.oauth2Config(provider, redirectURL).AuthCodeURL(
+		state.State,
+		oidc.Nonce(state.Nonce),
+		oauth2.S256ChallengeOption(state.Verifier),
+	)
+
+	return authURL, state, nil
+}
+
+// exchangeOIDCCode exchanges the authorization code for the tokens and
+// returns the identity of the verified ID token.
+func (s *Service) exchangeOIDCCode(
+	ctx context.Context, code, redirectURL string, state OIDCLoginState,
+) (oidcIdentity, error) {
+	provider, err := s.getOIDCProvider(ctx)
+	if err != nil {
+		return oidcIdentity{}, err
+	}
+
+	token, err := s.oauth2Config(provider, redirectURL).Exchange(
+		ctx, code, oauth2.VerifierOption(state.Verifier),
+	)
+	if err != nil {
+		return oidcIdentity{}, fmt.Errorf("error exchanging the authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return oidcIdentity{}, errors.New("the provider did not return an ID token")
+	}
+
+	idToken, err := provider.Verifier(&oidc.Config{
+		ClientID: s.env.PBW_OIDC_CLIENT_ID,
+	}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return oidcIdentity{}, fmt.Errorf("invalid ID token: %w", err)
+	}
+	if idToken.Nonce != state.Nonce {
+		return oidcIdentity{}, errors.New("invalid ID token nonce")
+	}
+
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return oidcIdentity{}, fmt.Errorf("error decoding the ID token claims: %w", err)
+	}
+
+	identity := oidcIdentity{
+		Subject: idToken.Subject,
+		Groups:  oidcGroups(claims, s.env.PBW_OIDC_GROUPS_CLAIM),
+	}
+	identity.Email, _ = claims["email"].(string)
+	identity.Name, _ = claims["name"].(string)
+	if verified, ok := claims["email_verified"].(bool); ok {
+		identity.EmailVerified = &verified
+	}
+
+	if identity.Email == "" {
+		return oidcIdentity{}, errors.New("the ID token has no email claim")
+	}
+	if identity.Name == "" {
+		identity.Name = identity.Email
+	}
+
+	return identity, nil
+}
@@ -0,0 +1,182 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/util/cryptoutil"
+	"github.com/google/uuid"
+)
+
+// OIDCLogin finishes the OpenID Connect login started by StartOIDCLogin for
+// the user, which is created the first time it logs in. Users with
+// two-factor authentication enabled still have to enter a code.
+func (s *Service) OIDCLogin(
+	ctx context.Context, code, redirectURL string, state OIDCLoginState,
+	ip, userAgent string,
+) (LoginResult, error) {
+	identity, err := s.exchangeOIDCCode(ctx, code, redirectURL, state)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	user, err := s.getOrCreateOIDCUser(ctx, identity)
+	if err != nil {
+		s.auditLogin(
+			ctx, audit.ActionLoginFailed, uuid.Nil, identity.Email, ip, userAgent,
+		)
+		return LoginResult{}, err
+	}
+
+	return s.finishLogin(ctx, user, ip, userAgent)
+}
+
+// getOrCreateOIDCUser returns the user linked to the identity. Existing users
+// are linked by email the first time they log in, only when the provider says
+// that the email is verified, and the other users are created.
+func (s *Service) getOrCreateOIDCUser(
+	ctx context.Context, identity oidcIdentity,
+) (dbgen.User, error) {
+	subject := sql.NullString{String: identity.Subject, Valid: true}
+
+	role, allowed := oidcRole(
+		identity.Groups, s.env.PBW_OIDC_ROLE_MAPPING, s.env.PBW_OIDC_DEFAULT_ROLE,
+	)
+	if !allowed {
+		return dbgen.User{}, fmt.Errorf(
+			"none of the groups of %s is allowed to log in", identity.Email,
+		)
+	}
+
+	user, err := s.dbgen.AuthServiceOIDCGetUserBySubject(ctx, subject)
+	if err == nil {
+		return s.syncOIDCUserRole(ctx, user, role), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return dbgen.User{}, err
+	}
+
+	email := strings.ToLower(identity.Email)
+	user, err = s.dbgen.AuthServiceLoginGetUserByEmail(ctx, email)
+	if err == nil {
+		if !oidcEmailVerified(identity) {
+			return dbgen.User{}, fmt.Errorf(
+				"the email %s is not verified by the provider, so it can't be "+
+					"linked to the existing user", email,
+			)
+		}
+		user, err = s.dbgen.AuthServiceOIDCLinkUser(
+			ctx, dbgen.AuthServiceOIDCLinkUserParams{
+				OidcSubject: subject,
+				ID:          user.ID,
+			},
+		)
+		if err != nil {
+			return dbgen.User{}, err
+		}
+		return s.syncOIDCUserRole(ctx, user, role), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return dbgen.User{}, err
+	}
+
+	return s.createOIDCUser(ctx, identity, role)
+}
+
+// oidcEmailVerified reports whether the provider says that the email of the
+// identity is verified. A missing email_verified claim counts as not
+// verified, otherwise anyone able to set an arbitrary email in the provider
+// could take over the existing users.
+func oidcEmailVerified(identity oidcIdentity) bool {
+	return identity.EmailVerified != nil && *identity.EmailVerified
+}
+
+// createOIDCUser creates the user of the identity, the first user of the
+// instance is always an admin. The password is random so it can only log in
+// with the provider.
+func (s *Service) createOIDCUser(
+	ctx context.Context, identity oidcIdentity, role string,
+) (dbgen.User, error) {
+	usersQty, err := s.dbgen.AuthServiceOIDCGetUsersQty(ctx)
+	if err != nil {
+		return dbgen.User{}, err
+	}
+	if usersQty == 0 {
+		role = users.RoleAdmin
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return dbgen.User{}, err
+	}
+	password, err := cryptoutil.CreateBcryptHash(base64.RawURLEncoding.EncodeToString(b))
+	if err != nil {
+		return dbgen.User{}, err
+	}
+
+	user, err := s.dbgen.AuthServiceOIDCCreateUser(
+		ctx, dbgen.AuthServiceOIDCCreateUserParams{
+			Name:        identity.Name,
+			Email:       identity.Email,
+			Password:    password,
+			Role:        role,
+			OidcSubject: sql.NullString{String: identity.Subject, Valid: true},
+		},
+	)
+	if err != nil {
+		return dbgen.User{}, err
+	}
+
+	// Admins act as admins in every workspace, the other users start in the
+	// default workspace with their role.
+	if role != users.RoleAdmin {
+		err := s.dbgen.AuthServiceOIDCAddToDefaultWorkspace(
+			ctx, dbgen.AuthServiceOIDCAddToDefaultWorkspaceParams{
+				UserID: user.ID,
+				Role:   role,
+			},
+		)
+		if err != nil {
+			return dbgen.User{}, err
+		}
+	}
+
+	return user, nil
+}
+
+// syncOIDCUserRole updates the role of the user to the one mapped from its
+// groups when the role mapping is configured, so the provider stays the
+// source of truth. The last active admin is never demoted.
+func (s *Service) syncOIDCUserRole(
+	ctx context.Context, user dbgen.User, role string,
+) dbgen.User {
+	if len(s.env.PBW_OIDC_ROLE_MAPPING) == 0 || user.Role == role {
+		return user
+	}
+
+	updated, err := s.dbgen.AuthServiceOIDCSetUserRole(
+		ctx, dbgen.AuthServiceOIDCSetUserRoleParams{
+			Role: role,
+			ID:   user.ID,
+		},
+	)
+	if err != nil {
+		logger.Warn("failed to sync the role of the OpenID Connect user", logger.KV{
+			"email": user.Email,
+			"role":  role,
+			"error": err,
+		})
+		return user
+	}
+
+	return updated
+}
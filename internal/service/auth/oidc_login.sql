@@ -0,0 +1,41 @@
+-- name: AuthServiceOIDCGetUserBySubject :one
+SELECT * FROM users WHERE oidc_subject = @oidc_subject;
+
+-- name: AuthServiceOIDCGetUsersQty :one
+SELECT COUNT(*) FROM users;
+
+-- name: AuthServiceOIDCLinkUser :one
+UPDATE users
+SET oidc_subject = @oidc_subject
+WHERE id = @id
+RETURNING *;
+
+-- name: AuthServiceOIDCCreateUser :one
+INSERT INTO users (name, email, password, role, oidc_subject)
+VALUES (@name, lower(@email), @password, @role, @oidc_subject)
+RETURNING *;
+
+-- name: AuthServiceOIDCSetUserRole :one
+UPDATE users
+SET role = @role
+WHERE id = @id
+AND (
+  @role::TEXT = 'admin'
+  OR
+  users.role <> 'admin'
+  OR
+  EXISTS (
+    SELECT 1 FROM users AS others
+    WHERE others.id <> @id
+    AND others.role = 'admin'
+    AND others.disabled_at IS NULL
+  )
+)
+RETURNING *;
+
+-- name: AuthServiceOIDCAddToDefaultWorkspace :exec
+INSERT INTO workspace_members (workspace_id, user_id, role)
+SELECT workspaces.id, @user_id, @role
+FROM workspaces
+WHERE workspaces.is_default
+ON CONFLICT (workspace_id, user_id) DO NOTHING;
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"slices"
+
+	"github.com/eduardolat/pgbackweb/internal/config"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+)
+
+// oidcGroups returns the values of the groups claim, which can be a list or a
+// single string.
+func oidcGroups(claims map[string]any, claim string) []string {
+	switch value := claims[claim].(type) {
+	case string:
+		return []string{value}
+	case []any:
+		groups := make([]string, 0, len(value))
+		for _, v := range value {
+			if group, ok := v.(string); ok {
+				groups = append(groups, group)
+			}
+		}
+		return groups
+	default:
+		return nil
+	}
+}
+
+// oidcRole returns the role with the most permissions among the ones mapped
+// to the groups, or defaultRole if none of the groups is mapped. It returns
+// false when the user must be rejected.
+func oidcRole(
+	groups []string, mapping map[string]string, defaultRole string,
+) (string, bool) {
+	best := -1
+	for _, group := range groups {
+		role, ok := mapping[group]
+		if !ok {
+			continue
+		}
+		// users.Roles goes from the most to the least permissions.
+		if i := slices.Index(users.Roles, role); i >= 0 && (best < 0 || i < best) {
+			best = i
+		}
+	}
+
+	if best >= 0 {
+		return users.Roles[best], true
+	}
+	if defaultRole == config.OIDCDefaultRoleNone || !users.IsValidRole(defaultRole) {
+		return "", false
+	}
+	return defaultRole, true
+}
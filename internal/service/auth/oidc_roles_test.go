@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/eduardolat/pgbackweb/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCGroups(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims map[string]any
+		want   []string
+	}{
+		{"List", map[string]any{"groups": []any{"a", "b"}}, []string{"a", "b"}},
+		{"Single value", map[string]any{"groups": "a"}, []string{"a"}},
+		{"Non string values", map[string]any{"groups": []any{"a", 1}}, []string{"a"}},
+		{"Missing claim", map[string]any{}, nil},
+		{"Invalid claim", map[string]any{"groups": 1}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, oidcGroups(tt.claims, "groups"))
+		})
+	}
+}
+
+func TestOIDCRole(t *testing.T) {
+	mapping := map[string]string{
+		"pbw-admins":    "admin",
+		"pbw-operators": "operator",
+		"pbw-viewers":   "viewer",
+	}
+
+	tests := []struct {
+		name        string
+		groups      []string
+		defaultRole string
+		wantRole    string
+		wantAllowed bool
+	}{
+		{"Mapped group", []string{"pbw-operators"}, "viewer", "operator", true},
+		{"Highest role wins", []string{"pbw-viewers", "pbw-admins", "pbw-operators"}, "viewer", "admin", true},
+		{"Default role", []string{"other"}, "viewer", "viewer", true},
+		{"No groups", nil, "operator", "operator", true},
+		{"Rejected without mapped group", []string{"other"}, config.OIDCDefaultRoleNone, "", false},
+		{"Mapped group with none default", []string{"pbw-viewers"}, config.OIDCDefaultRoleNone, "viewer", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, allowed := oidcRole(tt.groups, mapping, tt.defaultRole)
+			assert.Equal(t, tt.wantRole, role)
+			assert.Equal(t, tt.wantAllowed, allowed)
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/config"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	mockOIDCClientID = "pbw"
+	mockOIDCCode     = "valid-code"
+)
+
+// mockOIDCProvider is a minimal OpenID Connect provider that issues an ID
+// token for mockOIDCCode, it records the PKCE challenge of the last login so
+// the token endpoint can check the verifier.
+type mockOIDCProvider struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	challenge string
+	// claims returns the claims of the ID token for the nonce of the login.
+	claims func(issuer, nonce string) map[string]any
+}
+
+func newMockOIDCProvider(t *testing.T) *mockOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	m := &mockOIDCProvider{key: key}
+	m.claims = func(issuer, nonce string) map[string]any {
+		return map[string]any{
+			"iss":            issuer,
+			"aud":            mockOIDCClientID,
+			"sub":            "user-1",
+			"exp":            time.Now().Add(time.Hour).Unix(),
+			"iat":            time.Now().Unix(),
+			"nonce":          nonce,
+			"email":          "John@Example.com",
+			"email_verified": true,
+			"name":           "John Doe",
+			"groups":         []string{"pbw-operators", "everyone"},
+		}
+	}
+
+	var nonce string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                m.server.URL,
+			"authorization_endpoint":                m.server.URL + "/authorize",
+			"token_endpoint":                        m.server.URL + "/token",
+			"jwks_uri":                              m.server.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+			Key: &m.key.PublicKey, KeyID: "test", Algorithm: "RS256", Use: "sig",
+		}}})
+	})
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		nonce = r.URL.Query().Get("nonce")
+		m.challenge = r.URL.Query().Get("code_challenge")
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		verifierOK := base64.RawURLEncoding.EncodeToString(sum[:]) == m.challenge
+		if r.PostForm.Get("code") != mockOIDCCode || !verifierOK {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     m.sign(t, m.claims(m.server.URL, nonce)),
+		})
+	})
+
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+func (m *mockOIDCProvider) sign(t *testing.T, claims map[string]any) string {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: m.key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", "test"),
+	)
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed, err := signer.Sign(payload)
+	require.NoError(t, err)
+	token, err := signed.CompactSerialize()
+	require.NoError(t, err)
+	return token
+}
+
+// login starts the login against the provider and follows the redirect to
+// the authorization endpoint, like the browser would.
+func (m *mockOIDCProvider) login(
+	t *testing.T, s *Service, redirectURL string,
+) OIDCLoginState {
+	authURL, state, err := s.StartOIDCLogin(context.Background(), redirectURL)
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, state.State, parsed.Query().Get("state"))
+	assert.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+
+	res, err := http.Get(authURL)
+	require.NoError(t, err)
+	_ = res.Body.Close()
+
+	return state
+}
+
+func newOIDCTestService(discoveryURL string) *Service {
+	return New(config.Env{
+		PBW_OIDC_DISCOVERY_URL: discoveryURL,
+		PBW_OIDC_CLIENT_ID:     mockOIDCClientID,
+		PBW_OIDC_CLIENT_SECRET: "secret",
+		PBW_OIDC_SCOPES:        []string{"openid", "profile", "email"},
+		PBW_OIDC_GROUPS_CLAIM:  "groups",
+	}, nil)
+}
+
+func TestExchangeOIDCCode(t *testing.T) {
+	const redirectURL = "http://localhost:8085/auth/oidc/callback"
+	ctx := context.Background()
+
+	t.Run("Valid login", func(t *testing.T) {
+		m := newMockOIDCProvider(t)
+		s := newOIDCTestService(m.server.URL + "/.well-known/openid-configuration")
+		state := m.login(t, s, redirectURL)
+
+		identity, err := s.exchangeOIDCCode(ctx, mockOIDCCode, redirectURL, state)
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", identity.Subject)
+		assert.Equal(t, "John@Example.com", identity.Email)
+		assert.Equal(t, "John Doe", identity.Name)
+		assert.Equal(t, []string{"pbw-operators", "everyone"}, identity.Groups)
+		require.NotNil(t, identity.EmailVerified)
+		assert.True(t, *identity.EmailVerified)
+	})
+
+	t.Run("Invalid code", func(t *testing.T) {
+		m := newMockOIDCProvider(t)
+		s := newOIDCTestService(m.server.URL)
+		state := m.login(t, s, redirectURL)
+
+		_, err := s.exchangeOIDCCode(ctx, "invalid-code", redirectURL, state)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid code verifier", func(t *testing.T) {
+		m := newMockOIDCProvider(t)
+		s := newOIDCTestService(m.server.URL)
+		state := m.login(t, s, redirectURL)
+		state.Verifier = "another-verifier"
+
+		_, err := s.exchangeOIDCCode(ctx, mockOIDCCode, redirectURL, state)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid nonce", func(t *testing.T) {
+		m := newMockOIDCProvider(t)
+		s := newOIDCTestService(m.server.URL)
+		state := m.login(t, s, redirectURL)
+		state.Nonce = "another-nonce"
+
+		_, err := s.exchangeOIDCCode(ctx, mockOIDCCode, redirectURL, state)
+		assert.ErrorContains(t, err, "nonce")
+	})
+
+	t.Run("Invalid audience", func(t *testing.T) {
+		m := newMockOIDCProvider(t)
+		claims := m.claims
+		m.claims = func(issuer, nonce string) map[string]any {
+			c := claims(issuer, nonce)
+			c["aud"] = "another-client"
+			return c
+		}
+		s := newOIDCTestService(m.server.URL)
+		state := m.login(t, s, redirectURL)
+
+		_, err := s.exchangeOIDCCode(ctx, mockOIDCCode, redirectURL, state)
+		assert.ErrorContains(t, err, "invalid ID token")
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		m := newMockOIDCProvider(t)
+		claims := m.claims
+		m.claims = func(issuer, nonce string) map[string]any {
+			c := claims(issuer, nonce)
+			c["exp"] = time.Now().Add(-time.Hour).Unix()
+			return c
+		}
+		s := newOIDCTestService(m.server.URL)
+		state := m.login(t, s, redirectURL)
+
+		_, err := s.exchangeOIDCCode(ctx, mockOIDCCode, redirectURL, state)
+		assert.ErrorContains(t, err, "invalid ID token")
+	})
+
+	t.Run("Missing email", func(t *testing.T) {
+		m := newMockOIDCProvider(t)
+		claims := m.claims
+		m.claims = func(issuer, nonce string) map[string]any {
+			c := claims(issuer, nonce)
+			delete(c, "email")
+			return c
+		}
+		s := newOIDCTestService(m.server.URL)
+		state := m.login(t, s, redirectURL)
+
+		_, err := s.exchangeOIDCCode(ctx, mockOIDCCode, redirectURL, state)
+		assert.ErrorContains(t, err, "email")
+	})
+
+	t.Run("Not configured", func(t *testing.T) {
+		s := newOIDCTestService("")
+		_, _, err := s.StartOIDCLogin(ctx, redirectURL)
+		assert.Error(t, err)
+	})
+}
+
+func TestOIDCEmailVerified(t *testing.T) {
+	verified, notVerified := true, false
+
+	tests := []struct {
+		name          string
+		emailVerified *bool
+		want          bool
+	}{
+		{"Verified", &verified, true},
+		{"Not verified", &notVerified, false},
+		{"Missing claim", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity := oidcIdentity{EmailVerified: tt.emailVerified}
+			assert.Equal(t, tt.want, oidcEmailVerified(identity))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/hotp"
+)
+
+const (
+	totpIssuer = "PG Back Web"
+	totpPeriod = 30
+	// totpSkew is the number of time steps accepted before and after the
+	// current one, to tolerate clock drift.
+	totpSkew = 1
+
+	recoveryCodesQty = 10
+
+	loginChallengeMaxAge      = time.Minute * 5
+	loginChallengeMaxAttempts = 5
+)
+
+// totpCounter returns the time step of t.
+func totpCounter(t time.Time) int64 {
+	return t.Unix() / totpPeriod
+}
+
+// verifyTOTP returns the time step of code if it's valid at t and newer than
+// lastCounter, so every code can only be used once.
+func verifyTOTP(
+	secret, code string, t time.Time, lastCounter int64,
+) (int64, bool) {
+	code = normalizeCode(code)
+	if len(code) != 6 {
+		return 0, false
+	}
+
+	current := totpCounter(t)
+	for counter := current - totpSkew; counter <= current+totpSkew; counter++ {
+		if counter <= lastCounter {
+			continue
+		}
+
+		expected, err := hotp.GenerateCodeCustom(
+			secret, uint64(counter), hotp.ValidateOpts{
+				Digits:    otp.DigitsSix,
+				Algorithm: otp.AlgorithmSHA1,
+			},
+		)
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return counter, true
+		}
+	}
+
+	return 0, false
+}
+
+// generateRecoveryCodes returns new random recovery codes in the format
+// xxxxx-xxxxx.
+func generateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, recoveryCodesQty)
+	for range recoveryCodesQty {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		code := hex.EncodeToString(b)
+		codes = append(codes, code[:5]+"-"+code[5:])
+	}
+	return codes, nil
+}
+
+// normalizeCode removes the spaces and dashes that users may type in the
+// TOTP and recovery codes.
+func normalizeCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer(" ", "", "-", "").Replace(code)
+}
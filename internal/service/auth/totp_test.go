@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyTOTP(t *testing.T) {
+	const secret = "JBSWY3DPEHPK3PXP"
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	codeAt := func(t *testing.T, at time.Time) string {
+		code, err := totp.GenerateCodeCustom(secret, at, totp.ValidateOpts{
+			Period: totpPeriod, Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1,
+		})
+		require.NoError(t, err)
+		return code
+	}
+
+	tests := []struct {
+		name        string
+		code        func(t *testing.T) string
+		lastCounter int64
+		wantCounter int64
+		wantOK      bool
+	}{
+		{
+			name:        "Current code",
+			code:        func(t *testing.T) string { return codeAt(t, now) },
+			wantCounter: totpCounter(now),
+			wantOK:      true,
+		},
+		{
+			name: "Code with spaces",
+			code: func(t *testing.T) string {
+				code := codeAt(t, now)
+				return code[:3] + " " + code[3:]
+			},
+			wantCounter: totpCounter(now),
+			wantOK:      true,
+		},
+		{
+			name:        "Previous time step",
+			code:        func(t *testing.T) string { return codeAt(t, now.Add(-totpPeriod*time.Second)) },
+			wantCounter: totpCounter(now) - 1,
+			wantOK:      true,
+		},
+		{
+			name:   "Too old",
+			code:   func(t *testing.T) string { return codeAt(t, now.Add(-3*totpPeriod*time.Second)) },
+			wantOK: false,
+		},
+		{
+			name:        "Already used",
+			code:        func(t *testing.T) string { return codeAt(t, now) },
+			lastCounter: totpCounter(now),
+			wantOK:      false,
+		},
+		{
+			name:   "Invalid code",
+			code:   func(t *testing.T) string { return "abcdef" },
+			wantOK: false,
+		},
+		{
+			name:   "Invalid length",
+			code:   func(t *testing.T) string { return "12345" },
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter, ok := verifyTOTP(secret, tt.code(t), now, tt.lastCounter)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.wantCounter, counter)
+			}
+		})
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := generateRecoveryCodes()
+	require.NoError(t, err)
+	assert.Len(t, codes, recoveryCodesQty)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{5}-[0-9a-f]{5}$`), code)
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+}
+
+func TestNormalizeCode(t *testing.T) {
+	assert.Equal(t, "123456", normalizeCode(" 123 456 "))
+	assert.Equal(t, "abcde12345", normalizeCode("ABCDE-12345"))
+}
@@ -0,0 +1,304 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image/png"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/util/cryptoutil"
+	"github.com/google/uuid"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+// ErrInvalidTwoFactorCode is returned when the TOTP or recovery code is
+// invalid or was already used.
+var ErrInvalidTwoFactorCode = errors.New("invalid two-factor authentication code")
+
+// TOTPSetup is what the user needs to add the account to an authenticator
+// app.
+type TOTPSetup struct {
+	Secret string
+	URL    string
+	// QRCode is the URL as a PNG data URI.
+	QRCode string
+}
+
+// StartTOTPSetup generates a new TOTP secret for the user, which is stored
+// encrypted but not enabled until the user enters a valid code.
+func (s *Service) StartTOTPSetup(
+	ctx context.Context, user dbgen.User,
+) (TOTPSetup, error) {
+	if user.TotpEnabledAt.Valid {
+		return TOTPSetup{}, errors.New("two-factor authentication is already enabled")
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      totpIssuer,
+		AccountName: user.Email,
+		Period:      totpPeriod,
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return TOTPSetup{}, fmt.Errorf("error generating the TOTP secret: %w", err)
+	}
+
+	rows, err := s.dbgen.AuthServiceSetTOTPSecret(
+		ctx, dbgen.AuthServiceSetTOTPSecretParams{
+			Secret:        key.Secret(),
+			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
+			ID:            user.ID,
+		},
+	)
+	if err != nil {
+		return TOTPSetup{}, err
+	}
+	if rows == 0 {
+		return TOTPSetup{}, errors.New("two-factor authentication is already enabled")
+	}
+
+	img, err := key.Image(256, 256)
+	if err != nil {
+		return TOTPSetup{}, fmt.Errorf("error generating the QR code: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return TOTPSetup{}, fmt.Errorf("error generating the QR code: %w", err)
+	}
+
+	return TOTPSetup{
+		Secret: key.Secret(),
+		URL:    key.URL(),
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}, nil
+}
+
+// EnableTOTP enables the two-factor authentication of the user after
+// checking a code of the secret generated by StartTOTPSetup, and returns the
+// recovery codes.
+func (s *Service) EnableTOTP(
+	ctx context.Context, userID uuid.UUID, code string,
+) ([]string, error) {
+	secret, err := s.getTOTPSecret(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if secret.TotpEnabledAt.Valid {
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+
+	counter, ok := verifyTOTP(secret.Secret, code, time.Now(), 0)
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	rows, err := s.dbgen.AuthServiceEnableTOTP(
+		ctx, dbgen.AuthServiceEnableTOTPParams{
+			Counter: counter,
+			ID:      userID,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if rows == 0 {
+		return nil, errors.New("two-factor authentication is already enabled")
+	}
+
+	codes, err := s.replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditTwoFactor(ctx, userID, twoFactorState{}, twoFactorState{
+		TwoFactorEnabled: true, RecoveryCodes: int64(len(codes)),
+	})
+	return codes, nil
+}
+
+// DisableTOTP disables the two-factor authentication of the user after
+// checking a TOTP or recovery code. It's not allowed when the two-factor
+// authentication is required for everyone.
+func (s *Service) DisableTOTP(
+	ctx context.Context, userID uuid.UUID, code string,
+) error {
+	required, err := s.dbgen.AuthServiceGetRequireTwoFactor(ctx)
+	if err != nil {
+		return err
+	}
+	if required {
+		return errors.New(
+			"two-factor authentication is required by the administrators",
+		)
+	}
+
+	if err := s.verifySecondFactor(ctx, userID, code); err != nil {
+		return err
+	}
+
+	unusedCodes, err := s.dbgen.AuthServiceGetUnusedRecoveryCodesQty(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.dbgen.AuthServiceDisableTOTP(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.dbgen.AuthServiceDeleteRecoveryCodes(ctx, userID); err != nil {
+		return err
+	}
+
+	s.auditTwoFactor(ctx, userID, twoFactorState{
+		TwoFactorEnabled: true, RecoveryCodes: unusedCodes,
+	}, twoFactorState{})
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the user after
+// checking a TOTP code.
+func (s *Service) RegenerateRecoveryCodes(
+	ctx context.Context, userID uuid.UUID, code string,
+) ([]string, error) {
+	if len(normalizeCode(code)) != 6 {
+		return nil, ErrInvalidTwoFactorCode
+	}
+	if err := s.verifySecondFactor(ctx, userID, code); err != nil {
+		return nil, err
+	}
+
+	unusedCodes, err := s.dbgen.AuthServiceGetUnusedRecoveryCodesQty(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.replaceRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditTwoFactor(ctx, userID, twoFactorState{
+		TwoFactorEnabled: true, RecoveryCodes: unusedCodes,
+	}, twoFactorState{
+		TwoFactorEnabled: true, RecoveryCodes: int64(len(codes)),
+	})
+	return codes, nil
+}
+
+// GetUnusedRecoveryCodesQty returns how many recovery codes the user has
+// left.
+func (s *Service) GetUnusedRecoveryCodesQty(
+	ctx context.Context, userID uuid.UUID,
+) (int64, error) {
+	return s.dbgen.AuthServiceGetUnusedRecoveryCodesQty(ctx, userID)
+}
+
+// IsTwoFactorRequired reports whether the administrators require the
+// two-factor authentication for everyone.
+func (s *Service) IsTwoFactorRequired(ctx context.Context) (bool, error) {
+	return s.dbgen.AuthServiceGetRequireTwoFactor(ctx)
+}
+
+func (s *Service) getTOTPSecret(
+	ctx context.Context, userID uuid.UUID,
+) (dbgen.AuthServiceGetTOTPSecretRow, error) {
+	secret, err := s.dbgen.AuthServiceGetTOTPSecret(
+		ctx, dbgen.AuthServiceGetTOTPSecretParams{
+			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
+			ID:            userID,
+		},
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return secret, errors.New("two-factor authentication is not set up")
+	}
+	return secret, err
+}
+
+// verifySecondFactor checks a TOTP code, or a recovery code, of a user with
+// two-factor authentication enabled and marks it as used.
+func (s *Service) verifySecondFactor(
+	ctx context.Context, userID uuid.UUID, code string,
+) error {
+	secret, err := s.getTOTPSecret(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !secret.TotpEnabledAt.Valid {
+		return errors.New("two-factor authentication is not enabled")
+	}
+
+	code = normalizeCode(code)
+	if len(code) == 6 {
+		counter, ok := verifyTOTP(
+			secret.Secret, code, time.Now(), secret.TotpLastCounter,
+		)
+		if !ok {
+			return ErrInvalidTwoFactorCode
+		}
+
+		// The update only succeeds once per time step, so concurrent logins
+		// can't reuse the same code.
+		rows, err := s.dbgen.AuthServiceUseTOTPCounter(
+			ctx, dbgen.AuthServiceUseTOTPCounterParams{
+				Counter: counter,
+				ID:      userID,
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	rows, err := s.dbgen.AuthServiceUseRecoveryCode(
+		ctx, dbgen.AuthServiceUseRecoveryCodeParams{
+			UserID:   userID,
+			CodeHash: cryptoutil.GetSHA256FromString(code),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+func (s *Service) replaceRecoveryCodes(
+	ctx context.Context, userID uuid.UUID,
+) ([]string, error) {
+	codes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("error generating the recovery codes: %w", err)
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, cryptoutil.GetSHA256FromString(normalizeCode(code)))
+	}
+
+	if err := s.dbgen.AuthServiceDeleteRecoveryCodes(ctx, userID); err != nil {
+		return nil, err
+	}
+	err = s.dbgen.AuthServiceCreateRecoveryCodes(
+		ctx, dbgen.AuthServiceCreateRecoveryCodesParams{
+			UserID:     userID,
+			CodeHashes: hashes,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,55 @@
+-- name: AuthServiceSetTOTPSecret :execrows
+UPDATE users
+SET totp_secret = pgp_sym_encrypt(@secret::TEXT, @encryption_key::TEXT)
+WHERE id = @id
+AND totp_enabled_at IS NULL;
+
+-- name: AuthServiceGetTOTPSecret :one
+SELECT
+  pgp_sym_decrypt(totp_secret, @encryption_key::TEXT)::TEXT AS secret,
+  totp_enabled_at,
+  totp_last_counter
+FROM users
+WHERE id = @id
+AND totp_secret IS NOT NULL
+AND disabled_at IS NULL;
+
+-- name: AuthServiceEnableTOTP :execrows
+UPDATE users
+SET totp_enabled_at = NOW(), totp_last_counter = @counter
+WHERE id = @id
+AND totp_secret IS NOT NULL
+AND totp_enabled_at IS NULL;
+
+-- name: AuthServiceUseTOTPCounter :execrows
+UPDATE users
+SET totp_last_counter = @counter
+WHERE id = @id
+AND totp_last_counter < @counter;
+
+-- name: AuthServiceDisableTOTP :exec
+UPDATE users
+SET totp_secret = NULL, totp_enabled_at = NULL, totp_last_counter = 0
+WHERE id = @id;
+
+-- name: AuthServiceDeleteRecoveryCodes :exec
+DELETE FROM user_recovery_codes WHERE user_id = @user_id;
+
+-- name: AuthServiceCreateRecoveryCodes :exec
+INSERT INTO user_recovery_codes (user_id, code_hash)
+SELECT @user_id, unnest(@code_hashes::TEXT[]);
+
+-- name: AuthServiceUseRecoveryCode :execrows
+UPDATE user_recovery_codes
+SET used_at = NOW()
+WHERE user_id = @user_id
+AND code_hash = @code_hash
+AND used_at IS NULL;
+
+-- name: AuthServiceGetUnusedRecoveryCodesQty :one
+SELECT COUNT(*) FROM user_recovery_codes
+WHERE user_id = @user_id
+AND used_at IS NULL;
+
+-- name: AuthServiceGetRequireTwoFactor :one
+SELECT require_two_factor FROM instance_settings;
@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/util/cryptoutil"
+	"github.com/google/uuid"
+)
+
+// ErrLoginChallengeExpired is returned when the second step of the login is
+// too late or has too many failed attempts, the user has to log in again.
+var ErrLoginChallengeExpired = errors.New("the login has expired, please log in again")
+
+// LoginResult is the session of the user that logged in, or the token of
+// the login challenge when the user has to enter a two-factor code to get
+// the session.
+type LoginResult struct {
+	Session        dbgen.AuthServiceLoginCreateSessionRow
+	ChallengeToken string
+}
+
+// finishLogin creates the session of the user, or a login challenge if the
+// user has two-factor authentication enabled.
+func (s *Service) finishLogin(
+	ctx context.Context, user dbgen.User, ip, userAgent string,
+) (LoginResult, error) {
+	if user.DisabledAt.Valid {
+		s.auditLogin(
+			ctx, audit.ActionLoginFailed, user.ID, user.Email, ip, userAgent,
+		)
+		return LoginResult{}, fmt.Errorf("the user is disabled")
+	}
+
+	if user.TotpEnabledAt.Valid {
+		token, err := s.createLoginChallenge(ctx, user.ID)
+		if err != nil {
+			return LoginResult{}, err
+		}
+		return LoginResult{ChallengeToken: token}, nil
+	}
+
+	session, err := s.createSession(ctx, user.ID, ip, userAgent)
+	if err != nil {
+		return LoginResult{}, err
+	}
+
+	s.auditLogin(ctx, audit.ActionLogin, user.ID, user.Email, ip, userAgent)
+	return LoginResult{Session: session}, nil
+}
+
+func (s *Service) createSession(
+	ctx context.Context, userID uuid.UUID, ip, userAgent string,
+) (dbgen.AuthServiceLoginCreateSessionRow, error) {
+	return s.dbgen.AuthServiceLoginCreateSession(
+		ctx, dbgen.AuthServiceLoginCreateSessionParams{
+			UserID:        userID,
+			Ip:            ip,
+			UserAgent:     userAgent,
+			Token:         uuid.NewString(),
+			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
+		},
+	)
+}
+
+func (s *Service) createLoginChallenge(
+	ctx context.Context, userID uuid.UUID,
+) (string, error) {
+	err := s.dbgen.AuthServiceDeleteOldLoginChallenges(
+		ctx, time.Now().Add(-loginChallengeMaxAge),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating the login challenge: %w", err)
+	}
+	token := hex.EncodeToString(b)
+
+	err = s.dbgen.AuthServiceCreateLoginChallenge(
+		ctx, dbgen.AuthServiceCreateLoginChallengeParams{
+			UserID:    userID,
+			TokenHash: cryptoutil.GetSHA256FromString(token),
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// VerifyTwoFactorLogin finishes the login of the challenge with a TOTP or
+// recovery code and creates the session of the user.
+func (s *Service) VerifyTwoFactorLogin(
+	ctx context.Context, challengeToken, code, ip, userAgent string,
+) (dbgen.AuthServiceLoginCreateSessionRow, error) {
+	tokenHash := cryptoutil.GetSHA256FromString(challengeToken)
+
+	challenge, err := s.dbgen.AuthServiceAttemptLoginChallenge(
+		ctx, dbgen.AuthServiceAttemptLoginChallengeParams{
+			TokenHash:    tokenHash,
+			MaxAttempts:  loginChallengeMaxAttempts,
+			CreatedAfter: time.Now().Add(-loginChallengeMaxAge),
+		},
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dbgen.AuthServiceLoginCreateSessionRow{}, ErrLoginChallengeExpired
+	}
+	if err != nil {
+		return dbgen.AuthServiceLoginCreateSessionRow{}, err
+	}
+
+	if err := s.verifySecondFactor(ctx, challenge.UserID, code); err != nil {
+		s.auditLogin(
+			ctx, audit.ActionLoginFailed, challenge.UserID, challenge.UserEmail,
+			ip, userAgent,
+		)
+		return dbgen.AuthServiceLoginCreateSessionRow{}, err
+	}
+
+	if err := s.dbgen.AuthServiceDeleteLoginChallenge(ctx, tokenHash); err != nil {
+		return dbgen.AuthServiceLoginCreateSessionRow{}, err
+	}
+
+	session, err := s.createSession(ctx, challenge.UserID, ip, userAgent)
+	if err != nil {
+		return dbgen.AuthServiceLoginCreateSessionRow{}, err
+	}
+
+	s.auditLogin(
+		ctx, audit.ActionLogin, challenge.UserID, challenge.UserEmail,
+		ip, userAgent,
+	)
+	return session, nil
+}
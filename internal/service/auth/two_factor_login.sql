@@ -0,0 +1,19 @@
+-- name: AuthServiceCreateLoginChallenge :exec
+INSERT INTO login_challenges (user_id, token_hash)
+VALUES (@user_id, @token_hash);
+
+-- name: AuthServiceDeleteOldLoginChallenges :exec
+DELETE FROM login_challenges WHERE created_at < @created_before;
+
+-- name: AuthServiceAttemptLoginChallenge :one
+UPDATE login_challenges
+SET attempts = login_challenges.attempts + 1
+FROM users
+WHERE users.id = login_challenges.user_id
+AND login_challenges.token_hash = @token_hash
+AND login_challenges.attempts < @max_attempts
+AND login_challenges.created_at >= @created_after
+RETURNING login_challenges.user_id, users.email AS user_email;
+
+-- name: AuthServiceDeleteLoginChallenge :exec
+DELETE FROM login_challenges WHERE token_hash = @token_hash;
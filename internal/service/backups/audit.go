@@ -0,0 +1,22 @@
+package backups
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+// auditSnapshot returns the backup to record its changes in the audit log,
+// or nil if it can't be read.
+func (s *Service) auditSnapshot(
+	ctx context.Context, id uuid.UUID,
+) *dbgen.Backup {
+	backup, err := s.dbgen.BackupsServiceGetBackup(
+		ctx, dbgen.BackupsServiceGetBackupParams{ID: id},
+	)
+	if err != nil {
+		return nil
+	}
+	return &backup
+}
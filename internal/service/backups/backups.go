@@ -4,22 +4,26 @@ import (
 	"github.com/eduardolat/pgbackweb/internal/cron"
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
 	"github.com/eduardolat/pgbackweb/internal/service/executions"
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
 )
 
 type Service struct {
 	dbgen             *dbgen.Queries
 	cr                *cron.Cron
 	executionsService *executions.Service
+	webhooksService   *webhooks.Service
 }
 
 func New(
 	dbgen *dbgen.Queries,
 	cr *cron.Cron,
 	executionsService *executions.Service,
+	webhooksService *webhooks.Service,
 ) *Service {
 	return &Service{
 		dbgen:             dbgen,
 		cr:                cr,
 		executionsService: executionsService,
+		webhooksService:   webhooksService,
 	}
 }
@@ -0,0 +1,158 @@
+package backups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// validateRunAfter checks that running the backup after runAfterID doesn't
+// create a loop of backups that start each other, and that it doesn't make a
+// backup of a group run after another backup of the same group.
+func (s *Service) validateRunAfter(
+	ctx context.Context, backupID uuid.UUID, runAfterID uuid.NullUUID,
+) error {
+	if !runAfterID.Valid {
+		return nil
+	}
+	if runAfterID.UUID == backupID {
+		return errors.New("a backup can't run after itself")
+	}
+
+	runAfter, err := s.getRunAfter(ctx)
+	if err != nil {
+		return err
+	}
+
+	if createsCycle(backupID, runAfterID.UUID, runAfter) {
+		return errors.New(
+			"the backup to run after already runs after this backup",
+		)
+	}
+
+	runAfter[backupID] = runAfterID.UUID
+	groups, err := s.dbgen.BackupsServiceGetAllBackupGroupBackupIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, backupIDs := range groups {
+		if runsAfterMember(backupIDs, runAfter) {
+			return errors.New(
+				"the backup to run after is in a group with this backup or with " +
+					"a backup that runs after it",
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateGroupChains checks that no backup of the group runs after another
+// backup of the group, it would be started both by the group and by the
+// backup it runs after.
+func (s *Service) validateGroupChains(
+	ctx context.Context, backupIDs []uuid.UUID,
+) error {
+	runAfter, err := s.getRunAfter(ctx)
+	if err != nil {
+		return err
+	}
+
+	if runsAfterMember(backupIDs, runAfter) {
+		return errors.New(
+			"a backup of the group can't run after another backup of the group",
+		)
+	}
+
+	return nil
+}
+
+// getRunAfter returns the backup that every chained backup runs after.
+func (s *Service) getRunAfter(
+	ctx context.Context,
+) (map[uuid.UUID]uuid.UUID, error) {
+	edges, err := s.dbgen.BackupsServiceGetRunAfterEdges(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	runAfter := make(map[uuid.UUID]uuid.UUID, len(edges))
+	for _, edge := range edges {
+		runAfter[edge.ID] = edge.RunAfterBackupID
+	}
+	return runAfter, nil
+}
+
+// ValidateChainNames checks the backups and groups that reference each other
+// by name, like the ones of the configuration file: no backup can run after
+// itself, directly or through other backups, and no backup of a group can run
+// after another backup of the same group. runAfter is the name of the backup
+// that every backup runs after, and groups are the names of the backups of
+// every group by group name.
+func ValidateChainNames(
+	runAfter map[string]string, groups map[string][]string,
+) error {
+	for name, runAfterName := range runAfter {
+		if name == runAfterName {
+			return fmt.Errorf("backup %q can't run after itself", name)
+		}
+		if createsCycle(name, runAfterName, runAfter) {
+			return fmt.Errorf(
+				"backup %q runs after itself through other backups", name,
+			)
+		}
+	}
+
+	for name, backupNames := range groups {
+		if runsAfterMember(backupNames, runAfter) {
+			return fmt.Errorf(
+				"backup group %q: a backup of the group can't run after another "+
+					"backup of the group",
+				name,
+			)
+		}
+	}
+
+	return nil
+}
+
+// createsCycle reports whether making backupID run after runAfterID creates
+// a cycle, given the backup that every other backup runs after.
+func createsCycle[T comparable](backupID, runAfterID T, runAfter map[T]T) bool {
+	visited := map[T]bool{}
+	for current, ok := runAfterID, true; ok; current, ok = runAfter[current] {
+		if current == backupID {
+			return true
+		}
+		if visited[current] {
+			return false
+		}
+		visited[current] = true
+	}
+	return false
+}
+
+// runsAfterMember reports whether any of the backups runs after another one
+// of them, directly or through other backups, given the backup that every
+// backup runs after.
+func runsAfterMember[T comparable](backupIDs []T, runAfter map[T]T) bool {
+	members := make(map[T]bool, len(backupIDs))
+	for _, id := range backupIDs {
+		members[id] = true
+	}
+
+	for _, id := range backupIDs {
+		visited := map[T]bool{id: true}
+		current, ok := runAfter[id]
+		for ok && !visited[current] {
+			if members[current] {
+				return true
+			}
+			visited[current] = true
+			current, ok = runAfter[current]
+		}
+	}
+	return false
+}
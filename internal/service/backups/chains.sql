@@ -0,0 +1,20 @@
+-- name: BackupsServiceIsChained :one
+SELECT (
+  EXISTS (
+    SELECT 1 FROM backups
+    WHERE id = @backup_id AND run_after_backup_id IS NOT NULL
+  )
+  OR
+  EXISTS (
+    SELECT 1 FROM backup_groups
+    WHERE is_active = TRUE AND @backup_id::UUID = ANY(backup_ids)
+  )
+)::BOOLEAN;
+
+-- name: BackupsServiceGetRunAfterEdges :many
+SELECT id, run_after_backup_id::UUID AS run_after_backup_id
+FROM backups
+WHERE run_after_backup_id IS NOT NULL;
+
+-- name: BackupsServiceGetAllBackupGroupBackupIDs :many
+SELECT backup_ids FROM backup_groups;
@@ -0,0 +1,156 @@
+package backups
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatesCycle(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name       string
+		backupID   uuid.UUID
+		runAfterID uuid.UUID
+		runAfter   map[uuid.UUID]uuid.UUID
+		want       bool
+	}{
+		{
+			name:       "No other chains",
+			backupID:   a,
+			runAfterID: b,
+			runAfter:   map[uuid.UUID]uuid.UUID{},
+			want:       false,
+		},
+		{
+			name:       "Extends a chain",
+			backupID:   c,
+			runAfterID: b,
+			runAfter:   map[uuid.UUID]uuid.UUID{b: a},
+			want:       false,
+		},
+		{
+			name:       "Runs after a backup that runs after it",
+			backupID:   a,
+			runAfterID: b,
+			runAfter:   map[uuid.UUID]uuid.UUID{b: a},
+			want:       true,
+		},
+		{
+			name:       "Closes a longer chain",
+			backupID:   a,
+			runAfterID: d,
+			runAfter:   map[uuid.UUID]uuid.UUID{b: a, c: b, d: c},
+			want:       true,
+		},
+		{
+			name:       "Replaces its own run after",
+			backupID:   b,
+			runAfterID: c,
+			runAfter:   map[uuid.UUID]uuid.UUID{b: a},
+			want:       false,
+		},
+		{
+			name:       "Existing cycle elsewhere",
+			backupID:   a,
+			runAfterID: b,
+			runAfter:   map[uuid.UUID]uuid.UUID{b: c, c: d, d: b},
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := createsCycle(tt.backupID, tt.runAfterID, tt.runAfter)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunsAfterMember(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name      string
+		backupIDs []uuid.UUID
+		runAfter  map[uuid.UUID]uuid.UUID
+		want      bool
+	}{
+		{
+			name:      "No chains",
+			backupIDs: []uuid.UUID{a, b},
+			runAfter:  map[uuid.UUID]uuid.UUID{},
+			want:      false,
+		},
+		{
+			name:      "Runs after a backup outside the group",
+			backupIDs: []uuid.UUID{a, b},
+			runAfter:  map[uuid.UUID]uuid.UUID{b: c},
+			want:      false,
+		},
+		{
+			name:      "Runs after another backup of the group",
+			backupIDs: []uuid.UUID{a, b},
+			runAfter:  map[uuid.UUID]uuid.UUID{b: a},
+			want:      true,
+		},
+		{
+			name:      "Runs after another backup of the group through others",
+			backupIDs: []uuid.UUID{a, d},
+			runAfter:  map[uuid.UUID]uuid.UUID{b: a, c: b, d: c},
+			want:      true,
+		},
+		{
+			name:      "Existing cycle outside the group",
+			backupIDs: []uuid.UUID{a},
+			runAfter:  map[uuid.UUID]uuid.UUID{a: b, b: c, c: b},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runsAfterMember(tt.backupIDs, tt.runAfter)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateChainNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		runAfter map[string]string
+		groups   map[string][]string
+		wantErr  string
+	}{
+		{
+			name:     "Valid chains and groups",
+			runAfter: map[string]string{"b": "a", "c": "b"},
+			groups:   map[string][]string{"nightly": {"a", "d"}},
+		},
+		{
+			name:     "Cycle",
+			runAfter: map[string]string{"a": "c", "b": "a", "c": "b"},
+			wantErr:  "runs after itself",
+		},
+		{
+			name:     "Group with a backup that runs after another one",
+			runAfter: map[string]string{"b": "a", "c": "b"},
+			groups:   map[string][]string{"nightly": {"c", "a"}},
+			wantErr:  `backup group "nightly"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateChainNames(tt.runAfter, tt.groups)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
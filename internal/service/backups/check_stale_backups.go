@@ -0,0 +1,53 @@
+package backups
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+)
+
+// CheckStaleBackups marks as stale the backups that have a RPO (recovery
+// point objective) configured and have not had a successful execution within
+// it. The backup_stale webhooks are triggered when a backup becomes stale.
+//
+// Backups created less than the RPO ago are not considered stale until
+// their first execution is due.
+func (s *Service) CheckStaleBackups() {
+	ctx := context.Background()
+
+	backups, err := s.dbgen.BackupsServiceGetStalenessData(ctx)
+	if err != nil {
+		logger.Error(
+			"error getting backups staleness data", logger.KV{"error": err},
+		)
+		return
+	}
+
+	for _, backup := range backups {
+		if backup.IsOverdue == backup.IsStale {
+			continue
+		}
+
+		err := s.dbgen.BackupsServiceSetIsStale(
+			ctx, dbgen.BackupsServiceSetIsStaleParams{
+				BackupID: backup.ID,
+				IsStale:  backup.IsOverdue,
+			},
+		)
+		if err != nil {
+			logger.Error("error storing backup staleness", logger.KV{
+				"backup_id": backup.ID,
+				"error":     err,
+			})
+			continue
+		}
+
+		if backup.IsOverdue {
+			logger.Warn("backup is stale", logger.KV{"backup_id": backup.ID})
+			s.webhooksService.RunBackupStale(backup.ID)
+		}
+	}
+
+	logger.Info("all backups checked for staleness")
+}
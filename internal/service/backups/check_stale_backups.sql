@@ -0,0 +1,20 @@
+-- name: BackupsServiceGetStalenessData :many
+SELECT
+  backups.id,
+  backups.is_stale,
+  (
+    backups.rpo_hours IS NOT NULL
+    AND
+    COALESCE(MAX(executions.finished_at), backups.created_at)
+    < NOW() - make_interval(hours => backups.rpo_hours::INTEGER)
+  )::BOOLEAN AS is_overdue
+FROM backups
+LEFT JOIN executions
+  ON executions.backup_id = backups.id
+  AND executions.status = 'success'
+GROUP BY backups.id;
+
+-- name: BackupsServiceSetIsStale :exec
+UPDATE backups
+SET is_stale = @is_stale
+WHERE id = @backup_id;
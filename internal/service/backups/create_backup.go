@@ -2,27 +2,49 @@ package backups
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/eduardolat/pgbackweb/internal/validate"
 )
 
 func (s *Service) CreateBackup(
 	ctx context.Context, params dbgen.BackupsServiceCreateBackupParams,
 ) (dbgen.Backup, error) {
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return dbgen.Backup{}, err
+	}
+
 	if !validate.CronExpression(params.CronExpression) {
 		return dbgen.Backup{}, fmt.Errorf("invalid cron expression")
 	}
 
+	if !params.WorkspaceID.Valid {
+		params.WorkspaceID = workspaces.FromContext(ctx)
+	}
 	backup, err := s.dbgen.BackupsServiceCreateBackup(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return backup, fmt.Errorf(
+			"the database, the destination or the backup to run after doesn't " +
+				"exist in the current workspace",
+		)
+	}
 	if err != nil {
 		return backup, err
 	}
 
-	if !backup.IsActive {
-		return backup, s.jobRemove(backup.ID)
-	}
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionCreate,
+		EntityType: audit.EntityBackup,
+		EntityID:   backup.ID,
+		EntityName: backup.Name,
+		After:      backup,
+	})
 
-	return backup, s.jobUpsert(backup.ID, backup.TimeZone, backup.CronExpression)
+	return backup, s.refreshJob(ctx, backup)
 }
@@ -3,12 +3,39 @@ INSERT INTO backups (
   database_id, destination_id, is_local, name, cron_expression, time_zone,
   is_active, dest_dir, retention_days, opt_data_only, opt_schema_only,
   opt_clean, opt_if_exists, opt_create, opt_no_comments,
-  max_part_size_mb, compression_level
+  max_part_size_mb, compression_level, size_deviation_threshold, rpo_hours,
+  run_after_backup_id, workspace_id
 )
-VALUES (
+SELECT
   @database_id, @destination_id, @is_local, @name, @cron_expression, @time_zone,
   @is_active, @dest_dir, @retention_days, @opt_data_only, @opt_schema_only,
   @opt_clean, @opt_if_exists, @opt_create, @opt_no_comments,
-  sqlc.narg('max_part_size_mb'), sqlc.narg('compression_level')
+  sqlc.narg('max_part_size_mb'), sqlc.narg('compression_level'),
+  @size_deviation_threshold, sqlc.narg('rpo_hours'),
+  sqlc.narg('run_after_backup_id'), databases.workspace_id
+FROM databases
+WHERE databases.id = @database_id
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  databases.workspace_id = sqlc.narg('workspace_id')::UUID
+)
+AND (
+  @destination_id::UUID IS NULL
+  OR
+  EXISTS (
+    SELECT 1 FROM destinations
+    WHERE destinations.id = @destination_id::UUID
+    AND destinations.workspace_id = databases.workspace_id
+  )
+)
+AND (
+  sqlc.narg('run_after_backup_id')::UUID IS NULL
+  OR
+  EXISTS (
+    SELECT 1 FROM backups
+    WHERE backups.id = sqlc.narg('run_after_backup_id')::UUID
+    AND backups.workspace_id = databases.workspace_id
+  )
 )
 RETURNING *;
@@ -0,0 +1,53 @@
+package backups
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
+)
+
+func (s *Service) CreateBackupGroup(
+	ctx context.Context, params dbgen.BackupsServiceCreateBackupGroupParams,
+) (dbgen.BackupGroup, error) {
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return dbgen.BackupGroup{}, err
+	}
+
+	err := ValidateBackupGroup(
+		params.CronExpression, params.TimeZone, params.BackupIds,
+	)
+	if err != nil {
+		return dbgen.BackupGroup{}, err
+	}
+	if err := s.validateGroupChains(ctx, params.BackupIds); err != nil {
+		return dbgen.BackupGroup{}, err
+	}
+
+	if !params.WorkspaceID.Valid {
+		params.WorkspaceID = workspaces.FromContext(ctx)
+	}
+	group, err := s.dbgen.BackupsServiceCreateBackupGroup(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return group, errors.New(
+			"some of the backups don't exist in the current workspace",
+		)
+	}
+	if err != nil {
+		return group, err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionCreate,
+		EntityType: audit.EntityBackupGroup,
+		EntityID:   group.ID,
+		EntityName: group.Name,
+		After:      group,
+	})
+
+	return group, s.refreshGroupJob(ctx, group, group.BackupIds)
+}
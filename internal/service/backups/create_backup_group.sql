@@ -0,0 +1,17 @@
+-- name: BackupsServiceCreateBackupGroup :one
+INSERT INTO backup_groups (
+  name, is_active, cron_expression, time_zone, backup_ids, workspace_id
+)
+SELECT
+  @name, @is_active, @cron_expression, @time_zone, @backup_ids, workspace.id
+FROM (
+  SELECT COALESCE(
+    sqlc.narg('workspace_id')::UUID, pbw_default_workspace_id()
+  ) AS id
+) AS workspace
+WHERE (
+  SELECT COUNT(*) FROM backups
+  WHERE backups.id = ANY(@backup_ids::UUID[])
+  AND backups.workspace_id = workspace.id
+) = cardinality(@backup_ids::UUID[])
+RETURNING *;
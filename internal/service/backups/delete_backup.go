@@ -3,16 +3,49 @@ package backups
 import (
 	"context"
 
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/google/uuid"
 )
 
 func (s *Service) DeleteBackup(
 	ctx context.Context, id uuid.UUID,
 ) error {
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return err
+	}
+
+	if err := s.ensureNotProvisioned(ctx, id); err != nil {
+		return err
+	}
+
 	err := s.jobRemove(id)
 	if err != nil {
 		return err
 	}
 
-	return s.dbgen.BackupsServiceDeleteBackup(ctx, id)
+	// The backups that run after this one go back to their own schedule once
+	// it's deleted.
+	dependentIDs, err := s.dbgen.BackupsServiceGetRunAfterBackupIDs(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	before := s.auditSnapshot(ctx, id)
+	if err := s.dbgen.BackupsServiceDeleteBackup(ctx, id); err != nil {
+		return err
+	}
+
+	entry := audit.Entry{
+		Action:     audit.ActionDelete,
+		EntityType: audit.EntityBackup,
+		EntityID:   id,
+		Before:     before,
+	}
+	if before != nil {
+		entry.EntityName = before.Name
+	}
+	audit.Record(ctx, s.dbgen, entry)
+
+	return s.refreshJobs(ctx, dependentIDs)
 }
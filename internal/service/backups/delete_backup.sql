@@ -1,3 +1,7 @@
 -- name: BackupsServiceDeleteBackup :exec
 DELETE FROM backups
 WHERE id = @id;
+
+-- name: BackupsServiceGetRunAfterBackupIDs :many
+SELECT id FROM backups
+WHERE run_after_backup_id = @backup_id;
@@ -0,0 +1,43 @@
+package backups
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/google/uuid"
+)
+
+func (s *Service) DeleteBackupGroup(ctx context.Context, id uuid.UUID) error {
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return err
+	}
+
+	if err := s.ensureGroupNotProvisioned(ctx, id); err != nil {
+		return err
+	}
+
+	group, err := s.GetBackupGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.dbgen.BackupsServiceDeleteBackupGroup(ctx, id); err != nil {
+		return err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionDelete,
+		EntityType: audit.EntityBackupGroup,
+		EntityID:   group.ID,
+		EntityName: group.Name,
+		Before:     group,
+	})
+
+	if err := s.jobRemove(id); err != nil {
+		return err
+	}
+
+	// The backups of the group go back to their own schedule.
+	return s.refreshJobs(ctx, group.BackupIds)
+}
@@ -0,0 +1,2 @@
+-- name: BackupsServiceDeleteBackupGroup :exec
+DELETE FROM backup_groups WHERE id = @group_id;
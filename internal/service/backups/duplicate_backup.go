@@ -4,11 +4,36 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/google/uuid"
 )
 
 func (s *Service) DuplicateBackup(
 	ctx context.Context, backupID uuid.UUID,
 ) (dbgen.Backup, error) {
-	return s.dbgen.BackupsServiceDuplicateBackup(ctx, backupID)
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return dbgen.Backup{}, err
+	}
+
+	backup, err := s.dbgen.BackupsServiceDuplicateBackup(
+		ctx, dbgen.BackupsServiceDuplicateBackupParams{
+			BackupID:    backupID,
+			WorkspaceID: workspaces.FromContext(ctx),
+		},
+	)
+	if err != nil {
+		return backup, err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionDuplicate,
+		EntityType: audit.EntityBackup,
+		EntityID:   backup.ID,
+		EntityName: backup.Name,
+		After:      backup,
+	})
+
+	return backup, nil
 }
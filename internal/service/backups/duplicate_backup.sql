@@ -5,9 +5,16 @@ SELECT (
   #= hstore('id', uuid_generate_v4()::text)
   #= hstore('name', (backups.name || ' (copy)')::text)
   #= hstore('is_active', false::text)
+  #= hstore('is_stale', false::text)
+  #= hstore('is_provisioned', false::text)
   #= hstore('created_at', now()::text)
   #= hstore('updated_at', now()::text)
 ).*
 FROM backups
 WHERE backups.id = @backup_id
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  backups.workspace_id = sqlc.narg('workspace_id')::UUID
+)
 RETURNING *;
@@ -0,0 +1,47 @@
+package backups
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ensureNotProvisioned returns an error when the backup is provisioned from the
+// configuration file, because those can only be changed by editing the file.
+func (s *Service) ensureNotProvisioned(ctx context.Context, id uuid.UUID) error {
+	backup, err := s.GetBackup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if backup.IsProvisioned {
+		return fmt.Errorf(
+			"backup %q is provisioned from the configuration file and is read-only",
+			backup.Name,
+		)
+	}
+
+	return nil
+}
+
+// ensureGroupNotProvisioned is the same as ensureNotProvisioned for backup
+// groups.
+func (s *Service) ensureGroupNotProvisioned(
+	ctx context.Context, id uuid.UUID,
+) error {
+	group, err := s.GetBackupGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if group.IsProvisioned {
+		return fmt.Errorf(
+			"backup group %q is provisioned from the configuration file and is "+
+				"read-only",
+			group.Name,
+		)
+	}
+
+	return nil
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 )
 
 func (s *Service) GetAllBackups(
 	ctx context.Context,
 ) ([]dbgen.Backup, error) {
-	return s.dbgen.BackupsServiceGetAllBackups(ctx)
+	return s.dbgen.BackupsServiceGetAllBackups(
+		ctx, workspaces.FromContext(ctx),
+	)
 }
@@ -1,3 +1,8 @@
 -- name: BackupsServiceGetAllBackups :many
 SELECT * FROM backups
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+)
 ORDER BY created_at DESC;
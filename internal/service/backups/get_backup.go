@@ -4,11 +4,17 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/google/uuid"
 )
 
 func (s *Service) GetBackup(
 	ctx context.Context, id uuid.UUID,
 ) (dbgen.Backup, error) {
-	return s.dbgen.BackupsServiceGetBackup(ctx, id)
+	return s.dbgen.BackupsServiceGetBackup(
+		ctx, dbgen.BackupsServiceGetBackupParams{
+			ID:          id,
+			WorkspaceID: workspaces.FromContext(ctx),
+		},
+	)
 }
@@ -1,3 +1,8 @@
 -- name: BackupsServiceGetBackup :one
 SELECT * FROM backups
-WHERE id = @id;
+WHERE id = @id
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+);
@@ -0,0 +1,20 @@
+package backups
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
+	"github.com/google/uuid"
+)
+
+func (s *Service) GetBackupGroup(
+	ctx context.Context, id uuid.UUID,
+) (dbgen.BackupGroup, error) {
+	return s.dbgen.BackupsServiceGetBackupGroup(
+		ctx, dbgen.BackupsServiceGetBackupGroupParams{
+			GroupID:     id,
+			WorkspaceID: workspaces.FromContext(ctx),
+		},
+	)
+}
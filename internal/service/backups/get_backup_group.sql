@@ -0,0 +1,8 @@
+-- name: BackupsServiceGetBackupGroup :one
+SELECT * FROM backup_groups
+WHERE id = @group_id
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+);
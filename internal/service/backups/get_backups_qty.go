@@ -4,10 +4,13 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 )
 
 func (s *Service) GetBackupsQty(
 	ctx context.Context,
 ) (dbgen.BackupsServiceGetBackupsQtyRow, error) {
-	return s.dbgen.BackupsServiceGetBackupsQty(ctx)
+	return s.dbgen.BackupsServiceGetBackupsQty(
+		ctx, workspaces.FromContext(ctx),
+	)
 }
@@ -2,5 +2,11 @@
 SELECT 
   COUNT(*) AS all,
   COALESCE(SUM(CASE WHEN is_active = true THEN 1 ELSE 0 END), 0)::INTEGER AS active,
-  COALESCE(SUM(CASE WHEN is_active = false THEN 1 ELSE 0 END), 0)::INTEGER AS inactive
-FROM backups;
+  COALESCE(SUM(CASE WHEN is_active = false THEN 1 ELSE 0 END), 0)::INTEGER AS inactive,
+  COALESCE(SUM(CASE WHEN is_active = true AND is_stale = true THEN 1 ELSE 0 END), 0)::INTEGER AS stale
+FROM backups
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+);
@@ -0,0 +1,16 @@
+package backups
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
+)
+
+func (s *Service) GetStaleBackups(
+	ctx context.Context,
+) ([]dbgen.Backup, error) {
+	return s.dbgen.BackupsServiceGetStaleBackups(
+		ctx, workspaces.FromContext(ctx),
+	)
+}
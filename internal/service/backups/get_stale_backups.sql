@@ -0,0 +1,9 @@
+-- name: BackupsServiceGetStaleBackups :many
+SELECT * FROM backups
+WHERE is_stale = true
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+)
+ORDER BY name ASC;
@@ -3,6 +3,7 @@ package backups
 import (
 	"context"
 
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/google/uuid"
 )
 
@@ -11,6 +12,18 @@ func (s *Service) jobUpsert(
 ) error {
 	return s.cr.UpsertJob(
 		backupID, timeZone, cronExpression,
-		s.executionsService.RunExecution, context.Background(), backupID,
+		s.executionsService.RunExecution, users.WithSystem(context.Background()),
+		backupID,
+	)
+}
+
+// groupJobUpsert schedules a backup group, the groups and the backups share
+// the scheduler because their IDs never collide.
+func (s *Service) groupJobUpsert(
+	groupID uuid.UUID, timeZone string, cronExpression string,
+) error {
+	return s.cr.UpsertJob(
+		groupID, timeZone, cronExpression,
+		s.RunBackupGroup, users.WithSystem(context.Background()), groupID,
 	)
 }
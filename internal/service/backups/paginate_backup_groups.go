@@ -0,0 +1,48 @@
+package backups
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
+	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
+)
+
+type PaginateBackupGroupsParams struct {
+	Page  int
+	Limit int
+}
+
+func (s *Service) PaginateBackupGroups(
+	ctx context.Context, params PaginateBackupGroupsParams,
+) (paginateutil.PaginateResponse, []dbgen.BackupsServicePaginateBackupGroupsRow, error) {
+	page := max(params.Page, 1)
+	limit := min(max(params.Limit, 1), 100)
+
+	count, err := s.dbgen.BackupsServicePaginateBackupGroupsCount(
+		ctx, workspaces.FromContext(ctx),
+	)
+	if err != nil {
+		return paginateutil.PaginateResponse{}, nil, err
+	}
+
+	paginateParams := paginateutil.PaginateParams{
+		Page:  page,
+		Limit: limit,
+	}
+	offset := paginateutil.CreateOffsetFromParams(paginateParams)
+	paginateResponse := paginateutil.CreatePaginateResponse(paginateParams, int(count))
+
+	groups, err := s.dbgen.BackupsServicePaginateBackupGroups(
+		ctx, dbgen.BackupsServicePaginateBackupGroupsParams{
+			Limit:       int32(limit),
+			Offset:      int32(offset),
+			WorkspaceID: workspaces.FromContext(ctx),
+		},
+	)
+	if err != nil {
+		return paginateutil.PaginateResponse{}, nil, err
+	}
+
+	return paginateResponse, groups, nil
+}
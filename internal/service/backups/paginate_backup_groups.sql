@@ -0,0 +1,25 @@
+-- name: BackupsServicePaginateBackupGroupsCount :one
+SELECT COUNT(*) FROM backup_groups
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+);
+
+-- name: BackupsServicePaginateBackupGroups :many
+SELECT
+  backup_groups.*,
+  ARRAY(
+    SELECT backups.name
+    FROM unnest(backup_groups.backup_ids) WITH ORDINALITY AS member(id, position)
+    INNER JOIN backups ON backups.id = member.id
+    ORDER BY member.position
+  )::TEXT[] AS backup_names
+FROM backup_groups
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  backup_groups.workspace_id = sqlc.narg('workspace_id')::UUID
+)
+ORDER BY backup_groups.created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -1,13 +1,25 @@
 -- name: BackupsServicePaginateBackupsCount :one
-SELECT COUNT(*) FROM backups;
+SELECT COUNT(*) FROM backups
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+);
 
 -- name: BackupsServicePaginateBackups :many
 SELECT
   backups.*,
   databases.name AS database_name,
-  destinations.name AS destination_name
+  destinations.name AS destination_name,
+  run_after.name AS run_after_backup_name
 FROM backups
 INNER JOIN databases ON backups.database_id = databases.id
 LEFT JOIN destinations ON backups.destination_id = destinations.id
+LEFT JOIN backups AS run_after ON backups.run_after_backup_id = run_after.id
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  backups.workspace_id = sqlc.narg('workspace_id')::UUID
+)
 ORDER BY backups.created_at DESC
 LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
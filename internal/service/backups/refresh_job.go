@@ -0,0 +1,87 @@
+package backups
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+// RefreshJob schedules or unschedules the backup to match what is stored in
+// the database. It is used when backups are changed without going through
+// this service, e.g. by the configuration file provisioning.
+func (s *Service) RefreshJob(ctx context.Context, backupID uuid.UUID) error {
+	backup, err := s.GetBackup(ctx, backupID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return s.jobRemove(backupID)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.refreshJob(ctx, backup)
+}
+
+// refreshJob schedules the backup when it is active and it isn't chained,
+// chained backups are started by the backup they run after or by their
+// group instead.
+func (s *Service) refreshJob(ctx context.Context, backup dbgen.Backup) error {
+	if !backup.IsActive {
+		return s.jobRemove(backup.ID)
+	}
+
+	isChained, err := s.dbgen.BackupsServiceIsChained(ctx, backup.ID)
+	if err != nil {
+		return err
+	}
+	if isChained {
+		return s.jobRemove(backup.ID)
+	}
+
+	return s.jobUpsert(backup.ID, backup.TimeZone, backup.CronExpression)
+}
+
+// refreshJobs refreshes the jobs of the backups, e.g. after they are added to
+// or removed from a group.
+func (s *Service) refreshJobs(ctx context.Context, backupIDs []uuid.UUID) error {
+	for _, backupID := range backupIDs {
+		if err := s.RefreshJob(ctx, backupID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RefreshGroupJob schedules or unschedules the group and its backups to match
+// what is stored in the database, the same as RefreshJob does for a backup.
+func (s *Service) RefreshGroupJob(ctx context.Context, groupID uuid.UUID) error {
+	group, err := s.GetBackupGroup(ctx, groupID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return s.jobRemove(groupID)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.refreshGroupJob(ctx, group, group.BackupIds)
+}
+
+// refreshGroupJob schedules or unschedules the group and refreshes the jobs
+// of the given backups, which are started by the group while it is active.
+func (s *Service) refreshGroupJob(
+	ctx context.Context, group dbgen.BackupGroup, backupIDs []uuid.UUID,
+) error {
+	var err error
+	if group.IsActive {
+		err = s.groupJobUpsert(group.ID, group.TimeZone, group.CronExpression)
+	} else {
+		err = s.jobRemove(group.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.refreshJobs(ctx, backupIDs)
+}
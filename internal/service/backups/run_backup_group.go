@@ -0,0 +1,36 @@
+package backups
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/google/uuid"
+)
+
+// RunBackupGroup runs the active backups of the group one after the other,
+// in the order of the group, and returns when the last one finishes. It
+// stops at the first backup that fails.
+func (s *Service) RunBackupGroup(ctx context.Context, groupID uuid.UUID) error {
+	if err := users.Authorize(ctx, users.PermissionRun); err != nil {
+		return err
+	}
+
+	group, err := s.GetBackupGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	backupIDs, err := s.dbgen.BackupsServiceGetBackupGroupBackupIDs(
+		ctx, group.ID,
+	)
+	if err != nil {
+		logger.Error("error getting backups of group", logger.KV{
+			"group_id": group.ID.String(),
+			"error":    err.Error(),
+		})
+		return err
+	}
+
+	return s.executionsService.RunSequence(ctx, backupIDs)
+}
@@ -0,0 +1,8 @@
+-- name: BackupsServiceGetBackupGroupBackupIDs :many
+SELECT backups.id
+FROM backup_groups,
+unnest(backup_groups.backup_ids) WITH ORDINALITY AS member(id, position)
+INNER JOIN backups ON backups.id = member.id
+WHERE backup_groups.id = @group_id
+AND backups.is_active = TRUE
+ORDER BY member.position;
@@ -15,14 +15,16 @@ func (s *Service) ScheduleAll() {
 	}
 
 	for _, backup := range activeBackups {
-		if !backup.IsActive {
+		// Chained backups are started by the backup they run after or by their
+		// group, not by their own schedule.
+		if !backup.IsActive || backup.IsChained {
 			err := s.jobRemove(backup.ID)
 			if err != nil {
 				logger.Error("error removing inactive backup", logger.KV{"error": err})
 			}
 		}
 
-		if backup.IsActive {
+		if backup.IsActive && !backup.IsChained {
 			err := s.jobUpsert(backup.ID, backup.TimeZone, backup.CronExpression)
 			if err != nil {
 				logger.Error("error scheduling backup", logger.KV{"error": err})
@@ -31,4 +33,24 @@ func (s *Service) ScheduleAll() {
 	}
 
 	logger.Info("all active backups scheduled")
+
+	groups, err := s.dbgen.BackupsServiceGetScheduleAllGroupsData(
+		context.Background(),
+	)
+	if err != nil {
+		logger.Error("error getting all backup groups", logger.KV{"error": err})
+	}
+
+	for _, group := range groups {
+		if !group.IsActive {
+			continue
+		}
+
+		err := s.groupJobUpsert(group.ID, group.TimeZone, group.CronExpression)
+		if err != nil {
+			logger.Error("error scheduling backup group", logger.KV{"error": err})
+		}
+	}
+
+	logger.Info("all active backup groups scheduled")
 }
@@ -3,6 +3,24 @@ SELECT
   id,
   is_active,
   cron_expression,
-  time_zone
+  time_zone,
+  (
+    run_after_backup_id IS NOT NULL
+    OR
+    EXISTS (
+      SELECT 1 FROM backup_groups
+      WHERE backup_groups.is_active = TRUE
+      AND backups.id = ANY(backup_groups.backup_ids)
+    )
+  )::BOOLEAN AS is_chained
 FROM backups
 ORDER BY created_at DESC;
+
+-- name: BackupsServiceGetScheduleAllGroupsData :many
+SELECT
+  id,
+  is_active,
+  cron_expression,
+  time_zone
+FROM backup_groups
+ORDER BY created_at DESC;
@@ -3,18 +3,34 @@ package backups
 import (
 	"context"
 
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/google/uuid"
 )
 
 func (s *Service) ToggleIsActive(ctx context.Context, backupID uuid.UUID) error {
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return err
+	}
+
+	if err := s.ensureNotProvisioned(ctx, backupID); err != nil {
+		return err
+	}
+
+	before := s.auditSnapshot(ctx, backupID)
 	backup, err := s.dbgen.BackupsServiceToggleIsActive(ctx, backupID)
 	if err != nil {
 		return err
 	}
 
-	if !backup.IsActive {
-		return s.jobRemove(backupID)
-	}
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionUpdate,
+		EntityType: audit.EntityBackup,
+		EntityID:   backup.ID,
+		EntityName: backup.Name,
+		Before:     before,
+		After:      backup,
+	})
 
-	return s.jobUpsert(backupID, backup.TimeZone, backup.CronExpression)
+	return s.refreshJob(ctx, backup)
 }
@@ -2,27 +2,56 @@ package backups
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/eduardolat/pgbackweb/internal/validate"
 )
 
 func (s *Service) UpdateBackup(
 	ctx context.Context, params dbgen.BackupsServiceUpdateBackupParams,
 ) (dbgen.Backup, error) {
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return dbgen.Backup{}, err
+	}
+
+	if err := s.ensureNotProvisioned(ctx, params.ID); err != nil {
+		return dbgen.Backup{}, err
+	}
+
 	if !validate.CronExpression(params.CronExpression.String) {
 		return dbgen.Backup{}, fmt.Errorf("invalid cron expression")
 	}
 
+	err := s.validateRunAfter(ctx, params.ID, params.RunAfterBackupID)
+	if err != nil {
+		return dbgen.Backup{}, err
+	}
+
+	before := s.auditSnapshot(ctx, params.ID)
 	backup, err := s.dbgen.BackupsServiceUpdateBackup(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return backup, fmt.Errorf(
+			"the destination or the backup to run after doesn't exist in the " +
+				"workspace of the backup",
+		)
+	}
 	if err != nil {
 		return backup, err
 	}
 
-	if !backup.IsActive {
-		return backup, s.jobRemove(backup.ID)
-	}
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionUpdate,
+		EntityType: audit.EntityBackup,
+		EntityID:   backup.ID,
+		EntityName: backup.Name,
+		Before:     before,
+		After:      backup,
+	})
 
-	return backup, s.jobUpsert(backup.ID, backup.TimeZone, backup.CronExpression)
+	return backup, s.refreshJob(ctx, backup)
 }
@@ -16,6 +16,29 @@ SET
   is_local = COALESCE(sqlc.narg('is_local'), is_local),
   destination_id = sqlc.narg('destination_id'),
   max_part_size_mb = sqlc.narg('max_part_size_mb'),
-  compression_level = sqlc.narg('compression_level')
+  compression_level = sqlc.narg('compression_level'),
+  size_deviation_threshold = COALESCE(
+    sqlc.narg('size_deviation_threshold'), size_deviation_threshold
+  ),
+  rpo_hours = sqlc.narg('rpo_hours'),
+  run_after_backup_id = sqlc.narg('run_after_backup_id')
 WHERE id = @id
+AND (
+  sqlc.narg('destination_id')::UUID IS NULL
+  OR
+  EXISTS (
+    SELECT 1 FROM destinations
+    WHERE destinations.id = sqlc.narg('destination_id')::UUID
+    AND destinations.workspace_id = backups.workspace_id
+  )
+)
+AND (
+  sqlc.narg('run_after_backup_id')::UUID IS NULL
+  OR
+  EXISTS (
+    SELECT 1 FROM backups AS run_after
+    WHERE run_after.id = sqlc.narg('run_after_backup_id')::UUID
+    AND run_after.workspace_id = backups.workspace_id
+  )
+)
 RETURNING *;
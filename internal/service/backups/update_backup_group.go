@@ -0,0 +1,71 @@
+package backups
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+)
+
+func (s *Service) UpdateBackupGroup(
+	ctx context.Context, params dbgen.BackupsServiceUpdateBackupGroupParams,
+) (dbgen.BackupGroup, error) {
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return dbgen.BackupGroup{}, err
+	}
+
+	if err := s.ensureGroupNotProvisioned(ctx, params.GroupID); err != nil {
+		return dbgen.BackupGroup{}, err
+	}
+
+	current, err := s.GetBackupGroup(ctx, params.GroupID)
+	if err != nil {
+		return dbgen.BackupGroup{}, err
+	}
+
+	// The fields that aren't updated keep their current value.
+	cronExpression, timeZone, backupIDs := current.CronExpression,
+		current.TimeZone, current.BackupIds
+	if params.CronExpression.Valid {
+		cronExpression = params.CronExpression.String
+	}
+	if params.TimeZone.Valid {
+		timeZone = params.TimeZone.String
+	}
+	if params.BackupIds != nil {
+		backupIDs = params.BackupIds
+	}
+	if err := ValidateBackupGroup(cronExpression, timeZone, backupIDs); err != nil {
+		return dbgen.BackupGroup{}, err
+	}
+	if err := s.validateGroupChains(ctx, backupIDs); err != nil {
+		return dbgen.BackupGroup{}, err
+	}
+
+	group, err := s.dbgen.BackupsServiceUpdateBackupGroup(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return group, errors.New(
+			"some of the backups don't exist in the workspace of the group",
+		)
+	}
+	if err != nil {
+		return group, err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionUpdate,
+		EntityType: audit.EntityBackupGroup,
+		EntityID:   group.ID,
+		EntityName: group.Name,
+		Before:     current,
+		After:      group,
+	})
+
+	// The backups removed from the group go back to their own schedule.
+	return group, s.refreshGroupJob(
+		ctx, group, append(current.BackupIds, group.BackupIds...),
+	)
+}
@@ -0,0 +1,19 @@
+-- name: BackupsServiceUpdateBackupGroup :one
+UPDATE backup_groups
+SET
+  name = COALESCE(sqlc.narg('name'), name),
+  is_active = COALESCE(sqlc.narg('is_active'), is_active),
+  cron_expression = COALESCE(sqlc.narg('cron_expression'), cron_expression),
+  time_zone = COALESCE(sqlc.narg('time_zone'), time_zone),
+  backup_ids = COALESCE(sqlc.narg('backup_ids'), backup_ids)
+WHERE id = @group_id
+AND (
+  sqlc.narg('backup_ids')::UUID[] IS NULL
+  OR
+  (
+    SELECT COUNT(*) FROM backups
+    WHERE backups.id = ANY(sqlc.narg('backup_ids')::UUID[])
+    AND backups.workspace_id = backup_groups.workspace_id
+  ) = cardinality(sqlc.narg('backup_ids')::UUID[])
+)
+RETURNING *;
@@ -0,0 +1,36 @@
+package backups
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/google/uuid"
+)
+
+// ValidateBackupGroup checks the settings of a backup group, it must have at
+// least one backup and every backup can only be once in the group.
+func ValidateBackupGroup(
+	cronExpression, timeZone string, backupIDs []uuid.UUID,
+) error {
+	if !validate.CronExpression(cronExpression) {
+		return errors.New("invalid cron expression")
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return fmt.Errorf("invalid time zone: %w", err)
+	}
+	if len(backupIDs) == 0 {
+		return errors.New("the group must have at least one backup")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(backupIDs))
+	for _, id := range backupIDs {
+		if seen[id] {
+			return errors.New("a backup can only be once in the group")
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package backups
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBackupGroup(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name      string
+		cron      string
+		timeZone  string
+		backupIDs []uuid.UUID
+		wantErr   bool
+	}{
+		{
+			name: "Valid group", cron: "0 2 * * *", timeZone: "UTC",
+			backupIDs: []uuid.UUID{a, b},
+		},
+		{
+			name: "Invalid cron expression", cron: "every night", timeZone: "UTC",
+			backupIDs: []uuid.UUID{a}, wantErr: true,
+		},
+		{
+			name: "Invalid time zone", cron: "0 2 * * *", timeZone: "Mars/Olympus",
+			backupIDs: []uuid.UUID{a}, wantErr: true,
+		},
+		{
+			name: "Without backups", cron: "0 2 * * *", timeZone: "UTC",
+			backupIDs: []uuid.UUID{}, wantErr: true,
+		},
+		{
+			name: "Repeated backup", cron: "0 2 * * *", timeZone: "UTC",
+			backupIDs: []uuid.UUID{a, b, a}, wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBackupGroup(tt.cron, tt.timeZone, tt.backupIDs)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,387 @@
+package bundles
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/service/backups"
+	"github.com/eduardolat/pgbackweb/internal/service/reports"
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+)
+
+// BundleVersion is the version of the bundle format written by the export,
+// bundles with a different version are rejected by the import.
+const BundleVersion = 1
+
+// checkValue is encrypted with the passphrase in every bundle so a wrong
+// passphrase is detected before importing anything.
+const checkValue = "pgbackweb-bundle"
+
+type Kind string
+
+const (
+	KindDatabase    Kind = "database"
+	KindDestination Kind = "destination"
+	KindBackup      Kind = "backup"
+	KindBackupGroup Kind = "backup_group"
+	KindWebhook     Kind = "webhook"
+	KindReport      Kind = "report"
+)
+
+// Bundle is a portable copy of the configuration of an instance. Secrets are
+// encrypted with the passphrase of the export instead of PBW_ENCRYPTION_KEY,
+// and entities reference each other by name instead of by ID.
+type Bundle struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	// Check is checkValue encrypted with the passphrase.
+	Check        string              `json:"check"`
+	Databases    []BundleDatabase    `json:"databases"`
+	Destinations []BundleDestination `json:"destinations"`
+	Backups      []BundleBackup      `json:"backups"`
+	// BackupGroups are empty in the bundles exported before them.
+	BackupGroups []BundleBackupGroup `json:"backup_groups"`
+	Webhooks     []BundleWebhook     `json:"webhooks"`
+	// Reports are empty in the bundles exported before them.
+	Reports    []BundleReport    `json:"reports"`
+	Executions []BundleExecution `json:"executions,omitempty"`
+}
+
+type BundleDatabase struct {
+	Name      string `json:"name" validate:"required"`
+	PgVersion string `json:"pg_version" validate:"required"`
+	// ConnectionString is encrypted with the passphrase and ASCII armored.
+	ConnectionString string `json:"connection_string" validate:"required"`
+	// The health check settings are empty in the bundles exported before
+	// them, the defaults are used for new databases.
+	HealthCheckIntervalMinutes *int32 `json:"health_check_interval_minutes" validate:"omitempty,min=1,max=1440"`
+	HealthFailureThreshold     *int32 `json:"health_failure_threshold" validate:"omitempty,min=1,max=100"`
+	HealthSuccessThreshold     *int32 `json:"health_success_threshold" validate:"omitempty,min=1,max=100"`
+}
+
+type BundleDestination struct {
+	Name             string `json:"name" validate:"required"`
+	BucketName       string `json:"bucket_name" validate:"required"`
+	Region           string `json:"region" validate:"required"`
+	Endpoint         string `json:"endpoint" validate:"required"`
+	ForcePathStyle   bool   `json:"force_path_style"`
+	SignatureVersion string `json:"signature_version" validate:"required,oneof=v2 v4"`
+	// AccessKey and SecretKey are encrypted with the passphrase and ASCII
+	// armored.
+	AccessKey string `json:"access_key" validate:"required"`
+	SecretKey string `json:"secret_key" validate:"required"`
+	// The health check settings are empty in the bundles exported before
+	// them, the defaults are used for new destinations.
+	HealthCheckIntervalMinutes *int32 `json:"health_check_interval_minutes" validate:"omitempty,min=1,max=1440"`
+	HealthFailureThreshold     *int32 `json:"health_failure_threshold" validate:"omitempty,min=1,max=100"`
+	HealthSuccessThreshold     *int32 `json:"health_success_threshold" validate:"omitempty,min=1,max=100"`
+}
+
+type BundleBackup struct {
+	Name                   string `json:"name" validate:"required"`
+	Database               string `json:"database" validate:"required"`
+	IsLocal                bool   `json:"is_local"`
+	Destination            string `json:"destination" validate:"required_if=IsLocal false,excluded_if=IsLocal true"`
+	CronExpression         string `json:"cron_expression" validate:"required"`
+	TimeZone               string `json:"time_zone" validate:"required"`
+	IsActive               bool   `json:"is_active"`
+	DestDir                string `json:"dest_dir" validate:"required"`
+	RetentionDays          int16  `json:"retention_days" validate:"min=0"`
+	OptDataOnly            bool   `json:"opt_data_only"`
+	OptSchemaOnly          bool   `json:"opt_schema_only"`
+	OptClean               bool   `json:"opt_clean"`
+	OptIfExists            bool   `json:"opt_if_exists"`
+	OptCreate              bool   `json:"opt_create"`
+	OptNoComments          bool   `json:"opt_no_comments"`
+	MaxPartSizeMb          *int32 `json:"max_part_size_mb" validate:"omitempty,min=1"`
+	CompressionLevel       *int16 `json:"compression_level" validate:"omitempty,min=0,max=9"`
+	SizeDeviationThreshold int16  `json:"size_deviation_threshold" validate:"min=0,max=1000"`
+	RpoHours               *int16 `json:"rpo_hours" validate:"omitempty,min=1,max=8760"`
+	// RunAfter is the name of the backup that this one runs after.
+	RunAfter *string `json:"run_after"`
+}
+
+type BundleBackupGroup struct {
+	Name           string `json:"name" validate:"required"`
+	IsActive       bool   `json:"is_active"`
+	CronExpression string `json:"cron_expression" validate:"required"`
+	TimeZone       string `json:"time_zone" validate:"required"`
+	// Backups are the names of the backups of the group in the order they run.
+	Backups []string `json:"backups" validate:"required,gt=0"`
+}
+
+type BundleWebhook struct {
+	Name      string   `json:"name" validate:"required"`
+	EventType string   `json:"event_type" validate:"required"`
+	Targets   []string `json:"targets" validate:"required,gt=0"`
+	IsActive  bool     `json:"is_active"`
+	// Channel is empty in the bundles exported before the chat channels.
+	Channel string  `json:"channel" validate:"omitempty,oneof=webhook slack discord teams telegram email"`
+	Url     string  `json:"url" validate:"required,url"`
+	Method  string  `json:"method" validate:"required,oneof=GET POST PUT PATCH DELETE"`
+	Headers *string `json:"headers"`
+	Body    *string `json:"body"`
+	// The HTTP settings are empty in the bundles exported before them.
+	TimeoutSeconds *int32  `json:"timeout_seconds" validate:"omitempty,min=1,max=300"`
+	TLSSkipVerify  bool    `json:"tls_skip_verify"`
+	CACertificate  *string `json:"ca_certificate"`
+	ProxyURL       *string `json:"proxy_url"`
+	// SigningSecret is encrypted with the passphrase and ASCII armored.
+	SigningSecret *string `json:"signing_secret"`
+}
+
+// timeoutSeconds returns the timeout of the webhook, the default one when the
+// bundle doesn't have it.
+func (w BundleWebhook) timeoutSeconds() int32 {
+	if w.TimeoutSeconds == nil {
+		return webhooks.DefaultTimeoutSeconds
+	}
+	return *w.TimeoutSeconds
+}
+
+type BundleReport struct {
+	Name           string `json:"name" validate:"required"`
+	IsActive       bool   `json:"is_active"`
+	Period         string `json:"period" validate:"required"`
+	CronExpression string `json:"cron_expression" validate:"required"`
+	TimeZone       string `json:"time_zone" validate:"required"`
+	Channel        string `json:"channel" validate:"required"`
+	Url            string `json:"url" validate:"required"`
+}
+
+// BundleExecution is a reference to the files of a successful execution that
+// are still stored in the destination (or in the local backups directory) of
+// its backup. Importing it makes the files downloadable and restorable from
+// the new instance without copying them.
+type BundleExecution struct {
+	Backup       string     `json:"backup" validate:"required"`
+	Message      *string    `json:"message"`
+	Path         string     `json:"path" validate:"required"`
+	FileSize     *int64     `json:"file_size"`
+	IsSuspicious bool       `json:"is_suspicious"`
+	StartedAt    time.Time  `json:"started_at" validate:"required"`
+	FinishedAt   *time.Time `json:"finished_at"`
+}
+
+// ParseBundle decodes and validates a bundle. Unknown fields are rejected so
+// bundles of newer versions fail loudly instead of being partially imported.
+func ParseBundle(r io.Reader) (Bundle, error) {
+	var bundle Bundle
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&bundle); err != nil {
+		return Bundle{}, fmt.Errorf("error parsing bundle: %w", err)
+	}
+
+	if err := bundle.validate(); err != nil {
+		return Bundle{}, fmt.Errorf("invalid bundle: %w", err)
+	}
+
+	return bundle, nil
+}
+
+// validate checks the format version, the fields and that every reference
+// points to an entity of the bundle.
+func (b Bundle) validate() error {
+	if b.Version != BundleVersion {
+		return fmt.Errorf(
+			"unsupported version %d, expected %d", b.Version, BundleVersion,
+		)
+	}
+	if b.Check == "" {
+		return fmt.Errorf("check is required")
+	}
+
+	databases := map[string]bool{}
+	for _, db := range b.Databases {
+		if err := validate.Struct(&db); err != nil {
+			return fmt.Errorf("database %q: %w", db.Name, err)
+		}
+		if databases[db.Name] {
+			return fmt.Errorf("database %q is included more than once", db.Name)
+		}
+		databases[db.Name] = true
+	}
+
+	destinations := map[string]bool{}
+	for _, dest := range b.Destinations {
+		if err := validate.Struct(&dest); err != nil {
+			return fmt.Errorf("destination %q: %w", dest.Name, err)
+		}
+		if destinations[dest.Name] {
+			return fmt.Errorf("destination %q is included more than once", dest.Name)
+		}
+		destinations[dest.Name] = true
+	}
+
+	backupNames := map[string]bool{}
+	for _, backup := range b.Backups {
+		if err := validate.Struct(&backup); err != nil {
+			return fmt.Errorf("backup %q: %w", backup.Name, err)
+		}
+		if backupNames[backup.Name] {
+			return fmt.Errorf("backup %q is included more than once", backup.Name)
+		}
+		if !validate.CronExpression(backup.CronExpression) {
+			return fmt.Errorf("backup %q: invalid cron expression", backup.Name)
+		}
+		if _, err := time.LoadLocation(backup.TimeZone); err != nil {
+			return fmt.Errorf("backup %q: invalid time zone: %w", backup.Name, err)
+		}
+		if !databases[backup.Database] {
+			return fmt.Errorf(
+				"backup %q: database %q not found", backup.Name, backup.Database,
+			)
+		}
+		if !backup.IsLocal && !destinations[backup.Destination] {
+			return fmt.Errorf(
+				"backup %q: destination %q not found", backup.Name, backup.Destination,
+			)
+		}
+		backupNames[backup.Name] = true
+	}
+
+	runAfter := map[string]string{}
+	for _, backup := range b.Backups {
+		if backup.RunAfter == nil {
+			continue
+		}
+		if !backupNames[*backup.RunAfter] {
+			return fmt.Errorf(
+				"backup %q: run after backup %q not found", backup.Name, *backup.RunAfter,
+			)
+		}
+		runAfter[backup.Name] = *backup.RunAfter
+	}
+
+	groups := map[string][]string{}
+	for _, group := range b.BackupGroups {
+		if err := validate.Struct(&group); err != nil {
+			return fmt.Errorf("backup group %q: %w", group.Name, err)
+		}
+		if _, ok := groups[group.Name]; ok {
+			return fmt.Errorf("backup group %q is included more than once", group.Name)
+		}
+		if !validate.CronExpression(group.CronExpression) {
+			return fmt.Errorf("backup group %q: invalid cron expression", group.Name)
+		}
+		if _, err := time.LoadLocation(group.TimeZone); err != nil {
+			return fmt.Errorf("backup group %q: invalid time zone: %w", group.Name, err)
+		}
+		seen := map[string]bool{}
+		for _, backup := range group.Backups {
+			if !backupNames[backup] {
+				return fmt.Errorf(
+					"backup group %q: backup %q not found", group.Name, backup,
+				)
+			}
+			if seen[backup] {
+				return fmt.Errorf(
+					"backup group %q: a backup can only be once in the group", group.Name,
+				)
+			}
+			seen[backup] = true
+		}
+		groups[group.Name] = group.Backups
+	}
+
+	if err := backups.ValidateChainNames(runAfter, groups); err != nil {
+		return err
+	}
+
+	webhookNames := map[string]bool{}
+	for _, webhook := range b.Webhooks {
+		if err := validate.Struct(&webhook); err != nil {
+			return fmt.Errorf("webhook %q: %w", webhook.Name, err)
+		}
+		if webhookNames[webhook.Name] {
+			return fmt.Errorf("webhook %q is included more than once", webhook.Name)
+		}
+		if webhook.Headers != nil && !validate.JSON(*webhook.Headers) {
+			return fmt.Errorf("webhook %q: headers must be valid JSON", webhook.Name)
+		}
+		if webhook.Body != nil && !validate.JSON(*webhook.Body) {
+			return fmt.Errorf("webhook %q: body must be valid JSON", webhook.Name)
+		}
+
+		kind := webhookTargetKind(webhook.EventType)
+		targets := map[Kind]map[string]bool{
+			KindDatabase:    databases,
+			KindDestination: destinations,
+			KindBackup:      backupNames,
+		}[kind]
+		if targets == nil {
+			return fmt.Errorf(
+				"webhook %q: invalid event type %s", webhook.Name, webhook.EventType,
+			)
+		}
+		for _, target := range webhook.Targets {
+			if !targets[target] {
+				return fmt.Errorf(
+					"webhook %q: %s %q not found", webhook.Name, kind, target,
+				)
+			}
+		}
+		webhookNames[webhook.Name] = true
+	}
+
+	reportNames := map[string]bool{}
+	for _, report := range b.Reports {
+		if err := validate.Struct(&report); err != nil {
+			return fmt.Errorf("report %q: %w", report.Name, err)
+		}
+		if reportNames[report.Name] {
+			return fmt.Errorf("report %q is included more than once", report.Name)
+		}
+		err := reports.ValidateReport(
+			report.Period, report.CronExpression, report.TimeZone, report.Channel,
+			report.Url,
+		)
+		if err != nil {
+			return fmt.Errorf("report %q: %w", report.Name, err)
+		}
+		reportNames[report.Name] = true
+	}
+
+	for _, execution := range b.Executions {
+		if err := validate.Struct(&execution); err != nil {
+			return fmt.Errorf("execution %q: %w", execution.Path, err)
+		}
+		if !backupNames[execution.Backup] {
+			return fmt.Errorf(
+				"execution %q: backup %q not found", execution.Path, execution.Backup,
+			)
+		}
+	}
+
+	return nil
+}
+
+// webhookTargetKind returns the kind of the entities targeted by webhooks of
+// the event type, or an empty kind for unknown event types.
+func webhookTargetKind(eventType string) Kind {
+	switch eventType {
+	case webhooks.EventTypeDatabaseHealthy.Value.Key,
+		webhooks.EventTypeDatabaseUnhealthy.Value.Key,
+		webhooks.EventTypeRestorationStarted.Value.Key,
+		webhooks.EventTypeRestorationSuccess.Value.Key,
+		webhooks.EventTypeRestorationFailed.Value.Key:
+		return KindDatabase
+	case webhooks.EventTypeDestinationHealthy.Value.Key,
+		webhooks.EventTypeDestinationUnhealthy.Value.Key,
+		webhooks.EventTypeDestinationUploadSlow.Value.Key:
+		return KindDestination
+	case webhooks.EventTypeExecutionStarted.Value.Key,
+		webhooks.EventTypeExecutionSuccess.Value.Key,
+		webhooks.EventTypeExecutionFailed.Value.Key,
+		webhooks.EventTypeExecutionSuspicious.Value.Key,
+		webhooks.EventTypeExecutionDeletedByRetention.Value.Key,
+		webhooks.EventTypeBackupStale.Value.Key:
+		return KindBackup
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,135 @@
+package bundles
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBundle(t *testing.T) {
+	valid := `{
+		"version": 1,
+		"exported_at": "2026-10-19T10:00:00Z",
+		"check": "armored",
+		"databases": [
+			{"name": "main", "pg_version": "16", "connection_string": "armored"}
+		],
+		"destinations": [{
+			"name": "s3", "bucket_name": "bucket", "region": "us-east-1",
+			"endpoint": "https://s3.amazonaws.com", "signature_version": "v4",
+			"access_key": "armored", "secret_key": "armored"
+		}],
+		"backups": [{
+			"name": "daily", "database": "main", "destination": "s3",
+			"cron_expression": "0 3 * * *", "time_zone": "UTC",
+			"dest_dir": "/main", "max_part_size_mb": null
+		}, {
+			"name": "analyze", "database": "main", "is_local": true,
+			"cron_expression": "0 4 * * *", "time_zone": "UTC",
+			"dest_dir": "/analyze", "run_after": "daily"
+		}],
+		"backup_groups": [{
+			"name": "nightly", "is_active": true, "cron_expression": "0 1 * * *",
+			"time_zone": "UTC", "backups": ["daily"]
+		}],
+		"webhooks": [{
+			"name": "failures", "event_type": "execution_failed",
+			"targets": ["daily"], "url": "https://example.com", "method": "POST",
+			"headers": null, "body": null
+		}],
+		"reports": [{
+			"name": "ops", "is_active": true, "period": "daily",
+			"cron_expression": "0 8 * * *", "time_zone": "UTC",
+			"channel": "email", "url": "mailto:ops@example.com"
+		}],
+		"executions": [{
+			"backup": "daily", "path": "/main/dump.zip",
+			"started_at": "2026-10-18T03:00:00Z"
+		}]
+	}`
+
+	t.Run("Valid bundle", func(t *testing.T) {
+		bundle, err := ParseBundle(strings.NewReader(valid))
+		assert.NoError(t, err)
+		assert.Equal(t, "main", bundle.Backups[0].Database)
+		assert.Nil(t, bundle.Backups[0].MaxPartSizeMb)
+		assert.Equal(t, "daily", *bundle.Backups[1].RunAfter)
+		assert.Equal(t, []string{"daily"}, bundle.BackupGroups[0].Backups)
+		assert.Len(t, bundle.Executions, 1)
+	})
+
+	tests := []struct {
+		name    string
+		replace [2]string
+		wantErr string
+	}{
+		{
+			name:    "unsupported version",
+			replace: [2]string{`"version": 1`, `"version": 2`},
+			wantErr: "unsupported version 2",
+		},
+		{
+			name:    "unknown field",
+			replace: [2]string{`"check"`, `"unknown": 1, "check"`},
+			wantErr: "unknown field",
+		},
+		{
+			name:    "missing database",
+			replace: [2]string{`"database": "main"`, `"database": "other"`},
+			wantErr: `database "other" not found`,
+		},
+		{
+			name:    "missing webhook target",
+			replace: [2]string{`"targets": ["daily"]`, `"targets": ["weekly"]`},
+			wantErr: `backup "weekly" not found`,
+		},
+		{
+			name: "invalid event type",
+			replace: [2]string{
+				`"event_type": "execution_failed"`, `"event_type": "nope"`,
+			},
+			wantErr: "invalid event type",
+		},
+		{
+			name:    "missing run after backup",
+			replace: [2]string{`"run_after": "daily"`, `"run_after": "weekly"`},
+			wantErr: `run after backup "weekly" not found`,
+		},
+		{
+			name:    "missing backup group backup",
+			replace: [2]string{`"backups": ["daily"]`, `"backups": ["weekly"]`},
+			wantErr: `backup group "nightly": backup "weekly" not found`,
+		},
+		{
+			name: "backup group with a backup that runs after another one",
+			replace: [2]string{
+				`"backups": ["daily"]`, `"backups": ["daily", "analyze"]`,
+			},
+			wantErr: "can't run after another backup of the group",
+		},
+		{
+			name:    "missing execution backup",
+			replace: [2]string{`"backup": "daily"`, `"backup": "weekly"`},
+			wantErr: `backup "weekly" not found`,
+		},
+		{
+			name:    "invalid report channel",
+			replace: [2]string{`"channel": "email"`, `"channel": "slack"`},
+			wantErr: "invalid channel slack",
+		},
+		{
+			name:    "invalid cron expression",
+			replace: [2]string{`"0 3 * * *"`, `"nope"`},
+			wantErr: "invalid cron expression",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := strings.Replace(valid, tt.replace[0], tt.replace[1], 1)
+			_, err := ParseBundle(strings.NewReader(content))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
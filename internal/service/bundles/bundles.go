@@ -0,0 +1,40 @@
+package bundles
+
+import (
+	"database/sql"
+
+	"github.com/eduardolat/pgbackweb/internal/config"
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/backups"
+	"github.com/eduardolat/pgbackweb/internal/service/databases"
+	"github.com/eduardolat/pgbackweb/internal/service/destinations"
+	"github.com/eduardolat/pgbackweb/internal/service/reports"
+)
+
+type Service struct {
+	env                 config.Env
+	db                  *sql.DB
+	dbgen               *dbgen.Queries
+	databasesService    *databases.Service
+	destinationsService *destinations.Service
+	backupsService      *backups.Service
+	reportsService      *reports.Service
+}
+
+func New(
+	env config.Env, db *sql.DB, dbgen *dbgen.Queries,
+	databasesService *databases.Service,
+	destinationsService *destinations.Service,
+	backupsService *backups.Service,
+	reportsService *reports.Service,
+) *Service {
+	return &Service{
+		env:                 env,
+		db:                  db,
+		dbgen:               dbgen,
+		databasesService:    databasesService,
+		destinationsService: destinationsService,
+		backupsService:      backupsService,
+		reportsService:      reportsService,
+	}
+}
@@ -0,0 +1,339 @@
+package bundles
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/google/uuid"
+)
+
+// ExportBundle returns all the databases, destinations, backups, backup
+// groups, webhooks and reports as a bundle with the secrets encrypted with the passphrase. When
+// includeExecutions is true the successful executions whose files still
+// exist are included as references to those files.
+func (s *Service) ExportBundle(
+	ctx context.Context, passphrase string, includeExecutions bool,
+) (Bundle, error) {
+	if err := users.Authorize(ctx, users.PermissionManageInstance); err != nil {
+		return Bundle{}, err
+	}
+
+	if len(passphrase) < 8 {
+		return Bundle{}, fmt.Errorf("the passphrase must have at least 8 characters")
+	}
+
+	check, err := s.dbgen.BundlesServiceEncryptCheck(
+		ctx, dbgen.BundlesServiceEncryptCheckParams{
+			CheckValue: checkValue,
+			Passphrase: passphrase,
+		},
+	)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("error encrypting bundle check: %w", err)
+	}
+
+	bundle := Bundle{
+		Version:      BundleVersion,
+		ExportedAt:   time.Now().UTC(),
+		Check:        check,
+		Databases:    []BundleDatabase{},
+		Destinations: []BundleDestination{},
+		Backups:      []BundleBackup{},
+		BackupGroups: []BundleBackupGroup{},
+		Webhooks:     []BundleWebhook{},
+		Reports:      []BundleReport{},
+	}
+
+	// Entities reference each other by name in the bundle, so the names must
+	// be unique to be resolved back on import.
+	names := map[Kind]map[uuid.UUID]string{
+		KindDatabase:    {},
+		KindDestination: {},
+		KindBackup:      {},
+	}
+	addName := func(kind Kind, id uuid.UUID, name string) error {
+		for _, existing := range names[kind] {
+			if existing == name {
+				return fmt.Errorf(
+					"there are multiple %ss named %q, rename them before exporting",
+					kind, name,
+				)
+			}
+		}
+		names[kind][id] = name
+		return nil
+	}
+
+	databases, err := s.dbgen.BundlesServiceExportDatabases(
+		ctx, dbgen.BundlesServiceExportDatabasesParams{
+			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
+			Passphrase:    passphrase,
+		},
+	)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("error exporting databases: %w", err)
+	}
+	for _, db := range databases {
+		if err := addName(KindDatabase, db.ID, db.Name); err != nil {
+			return Bundle{}, err
+		}
+		bundle.Databases = append(bundle.Databases, BundleDatabase{
+			Name:                       db.Name,
+			PgVersion:                  db.PgVersion,
+			ConnectionString:           db.ConnectionString,
+			HealthCheckIntervalMinutes: &db.HealthCheckIntervalMinutes,
+			HealthFailureThreshold:     &db.HealthFailureThreshold,
+			HealthSuccessThreshold:     &db.HealthSuccessThreshold,
+		})
+	}
+
+	destinations, err := s.dbgen.BundlesServiceExportDestinations(
+		ctx, dbgen.BundlesServiceExportDestinationsParams{
+			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
+			Passphrase:    passphrase,
+		},
+	)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("error exporting destinations: %w", err)
+	}
+	for _, dest := range destinations {
+		if err := addName(KindDestination, dest.ID, dest.Name); err != nil {
+			return Bundle{}, err
+		}
+		bundle.Destinations = append(bundle.Destinations, BundleDestination{
+			Name:                       dest.Name,
+			BucketName:                 dest.BucketName,
+			Region:                     dest.Region,
+			Endpoint:                   dest.Endpoint,
+			ForcePathStyle:             dest.ForcePathStyle,
+			SignatureVersion:           dest.SignatureVersion,
+			AccessKey:                  dest.AccessKey,
+			SecretKey:                  dest.SecretKey,
+			HealthCheckIntervalMinutes: &dest.HealthCheckIntervalMinutes,
+			HealthFailureThreshold:     &dest.HealthFailureThreshold,
+			HealthSuccessThreshold:     &dest.HealthSuccessThreshold,
+		})
+	}
+
+	backups, err := s.dbgen.BundlesServiceExportBackups(ctx)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("error exporting backups: %w", err)
+	}
+	for _, backup := range backups {
+		if err := addName(KindBackup, backup.ID, backup.Name); err != nil {
+			return Bundle{}, err
+		}
+		bundle.Backups = append(bundle.Backups, BundleBackup{
+			Name:                   backup.Name,
+			Database:               backup.DatabaseName,
+			IsLocal:                backup.IsLocal,
+			Destination:            backup.DestinationName.String,
+			CronExpression:         backup.CronExpression,
+			TimeZone:               backup.TimeZone,
+			IsActive:               backup.IsActive,
+			DestDir:                backup.DestDir,
+			RetentionDays:          backup.RetentionDays,
+			OptDataOnly:            backup.OptDataOnly,
+			OptSchemaOnly:          backup.OptSchemaOnly,
+			OptClean:               backup.OptClean,
+			OptIfExists:            backup.OptIfExists,
+			OptCreate:              backup.OptCreate,
+			OptNoComments:          backup.OptNoComments,
+			MaxPartSizeMb:          nullablePtr(backup.MaxPartSizeMb.Valid, backup.MaxPartSizeMb.Int32),
+			CompressionLevel:       nullablePtr(backup.CompressionLevel.Valid, backup.CompressionLevel.Int16),
+			SizeDeviationThreshold: backup.SizeDeviationThreshold,
+			RpoHours:               nullablePtr(backup.RpoHours.Valid, backup.RpoHours.Int16),
+		})
+	}
+
+	// The backups are named after all of them are added because a backup can
+	// run after one created later.
+	for i, backup := range backups {
+		if !backup.RunAfterBackupID.Valid {
+			continue
+		}
+		name := names[KindBackup][backup.RunAfterBackupID.UUID]
+		bundle.Backups[i].RunAfter = &name
+	}
+
+	groups, err := s.dbgen.BundlesServiceExportBackupGroups(ctx)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("error exporting backup groups: %w", err)
+	}
+	groupNames := map[string]bool{}
+	for _, group := range groups {
+		if groupNames[group.Name] {
+			return Bundle{}, fmt.Errorf(
+				"there are multiple backup groups named %q, rename them before exporting",
+				group.Name,
+			)
+		}
+		groupNames[group.Name] = true
+
+		// Backups deleted after the group was saved are left out, the same way
+		// they are skipped when the group runs.
+		backupNames := []string{}
+		for _, id := range group.BackupIds {
+			if name, ok := names[KindBackup][id]; ok {
+				backupNames = append(backupNames, name)
+			}
+		}
+		if len(backupNames) == 0 {
+			continue
+		}
+
+		bundle.BackupGroups = append(bundle.BackupGroups, BundleBackupGroup{
+			Name:           group.Name,
+			IsActive:       group.IsActive,
+			CronExpression: group.CronExpression,
+			TimeZone:       group.TimeZone,
+			Backups:        backupNames,
+		})
+	}
+
+	webhooks, err := s.dbgen.BundlesServiceExportWebhooks(
+		ctx, dbgen.BundlesServiceExportWebhooksParams{
+			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
+			Passphrase:    passphrase,
+		},
+	)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("error exporting webhooks: %w", err)
+	}
+	webhookNames := map[string]bool{}
+	for _, webhook := range webhooks {
+		if webhookNames[webhook.Name] {
+			return Bundle{}, fmt.Errorf(
+				"there are multiple webhooks named %q, rename them before exporting",
+				webhook.Name,
+			)
+		}
+		webhookNames[webhook.Name] = true
+
+		// Targets deleted after the webhook was created are left out, the
+		// same way they are ignored when the webhook runs.
+		targets := []string{}
+		for _, id := range webhook.TargetIds {
+			if name, ok := names[webhookTargetKind(webhook.EventType)][id]; ok {
+				targets = append(targets, name)
+			}
+		}
+		if len(targets) == 0 {
+			continue
+		}
+
+		bundle.Webhooks = append(bundle.Webhooks, BundleWebhook{
+			Name:           webhook.Name,
+			EventType:      webhook.EventType,
+			Targets:        targets,
+			IsActive:       webhook.IsActive,
+			Channel:        webhook.Channel,
+			Url:            webhook.Url,
+			Method:         webhook.Method,
+			Headers:        nullablePtr(webhook.Headers.Valid, webhook.Headers.String),
+			Body:           nullablePtr(webhook.Body.Valid, webhook.Body.String),
+			TimeoutSeconds: &webhook.TimeoutSeconds,
+			TLSSkipVerify:  webhook.TlsSkipVerify,
+			CACertificate: nullablePtr(
+				webhook.CaCertificate.Valid, webhook.CaCertificate.String,
+			),
+			ProxyURL: nullablePtr(webhook.ProxyUrl.Valid, webhook.ProxyUrl.String),
+			SigningSecret: nullablePtr(
+				webhook.ExportedSigningSecret.Valid, webhook.ExportedSigningSecret.String,
+			),
+		})
+	}
+
+	reports, err := s.dbgen.BundlesServiceExportReports(ctx)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("error exporting reports: %w", err)
+	}
+	reportNames := map[string]bool{}
+	for _, report := range reports {
+		if reportNames[report.Name] {
+			return Bundle{}, fmt.Errorf(
+				"there are multiple reports named %q, rename them before exporting",
+				report.Name,
+			)
+		}
+		reportNames[report.Name] = true
+
+		bundle.Reports = append(bundle.Reports, BundleReport{
+			Name:           report.Name,
+			IsActive:       report.IsActive,
+			Period:         report.Period,
+			CronExpression: report.CronExpression,
+			TimeZone:       report.TimeZone,
+			Channel:        report.Channel,
+			Url:            report.Url,
+		})
+	}
+
+	if !includeExecutions {
+		s.auditExport(ctx, bundle)
+		return bundle, nil
+	}
+
+	executions, err := s.dbgen.BundlesServiceExportExecutions(ctx)
+	if err != nil {
+		return Bundle{}, fmt.Errorf("error exporting executions: %w", err)
+	}
+	for _, execution := range executions {
+		bundle.Executions = append(bundle.Executions, BundleExecution{
+			Backup:       execution.BackupName,
+			Message:      nullablePtr(execution.Message.Valid, execution.Message.String),
+			Path:         execution.Path.String,
+			FileSize:     nullablePtr(execution.FileSize.Valid, execution.FileSize.Int64),
+			IsSuspicious: execution.IsSuspicious,
+			StartedAt:    execution.StartedAt,
+			FinishedAt:   nullablePtr(execution.FinishedAt.Valid, execution.FinishedAt.Time),
+		})
+	}
+
+	s.auditExport(ctx, bundle)
+	return bundle, nil
+}
+
+// nullablePtr returns a pointer to the value of a nullable column, or nil
+// when it is NULL.
+func nullablePtr[T any](valid bool, value T) *T {
+	if !valid {
+		return nil
+	}
+	return &value
+}
+
+// bundleSummary is recorded in the audit log instead of the whole bundle.
+type bundleSummary struct {
+	Databases    int
+	Destinations int
+	Backups      int
+	BackupGroups int
+	Webhooks     int
+	Reports      int
+	Executions   int
+}
+
+func newBundleSummary(bundle Bundle) bundleSummary {
+	return bundleSummary{
+		Databases:    len(bundle.Databases),
+		Destinations: len(bundle.Destinations),
+		Backups:      len(bundle.Backups),
+		BackupGroups: len(bundle.BackupGroups),
+		Webhooks:     len(bundle.Webhooks),
+		Reports:      len(bundle.Reports),
+		Executions:   len(bundle.Executions),
+	}
+}
+
+func (s *Service) auditExport(ctx context.Context, bundle Bundle) {
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionExport,
+		EntityType: audit.EntityBundle,
+		After:      newBundleSummary(bundle),
+	})
+}
@@ -0,0 +1,78 @@
+-- name: BundlesServiceEncryptCheck :one
+SELECT armor(pgp_sym_encrypt(@check_value, @passphrase))::TEXT;
+
+-- name: BundlesServiceExportDatabases :many
+SELECT
+  id,
+  name,
+  pg_version,
+  health_check_interval_minutes,
+  health_failure_threshold,
+  health_success_threshold,
+  armor(pgp_sym_encrypt(
+    pgp_sym_decrypt(connection_string, @encryption_key), @passphrase
+  ))::TEXT AS connection_string
+FROM databases
+ORDER BY created_at;
+
+-- name: BundlesServiceExportDestinations :many
+SELECT
+  id,
+  name,
+  bucket_name,
+  region,
+  endpoint,
+  force_path_style,
+  signature_version,
+  health_check_interval_minutes,
+  health_failure_threshold,
+  health_success_threshold,
+  armor(pgp_sym_encrypt(
+    pgp_sym_decrypt(access_key, @encryption_key), @passphrase
+  ))::TEXT AS access_key,
+  armor(pgp_sym_encrypt(
+    pgp_sym_decrypt(secret_key, @encryption_key), @passphrase
+  ))::TEXT AS secret_key
+FROM destinations
+ORDER BY created_at;
+
+-- name: BundlesServiceExportBackups :many
+SELECT
+  backups.*,
+  databases.name AS database_name,
+  destinations.name AS destination_name
+FROM backups
+INNER JOIN databases ON backups.database_id = databases.id
+LEFT JOIN destinations ON backups.destination_id = destinations.id
+ORDER BY backups.created_at;
+
+-- name: BundlesServiceExportBackupGroups :many
+SELECT * FROM backup_groups
+ORDER BY created_at;
+
+-- name: BundlesServiceExportWebhooks :many
+SELECT
+  webhooks.*,
+  CASE
+    WHEN signing_secret IS NOT NULL
+    THEN armor(pgp_sym_encrypt(
+      pgp_sym_decrypt(signing_secret, @encryption_key), @passphrase
+    ))::TEXT
+  END AS exported_signing_secret
+FROM webhooks
+ORDER BY created_at;
+
+-- name: BundlesServiceExportReports :many
+SELECT * FROM reports
+ORDER BY created_at;
+
+-- name: BundlesServiceExportExecutions :many
+SELECT
+  executions.*,
+  backups.name AS backup_name
+FROM executions
+INNER JOIN backups ON executions.backup_id = backups.id
+WHERE executions.status = 'success'
+AND executions.path IS NOT NULL
+AND executions.deleted_at IS NULL
+ORDER BY executions.started_at;
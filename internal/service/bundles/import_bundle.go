@@ -0,0 +1,730 @@
+package bundles
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/google/uuid"
+)
+
+// ImportBundle stores the entities of the bundle, resolving the name
+// conflicts with the given strategy. Everything is imported in a single
+// transaction, so a failure leaves the instance untouched.
+func (s *Service) ImportBundle(
+	ctx context.Context, bundle Bundle, passphrase string,
+	strategy ConflictStrategy,
+) (ImportResult, error) {
+	if err := users.Authorize(ctx, users.PermissionManageInstance); err != nil {
+		return ImportResult{}, err
+	}
+
+	if !strategy.isValid() {
+		return ImportResult{}, fmt.Errorf("invalid conflict strategy %q", strategy)
+	}
+	if err := bundle.validate(); err != nil {
+		return ImportResult{}, fmt.Errorf("invalid bundle: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	imp := &importer{
+		encryptionKey: s.env.PBW_ENCRYPTION_KEY,
+		dbgen:         s.dbgen.WithTx(tx),
+		passphrase:    passphrase,
+		strategy:      strategy,
+		result:        ImportResult{Items: []ImportedItem{}},
+	}
+
+	check, err := imp.dbgen.BundlesServiceDecryptCheck(
+		ctx, dbgen.BundlesServiceDecryptCheckParams{
+			Check:      bundle.Check,
+			Passphrase: passphrase,
+		},
+	)
+	if err != nil || check != checkValue {
+		return ImportResult{}, fmt.Errorf(
+			"the passphrase is wrong or the bundle is corrupted",
+		)
+	}
+
+	databaseIDs, err := imp.importDatabases(ctx, bundle.Databases)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	destinationIDs, err := imp.importDestinations(ctx, bundle.Destinations)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	backupIDs, err := imp.importBackups(
+		ctx, bundle.Backups, databaseIDs, destinationIDs,
+	)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	if err := imp.importRunAfter(ctx, bundle.Backups, backupIDs); err != nil {
+		return ImportResult{}, err
+	}
+	err = imp.importBackupGroups(ctx, bundle.BackupGroups, backupIDs)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	err = imp.importWebhooks(ctx, bundle.Webhooks, map[Kind]map[string]uuid.UUID{
+		KindDatabase:    databaseIDs,
+		KindDestination: destinationIDs,
+		KindBackup:      backupIDs,
+	})
+	if err != nil {
+		return ImportResult{}, err
+	}
+	if err := imp.importReports(ctx, bundle.Reports); err != nil {
+		return ImportResult{}, err
+	}
+	if err := imp.importExecutions(ctx, bundle.Executions, backupIDs); err != nil {
+		return ImportResult{}, err
+	}
+
+	audit.Record(ctx, imp.dbgen, audit.Entry{
+		Action:     audit.ActionImport,
+		EntityType: audit.EntityBundle,
+		After:      newBundleSummary(bundle),
+	})
+
+	if err := tx.Commit(); err != nil {
+		return ImportResult{}, fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	for _, id := range append(imp.changed[KindBackup], imp.ungrouped...) {
+		if err := s.backupsService.RefreshJob(ctx, id); err != nil {
+			return imp.result, fmt.Errorf("error scheduling backup %s: %w", id, err)
+		}
+	}
+	for _, id := range imp.changed[KindBackupGroup] {
+		if err := s.backupsService.RefreshGroupJob(ctx, id); err != nil {
+			return imp.result, fmt.Errorf(
+				"error scheduling backup group %s: %w", id, err,
+			)
+		}
+	}
+	for _, id := range imp.changed[KindReport] {
+		if err := s.reportsService.RefreshJob(ctx, id); err != nil {
+			return imp.result, fmt.Errorf("error scheduling report %s: %w", id, err)
+		}
+	}
+
+	// Test the imported connections in the background so unreachable
+	// dependencies do not block the import.
+	go func() {
+		for _, id := range imp.changed[KindDatabase] {
+			err := s.databasesService.TestDatabaseAndStoreResult(
+				users.WithSystem(context.Background()), id,
+			)
+			if err != nil {
+				logger.Error("error testing imported database", logger.KV{
+					"database_id": id.String(), "error": err,
+				})
+			}
+		}
+		for _, id := range imp.changed[KindDestination] {
+			err := s.destinationsService.TestDestinationAndStoreResult(
+				users.WithSystem(context.Background()), id,
+			)
+			if err != nil {
+				logger.Error("error testing imported destination", logger.KV{
+					"destination_id": id.String(), "error": err,
+				})
+			}
+		}
+	}()
+
+	logger.Info("configuration bundle imported", logger.KV{
+		"strategy":   string(strategy),
+		"items":      len(imp.result.Items),
+		"executions": imp.result.Executions,
+	})
+	return imp.result, nil
+}
+
+// importer holds the state of an import running in a transaction.
+type importer struct {
+	encryptionKey string
+	dbgen         *dbgen.Queries
+	passphrase    string
+	strategy      ConflictStrategy
+	result        ImportResult
+
+	// changed are the created, overwritten and renamed entities by kind.
+	changed map[Kind][]uuid.UUID
+	// ungrouped are the backups of the overwritten groups before the import,
+	// the ones removed from the groups go back to their own schedule.
+	ungrouped []uuid.UUID
+}
+
+// record adds the outcome of an entity to the result.
+func (imp *importer) record(
+	kind Kind, name string, res resolution, id uuid.UUID,
+) {
+	item := ImportedItem{Kind: kind, Name: name, Action: res.action}
+	if res.action == ImportRenamed {
+		item.NewName = res.name
+	}
+	imp.result.Items = append(imp.result.Items, item)
+
+	if res.action == ImportSkipped {
+		return
+	}
+	if imp.changed == nil {
+		imp.changed = map[Kind][]uuid.UUID{}
+	}
+	imp.changed[kind] = append(imp.changed[kind], id)
+}
+
+// importError adds the entity that failed to the error.
+func importError(kind Kind, name string, err error) error {
+	return fmt.Errorf("error importing %s %q: %w", kind, name, err)
+}
+
+// nullInt32 converts an optional value of the bundle to a nullable
+// parameter, the queries keep the current or default value when it is null.
+func nullInt32(value *int32) sql.NullInt32 {
+	if value == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *value, Valid: true}
+}
+
+func (imp *importer) importDatabases(
+	ctx context.Context, databases []BundleDatabase,
+) (map[string]uuid.UUID, error) {
+	rows, err := imp.dbgen.BundlesServiceGetDatabases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting databases: %w", err)
+	}
+	existing := make([]stored, 0, len(rows))
+	for _, row := range rows {
+		existing = append(existing, stored{row.ID, row.Name, row.IsProvisioned})
+	}
+
+	ids := map[string]uuid.UUID{}
+	for _, db := range databases {
+		res, err := resolve(KindDatabase, db.Name, existing, imp.strategy)
+		if err != nil {
+			return nil, err
+		}
+
+		id := res.id
+		switch res.action {
+		case ImportCreated, ImportRenamed:
+			id, err = imp.dbgen.BundlesServiceCreateDatabase(
+				ctx, dbgen.BundlesServiceCreateDatabaseParams{
+					Name:                       res.name,
+					ConnectionString:           db.ConnectionString,
+					Passphrase:                 imp.passphrase,
+					EncryptionKey:              imp.encryptionKey,
+					PgVersion:                  db.PgVersion,
+					HealthCheckIntervalMinutes: nullInt32(db.HealthCheckIntervalMinutes),
+					HealthFailureThreshold:     nullInt32(db.HealthFailureThreshold),
+					HealthSuccessThreshold:     nullInt32(db.HealthSuccessThreshold),
+				},
+			)
+			existing = append(existing, stored{id: id, name: res.name})
+		case ImportOverwritten:
+			err = imp.dbgen.BundlesServiceUpdateDatabase(
+				ctx, dbgen.BundlesServiceUpdateDatabaseParams{
+					PgVersion:                  db.PgVersion,
+					ConnectionString:           db.ConnectionString,
+					Passphrase:                 imp.passphrase,
+					EncryptionKey:              imp.encryptionKey,
+					HealthCheckIntervalMinutes: nullInt32(db.HealthCheckIntervalMinutes),
+					HealthFailureThreshold:     nullInt32(db.HealthFailureThreshold),
+					HealthSuccessThreshold:     nullInt32(db.HealthSuccessThreshold),
+					ID:                         id,
+				},
+			)
+		}
+		if err != nil {
+			return nil, importError(KindDatabase, db.Name, err)
+		}
+
+		ids[db.Name] = id
+		imp.record(KindDatabase, db.Name, res, id)
+	}
+
+	return ids, nil
+}
+
+func (imp *importer) importDestinations(
+	ctx context.Context, destinations []BundleDestination,
+) (map[string]uuid.UUID, error) {
+	rows, err := imp.dbgen.BundlesServiceGetDestinations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting destinations: %w", err)
+	}
+	existing := make([]stored, 0, len(rows))
+	for _, row := range rows {
+		existing = append(existing, stored{row.ID, row.Name, row.IsProvisioned})
+	}
+
+	ids := map[string]uuid.UUID{}
+	for _, dest := range destinations {
+		res, err := resolve(KindDestination, dest.Name, existing, imp.strategy)
+		if err != nil {
+			return nil, err
+		}
+
+		id := res.id
+		switch res.action {
+		case ImportCreated, ImportRenamed:
+			id, err = imp.dbgen.BundlesServiceCreateDestination(
+				ctx, dbgen.BundlesServiceCreateDestinationParams{
+					Name:                       res.name,
+					BucketName:                 dest.BucketName,
+					Region:                     dest.Region,
+					Endpoint:                   dest.Endpoint,
+					ForcePathStyle:             dest.ForcePathStyle,
+					SignatureVersion:           dest.SignatureVersion,
+					AccessKey:                  dest.AccessKey,
+					Passphrase:                 imp.passphrase,
+					EncryptionKey:              imp.encryptionKey,
+					SecretKey:                  dest.SecretKey,
+					HealthCheckIntervalMinutes: nullInt32(dest.HealthCheckIntervalMinutes),
+					HealthFailureThreshold:     nullInt32(dest.HealthFailureThreshold),
+					HealthSuccessThreshold:     nullInt32(dest.HealthSuccessThreshold),
+				},
+			)
+			existing = append(existing, stored{id: id, name: res.name})
+		case ImportOverwritten:
+			err = imp.dbgen.BundlesServiceUpdateDestination(
+				ctx, dbgen.BundlesServiceUpdateDestinationParams{
+					BucketName:                 dest.BucketName,
+					Region:                     dest.Region,
+					Endpoint:                   dest.Endpoint,
+					ForcePathStyle:             dest.ForcePathStyle,
+					SignatureVersion:           dest.SignatureVersion,
+					AccessKey:                  dest.AccessKey,
+					Passphrase:                 imp.passphrase,
+					EncryptionKey:              imp.encryptionKey,
+					SecretKey:                  dest.SecretKey,
+					HealthCheckIntervalMinutes: nullInt32(dest.HealthCheckIntervalMinutes),
+					HealthFailureThreshold:     nullInt32(dest.HealthFailureThreshold),
+					HealthSuccessThreshold:     nullInt32(dest.HealthSuccessThreshold),
+					ID:                         id,
+				},
+			)
+		}
+		if err != nil {
+			return nil, importError(KindDestination, dest.Name, err)
+		}
+
+		ids[dest.Name] = id
+		imp.record(KindDestination, dest.Name, res, id)
+	}
+
+	return ids, nil
+}
+
+func (imp *importer) importBackups(
+	ctx context.Context, backups []BundleBackup,
+	databaseIDs, destinationIDs map[string]uuid.UUID,
+) (map[string]uuid.UUID, error) {
+	rows, err := imp.dbgen.BundlesServiceGetBackups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting backups: %w", err)
+	}
+	existing := make([]stored, 0, len(rows))
+	storedDatabaseIDs := map[uuid.UUID]uuid.UUID{}
+	for _, row := range rows {
+		existing = append(existing, stored{row.ID, row.Name, row.IsProvisioned})
+		storedDatabaseIDs[row.ID] = row.DatabaseID
+	}
+
+	ids := map[string]uuid.UUID{}
+	for _, backup := range backups {
+		res, err := resolve(KindBackup, backup.Name, existing, imp.strategy)
+		if err != nil {
+			return nil, err
+		}
+
+		destinationID := uuid.NullUUID{}
+		if !backup.IsLocal {
+			destinationID = uuid.NullUUID{
+				UUID: destinationIDs[backup.Destination], Valid: true,
+			}
+		}
+		maxPartSizeMb := sql.NullInt32{}
+		if backup.MaxPartSizeMb != nil {
+			maxPartSizeMb = sql.NullInt32{Int32: *backup.MaxPartSizeMb, Valid: true}
+		}
+		compressionLevel := sql.NullInt16{}
+		if backup.CompressionLevel != nil {
+			compressionLevel = sql.NullInt16{
+				Int16: *backup.CompressionLevel, Valid: true,
+			}
+		}
+		rpoHours := sql.NullInt16{}
+		if backup.RpoHours != nil {
+			rpoHours = sql.NullInt16{Int16: *backup.RpoHours, Valid: true}
+		}
+
+		id := res.id
+		switch res.action {
+		case ImportCreated, ImportRenamed:
+			id, err = imp.dbgen.BundlesServiceCreateBackup(
+				ctx, dbgen.BundlesServiceCreateBackupParams{
+					DatabaseID:             databaseIDs[backup.Database],
+					DestinationID:          destinationID,
+					IsLocal:                backup.IsLocal,
+					Name:                   res.name,
+					CronExpression:         backup.CronExpression,
+					TimeZone:               backup.TimeZone,
+					IsActive:               backup.IsActive,
+					DestDir:                backup.DestDir,
+					RetentionDays:          backup.RetentionDays,
+					OptDataOnly:            backup.OptDataOnly,
+					OptSchemaOnly:          backup.OptSchemaOnly,
+					OptClean:               backup.OptClean,
+					OptIfExists:            backup.OptIfExists,
+					OptCreate:              backup.OptCreate,
+					OptNoComments:          backup.OptNoComments,
+					MaxPartSizeMb:          maxPartSizeMb,
+					CompressionLevel:       compressionLevel,
+					SizeDeviationThreshold: backup.SizeDeviationThreshold,
+					RpoHours:               rpoHours,
+				},
+			)
+			existing = append(existing, stored{id: id, name: res.name})
+		case ImportOverwritten:
+			// The executions of a backup belong to its database, so moving the
+			// backup to another database is not allowed, the same as in the UI.
+			if storedDatabaseIDs[id] != databaseIDs[backup.Database] {
+				return nil, importError(KindBackup, backup.Name, fmt.Errorf(
+					"the database of an existing backup can't be changed",
+				))
+			}
+			err = imp.dbgen.BundlesServiceUpdateBackup(
+				ctx, dbgen.BundlesServiceUpdateBackupParams{
+					DestinationID:          destinationID,
+					IsLocal:                backup.IsLocal,
+					CronExpression:         backup.CronExpression,
+					TimeZone:               backup.TimeZone,
+					IsActive:               backup.IsActive,
+					DestDir:                backup.DestDir,
+					RetentionDays:          backup.RetentionDays,
+					OptDataOnly:            backup.OptDataOnly,
+					OptSchemaOnly:          backup.OptSchemaOnly,
+					OptClean:               backup.OptClean,
+					OptIfExists:            backup.OptIfExists,
+					OptCreate:              backup.OptCreate,
+					OptNoComments:          backup.OptNoComments,
+					MaxPartSizeMb:          maxPartSizeMb,
+					CompressionLevel:       compressionLevel,
+					SizeDeviationThreshold: backup.SizeDeviationThreshold,
+					RpoHours:               rpoHours,
+					ID:                     id,
+				},
+			)
+		}
+		if err != nil {
+			return nil, importError(KindBackup, backup.Name, err)
+		}
+
+		ids[backup.Name] = id
+		imp.record(KindBackup, backup.Name, res, id)
+	}
+
+	return ids, nil
+}
+
+// importRunAfter sets the backup that every imported backup runs after. It
+// runs once all the backups are stored because a backup can run after one
+// that comes later in the bundle. The skipped backups are left untouched.
+func (imp *importer) importRunAfter(
+	ctx context.Context, backups []BundleBackup, backupIDs map[string]uuid.UUID,
+) error {
+	for _, backup := range backups {
+		id := backupIDs[backup.Name]
+		if !slices.Contains(imp.changed[KindBackup], id) {
+			continue
+		}
+
+		runAfterID := uuid.NullUUID{}
+		if backup.RunAfter != nil {
+			runAfterID = uuid.NullUUID{UUID: backupIDs[*backup.RunAfter], Valid: true}
+		}
+
+		err := imp.dbgen.BundlesServiceSetBackupRunAfter(
+			ctx, dbgen.BundlesServiceSetBackupRunAfterParams{
+				RunAfterBackupID: runAfterID,
+				ID:               id,
+			},
+		)
+		if err != nil {
+			return importError(KindBackup, backup.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (imp *importer) importBackupGroups(
+	ctx context.Context, groups []BundleBackupGroup,
+	backupIDs map[string]uuid.UUID,
+) error {
+	rows, err := imp.dbgen.BundlesServiceGetBackupGroups(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting backup groups: %w", err)
+	}
+	existing := make([]stored, 0, len(rows))
+	storedBackupIDs := map[uuid.UUID][]uuid.UUID{}
+	for _, row := range rows {
+		existing = append(existing, stored{row.ID, row.Name, row.IsProvisioned})
+		storedBackupIDs[row.ID] = row.BackupIds
+	}
+
+	for _, group := range groups {
+		res, err := resolve(KindBackupGroup, group.Name, existing, imp.strategy)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]uuid.UUID, 0, len(group.Backups))
+		for _, backup := range group.Backups {
+			ids = append(ids, backupIDs[backup])
+		}
+
+		id := res.id
+		switch res.action {
+		case ImportCreated, ImportRenamed:
+			id, err = imp.dbgen.BundlesServiceCreateBackupGroup(
+				ctx, dbgen.BundlesServiceCreateBackupGroupParams{
+					Name:           res.name,
+					IsActive:       group.IsActive,
+					CronExpression: group.CronExpression,
+					TimeZone:       group.TimeZone,
+					BackupIds:      ids,
+				},
+			)
+			existing = append(existing, stored{id: id, name: res.name})
+		case ImportOverwritten:
+			err = imp.dbgen.BundlesServiceUpdateBackupGroup(
+				ctx, dbgen.BundlesServiceUpdateBackupGroupParams{
+					IsActive:       group.IsActive,
+					CronExpression: group.CronExpression,
+					TimeZone:       group.TimeZone,
+					BackupIds:      ids,
+					ID:             id,
+				},
+			)
+			imp.ungrouped = append(imp.ungrouped, storedBackupIDs[id]...)
+		}
+		if err != nil {
+			return importError(KindBackupGroup, group.Name, err)
+		}
+
+		imp.record(KindBackupGroup, group.Name, res, id)
+	}
+
+	return nil
+}
+
+func (imp *importer) importWebhooks(
+	ctx context.Context, webhooks []BundleWebhook,
+	targetIDs map[Kind]map[string]uuid.UUID,
+) error {
+	rows, err := imp.dbgen.BundlesServiceGetWebhooks(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting webhooks: %w", err)
+	}
+	existing := make([]stored, 0, len(rows))
+	for _, row := range rows {
+		existing = append(existing, stored{row.ID, row.Name, row.IsProvisioned})
+	}
+
+	for _, webhook := range webhooks {
+		res, err := resolve(KindWebhook, webhook.Name, existing, imp.strategy)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]uuid.UUID, 0, len(webhook.Targets))
+		for _, target := range webhook.Targets {
+			ids = append(ids, targetIDs[webhookTargetKind(webhook.EventType)][target])
+		}
+		headers := sql.NullString{}
+		if webhook.Headers != nil {
+			headers = sql.NullString{String: *webhook.Headers, Valid: true}
+		}
+		body := sql.NullString{}
+		if webhook.Body != nil {
+			body = sql.NullString{String: *webhook.Body, Valid: true}
+		}
+		channel := webhook.Channel
+		if channel == "" {
+			channel = "webhook"
+		}
+		signingSecret := sql.NullString{}
+		if webhook.SigningSecret != nil {
+			signingSecret = sql.NullString{String: *webhook.SigningSecret, Valid: true}
+		}
+		caCertificate := sql.NullString{}
+		if webhook.CACertificate != nil {
+			caCertificate = sql.NullString{String: *webhook.CACertificate, Valid: true}
+		}
+		proxyURL := sql.NullString{}
+		if webhook.ProxyURL != nil {
+			proxyURL = sql.NullString{String: *webhook.ProxyURL, Valid: true}
+		}
+
+		id := res.id
+		switch res.action {
+		case ImportCreated, ImportRenamed:
+			id, err = imp.dbgen.BundlesServiceCreateWebhook(
+				ctx, dbgen.BundlesServiceCreateWebhookParams{
+					Name:           res.name,
+					IsActive:       webhook.IsActive,
+					EventType:      webhook.EventType,
+					TargetIds:      ids,
+					Channel:        channel,
+					Url:            webhook.Url,
+					Method:         webhook.Method,
+					Headers:        headers,
+					Body:           body,
+					TimeoutSeconds: webhook.timeoutSeconds(),
+					TlsSkipVerify:  webhook.TLSSkipVerify,
+					CaCertificate:  caCertificate,
+					ProxyUrl:       proxyURL,
+					SigningSecret:  signingSecret,
+					Passphrase:     imp.passphrase,
+					EncryptionKey:  imp.encryptionKey,
+				},
+			)
+			existing = append(existing, stored{id: id, name: res.name})
+		case ImportOverwritten:
+			err = imp.dbgen.BundlesServiceUpdateWebhook(
+				ctx, dbgen.BundlesServiceUpdateWebhookParams{
+					IsActive:       webhook.IsActive,
+					EventType:      webhook.EventType,
+					TargetIds:      ids,
+					Channel:        channel,
+					Url:            webhook.Url,
+					Method:         webhook.Method,
+					Headers:        headers,
+					Body:           body,
+					TimeoutSeconds: webhook.timeoutSeconds(),
+					TlsSkipVerify:  webhook.TLSSkipVerify,
+					CaCertificate:  caCertificate,
+					ProxyUrl:       proxyURL,
+					SigningSecret:  signingSecret,
+					Passphrase:     imp.passphrase,
+					EncryptionKey:  imp.encryptionKey,
+					ID:             id,
+				},
+			)
+		}
+		if err != nil {
+			return importError(KindWebhook, webhook.Name, err)
+		}
+
+		imp.record(KindWebhook, webhook.Name, res, id)
+	}
+
+	return nil
+}
+
+func (imp *importer) importReports(
+	ctx context.Context, reports []BundleReport,
+) error {
+	rows, err := imp.dbgen.BundlesServiceGetReports(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting reports: %w", err)
+	}
+	existing := make([]stored, 0, len(rows))
+	for _, row := range rows {
+		existing = append(existing, stored{row.ID, row.Name, row.IsProvisioned})
+	}
+
+	for _, report := range reports {
+		res, err := resolve(KindReport, report.Name, existing, imp.strategy)
+		if err != nil {
+			return err
+		}
+
+		id := res.id
+		switch res.action {
+		case ImportCreated, ImportRenamed:
+			id, err = imp.dbgen.BundlesServiceCreateReport(
+				ctx, dbgen.BundlesServiceCreateReportParams{
+					Name:           res.name,
+					IsActive:       report.IsActive,
+					Period:         report.Period,
+					CronExpression: report.CronExpression,
+					TimeZone:       report.TimeZone,
+					Channel:        report.Channel,
+					Url:            report.Url,
+				},
+			)
+			existing = append(existing, stored{id: id, name: res.name})
+		case ImportOverwritten:
+			err = imp.dbgen.BundlesServiceUpdateReport(
+				ctx, dbgen.BundlesServiceUpdateReportParams{
+					IsActive:       report.IsActive,
+					Period:         report.Period,
+					CronExpression: report.CronExpression,
+					TimeZone:       report.TimeZone,
+					Channel:        report.Channel,
+					Url:            report.Url,
+					ID:             id,
+				},
+			)
+		}
+		if err != nil {
+			return importError(KindReport, report.Name, err)
+		}
+
+		imp.record(KindReport, report.Name, res, id)
+	}
+
+	return nil
+}
+
+// importExecutions stores the execution references, the ones that already
+// exist for the same backup and path are ignored.
+func (imp *importer) importExecutions(
+	ctx context.Context, executions []BundleExecution,
+	backupIDs map[string]uuid.UUID,
+) error {
+	for _, execution := range executions {
+		params := dbgen.BundlesServiceImportExecutionParams{
+			BackupID:     backupIDs[execution.Backup],
+			Path:         execution.Path,
+			IsSuspicious: execution.IsSuspicious,
+			StartedAt:    execution.StartedAt,
+		}
+		if execution.Message != nil {
+			params.Message = sql.NullString{String: *execution.Message, Valid: true}
+		}
+		if execution.FileSize != nil {
+			params.FileSize = sql.NullInt64{Int64: *execution.FileSize, Valid: true}
+		}
+		if execution.FinishedAt != nil {
+			params.FinishedAt = sql.NullTime{Time: *execution.FinishedAt, Valid: true}
+		}
+
+		imported, err := imp.dbgen.BundlesServiceImportExecution(ctx, params)
+		if err != nil {
+			return fmt.Errorf("error importing execution %q: %w", execution.Path, err)
+		}
+		imp.result.Executions += imported
+	}
+
+	return nil
+}
@@ -0,0 +1,248 @@
+-- name: BundlesServiceDecryptCheck :one
+SELECT pgp_sym_decrypt(dearmor(@check), @passphrase)::TEXT;
+
+-- name: BundlesServiceGetDatabases :many
+SELECT id, name, is_provisioned FROM databases
+ORDER BY created_at;
+
+-- name: BundlesServiceGetDestinations :many
+SELECT id, name, is_provisioned FROM destinations
+ORDER BY created_at;
+
+-- name: BundlesServiceGetBackups :many
+SELECT id, name, is_provisioned, database_id FROM backups
+ORDER BY created_at;
+
+-- name: BundlesServiceGetBackupGroups :many
+SELECT id, name, is_provisioned, backup_ids FROM backup_groups
+ORDER BY created_at;
+
+-- name: BundlesServiceGetWebhooks :many
+SELECT id, name, is_provisioned FROM webhooks
+ORDER BY created_at;
+
+-- name: BundlesServiceGetReports :many
+SELECT id, name, is_provisioned FROM reports
+ORDER BY created_at;
+
+-- name: BundlesServiceCreateDatabase :one
+INSERT INTO databases (
+  name, connection_string, pg_version, health_check_interval_minutes,
+  health_failure_threshold, health_success_threshold
+)
+VALUES (
+  @name,
+  pgp_sym_encrypt(
+    pgp_sym_decrypt(dearmor(@connection_string), @passphrase),
+    @encryption_key
+  ),
+  @pg_version,
+  COALESCE(sqlc.narg('health_check_interval_minutes')::INTEGER, 10),
+  COALESCE(sqlc.narg('health_failure_threshold')::INTEGER, 1),
+  COALESCE(sqlc.narg('health_success_threshold')::INTEGER, 1)
+)
+RETURNING id;
+
+-- name: BundlesServiceUpdateDatabase :exec
+UPDATE databases
+SET
+  pg_version = @pg_version,
+  connection_string = pgp_sym_encrypt(
+    pgp_sym_decrypt(dearmor(@connection_string), @passphrase),
+    @encryption_key
+  ),
+  health_check_interval_minutes = COALESCE(
+    sqlc.narg('health_check_interval_minutes'), health_check_interval_minutes
+  ),
+  health_failure_threshold = COALESCE(
+    sqlc.narg('health_failure_threshold'), health_failure_threshold
+  ),
+  health_success_threshold = COALESCE(
+    sqlc.narg('health_success_threshold'), health_success_threshold
+  )
+WHERE id = @id AND is_provisioned = FALSE;
+
+-- name: BundlesServiceCreateDestination :one
+INSERT INTO destinations (
+  name, bucket_name, region, endpoint, force_path_style, signature_version,
+  access_key, secret_key, health_check_interval_minutes,
+  health_failure_threshold, health_success_threshold
+)
+VALUES (
+  @name, @bucket_name, @region, @endpoint, @force_path_style,
+  @signature_version,
+  pgp_sym_encrypt(
+    pgp_sym_decrypt(dearmor(@access_key), @passphrase), @encryption_key
+  ),
+  pgp_sym_encrypt(
+    pgp_sym_decrypt(dearmor(@secret_key), @passphrase), @encryption_key
+  ),
+  COALESCE(sqlc.narg('health_check_interval_minutes')::INTEGER, 10),
+  COALESCE(sqlc.narg('health_failure_threshold')::INTEGER, 1),
+  COALESCE(sqlc.narg('health_success_threshold')::INTEGER, 1)
+)
+RETURNING id;
+
+-- name: BundlesServiceUpdateDestination :exec
+UPDATE destinations
+SET
+  bucket_name = @bucket_name,
+  region = @region,
+  endpoint = @endpoint,
+  force_path_style = @force_path_style,
+  signature_version = @signature_version,
+  access_key = pgp_sym_encrypt(
+    pgp_sym_decrypt(dearmor(@access_key), @passphrase), @encryption_key
+  ),
+  secret_key = pgp_sym_encrypt(
+    pgp_sym_decrypt(dearmor(@secret_key), @passphrase), @encryption_key
+  ),
+  health_check_interval_minutes = COALESCE(
+    sqlc.narg('health_check_interval_minutes'), health_check_interval_minutes
+  ),
+  health_failure_threshold = COALESCE(
+    sqlc.narg('health_failure_threshold'), health_failure_threshold
+  ),
+  health_success_threshold = COALESCE(
+    sqlc.narg('health_success_threshold'), health_success_threshold
+  )
+WHERE id = @id AND is_provisioned = FALSE;
+
+-- name: BundlesServiceCreateBackup :one
+INSERT INTO backups (
+  database_id, destination_id, is_local, name, cron_expression, time_zone,
+  is_active, dest_dir, retention_days, opt_data_only, opt_schema_only,
+  opt_clean, opt_if_exists, opt_create, opt_no_comments,
+  max_part_size_mb, compression_level, size_deviation_threshold, rpo_hours
+)
+VALUES (
+  @database_id, sqlc.narg('destination_id'), @is_local, @name,
+  @cron_expression, @time_zone, @is_active, @dest_dir, @retention_days,
+  @opt_data_only, @opt_schema_only, @opt_clean, @opt_if_exists, @opt_create,
+  @opt_no_comments, sqlc.narg('max_part_size_mb'),
+  sqlc.narg('compression_level'), @size_deviation_threshold,
+  sqlc.narg('rpo_hours')
+)
+RETURNING id;
+
+-- name: BundlesServiceUpdateBackup :exec
+UPDATE backups
+SET
+  destination_id = sqlc.narg('destination_id'),
+  is_local = @is_local,
+  cron_expression = @cron_expression,
+  time_zone = @time_zone,
+  is_active = @is_active,
+  dest_dir = @dest_dir,
+  retention_days = @retention_days,
+  opt_data_only = @opt_data_only,
+  opt_schema_only = @opt_schema_only,
+  opt_clean = @opt_clean,
+  opt_if_exists = @opt_if_exists,
+  opt_create = @opt_create,
+  opt_no_comments = @opt_no_comments,
+  max_part_size_mb = sqlc.narg('max_part_size_mb'),
+  compression_level = sqlc.narg('compression_level'),
+  size_deviation_threshold = @size_deviation_threshold,
+  rpo_hours = sqlc.narg('rpo_hours')
+WHERE id = @id AND is_provisioned = FALSE;
+
+-- name: BundlesServiceSetBackupRunAfter :exec
+UPDATE backups
+SET run_after_backup_id = sqlc.narg('run_after_backup_id')
+WHERE id = @id AND is_provisioned = FALSE;
+
+-- name: BundlesServiceCreateBackupGroup :one
+INSERT INTO backup_groups (
+  name, is_active, cron_expression, time_zone, backup_ids
+)
+VALUES (
+  @name, @is_active, @cron_expression, @time_zone, @backup_ids
+)
+RETURNING id;
+
+-- name: BundlesServiceUpdateBackupGroup :exec
+UPDATE backup_groups
+SET
+  is_active = @is_active,
+  cron_expression = @cron_expression,
+  time_zone = @time_zone,
+  backup_ids = @backup_ids
+WHERE id = @id AND is_provisioned = FALSE;
+
+-- name: BundlesServiceCreateWebhook :one
+INSERT INTO webhooks (
+  name, is_active, event_type, target_ids, channel, url, method, headers,
+  body, timeout_seconds, tls_skip_verify, ca_certificate, proxy_url,
+  signing_secret
+)
+VALUES (
+  @name, @is_active, @event_type, @target_ids, @channel, @url, @method,
+  sqlc.narg('headers'), sqlc.narg('body'), @timeout_seconds, @tls_skip_verify,
+  sqlc.narg('ca_certificate'), sqlc.narg('proxy_url'),
+  CASE
+    WHEN sqlc.narg('signing_secret')::TEXT IS NOT NULL
+    THEN pgp_sym_encrypt(
+      pgp_sym_decrypt(dearmor(sqlc.narg('signing_secret')::TEXT), @passphrase),
+      @encryption_key
+    )
+  END
+)
+RETURNING id;
+
+-- name: BundlesServiceUpdateWebhook :exec
+UPDATE webhooks
+SET
+  is_active = @is_active,
+  event_type = @event_type,
+  target_ids = @target_ids,
+  channel = @channel,
+  url = @url,
+  method = @method,
+  headers = sqlc.narg('headers'),
+  body = sqlc.narg('body'),
+  timeout_seconds = @timeout_seconds,
+  tls_skip_verify = @tls_skip_verify,
+  ca_certificate = sqlc.narg('ca_certificate'),
+  proxy_url = sqlc.narg('proxy_url'),
+  signing_secret = CASE
+    WHEN sqlc.narg('signing_secret')::TEXT IS NOT NULL
+    THEN pgp_sym_encrypt(
+      pgp_sym_decrypt(dearmor(sqlc.narg('signing_secret')::TEXT), @passphrase),
+      @encryption_key
+    )
+  END
+WHERE id = @id AND is_provisioned = FALSE;
+
+-- name: BundlesServiceCreateReport :one
+INSERT INTO reports (
+  name, is_active, period, cron_expression, time_zone, channel, url
+)
+VALUES (
+  @name, @is_active, @period, @cron_expression, @time_zone, @channel, @url
+)
+RETURNING id;
+
+-- name: BundlesServiceUpdateReport :exec
+UPDATE reports
+SET
+  is_active = @is_active,
+  period = @period,
+  cron_expression = @cron_expression,
+  time_zone = @time_zone,
+  channel = @channel,
+  url = @url
+WHERE id = @id AND is_provisioned = FALSE;
+
+-- name: BundlesServiceImportExecution :execrows
+INSERT INTO executions (
+  backup_id, status, message, path, file_size, is_suspicious, started_at,
+  finished_at
+)
+SELECT
+  @backup_id, 'success', sqlc.narg('message'), @path, sqlc.narg('file_size'),
+  @is_suspicious, @started_at, sqlc.narg('finished_at')
+WHERE NOT EXISTS (
+  SELECT 1 FROM executions
+  WHERE executions.backup_id = @backup_id AND executions.path = @path
+);
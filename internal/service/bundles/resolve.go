@@ -0,0 +1,149 @@
+package bundles
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ConflictStrategy decides what happens when an entity of the bundle has the
+// same name as a stored entity of the same kind.
+type ConflictStrategy string
+
+const (
+	// ConflictSkip keeps the stored entity and uses it wherever the bundle
+	// references the name.
+	ConflictSkip ConflictStrategy = "skip"
+	// ConflictOverwrite replaces the stored entity with the one of the bundle.
+	ConflictOverwrite ConflictStrategy = "overwrite"
+	// ConflictRename imports the entity of the bundle with a new name.
+	ConflictRename ConflictStrategy = "rename"
+)
+
+// ConflictStrategies are the valid conflict strategies.
+var ConflictStrategies = []ConflictStrategy{
+	ConflictSkip, ConflictOverwrite, ConflictRename,
+}
+
+func (cs ConflictStrategy) isValid() bool {
+	for _, strategy := range ConflictStrategies {
+		if cs == strategy {
+			return true
+		}
+	}
+	return false
+}
+
+type ImportAction string
+
+const (
+	ImportCreated     ImportAction = "created"
+	ImportOverwritten ImportAction = "overwritten"
+	ImportRenamed     ImportAction = "renamed"
+	ImportSkipped     ImportAction = "skipped"
+)
+
+// stored is an entity that already exists in the instance.
+type stored struct {
+	id            uuid.UUID
+	name          string
+	isProvisioned bool
+}
+
+// resolution is how an entity of the bundle is imported. id is the stored
+// entity to overwrite or to use instead of the imported one, and name is the
+// name to store the entity with.
+type resolution struct {
+	action ImportAction
+	id     uuid.UUID
+	name   string
+}
+
+// resolve applies the conflict strategy to an entity of the bundle.
+func resolve(
+	kind Kind, name string, existing []stored, strategy ConflictStrategy,
+) (resolution, error) {
+	matches := []stored{}
+	for _, item := range existing {
+		if item.name == name {
+			matches = append(matches, item)
+		}
+	}
+
+	if len(matches) == 0 {
+		return resolution{action: ImportCreated, name: name}, nil
+	}
+
+	if strategy == ConflictRename {
+		return resolution{
+			action: ImportRenamed,
+			name:   uniqueName(name, existing),
+		}, nil
+	}
+
+	if len(matches) > 1 {
+		return resolution{}, fmt.Errorf(
+			"there are multiple %ss named %q, use the rename strategy or rename "+
+				"them before importing", kind, name,
+		)
+	}
+
+	if strategy == ConflictSkip {
+		return resolution{action: ImportSkipped, id: matches[0].id, name: name}, nil
+	}
+
+	if matches[0].isProvisioned {
+		return resolution{}, fmt.Errorf(
+			"%s %q is provisioned from the configuration file and can't be "+
+				"overwritten", kind, name,
+		)
+	}
+	return resolution{action: ImportOverwritten, id: matches[0].id, name: name}, nil
+}
+
+// uniqueName returns the name with an "(imported)" suffix, numbered when
+// needed, that is not used by any of the existing entities.
+func uniqueName(name string, existing []stored) string {
+	taken := map[string]bool{}
+	for _, item := range existing {
+		taken[item.name] = true
+	}
+
+	candidate := name + " (imported)"
+	for i := 2; taken[candidate]; i++ {
+		candidate = fmt.Sprintf("%s (imported %d)", name, i)
+	}
+	return candidate
+}
+
+// ImportedItem is the outcome of importing an entity of the bundle.
+type ImportedItem struct {
+	Kind   Kind         `json:"kind"`
+	Name   string       `json:"name"`
+	Action ImportAction `json:"action"`
+	// NewName is the name the entity was stored with when it was renamed.
+	NewName string `json:"new_name,omitempty"`
+}
+
+// ImportResult summarizes an import.
+type ImportResult struct {
+	Items []ImportedItem `json:"items"`
+	// Executions is the number of execution references imported, the ones
+	// that already existed are not counted.
+	Executions int64 `json:"executions"`
+}
+
+// String returns the result in a human readable form, one entity per line.
+func (r ImportResult) String() string {
+	var sb strings.Builder
+	for _, item := range r.Items {
+		fmt.Fprintf(&sb, "%s %s %q", item.Action, item.Kind, item.Name)
+		if item.NewName != "" {
+			fmt.Fprintf(&sb, " as %q", item.NewName)
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "imported %d execution references\n", r.Executions)
+	return sb.String()
+}
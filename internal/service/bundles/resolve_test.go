@@ -0,0 +1,115 @@
+package bundles
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	mainID, provisionedID := uuid.New(), uuid.New()
+	existing := []stored{
+		{id: mainID, name: "main"},
+		{id: provisionedID, name: "config", isProvisioned: true},
+		{id: uuid.New(), name: "dup"},
+		{id: uuid.New(), name: "dup"},
+	}
+
+	tests := []struct {
+		name     string
+		entity   string
+		strategy ConflictStrategy
+		want     resolution
+		wantErr  string
+	}{
+		{
+			name:     "create when there is no conflict",
+			entity:   "new",
+			strategy: ConflictSkip,
+			want:     resolution{action: ImportCreated, name: "new"},
+		},
+		{
+			name:     "skip",
+			entity:   "main",
+			strategy: ConflictSkip,
+			want:     resolution{action: ImportSkipped, id: mainID, name: "main"},
+		},
+		{
+			name:     "overwrite",
+			entity:   "main",
+			strategy: ConflictOverwrite,
+			want:     resolution{action: ImportOverwritten, id: mainID, name: "main"},
+		},
+		{
+			name:     "rename",
+			entity:   "main",
+			strategy: ConflictRename,
+			want:     resolution{action: ImportRenamed, name: "main (imported)"},
+		},
+		{
+			name:     "skip provisioned",
+			entity:   "config",
+			strategy: ConflictSkip,
+			want: resolution{
+				action: ImportSkipped, id: provisionedID, name: "config",
+			},
+		},
+		{
+			name:     "overwrite provisioned",
+			entity:   "config",
+			strategy: ConflictOverwrite,
+			wantErr:  "provisioned from the configuration file",
+		},
+		{
+			name:     "skip ambiguous",
+			entity:   "dup",
+			strategy: ConflictSkip,
+			wantErr:  `multiple databases named "dup"`,
+		},
+		{
+			name:     "rename ambiguous",
+			entity:   "dup",
+			strategy: ConflictRename,
+			want:     resolution{action: ImportRenamed, name: "dup (imported)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolve(KindDatabase, tt.entity, existing, tt.strategy)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	assert.Equal(t, "main (imported)", uniqueName("main", nil))
+	assert.Equal(t, "main (imported 3)", uniqueName("main", []stored{
+		{name: "main"}, {name: "main (imported)"}, {name: "main (imported 2)"},
+	}))
+}
+
+func TestImportResultString(t *testing.T) {
+	result := ImportResult{
+		Items: []ImportedItem{
+			{Kind: KindDatabase, Name: "main", Action: ImportCreated},
+			{
+				Kind: KindBackup, Name: "daily", Action: ImportRenamed,
+				NewName: "daily (imported)",
+			},
+		},
+		Executions: 2,
+	}
+	assert.Equal(t, ""+
+		"created database \"main\"\n"+
+		"renamed backup \"daily\" as \"daily (imported)\"\n"+
+		"imported 2 execution references\n",
+		result.String(),
+	)
+}
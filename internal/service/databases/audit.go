@@ -0,0 +1,25 @@
+package databases
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+// auditSnapshot returns the database with its decrypted connection string,
+// to record its changes in the audit log, or nil if it can't be read.
+func (s *Service) auditSnapshot(
+	ctx context.Context, id uuid.UUID,
+) *dbgen.DatabasesServiceGetDatabaseRow {
+	database, err := s.dbgen.DatabasesServiceGetDatabase(
+		ctx, dbgen.DatabasesServiceGetDatabaseParams{
+			ID:            id,
+			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
+		},
+	)
+	if err != nil {
+		return nil
+	}
+	return &database
+}
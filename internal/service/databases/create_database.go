@@ -4,18 +4,37 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 )
 
 func (s *Service) CreateDatabase(
 	ctx context.Context, params dbgen.DatabasesServiceCreateDatabaseParams,
 ) (dbgen.Database, error) {
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return dbgen.Database{}, err
+	}
+
 	err := s.TestDatabase(ctx, params.PgVersion, params.ConnectionString)
 	if err != nil {
 		return dbgen.Database{}, err
 	}
 
 	params.EncryptionKey = s.env.PBW_ENCRYPTION_KEY
+	if !params.WorkspaceID.Valid {
+		params.WorkspaceID = workspaces.FromContext(ctx)
+	}
 	db, err := s.dbgen.DatabasesServiceCreateDatabase(ctx, params)
+	if err == nil {
+		audit.Record(ctx, s.dbgen, audit.Entry{
+			Action:     audit.ActionCreate,
+			EntityType: audit.EntityDatabase,
+			EntityID:   db.ID,
+			EntityName: db.Name,
+			After:      s.auditSnapshot(ctx, db.ID),
+		})
+	}
 
 	_ = s.TestDatabaseAndStoreResult(ctx, db.ID)
 
@@ -1,8 +1,14 @@
 -- name: DatabasesServiceCreateDatabase :one
 INSERT INTO databases (
-  name, connection_string, pg_version
+  name, connection_string, pg_version, workspace_id,
+  health_check_interval_minutes, health_failure_threshold,
+  health_success_threshold
 )
 VALUES (
-  @name, pgp_sym_encrypt(@connection_string, @encryption_key), @pg_version
+  @name, pgp_sym_encrypt(@connection_string, @encryption_key), @pg_version,
+  COALESCE(sqlc.narg('workspace_id')::UUID, pbw_default_workspace_id()),
+  COALESCE(sqlc.narg('health_check_interval_minutes')::INTEGER, 10),
+  COALESCE(sqlc.narg('health_failure_threshold')::INTEGER, 1),
+  COALESCE(sqlc.narg('health_success_threshold')::INTEGER, 1)
 )
 RETURNING *;
@@ -0,0 +1,26 @@
+package databases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ensureNotProvisioned returns an error when the database is provisioned from the
+// configuration file, because those can only be changed by editing the file.
+func (s *Service) ensureNotProvisioned(ctx context.Context, id uuid.UUID) error {
+	database, err := s.GetDatabase(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if database.IsProvisioned {
+		return fmt.Errorf(
+			"database %q is provisioned from the configuration file and is read-only",
+			database.Name,
+		)
+	}
+
+	return nil
+}
@@ -4,12 +4,28 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 )
 
 func (s *Service) GetAllDatabases(
 	ctx context.Context,
 ) ([]dbgen.DatabasesServiceGetAllDatabasesRow, error) {
-	return s.dbgen.DatabasesServiceGetAllDatabases(
-		ctx, s.env.PBW_ENCRYPTION_KEY,
+	databases, err := s.dbgen.DatabasesServiceGetAllDatabases(
+		ctx, dbgen.DatabasesServiceGetAllDatabasesParams{
+			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
+			WorkspaceID:   workspaces.FromContext(ctx),
+		},
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !users.Can(ctx, users.PermissionReadSecrets) {
+		for i := range databases {
+			databases[i].DecryptedConnectionString = ""
+		}
+	}
+
+	return databases, nil
 }
@@ -3,4 +3,9 @@ SELECT
   *,
   pgp_sym_decrypt(connection_string, @encryption_key) AS decrypted_connection_string
 FROM databases
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+)
 ORDER BY created_at DESC;
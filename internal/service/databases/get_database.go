@@ -4,17 +4,30 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/google/uuid"
 )
 
-// GetDatabase retrieves a database entry by ID.
+// GetDatabase retrieves a database entry by ID, the connection string is
+// left empty when the user can't read secrets.
 func (s *Service) GetDatabase(
 	ctx context.Context, id uuid.UUID,
 ) (dbgen.DatabasesServiceGetDatabaseRow, error) {
-	return s.dbgen.DatabasesServiceGetDatabase(
+	database, err := s.dbgen.DatabasesServiceGetDatabase(
 		ctx, dbgen.DatabasesServiceGetDatabaseParams{
 			ID:            id,
 			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
+			WorkspaceID:   workspaces.FromContext(ctx),
 		},
 	)
+	if err != nil {
+		return database, err
+	}
+
+	if !users.Can(ctx, users.PermissionReadSecrets) {
+		database.DecryptedConnectionString = ""
+	}
+
+	return database, nil
 }
@@ -4,10 +4,13 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 )
 
 func (s *Service) GetDatabasesQty(
 	ctx context.Context,
 ) (dbgen.DatabasesServiceGetDatabasesQtyRow, error) {
-	return s.dbgen.DatabasesServiceGetDatabasesQty(ctx)
+	return s.dbgen.DatabasesServiceGetDatabasesQty(
+		ctx, workspaces.FromContext(ctx),
+	)
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
 )
 
@@ -18,7 +20,9 @@ func (s *Service) PaginateDatabases(
 	page := max(params.Page, 1)
 	limit := min(max(params.Limit, 1), 100)
 
-	count, err := s.dbgen.DatabasesServicePaginateDatabasesCount(ctx)
+	count, err := s.dbgen.DatabasesServicePaginateDatabasesCount(
+		ctx, workspaces.FromContext(ctx),
+	)
 	if err != nil {
 		return paginateutil.PaginateResponse{}, nil, err
 	}
@@ -35,11 +39,18 @@ func (s *Service) PaginateDatabases(
 			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
 			Limit:         int32(params.Limit),
 			Offset:        int32(offset),
+			WorkspaceID:   workspaces.FromContext(ctx),
 		},
 	)
 	if err != nil {
 		return paginateutil.PaginateResponse{}, nil, err
 	}
 
+	if !users.Can(ctx, users.PermissionReadSecrets) {
+		for i := range databases {
+			databases[i].DecryptedConnectionString = ""
+		}
+	}
+
 	return paginateResponse, databases, nil
 }
@@ -1,10 +1,20 @@
 -- name: DatabasesServicePaginateDatabasesCount :one
-SELECT COUNT(*) FROM databases;
+SELECT COUNT(*) FROM databases
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+);
 
 -- name: DatabasesServicePaginateDatabases :many
 SELECT
   *,
   pgp_sym_decrypt(connection_string, @encryption_key) AS decrypted_connection_string
 FROM databases
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+)
 ORDER BY created_at DESC
 LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -3,51 +3,81 @@ package databases
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/integration/secrets"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/health"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/google/uuid"
 )
 
 func (s *Service) TestDatabaseAndStoreResult(
 	ctx context.Context, databaseID uuid.UUID,
 ) error {
-	storeRes := func(ok bool, err error) error {
-		var errMsg string
-		if err != nil {
-			errMsg = err.Error()
-		}
-
-		secondErr := s.dbgen.DatabasesServiceSetTestData(
-			ctx, dbgen.DatabasesServiceSetTestDataParams{
-				DatabaseID: databaseID,
-				TestOk:     sql.NullBool{Valid: true, Bool: ok},
-				TestError:  sql.NullString{Valid: true, String: errMsg},
-			},
-		)
-		if secondErr != nil {
-			return fmt.Errorf("error storing test result: %w: %w", secondErr, err)
-		}
+	if err := users.Authorize(ctx, users.PermissionRun); err != nil {
 		return err
 	}
 
 	db, err := s.GetDatabase(ctx, databaseID)
 	if err != nil {
-		return storeRes(false, fmt.Errorf("error getting database: %w", err))
+		return fmt.Errorf("error getting database: %w", err)
 	}
 
-	err = s.TestDatabase(ctx, db.PgVersion, db.DecryptedConnectionString)
-	if err != nil && db.TestOk.Valid && db.TestOk.Bool {
-		s.webhooksService.RunDatabaseUnhealthy(db.ID)
+	start := time.Now()
+	testErr := s.TestDatabase(ctx, db.PgVersion, db.DecryptedConnectionString)
+	latency := time.Since(start)
+
+	var errMsg string
+	if testErr != nil {
+		errMsg = testErr.Error()
 	}
+
+	state, changed := health.Next(
+		health.State{
+			Ok:                   db.TestOk,
+			ConsecutiveFailures:  db.HealthConsecutiveFailures,
+			ConsecutiveSuccesses: db.HealthConsecutiveSuccesses,
+		},
+		testErr == nil,
+		health.Thresholds{
+			Failures:  db.HealthFailureThreshold,
+			Successes: db.HealthSuccessThreshold,
+		},
+	)
+
+	err = s.dbgen.DatabasesServiceSetTestData(
+		ctx, dbgen.DatabasesServiceSetTestDataParams{
+			DatabaseID:                 databaseID,
+			TestOk:                     state.Ok,
+			TestError:                  sql.NullString{Valid: true, String: errMsg},
+			HealthConsecutiveFailures:  state.ConsecutiveFailures,
+			HealthConsecutiveSuccesses: state.ConsecutiveSuccesses,
+		},
+	)
 	if err != nil {
-		return storeRes(false, err)
+		return errors.Join(fmt.Errorf("error storing test result: %w", err), testErr)
 	}
 
-	if db.TestOk.Valid && !db.TestOk.Bool {
+	health.Record(ctx, s.dbgen, health.Check{
+		DatabaseID: uuid.NullUUID{UUID: databaseID, Valid: true},
+		Ok:         testErr == nil,
+		Error:      errMsg,
+		Latency:    latency,
+		Changed:    changed,
+	})
+
+	// The webhooks run after the result is stored so their event includes it.
+	if changed && !state.Ok.Bool {
+		s.webhooksService.RunDatabaseUnhealthy(db.ID)
+	}
+	if changed && state.Ok.Bool {
 		s.webhooksService.RunDatabaseHealthy(db.ID)
 	}
-	return storeRes(true, nil)
+	return testErr
 }
 
 func (s *Service) TestDatabase(
@@ -58,8 +88,20 @@ func (s *Service) TestDatabase(
 		return fmt.Errorf("error parsing PostgreSQL version: %w", err)
 	}
 
-	err = s.ints.PGClient.Test(pgVersion, connString)
+	reference := connString
+	connString, err = s.ints.SecretsClient.Resolve(ctx, connString)
 	if err != nil {
+		return err
+	}
+
+	err = s.ints.PGClient.Test(pgVersion, connString)
+	if err != nil && secrets.IsReference(reference) {
+		logger.Error("error testing database", logger.KV{
+			"reference": reference,
+			"error":     err.Error(),
+		})
+	}
+	if err := secrets.HideOutput(reference, err); err != nil {
 		return fmt.Errorf("error testing database: %w", err)
 	}
 
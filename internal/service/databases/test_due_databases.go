@@ -0,0 +1,46 @@
+package databases
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"golang.org/x/sync/errgroup"
+)
+
+// TestDueDatabases tests the databases whose health check interval has
+// passed since their last test, it runs every minute.
+func (s *Service) TestDueDatabases() {
+	ctx := users.WithSystem(context.Background())
+
+	databaseIDs, err := s.dbgen.DatabasesServiceGetDatabasesToTest(ctx)
+	if err != nil {
+		logger.Error(
+			"error getting the databases to test them", logger.KV{"error": err},
+		)
+		return
+	}
+	if len(databaseIDs) == 0 {
+		return
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(5)
+
+	for _, databaseID := range databaseIDs {
+		databaseID := databaseID
+		eg.Go(func() error {
+			err := s.TestDatabaseAndStoreResult(ctx, databaseID)
+			if err != nil {
+				logger.Error(
+					"error testing database",
+					logger.KV{"database_id": databaseID, "error": err},
+				)
+			}
+			return nil
+		})
+	}
+
+	_ = eg.Wait()
+	logger.Info("due databases tested", logger.KV{"tested": len(databaseIDs)})
+}
@@ -4,12 +4,22 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 )
 
 // UpdateDatabase updates an existing database entry.
 func (s *Service) UpdateDatabase(
 	ctx context.Context, params dbgen.DatabasesServiceUpdateDatabaseParams,
 ) (dbgen.Database, error) {
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return dbgen.Database{}, err
+	}
+
+	if err := s.ensureNotProvisioned(ctx, params.ID); err != nil {
+		return dbgen.Database{}, err
+	}
+
 	err := s.TestDatabase(
 		ctx, params.PgVersion.String, params.ConnectionString.String,
 	)
@@ -17,8 +27,19 @@ func (s *Service) UpdateDatabase(
 		return dbgen.Database{}, err
 	}
 
+	before := s.auditSnapshot(ctx, params.ID)
 	params.EncryptionKey = s.env.PBW_ENCRYPTION_KEY
 	db, err := s.dbgen.DatabasesServiceUpdateDatabase(ctx, params)
+	if err == nil {
+		audit.Record(ctx, s.dbgen, audit.Entry{
+			Action:     audit.ActionUpdate,
+			EntityType: audit.EntityDatabase,
+			EntityID:   db.ID,
+			EntityName: db.Name,
+			Before:     before,
+			After:      s.auditSnapshot(ctx, db.ID),
+		})
+	}
 
 	_ = s.TestDatabaseAndStoreResult(ctx, db.ID)
 
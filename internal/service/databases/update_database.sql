@@ -3,6 +3,15 @@ UPDATE databases
 SET
   name = COALESCE(sqlc.narg('name'), name),
   pg_version = COALESCE(sqlc.narg('pg_version'), pg_version),
+  health_check_interval_minutes = COALESCE(
+    sqlc.narg('health_check_interval_minutes'), health_check_interval_minutes
+  ),
+  health_failure_threshold = COALESCE(
+    sqlc.narg('health_failure_threshold'), health_failure_threshold
+  ),
+  health_success_threshold = COALESCE(
+    sqlc.narg('health_success_threshold'), health_success_threshold
+  ),
   connection_string = CASE
     WHEN sqlc.narg('connection_string')::TEXT IS NOT NULL
     THEN pgp_sym_encrypt(
@@ -0,0 +1,25 @@
+package destinations
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+// auditSnapshot returns the destination with its decrypted keys, to record
+// its changes in the audit log, or nil if it can't be read.
+func (s *Service) auditSnapshot(
+	ctx context.Context, id uuid.UUID,
+) *dbgen.DestinationsServiceGetDestinationRow {
+	destination, err := s.dbgen.DestinationsServiceGetDestination(
+		ctx, dbgen.DestinationsServiceGetDestinationParams{
+			ID:            id,
+			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
+		},
+	)
+	if err != nil {
+		return nil
+	}
+	return &destination
+}
@@ -4,13 +4,20 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 )
 
 func (s *Service) CreateDestination(
 	ctx context.Context, params dbgen.DestinationsServiceCreateDestinationParams,
 ) (dbgen.Destination, error) {
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return dbgen.Destination{}, err
+	}
+
 	err := s.TestDestination(
-		params.AccessKey, params.SecretKey, params.Region, params.Endpoint,
+		ctx, params.AccessKey, params.SecretKey, params.Region, params.Endpoint,
 		params.BucketName, params.ForcePathStyle, params.SignatureVersion,
 	)
 	if err != nil {
@@ -18,7 +25,19 @@ func (s *Service) CreateDestination(
 	}
 
 	params.EncryptionKey = s.env.PBW_ENCRYPTION_KEY
+	if !params.WorkspaceID.Valid {
+		params.WorkspaceID = workspaces.FromContext(ctx)
+	}
 	dest, err := s.dbgen.DestinationsServiceCreateDestination(ctx, params)
+	if err == nil {
+		audit.Record(ctx, s.dbgen, audit.Entry{
+			Action:     audit.ActionCreate,
+			EntityType: audit.EntityDestination,
+			EntityID:   dest.ID,
+			EntityName: dest.Name,
+			After:      s.auditSnapshot(ctx, dest.ID),
+		})
+	}
 
 	_ = s.TestDestinationAndStoreResult(ctx, dest.ID)
 
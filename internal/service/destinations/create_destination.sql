@@ -1,11 +1,16 @@
 -- name: DestinationsServiceCreateDestination :one
 INSERT INTO destinations (
   name, bucket_name, region, endpoint, force_path_style, signature_version,
-  access_key, secret_key
+  access_key, secret_key, workspace_id, health_check_interval_minutes,
+  health_failure_threshold, health_success_threshold
 )
 VALUES (
   @name, @bucket_name, @region, @endpoint, @force_path_style, @signature_version,
   pgp_sym_encrypt(@access_key, @encryption_key),
-  pgp_sym_encrypt(@secret_key, @encryption_key)
+  pgp_sym_encrypt(@secret_key, @encryption_key),
+  COALESCE(sqlc.narg('workspace_id')::UUID, pbw_default_workspace_id()),
+  COALESCE(sqlc.narg('health_check_interval_minutes')::INTEGER, 10),
+  COALESCE(sqlc.narg('health_failure_threshold')::INTEGER, 1),
+  COALESCE(sqlc.narg('health_success_threshold')::INTEGER, 1)
 )
 RETURNING *;
@@ -3,11 +3,36 @@ package destinations
 import (
 	"context"
 
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/google/uuid"
 )
 
 func (s *Service) DeleteDestination(
 	ctx context.Context, id uuid.UUID,
 ) error {
-	return s.dbgen.DestinationsServiceDeleteDestination(ctx, id)
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return err
+	}
+
+	if err := s.ensureNotProvisioned(ctx, id); err != nil {
+		return err
+	}
+
+	before := s.auditSnapshot(ctx, id)
+	if err := s.dbgen.DestinationsServiceDeleteDestination(ctx, id); err != nil {
+		return err
+	}
+
+	entry := audit.Entry{
+		Action:     audit.ActionDelete,
+		EntityType: audit.EntityDestination,
+		EntityID:   id,
+		Before:     before,
+	}
+	if before != nil {
+		entry.EntityName = before.Name
+	}
+	audit.Record(ctx, s.dbgen, entry)
+	return nil
 }
@@ -0,0 +1,26 @@
+package destinations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ensureNotProvisioned returns an error when the destination is provisioned from the
+// configuration file, because those can only be changed by editing the file.
+func (s *Service) ensureNotProvisioned(ctx context.Context, id uuid.UUID) error {
+	destination, err := s.GetDestination(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if destination.IsProvisioned {
+		return fmt.Errorf(
+			"destination %q is provisioned from the configuration file and is read-only",
+			destination.Name,
+		)
+	}
+
+	return nil
+}
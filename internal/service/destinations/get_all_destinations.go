@@ -4,12 +4,29 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 )
 
 func (s *Service) GetAllDestinations(
 	ctx context.Context,
 ) ([]dbgen.DestinationsServiceGetAllDestinationsRow, error) {
-	return s.dbgen.DestinationsServiceGetAllDestinations(
-		ctx, s.env.PBW_ENCRYPTION_KEY,
+	destinations, err := s.dbgen.DestinationsServiceGetAllDestinations(
+		ctx, dbgen.DestinationsServiceGetAllDestinationsParams{
+			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
+			WorkspaceID:   workspaces.FromContext(ctx),
+		},
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !users.Can(ctx, users.PermissionReadSecrets) {
+		for i := range destinations {
+			destinations[i].DecryptedAccessKey = ""
+			destinations[i].DecryptedSecretKey = ""
+		}
+	}
+
+	return destinations, nil
 }
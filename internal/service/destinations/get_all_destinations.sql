@@ -4,4 +4,9 @@ SELECT
   pgp_sym_decrypt(access_key, @encryption_key) AS decrypted_access_key,
   pgp_sym_decrypt(secret_key, @encryption_key) AS decrypted_secret_key
 FROM destinations
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+)
 ORDER BY created_at DESC;
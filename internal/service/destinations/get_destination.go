@@ -4,16 +4,29 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/google/uuid"
 )
 
 func (s *Service) GetDestination(
 	ctx context.Context, id uuid.UUID,
 ) (dbgen.DestinationsServiceGetDestinationRow, error) {
-	return s.dbgen.DestinationsServiceGetDestination(
+	destination, err := s.dbgen.DestinationsServiceGetDestination(
 		ctx, dbgen.DestinationsServiceGetDestinationParams{
 			ID:            id,
 			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
+			WorkspaceID:   workspaces.FromContext(ctx),
 		},
 	)
+	if err != nil {
+		return destination, err
+	}
+
+	if !users.Can(ctx, users.PermissionReadSecrets) {
+		destination.DecryptedAccessKey = ""
+		destination.DecryptedSecretKey = ""
+	}
+
+	return destination, nil
 }
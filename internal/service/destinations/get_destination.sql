@@ -4,4 +4,9 @@ SELECT
   pgp_sym_decrypt(access_key, @encryption_key) AS decrypted_access_key,
   pgp_sym_decrypt(secret_key, @encryption_key) AS decrypted_secret_key
 FROM destinations
-WHERE id = @id;
+WHERE id = @id
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+);
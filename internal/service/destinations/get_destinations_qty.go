@@ -4,10 +4,13 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 )
 
 func (s *Service) GetDestinationsQty(
 	ctx context.Context,
 ) (dbgen.DestinationsServiceGetDestinationsQtyRow, error) {
-	return s.dbgen.DestinationsServiceGetDestinationsQty(ctx)
+	return s.dbgen.DestinationsServiceGetDestinationsQty(
+		ctx, workspaces.FromContext(ctx),
+	)
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
 )
 
@@ -18,7 +20,9 @@ func (s *Service) PaginateDestinations(
 	page := max(params.Page, 1)
 	limit := min(max(params.Limit, 1), 100)
 
-	count, err := s.dbgen.DestinationsServicePaginateDestinationsCount(ctx)
+	count, err := s.dbgen.DestinationsServicePaginateDestinationsCount(
+		ctx, workspaces.FromContext(ctx),
+	)
 	if err != nil {
 		return paginateutil.PaginateResponse{}, nil, err
 	}
@@ -35,11 +39,19 @@ func (s *Service) PaginateDestinations(
 			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
 			Limit:         int32(params.Limit),
 			Offset:        int32(offset),
+			WorkspaceID:   workspaces.FromContext(ctx),
 		},
 	)
 	if err != nil {
 		return paginateutil.PaginateResponse{}, nil, err
 	}
 
+	if !users.Can(ctx, users.PermissionReadSecrets) {
+		for i := range destinations {
+			destinations[i].DecryptedAccessKey = ""
+			destinations[i].DecryptedSecretKey = ""
+		}
+	}
+
 	return paginateResponse, destinations, nil
 }
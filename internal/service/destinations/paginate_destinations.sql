@@ -1,5 +1,10 @@
 -- name: DestinationsServicePaginateDestinationsCount :one
-SELECT COUNT(*) FROM destinations;
+SELECT COUNT(*) FROM destinations
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+);
 
 -- name: DestinationsServicePaginateDestinations :many
 SELECT
@@ -7,5 +12,10 @@ SELECT
   pgp_sym_decrypt(access_key, @encryption_key) AS decrypted_access_key,
   pgp_sym_decrypt(secret_key, @encryption_key) AS decrypted_secret_key
 FROM destinations
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+)
 ORDER BY created_at DESC
 LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -3,63 +3,97 @@ package destinations
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/health"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/google/uuid"
 )
 
 func (s *Service) TestDestinationAndStoreResult(
 	ctx context.Context, destinationID uuid.UUID,
 ) error {
-	storeRes := func(ok bool, err error) error {
-		var errMsg string
-		if err != nil {
-			errMsg = err.Error()
-		}
-
-		secondErr := s.dbgen.DestinationsServiceSetTestData(
-			ctx, dbgen.DestinationsServiceSetTestDataParams{
-				DestinationID: destinationID,
-				TestOk:        sql.NullBool{Valid: true, Bool: ok},
-				TestError:     sql.NullString{Valid: true, String: errMsg},
-			},
-		)
-		if secondErr != nil {
-			return fmt.Errorf("error storing test result: %w: %w", secondErr, err)
-		}
+	if err := users.Authorize(ctx, users.PermissionRun); err != nil {
 		return err
 	}
 
 	dest, err := s.GetDestination(ctx, destinationID)
 	if err != nil {
-		return storeRes(false, fmt.Errorf("error getting destination: %w", err))
+		return fmt.Errorf("error getting destination: %w", err)
 	}
 
-	err = s.TestDestination(
-		dest.DecryptedAccessKey, dest.DecryptedSecretKey, dest.Region,
+	start := time.Now()
+	testErr := s.TestDestination(
+		ctx, dest.DecryptedAccessKey, dest.DecryptedSecretKey, dest.Region,
 		dest.Endpoint, dest.BucketName, dest.ForcePathStyle,
 		dest.SignatureVersion,
 	)
-	if err != nil && dest.TestOk.Valid && dest.TestOk.Bool {
-		s.webhooksService.RunDestinationUnhealthy(dest.ID)
+	latency := time.Since(start)
+
+	var errMsg string
+	if testErr != nil {
+		errMsg = testErr.Error()
 	}
+
+	state, changed := health.Next(
+		health.State{
+			Ok:                   dest.TestOk,
+			ConsecutiveFailures:  dest.HealthConsecutiveFailures,
+			ConsecutiveSuccesses: dest.HealthConsecutiveSuccesses,
+		},
+		testErr == nil,
+		health.Thresholds{
+			Failures:  dest.HealthFailureThreshold,
+			Successes: dest.HealthSuccessThreshold,
+		},
+	)
+
+	err = s.dbgen.DestinationsServiceSetTestData(
+		ctx, dbgen.DestinationsServiceSetTestDataParams{
+			DestinationID:              destinationID,
+			TestOk:                     state.Ok,
+			TestError:                  sql.NullString{Valid: true, String: errMsg},
+			HealthConsecutiveFailures:  state.ConsecutiveFailures,
+			HealthConsecutiveSuccesses: state.ConsecutiveSuccesses,
+		},
+	)
 	if err != nil {
-		return storeRes(false, err)
+		return errors.Join(fmt.Errorf("error storing test result: %w", err), testErr)
 	}
 
-	if dest.TestOk.Valid && !dest.TestOk.Bool {
+	health.Record(ctx, s.dbgen, health.Check{
+		DestinationID: uuid.NullUUID{UUID: destinationID, Valid: true},
+		Ok:            testErr == nil,
+		Error:         errMsg,
+		Latency:       latency,
+		Changed:       changed,
+	})
+
+	// The webhooks run after the result is stored so their event includes it.
+	if changed && !state.Ok.Bool {
+		s.webhooksService.RunDestinationUnhealthy(dest.ID)
+	}
+	if changed && state.Ok.Bool {
 		s.webhooksService.RunDestinationHealthy(dest.ID)
 	}
-	return storeRes(true, nil)
+	return testErr
 }
 
 func (s *Service) TestDestination(
+	ctx context.Context,
 	accessKey, secretKey, region, endpoint, bucketName string,
 	forcePathStyle bool,
 	signatureVersion string,
 ) error {
-	err := s.ints.StorageClient.S3Test(
+	err := s.ints.SecretsClient.ResolveAll(ctx, &accessKey, &secretKey)
+	if err != nil {
+		return err
+	}
+
+	err = s.ints.StorageClient.S3Test(
 		accessKey, secretKey, region, endpoint, bucketName,
 		forcePathStyle, signatureVersion,
 	)
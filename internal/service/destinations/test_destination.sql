@@ -2,5 +2,7 @@
 UPDATE destinations
 SET test_ok = @test_ok,
     test_error = @test_error,
+    health_consecutive_failures = @health_consecutive_failures,
+    health_consecutive_successes = @health_consecutive_successes,
     last_test_at = NOW()
 WHERE id = @destination_id;
@@ -0,0 +1,48 @@
+package destinations
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"golang.org/x/sync/errgroup"
+)
+
+// TestDueDestinations tests the destinations whose health check interval
+// has passed since their last test, it runs every minute.
+func (s *Service) TestDueDestinations() {
+	ctx := users.WithSystem(context.Background())
+
+	destinationIDs, err := s.dbgen.DestinationsServiceGetDestinationsToTest(ctx)
+	if err != nil {
+		logger.Error(
+			"error getting the destinations to test them", logger.KV{"error": err},
+		)
+		return
+	}
+	if len(destinationIDs) == 0 {
+		return
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(5)
+
+	for _, destinationID := range destinationIDs {
+		destinationID := destinationID
+		eg.Go(func() error {
+			err := s.TestDestinationAndStoreResult(ctx, destinationID)
+			if err != nil {
+				logger.Error(
+					"error testing destination",
+					logger.KV{"destination_id": destinationID, "error": err},
+				)
+			}
+			return nil
+		})
+	}
+
+	_ = eg.Wait()
+	logger.Info(
+		"due destinations tested", logger.KV{"tested": len(destinationIDs)},
+	)
+}
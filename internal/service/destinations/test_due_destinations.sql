@@ -0,0 +1,9 @@
+-- name: DestinationsServiceGetDestinationsToTest :many
+-- The times are truncated to the minute so the checks that take a few
+-- seconds don't push the next one to the following run of the schedule.
+SELECT id
+FROM destinations
+WHERE last_test_at IS NULL
+OR date_trunc('minute', last_test_at) <= date_trunc('minute', NOW())
+  - make_interval(mins => health_check_interval_minutes)
+ORDER BY last_test_at NULLS FIRST;
@@ -4,13 +4,23 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 )
 
 func (s *Service) UpdateDestination(
 	ctx context.Context, params dbgen.DestinationsServiceUpdateDestinationParams,
 ) (dbgen.Destination, error) {
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return dbgen.Destination{}, err
+	}
+
+	if err := s.ensureNotProvisioned(ctx, params.ID); err != nil {
+		return dbgen.Destination{}, err
+	}
+
 	err := s.TestDestination(
-		params.AccessKey.String, params.SecretKey.String, params.Region.String,
+		ctx, params.AccessKey.String, params.SecretKey.String, params.Region.String,
 		params.Endpoint.String, params.BucketName.String,
 		params.ForcePathStyle.Bool, params.SignatureVersion.String,
 	)
@@ -18,8 +28,19 @@ func (s *Service) UpdateDestination(
 		return dbgen.Destination{}, err
 	}
 
+	before := s.auditSnapshot(ctx, params.ID)
 	params.EncryptionKey = s.env.PBW_ENCRYPTION_KEY
 	dest, err := s.dbgen.DestinationsServiceUpdateDestination(ctx, params)
+	if err == nil {
+		audit.Record(ctx, s.dbgen, audit.Entry{
+			Action:     audit.ActionUpdate,
+			EntityType: audit.EntityDestination,
+			EntityID:   dest.ID,
+			EntityName: dest.Name,
+			Before:     before,
+			After:      s.auditSnapshot(ctx, dest.ID),
+		})
+	}
 
 	_ = s.TestDestinationAndStoreResult(ctx, dest.ID)
 
@@ -7,6 +7,15 @@ SET
   endpoint = COALESCE(sqlc.narg('endpoint'), endpoint),
   force_path_style = COALESCE(sqlc.narg('force_path_style'), force_path_style),
   signature_version = COALESCE(sqlc.narg('signature_version'), signature_version),
+  health_check_interval_minutes = COALESCE(
+    sqlc.narg('health_check_interval_minutes'), health_check_interval_minutes
+  ),
+  health_failure_threshold = COALESCE(
+    sqlc.narg('health_failure_threshold'), health_failure_threshold
+  ),
+  health_success_threshold = COALESCE(
+    sqlc.narg('health_success_threshold'), health_success_threshold
+  ),
   access_key = CASE
     WHEN sqlc.narg('access_key')::TEXT IS NOT NULL
     THEN pgp_sym_encrypt(sqlc.narg('access_key')::TEXT, sqlc.arg('encryption_key')::TEXT)
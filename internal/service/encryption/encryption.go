@@ -0,0 +1,22 @@
+package encryption
+
+import (
+	"database/sql"
+
+	"github.com/eduardolat/pgbackweb/internal/config"
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+)
+
+type Service struct {
+	env   config.Env
+	db    *sql.DB
+	dbgen *dbgen.Queries
+}
+
+func New(env config.Env, db *sql.DB, dbgen *dbgen.Queries) *Service {
+	return &Service{
+		env:   env,
+		db:    db,
+		dbgen: dbgen,
+	}
+}
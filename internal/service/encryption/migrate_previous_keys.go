@@ -0,0 +1,36 @@
+package encryption
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/logger"
+)
+
+// MigratePreviousKeys re-encrypts with PBW_ENCRYPTION_KEY the secrets that
+// are still encrypted with any of PBW_PREVIOUS_ENCRYPTION_KEYS. It does
+// nothing when there are no previous keys, and it is a no-op once every
+// secret uses the current key, so the previous keys can be kept during a
+// transition window.
+func (s *Service) MigratePreviousKeys(ctx context.Context) (Result, error) {
+	if len(s.env.PBW_PREVIOUS_ENCRYPTION_KEYS) == 0 {
+		return Result{}, nil
+	}
+
+	keys := decryptionKeys(append(
+		[]string{s.env.PBW_ENCRYPTION_KEY}, s.env.PBW_PREVIOUS_ENCRYPTION_KEYS...,
+	)...)
+
+	result, err := s.reencrypt(ctx, keys, s.env.PBW_ENCRYPTION_KEY, false)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if result.Total() > 0 {
+		logger.Info("secrets migrated from previous encryption keys", logger.KV{
+			"databases":    result.Databases,
+			"destinations": result.Destinations,
+			"sessions":     result.Sessions,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,141 @@
+package encryption
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+)
+
+// Result is the number of rows whose secrets were re-encrypted.
+type Result struct {
+	Databases             int64 `json:"databases"`
+	Destinations          int64 `json:"destinations"`
+	Sessions              int64 `json:"sessions"`
+	TOTPSecrets           int64 `json:"totp_secrets"`
+	WebhookSigningSecrets int64 `json:"webhook_signing_secrets"`
+	SMTPPasswords         int64 `json:"smtp_passwords"`
+}
+
+// Total returns the number of re-encrypted rows.
+func (r Result) Total() int64 {
+	return r.Databases + r.Destinations + r.Sessions + r.TOTPSecrets +
+		r.WebhookSigningSecrets + r.SMTPPasswords
+}
+
+// String returns the result in a human readable form.
+func (r Result) String() string {
+	return fmt.Sprintf(
+		"re-encrypted %d databases, %d destinations, %d sessions, %d TOTP "+
+			"secrets, %d webhook signing secrets and %d SMTP passwords",
+		r.Databases, r.Destinations, r.Sessions, r.TOTPSecrets,
+		r.WebhookSigningSecrets, r.SMTPPasswords,
+	)
+}
+
+// reencrypt re-encrypts with newKey every secret that is not encrypted with
+// it yet, decrypting it with the first of decryptionKeys that works. It runs
+// in a single transaction and verifies that every secret decrypts to the
+// same value with newKey before committing, when dryRun is true the
+// transaction is always rolled back.
+func (s *Service) reencrypt(
+	ctx context.Context, decryptionKeys []string, newKey string, dryRun bool,
+) (Result, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Result{}, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	q := s.dbgen.WithTx(tx)
+
+	before, err := q.EncryptionServiceGetChecksum(ctx, decryptionKeys)
+	if err != nil {
+		return Result{}, fmt.Errorf(
+			"error decrypting the secrets, check the encryption keys: %w", err,
+		)
+	}
+
+	var result Result
+	params := dbgen.EncryptionServiceReencryptDatabasesParams{
+		DecryptionKeys: decryptionKeys,
+		NewKey:         newKey,
+	}
+
+	result.Databases, err = q.EncryptionServiceReencryptDatabases(ctx, params)
+	if err != nil {
+		return Result{}, fmt.Errorf("error re-encrypting databases: %w", err)
+	}
+
+	result.Destinations, err = q.EncryptionServiceReencryptDestinations(
+		ctx, dbgen.EncryptionServiceReencryptDestinationsParams(params),
+	)
+	if err != nil {
+		return Result{}, fmt.Errorf("error re-encrypting destinations: %w", err)
+	}
+
+	result.Sessions, err = q.EncryptionServiceReencryptSessions(
+		ctx, dbgen.EncryptionServiceReencryptSessionsParams(params),
+	)
+	if err != nil {
+		return Result{}, fmt.Errorf("error re-encrypting sessions: %w", err)
+	}
+
+	result.TOTPSecrets, err = q.EncryptionServiceReencryptTOTPSecrets(
+		ctx, dbgen.EncryptionServiceReencryptTOTPSecretsParams(params),
+	)
+	if err != nil {
+		return Result{}, fmt.Errorf("error re-encrypting TOTP secrets: %w", err)
+	}
+
+	result.WebhookSigningSecrets, err = q.EncryptionServiceReencryptWebhookSigningSecrets(
+		ctx, dbgen.EncryptionServiceReencryptWebhookSigningSecretsParams(params),
+	)
+	if err != nil {
+		return Result{}, fmt.Errorf(
+			"error re-encrypting webhook signing secrets: %w", err,
+		)
+	}
+
+	result.SMTPPasswords, err = q.EncryptionServiceReencryptSMTPPassword(
+		ctx, dbgen.EncryptionServiceReencryptSMTPPasswordParams(params),
+	)
+	if err != nil {
+		return Result{}, fmt.Errorf("error re-encrypting SMTP password: %w", err)
+	}
+
+	after, err := q.EncryptionServiceGetChecksum(ctx, []string{newKey})
+	if err != nil {
+		return Result{}, fmt.Errorf(
+			"error verifying the secrets with the new key: %w", err,
+		)
+	}
+	if before != after {
+		return Result{}, fmt.Errorf(
+			"the re-encrypted secrets don't match the original ones",
+		)
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Result{}, fmt.Errorf("error committing transaction: %w", err)
+	}
+	return result, nil
+}
+
+// decryptionKeys returns the keys to try, in order and without duplicates
+// or empty keys.
+func decryptionKeys(keys ...string) []string {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, key := range keys {
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, key)
+	}
+	return unique
+}
@@ -0,0 +1,97 @@
+-- name: EncryptionServiceGetChecksum :one
+SELECT md5(concat_ws('|',
+  (
+    SELECT string_agg(
+      id::TEXT || '=' || pbw_decrypt_any(connection_string, @keys::TEXT[]),
+      ',' ORDER BY id
+    )
+    FROM databases
+  ),
+  (
+    SELECT string_agg(
+      id::TEXT || '=' || pbw_decrypt_any(access_key, @keys::TEXT[]) ||
+      ':' || pbw_decrypt_any(secret_key, @keys::TEXT[]),
+      ',' ORDER BY id
+    )
+    FROM destinations
+  ),
+  (
+    SELECT string_agg(
+      id::TEXT || '=' || pbw_decrypt_any(token, @keys::TEXT[]),
+      ',' ORDER BY id
+    )
+    FROM sessions
+  ),
+  (
+    SELECT string_agg(
+      id::TEXT || '=' || pbw_decrypt_any(totp_secret, @keys::TEXT[]),
+      ',' ORDER BY id
+    )
+    FROM users
+    WHERE totp_secret IS NOT NULL
+  ),
+  (
+    SELECT string_agg(
+      id::TEXT || '=' || pbw_decrypt_any(signing_secret, @keys::TEXT[]),
+      ',' ORDER BY id
+    )
+    FROM webhooks
+    WHERE signing_secret IS NOT NULL
+  ),
+  (
+    SELECT pbw_decrypt_any(smtp_password, @keys::TEXT[])
+    FROM instance_settings
+    WHERE smtp_password IS NOT NULL
+  )
+))::TEXT;
+
+-- name: EncryptionServiceReencryptDatabases :execrows
+UPDATE databases
+SET connection_string = pgp_sym_encrypt(
+  pbw_decrypt_any(connection_string, @decryption_keys::TEXT[]),
+  @new_key::TEXT
+)
+WHERE pbw_try_decrypt(connection_string, @new_key::TEXT) IS NULL;
+
+-- name: EncryptionServiceReencryptDestinations :execrows
+UPDATE destinations
+SET
+  access_key = pgp_sym_encrypt(
+    pbw_decrypt_any(access_key, @decryption_keys::TEXT[]), @new_key::TEXT
+  ),
+  secret_key = pgp_sym_encrypt(
+    pbw_decrypt_any(secret_key, @decryption_keys::TEXT[]), @new_key::TEXT
+  )
+WHERE pbw_try_decrypt(access_key, @new_key::TEXT) IS NULL
+OR pbw_try_decrypt(secret_key, @new_key::TEXT) IS NULL;
+
+-- name: EncryptionServiceReencryptSessions :execrows
+UPDATE sessions
+SET token = pgp_sym_encrypt(
+  pbw_decrypt_any(token, @decryption_keys::TEXT[]), @new_key::TEXT
+)
+WHERE pbw_try_decrypt(token, @new_key::TEXT) IS NULL;
+
+-- name: EncryptionServiceReencryptTOTPSecrets :execrows
+UPDATE users
+SET totp_secret = pgp_sym_encrypt(
+  pbw_decrypt_any(totp_secret, @decryption_keys::TEXT[]), @new_key::TEXT
+)
+WHERE totp_secret IS NOT NULL
+AND pbw_try_decrypt(totp_secret, @new_key::TEXT) IS NULL;
+
+-- name: EncryptionServiceReencryptWebhookSigningSecrets :execrows
+UPDATE webhooks
+SET signing_secret = pgp_sym_encrypt(
+  pbw_decrypt_any(signing_secret, @decryption_keys::TEXT[]), @new_key::TEXT
+)
+WHERE signing_secret IS NOT NULL
+AND pbw_try_decrypt(signing_secret, @new_key::TEXT) IS NULL;
+
+-- name: EncryptionServiceReencryptSMTPPassword :execrows
+UPDATE instance_settings
+SET smtp_password = pgp_sym_encrypt(
+  pbw_decrypt_any(smtp_password, @decryption_keys::TEXT[]), @new_key::TEXT
+)
+WHERE smtp_password IS NOT NULL
+AND pbw_try_decrypt(smtp_password, @new_key::TEXT) IS NULL;
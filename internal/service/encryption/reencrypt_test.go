@@ -0,0 +1,56 @@
+package encryption
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecryptionKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+		want []string
+	}{
+		{
+			name: "keeps the order",
+			keys: []string{"new", "current", "old"},
+			want: []string{"new", "current", "old"},
+		},
+		{
+			name: "removes duplicates",
+			keys: []string{"new", "current", "new", "current"},
+			want: []string{"new", "current"},
+		},
+		{
+			name: "removes empty keys",
+			keys: []string{"", "current", ""},
+			want: []string{"current"},
+		},
+		{
+			name: "no keys",
+			keys: nil,
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, decryptionKeys(tt.keys...))
+		})
+	}
+}
+
+func TestResult(t *testing.T) {
+	result := Result{
+		Databases: 2, Destinations: 1, Sessions: 5, TOTPSecrets: 3,
+		WebhookSigningSecrets: 4, SMTPPasswords: 1,
+	}
+	assert.Equal(t, int64(16), result.Total())
+	assert.Equal(
+		t,
+		"re-encrypted 2 databases, 1 destinations, 5 sessions, 3 TOTP secrets, "+
+			"4 webhook signing secrets and 1 SMTP passwords",
+		result.String(),
+	)
+}
@@ -0,0 +1,50 @@
+package encryption
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+)
+
+// RotateKey re-encrypts every secret with newKey. The secrets are decrypted
+// with PBW_ENCRYPTION_KEY or any of PBW_PREVIOUS_ENCRYPTION_KEYS, so a
+// rotation that was interrupted can be run again. PBW_ENCRYPTION_KEY must be
+// changed to newKey right after, the server can't decrypt the secrets with
+// the old key anymore.
+func (s *Service) RotateKey(
+	ctx context.Context, newKey string, dryRun bool,
+) (Result, error) {
+	if len(newKey) < 8 {
+		return Result{}, fmt.Errorf("the new key must have at least 8 characters")
+	}
+	if newKey == s.env.PBW_ENCRYPTION_KEY {
+		return Result{}, fmt.Errorf("the new key is the same as the current one")
+	}
+
+	keys := decryptionKeys(append(
+		[]string{newKey, s.env.PBW_ENCRYPTION_KEY},
+		s.env.PBW_PREVIOUS_ENCRYPTION_KEYS...,
+	)...)
+
+	result, err := s.reencrypt(ctx, keys, newKey, dryRun)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if !dryRun {
+		logger.Info("encryption key rotated", logger.KV{
+			"databases":    result.Databases,
+			"destinations": result.Destinations,
+			"sessions":     result.Sessions,
+		})
+		audit.Record(ctx, s.dbgen, audit.Entry{
+			Action:     audit.ActionUpdate,
+			EntityType: audit.EntityInstance,
+			EntityName: "encryption key",
+			After:      struct{ ReencryptedRows int64 }{result.Total()},
+		})
+	}
+	return result, nil
+}
@@ -4,11 +4,17 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/google/uuid"
 )
 
 func (s *Service) GetExecution(
 	ctx context.Context, id uuid.UUID,
 ) (dbgen.ExecutionsServiceGetExecutionRow, error) {
-	return s.dbgen.ExecutionsServiceGetExecution(ctx, id)
+	return s.dbgen.ExecutionsServiceGetExecution(
+		ctx, dbgen.ExecutionsServiceGetExecutionParams{
+			ID:          id,
+			WorkspaceID: workspaces.FromContext(ctx),
+		},
+	)
 }
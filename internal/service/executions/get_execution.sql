@@ -6,4 +6,9 @@ SELECT
 FROM executions
 INNER JOIN backups ON backups.id = executions.backup_id
 INNER JOIN databases ON databases.id = backups.database_id
-WHERE executions.id = @id;
+WHERE executions.id = @id
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  backups.workspace_id = sqlc.narg('workspace_id')::UUID
+);
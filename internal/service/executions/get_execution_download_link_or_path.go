@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/eduardolat/pgbackweb/internal/util/strutil"
 	"github.com/google/uuid"
 )
@@ -23,6 +24,7 @@ func (s *Service) GetAllExecutionLinksOrPaths(
 		ctx, dbgen.ExecutionsServiceGetDownloadLinkOrPathDataParams{
 			ExecutionID:   executionID,
 			DecryptionKey: s.env.PBW_ENCRYPTION_KEY,
+			WorkspaceID:   workspaces.FromContext(ctx),
 		},
 	)
 	if err != nil {
@@ -43,6 +45,13 @@ func (s *Service) GetAllExecutionLinksOrPaths(
 		return true, fullPaths, nil
 	}
 
+	err = s.ints.SecretsClient.ResolveAll(
+		ctx, &data.DecryptedAccessKey, &data.DecryptedSecretKey,
+	)
+	if err != nil {
+		return false, nil, err
+	}
+
 	var links []string
 	for _, p := range paths {
 		link, err := s.ints.StorageClient.S3GetDownloadLink(
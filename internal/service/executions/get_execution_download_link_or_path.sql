@@ -23,4 +23,9 @@ SELECT
 FROM executions
 INNER JOIN backups ON backups.id = executions.backup_id
 LEFT JOIN destinations ON destinations.id = backups.destination_id
-WHERE executions.id = @execution_id;
+WHERE executions.id = @execution_id
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  backups.workspace_id = sqlc.narg('workspace_id')::UUID
+);
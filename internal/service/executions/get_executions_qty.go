@@ -2,12 +2,32 @@ package executions
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 )
 
 func (s *Service) GetExecutionsQty(
 	ctx context.Context,
 ) (dbgen.ExecutionsServiceGetExecutionsQtyRow, error) {
-	return s.dbgen.ExecutionsServiceGetExecutionsQty(ctx)
+	return s.dbgen.ExecutionsServiceGetExecutionsQty(
+		ctx, dbgen.ExecutionsServiceGetExecutionsQtyParams{
+			WorkspaceID: workspaces.FromContext(ctx),
+		},
+	)
+}
+
+// GetExecutionsQtySince counts the executions like GetExecutionsQty, but
+// only the ones started since the given time.
+func (s *Service) GetExecutionsQtySince(
+	ctx context.Context, since time.Time,
+) (dbgen.ExecutionsServiceGetExecutionsQtyRow, error) {
+	return s.dbgen.ExecutionsServiceGetExecutionsQty(
+		ctx, dbgen.ExecutionsServiceGetExecutionsQtyParams{
+			WorkspaceID: workspaces.FromContext(ctx),
+			Since:       sql.NullTime{Time: since, Valid: true},
+		},
+	)
 }
@@ -1,8 +1,19 @@
 -- name: ExecutionsServiceGetExecutionsQty :one
 SELECT 
   COUNT(*) AS all,
-  COALESCE(SUM(CASE WHEN status = 'running' THEN 1 ELSE 0 END), 0)::INTEGER AS running,
-  COALESCE(SUM(CASE WHEN status = 'success' THEN 1 ELSE 0 END), 0)::INTEGER AS success,
-  COALESCE(SUM(CASE WHEN status = 'failed' THEN 1 ELSE 0 END), 0)::INTEGER AS failed,
-  COALESCE(SUM(CASE WHEN status = 'deleted' THEN 1 ELSE 0 END), 0)::INTEGER AS deleted
-FROM executions;
+  COALESCE(SUM(CASE WHEN executions.status = 'running' THEN 1 ELSE 0 END), 0)::INTEGER AS running,
+  COALESCE(SUM(CASE WHEN executions.status = 'success' THEN 1 ELSE 0 END), 0)::INTEGER AS success,
+  COALESCE(SUM(CASE WHEN executions.status = 'failed' THEN 1 ELSE 0 END), 0)::INTEGER AS failed,
+  COALESCE(SUM(CASE WHEN executions.status = 'deleted' THEN 1 ELSE 0 END), 0)::INTEGER AS deleted
+FROM executions
+INNER JOIN backups ON backups.id = executions.backup_id
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  backups.workspace_id = sqlc.narg('workspace_id')::UUID
+)
+AND (
+  sqlc.narg('since')::TIMESTAMPTZ IS NULL
+  OR
+  executions.started_at >= sqlc.narg('since')::TIMESTAMPTZ
+);
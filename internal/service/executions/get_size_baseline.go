@@ -0,0 +1,51 @@
+package executions
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/util/numutil"
+	"github.com/google/uuid"
+)
+
+const (
+	// sizeBaselineWindow is the number of previous executions used to
+	// compute the rolling file size baseline of a backup.
+	sizeBaselineWindow = 10
+
+	// sizeBaselineMinSamples is the minimum number of previous executions
+	// required before a size deviation can be reported.
+	sizeBaselineMinSamples = 3
+)
+
+// checkSizeDeviation compares the file size of an execution against the
+// rolling average of the previous executions of the same backup.
+//
+// It returns the deviation percentage and whether it exceeds the given
+// threshold. A threshold of 0 disables the check.
+func (s *Service) checkSizeDeviation(
+	ctx context.Context, backupID uuid.UUID, executionID uuid.UUID,
+	threshold int16, fileSize int64,
+) (float64, bool, error) {
+	if threshold <= 0 {
+		return 0, false, nil
+	}
+
+	baseline, err := s.dbgen.ExecutionsServiceGetSizeBaseline(
+		ctx, dbgen.ExecutionsServiceGetSizeBaselineParams{
+			BackupID:    backupID,
+			ExecutionID: executionID,
+			WindowSize:  sizeBaselineWindow,
+		},
+	)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if baseline.Samples < sizeBaselineMinSamples {
+		return 0, false, nil
+	}
+
+	deviation := numutil.PercentDeviation(baseline.AverageSize, fileSize)
+	return deviation, deviation > float64(threshold), nil
+}
@@ -0,0 +1,15 @@
+-- name: ExecutionsServiceGetSizeBaseline :one
+SELECT
+  COUNT(*) AS samples,
+  COALESCE(AVG(recent.file_size), 0)::BIGINT AS average_size
+FROM (
+  SELECT executions.file_size
+  FROM executions
+  WHERE executions.backup_id = @backup_id
+  AND executions.id != @execution_id
+  AND executions.status IN ('success', 'deleted')
+  AND executions.is_suspicious = FALSE
+  AND executions.file_size IS NOT NULL
+  ORDER BY executions.started_at DESC
+  LIMIT sqlc.arg('window_size')::INTEGER
+) AS recent;
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
 	"github.com/google/uuid"
 )
@@ -27,6 +28,7 @@ func (s *Service) PaginateExecutions(
 			BackupID:      params.BackupFilter,
 			DatabaseID:    params.DatabaseFilter,
 			DestinationID: params.DestinationFilter,
+			WorkspaceID:   workspaces.FromContext(ctx),
 		},
 	)
 	if err != nil {
@@ -47,6 +49,7 @@ func (s *Service) PaginateExecutions(
 			DestinationID: params.DestinationFilter,
 			Limit:         int32(params.Limit),
 			Offset:        int32(offset),
+			WorkspaceID:   workspaces.FromContext(ctx),
 		},
 	)
 	if err != nil {
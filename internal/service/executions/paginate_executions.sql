@@ -21,6 +21,12 @@ AND
   sqlc.narg('destination_id')::UUID IS NULL
   OR
   destinations.id = sqlc.narg('destination_id')::UUID
+)
+AND
+(
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  backups.workspace_id = sqlc.narg('workspace_id')::UUID
 );
 
 -- name: ExecutionsServicePaginateExecutions :many
@@ -53,5 +59,11 @@ AND
   OR
   destinations.id = sqlc.narg('destination_id')::UUID
 )
+AND
+(
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  backups.workspace_id = sqlc.narg('workspace_id')::UUID
+)
 ORDER BY executions.started_at DESC
 LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -0,0 +1,71 @@
+package executions
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/google/uuid"
+)
+
+type sequenceCtxKey struct{}
+
+// withSequence returns a copy of ctx that carries the backups that run after
+// the current one when it succeeds.
+func withSequence(ctx context.Context, backupIDs []uuid.UUID) context.Context {
+	return context.WithValue(ctx, sequenceCtxKey{}, backupIDs)
+}
+
+func sequenceFromContext(ctx context.Context) []uuid.UUID {
+	backupIDs, _ := ctx.Value(sequenceCtxKey{}).([]uuid.UUID)
+	return backupIDs
+}
+
+// RunSequence runs the backups one after the other and returns when the last
+// one finishes. It stops at the first backup that doesn't succeed.
+func (s *Service) RunSequence(
+	ctx context.Context, backupIDs []uuid.UUID,
+) error {
+	if len(backupIDs) == 0 {
+		return nil
+	}
+
+	return s.RunExecution(withSequence(ctx, backupIDs[1:]), backupIDs[0])
+}
+
+// runChain is called when an execution of the backup finishes. If it
+// succeeded, it runs the backups that run after it and then the next backup
+// of the sequence in ctx, if any. They run one after the other, never at the
+// same time.
+func (s *Service) runChain(ctx context.Context, backupID uuid.UUID, status string) {
+	sequence := sequenceFromContext(ctx)
+
+	if status != "success" {
+		if len(sequence) > 0 {
+			logger.Warn("backup sequence stopped", logger.KV{
+				"backup_id": backupID.String(),
+				"skipped":   len(sequence),
+			})
+		}
+		return
+	}
+
+	dependentIDs, err := s.dbgen.ExecutionsServiceGetDependentBackupIDs(
+		ctx, backupID,
+	)
+	if err != nil {
+		logger.Error("error getting backups that run after backup", logger.KV{
+			"backup_id": backupID.String(),
+			"error":     err.Error(),
+		})
+	}
+
+	// The dependent backups don't continue the sequence, only the backup that
+	// started it does.
+	for _, dependentID := range dependentIDs {
+		_ = s.RunExecution(withSequence(ctx, nil), dependentID)
+	}
+
+	if len(sequence) > 0 {
+		_ = s.RunSequence(ctx, sequence)
+	}
+}
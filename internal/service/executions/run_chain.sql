@@ -0,0 +1,5 @@
+-- name: ExecutionsServiceGetDependentBackupIDs :many
+SELECT id FROM backups
+WHERE run_after_backup_id = @backup_id
+AND is_active = TRUE
+ORDER BY created_at ASC;
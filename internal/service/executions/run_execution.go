@@ -11,7 +11,12 @@ import (
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
 	"github.com/eduardolat/pgbackweb/internal/integration/postgres"
+	"github.com/eduardolat/pgbackweb/internal/integration/secrets"
 	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/eduardolat/pgbackweb/internal/util/strutil"
 	"github.com/eduardolat/pgbackweb/internal/util/timeutil"
 	"github.com/google/uuid"
@@ -19,18 +24,36 @@ import (
 
 // RunExecution runs a backup execution
 func (s *Service) RunExecution(ctx context.Context, backupID uuid.UUID) error {
+	if err := users.Authorize(ctx, users.PermissionRun); err != nil {
+		return err
+	}
+
+	// The backups chained to this one run once it finishes, after the
+	// temporary files of the dump are removed.
+	var status string
+	defer func() { s.runChain(ctx, backupID, status) }()
+
 	updateExec := func(params dbgen.ExecutionsServiceUpdateExecutionParams) error {
+		_, err := s.dbgen.ExecutionsServiceUpdateExecution(
+			ctx, params,
+		)
+		if err == nil {
+			status = params.Status.String
+		}
+
+		// The webhooks run after the update so their event includes it.
 		if params.Status.String == "success" {
-			s.webhooksService.RunExecutionSuccess(backupID)
+			s.webhooksService.RunExecutionSuccess(backupID, params.ID)
 		}
 
 		if params.Status.String == "failed" {
-			s.webhooksService.RunExecutionFailed(backupID)
+			s.webhooksService.RunExecutionFailed(backupID, params.ID)
+		}
+
+		if params.IsSuspicious.Valid && params.IsSuspicious.Bool {
+			s.webhooksService.RunExecutionSuspicious(backupID, params.ID)
 		}
 
-		_, err := s.dbgen.ExecutionsServiceUpdateExecution(
-			ctx, params,
-		)
 		return err
 	}
 
@@ -45,6 +68,7 @@ func (s *Service) RunExecution(ctx context.Context, backupID uuid.UUID) error {
 		ctx, dbgen.ExecutionsServiceGetBackupDataParams{
 			BackupID:      backupID,
 			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
+			WorkspaceID:   workspaces.FromContext(ctx),
 		},
 	)
 	if err != nil {
@@ -61,6 +85,36 @@ func (s *Service) RunExecution(ctx context.Context, backupID uuid.UUID) error {
 		return err
 	}
 
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionRun,
+		EntityType: audit.EntityExecution,
+		EntityID:   ex.ID,
+		After:      ex,
+	})
+	s.webhooksService.RunExecutionStarted(backupID, ex.ID)
+
+	// The connection string and the S3 keys can be references to external
+	// secrets, they are resolved on every run so rotated secrets are used
+	// without updating them in PG Back Web.
+	references := []string{
+		back.DecryptedDatabaseConnectionString,
+		back.DecryptedDestinationAccessKey, back.DecryptedDestinationSecretKey,
+	}
+	err = s.ints.SecretsClient.ResolveAll(
+		ctx, &back.DecryptedDatabaseConnectionString,
+		&back.DecryptedDestinationAccessKey, &back.DecryptedDestinationSecretKey,
+	)
+	if err != nil {
+		logError(err)
+		err = hideSecrets(err, references...)
+		return updateExec(dbgen.ExecutionsServiceUpdateExecutionParams{
+			ID:         ex.ID,
+			Status:     sql.NullString{Valid: true, String: "failed"},
+			Message:    sql.NullString{Valid: true, String: err.Error()},
+			FinishedAt: sql.NullTime{Valid: true, Time: time.Now()},
+		})
+	}
+
 	if !back.BackupIsLocal {
 		err = s.ints.StorageClient.S3Test(
 			back.DecryptedDestinationAccessKey, back.DecryptedDestinationSecretKey,
@@ -70,6 +124,7 @@ func (s *Service) RunExecution(ctx context.Context, backupID uuid.UUID) error {
 		)
 		if err != nil {
 			logError(err)
+			err = hideSecrets(err, references...)
 			return updateExec(dbgen.ExecutionsServiceUpdateExecutionParams{
 				ID:         ex.ID,
 				Status:     sql.NullString{Valid: true, String: "failed"},
@@ -82,6 +137,7 @@ func (s *Service) RunExecution(ctx context.Context, backupID uuid.UUID) error {
 	pgVersion, err := s.ints.PGClient.ParseVersion(back.DatabasePgVersion)
 	if err != nil {
 		logError(err)
+		err = hideSecrets(err, references...)
 		return updateExec(dbgen.ExecutionsServiceUpdateExecutionParams{
 			ID:         ex.ID,
 			Status:     sql.NullString{Valid: true, String: "failed"},
@@ -93,6 +149,7 @@ func (s *Service) RunExecution(ctx context.Context, backupID uuid.UUID) error {
 	err = s.ints.PGClient.Test(pgVersion, back.DecryptedDatabaseConnectionString)
 	if err != nil {
 		logError(err)
+		err = hideSecrets(err, references...)
 		return updateExec(dbgen.ExecutionsServiceUpdateExecutionParams{
 			ID:         ex.ID,
 			Status:     sql.NullString{Valid: true, String: "failed"},
@@ -154,6 +211,7 @@ func (s *Service) RunExecution(ctx context.Context, backupID uuid.UUID) error {
 
 	if dumpErr != nil {
 		logError(dumpErr)
+		dumpErr = hideSecrets(dumpErr, references...)
 		return updateExec(dbgen.ExecutionsServiceUpdateExecutionParams{
 			ID:         ex.ID,
 			Status:     sql.NullString{Valid: true, String: "failed"},
@@ -171,6 +229,7 @@ func (s *Service) RunExecution(ctx context.Context, backupID uuid.UUID) error {
 
 	var uploadedPaths []string
 	totalFileSize := int64(0)
+	uploadStart := time.Now()
 
 	for i, part := range parts {
 		var fileName string
@@ -184,6 +243,7 @@ func (s *Service) RunExecution(ctx context.Context, backupID uuid.UUID) error {
 		partFile, openErr := os.Open(part.FilePath)
 		if openErr != nil {
 			logError(openErr)
+			openErr = hideSecrets(openErr, references...)
 			return updateExec(dbgen.ExecutionsServiceUpdateExecutionParams{
 				ID:         ex.ID,
 				Status:     sql.NullString{Valid: true, String: "failed"},
@@ -210,6 +270,7 @@ func (s *Service) RunExecution(ctx context.Context, backupID uuid.UUID) error {
 
 		if uploadErr != nil {
 			logError(uploadErr)
+			uploadErr = hideSecrets(uploadErr, references...)
 			return updateExec(dbgen.ExecutionsServiceUpdateExecutionParams{
 				ID:         ex.ID,
 				Status:     sql.NullString{Valid: true, String: "failed"},
@@ -222,6 +283,8 @@ func (s *Service) RunExecution(ctx context.Context, backupID uuid.UUID) error {
 		totalFileSize += partSize
 	}
 
+	uploadDuration := time.Since(uploadStart)
+
 	pathJSON, _ := json.Marshal(uploadedPaths)
 	pathStr := string(pathJSON)
 
@@ -230,12 +293,63 @@ func (s *Service) RunExecution(ctx context.Context, backupID uuid.UUID) error {
 		"execution_id": ex.ID.String(),
 		"parts":        len(parts),
 	})
-	return updateExec(dbgen.ExecutionsServiceUpdateExecutionParams{
-		ID:         ex.ID,
-		Status:     sql.NullString{Valid: true, String: "success"},
-		Message:    sql.NullString{Valid: true, String: "Backup created successfully"},
-		Path:       sql.NullString{Valid: true, String: pathStr},
-		FinishedAt: sql.NullTime{Valid: true, Time: time.Now()},
-		FileSize:   sql.NullInt64{Valid: true, Int64: totalFileSize},
+
+	message := "Backup created successfully"
+	deviation, isSuspicious, err := s.checkSizeDeviation(
+		ctx, backupID, ex.ID, back.BackupSizeDeviationThreshold, totalFileSize,
+	)
+	if err != nil {
+		logError(err)
+	}
+	if isSuspicious {
+		message = fmt.Sprintf(
+			"Backup created successfully, but its size deviates %.0f%% from the "+
+				"average of the previous executions (threshold %d%%)",
+			deviation, back.BackupSizeDeviationThreshold,
+		)
+		logger.Warn("backup size deviates from its history", logger.KV{
+			"backup_id":    backupID.String(),
+			"execution_id": ex.ID.String(),
+			"file_size":    totalFileSize,
+			"deviation":    deviation,
+		})
+	}
+
+	err = updateExec(dbgen.ExecutionsServiceUpdateExecutionParams{
+		ID:           ex.ID,
+		Status:       sql.NullString{Valid: true, String: "success"},
+		Message:      sql.NullString{Valid: true, String: message},
+		Path:         sql.NullString{Valid: true, String: pathStr},
+		FinishedAt:   sql.NullTime{Valid: true, Time: time.Now()},
+		FileSize:     sql.NullInt64{Valid: true, Int64: totalFileSize},
+		IsSuspicious: sql.NullBool{Valid: true, Bool: isSuspicious},
 	})
+
+	threshold := s.env.PBW_SLOW_UPLOAD_THRESHOLD
+	if back.BackupDestinationID.Valid && threshold > 0 && uploadDuration > threshold {
+		logger.Warn("backup upload was slow", logger.KV{
+			"backup_id":      backupID.String(),
+			"execution_id":   ex.ID.String(),
+			"destination_id": back.BackupDestinationID.UUID.String(),
+			"duration":       uploadDuration.String(),
+		})
+		s.webhooksService.RunDestinationUploadSlow(
+			back.BackupDestinationID.UUID, ex.ID,
+			webhooks.SlowUploadMessage(totalFileSize, uploadDuration, threshold),
+		)
+	}
+
+	return err
+}
+
+// hideSecrets hides err with secrets.HideOutput when one of the references
+// is a secret reference, the errors of the steps that use the resolved
+// secrets are stored in the execution message and can include them.
+func hideSecrets(err error, references ...string) error {
+	for _, reference := range references {
+		if secrets.IsReference(reference) {
+			return secrets.HideOutput(reference, err)
+		}
+	}
+	return err
 }
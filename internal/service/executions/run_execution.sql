@@ -3,6 +3,7 @@ SELECT
   backups.is_active as backup_is_active,
   backups.is_local as backup_is_local,
   backups.dest_dir as backup_dest_dir,
+  backups.destination_id as backup_destination_id,
   backups.opt_data_only as backup_opt_data_only,
   backups.opt_schema_only as backup_opt_schema_only,
   backups.opt_clean as backup_opt_clean,
@@ -11,6 +12,7 @@ SELECT
   backups.opt_no_comments as backup_opt_no_comments,
   backups.max_part_size_mb as backup_max_part_size_mb,
   backups.compression_level as backup_compression_level,
+  backups.size_deviation_threshold as backup_size_deviation_threshold,
 
   pgp_sym_decrypt(databases.connection_string, @encryption_key) AS decrypted_database_connection_string,
   databases.pg_version as database_pg_version,
@@ -35,4 +37,9 @@ SELECT
 FROM backups
 INNER JOIN databases ON backups.database_id = databases.id
 LEFT JOIN destinations ON backups.destination_id = destinations.id
-WHERE backups.id = @backup_id;
+WHERE backups.id = @backup_id
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  backups.workspace_id = sqlc.narg('workspace_id')::UUID
+);
@@ -6,6 +6,9 @@ import (
 	"errors"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/eduardolat/pgbackweb/internal/util/strutil"
 	"github.com/google/uuid"
 )
@@ -13,10 +16,15 @@ import (
 func (s *Service) SoftDeleteExecution(
 	ctx context.Context, executionID uuid.UUID,
 ) error {
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return err
+	}
+
 	execution, err := s.dbgen.ExecutionsServiceGetExecutionForSoftDelete(
 		ctx, dbgen.ExecutionsServiceGetExecutionForSoftDeleteParams{
 			ExecutionID:   executionID,
 			EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
+			WorkspaceID:   workspaces.FromContext(ctx),
 		},
 	)
 	if err != nil && errors.Is(err, sql.ErrNoRows) {
@@ -26,6 +34,14 @@ func (s *Service) SoftDeleteExecution(
 		return err
 	}
 
+	err = s.ints.SecretsClient.ResolveAll(
+		ctx, &execution.DecryptedDestinationAccessKey,
+		&execution.DecryptedDestinationSecretKey,
+	)
+	if err != nil {
+		return err
+	}
+
 	if execution.ExecutionPath.Valid {
 		for _, p := range strutil.ParseJSONStringArray(execution.ExecutionPath.String) {
 			if !execution.BackupIsLocal {
@@ -48,5 +64,16 @@ func (s *Service) SoftDeleteExecution(
 		}
 	}
 
-	return s.dbgen.ExecutionsServiceSoftDeleteExecution(ctx, executionID)
+	err = s.dbgen.ExecutionsServiceSoftDeleteExecution(ctx, executionID)
+	if err != nil {
+		return err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionDelete,
+		EntityType: audit.EntityExecution,
+		EntityID:   executionID,
+	})
+
+	return nil
 }
@@ -4,10 +4,11 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 )
 
 func (s *Service) SoftDeleteExpiredExecutions() {
-	ctx := context.Background()
+	ctx := users.WithSystem(context.Background())
 
 	expiredExecutions, err := s.dbgen.ExecutionsServiceGetExpiredExecutions(ctx)
 	if err != nil {
@@ -26,6 +27,9 @@ func (s *Service) SoftDeleteExpiredExecutions() {
 			)
 			return
 		}
+		s.webhooksService.RunExecutionDeletedByRetention(
+			execution.BackupID, execution.ID,
+		)
 	}
 
 	logger.Info("expired executions soft deleted")
@@ -0,0 +1,29 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/logger"
+)
+
+// DeleteOldChecks deletes the checks older than the retention set in
+// PBW_HEALTH_HISTORY_RETENTION_DAYS, zero keeps them forever.
+func (s *Service) DeleteOldChecks() {
+	if s.env.PBW_HEALTH_HISTORY_RETENTION_DAYS <= 0 {
+		return
+	}
+
+	ctx := context.Background()
+	createdBefore := time.Now().AddDate(
+		0, 0, -s.env.PBW_HEALTH_HISTORY_RETENTION_DAYS,
+	)
+
+	deleted, err := s.dbgen.HealthServiceDeleteOldChecks(ctx, createdBefore)
+	if err != nil {
+		logger.Error("error deleting old health checks", logger.KV{"error": err})
+		return
+	}
+
+	logger.Info("old health checks deleted", logger.KV{"deleted": deleted})
+}
@@ -0,0 +1,2 @@
+-- name: HealthServiceDeleteOldChecks :execrows
+DELETE FROM health_checks WHERE created_at < @created_before;
@@ -0,0 +1,108 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+// reportChecks is how many of the latest checks the report includes.
+const reportChecks = 60
+
+// flappingChangesPerDay is how many times a day the health must change on
+// average for a database or destination to be flapping.
+const flappingChangesPerDay = 4
+
+// Period is the summary of the checks of a database or destination in a
+// period of time.
+type Period struct {
+	Name     string
+	Duration time.Duration
+	Checks   int64
+	// Uptime is the percentage of the checks that succeeded.
+	Uptime     float64
+	AvgLatency time.Duration
+	MaxLatency time.Duration
+	// Changes is how many times the health changed, a lot of them in a short
+	// period means the database or destination is flapping.
+	Changes int64
+}
+
+// Flapping reports whether the health changed too often in the period,
+// e.g. because of an intermittent network issue.
+func (p Period) Flapping() bool {
+	days := p.Duration.Hours() / 24
+	return days > 0 && float64(p.Changes)/days >= flappingChangesPerDay
+}
+
+// Report is the health history of a database or destination.
+type Report struct {
+	Periods []Period
+	// Checks are the latest checks, newest first.
+	Checks []dbgen.HealthCheck
+}
+
+// GetReport returns the health history of the database or destination,
+// only one of the IDs must be set. It doesn't check the access to them.
+func (s *Service) GetReport(
+	ctx context.Context, databaseID, destinationID uuid.NullUUID,
+) (Report, error) {
+	periods := []struct {
+		name     string
+		duration time.Duration
+	}{
+		{name: "Last 24 hours", duration: 24 * time.Hour},
+		{name: "Last 7 days", duration: 7 * 24 * time.Hour},
+		{name: "Last 30 days", duration: 30 * 24 * time.Hour},
+	}
+
+	report := Report{}
+	now := time.Now()
+	for _, period := range periods {
+		summary, err := s.dbgen.HealthServiceGetSummary(
+			ctx, dbgen.HealthServiceGetSummaryParams{
+				DatabaseID:    databaseID,
+				DestinationID: destinationID,
+				Since:         now.Add(-period.duration),
+			},
+		)
+		if err != nil {
+			return Report{}, err
+		}
+
+		report.Periods = append(report.Periods, Period{
+			Name:       period.name,
+			Duration:   period.duration,
+			Checks:     summary.Checks,
+			Uptime:     Uptime(summary.OkChecks, summary.Checks),
+			AvgLatency: time.Duration(summary.AvgLatencyMs) * time.Millisecond,
+			MaxLatency: time.Duration(summary.MaxLatencyMs) * time.Millisecond,
+			Changes:    summary.Changes,
+		})
+	}
+
+	checks, err := s.dbgen.HealthServiceListChecks(
+		ctx, dbgen.HealthServiceListChecksParams{
+			DatabaseID:    databaseID,
+			DestinationID: destinationID,
+			Limit:         reportChecks,
+		},
+	)
+	if err != nil {
+		return Report{}, err
+	}
+	report.Checks = checks
+
+	return report, nil
+}
+
+// Uptime returns the percentage of the checks that succeeded, zero when
+// there are no checks.
+func Uptime(okChecks, checks int64) float64 {
+	if checks == 0 {
+		return 0
+	}
+	return float64(okChecks) * 100 / float64(checks)
+}
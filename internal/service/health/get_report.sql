@@ -0,0 +1,23 @@
+-- name: HealthServiceGetSummary :one
+SELECT
+  COUNT(*) AS checks,
+  COUNT(*) FILTER (WHERE ok) AS ok_checks,
+  COUNT(*) FILTER (WHERE changed) AS changes,
+  COALESCE(AVG(latency_ms), 0)::INTEGER AS avg_latency_ms,
+  COALESCE(MAX(latency_ms), 0)::INTEGER AS max_latency_ms
+FROM health_checks
+WHERE (
+  database_id = sqlc.narg('database_id')
+  OR destination_id = sqlc.narg('destination_id')
+)
+AND created_at >= @since;
+
+-- name: HealthServiceListChecks :many
+SELECT *
+FROM health_checks
+WHERE (
+  database_id = sqlc.narg('database_id')
+  OR destination_id = sqlc.narg('destination_id')
+)
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit');
@@ -0,0 +1,37 @@
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUptime(t *testing.T) {
+	assert.Equal(t, 0.0, Uptime(0, 0))
+	assert.Equal(t, 100.0, Uptime(4, 4))
+	assert.Equal(t, 75.0, Uptime(3, 4))
+}
+
+func TestPeriodFlapping(t *testing.T) {
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name   string
+		period Period
+		want   bool
+	}{
+		{name: "No changes", period: Period{Duration: day}},
+		{name: "An outage in a day", period: Period{Duration: day, Changes: 2}},
+		{name: "Two outages in a day", period: Period{Duration: day, Changes: 4}, want: true},
+		{name: "Few changes in a week", period: Period{Duration: 7 * day, Changes: 10}},
+		{name: "Many changes in a week", period: Period{Duration: 7 * day, Changes: 30}, want: true},
+		{name: "Empty period", period: Period{Changes: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.period.Flapping())
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package health
+
+import (
+	"github.com/eduardolat/pgbackweb/internal/config"
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+)
+
+// The defaults of the health settings of the databases and destinations,
+// the same as the defaults of their columns.
+const (
+	DefaultCheckIntervalMinutes = 10
+	DefaultFailureThreshold     = 1
+	DefaultSuccessThreshold     = 1
+)
+
+// Service reads and cleans the history of the health checks of the
+// databases and destinations. The checks are recorded by their services
+// with Record, and the routes that read the history check the access to the
+// database or destination first.
+type Service struct {
+	env   config.Env
+	dbgen *dbgen.Queries
+}
+
+func New(env config.Env, dbgen *dbgen.Queries) *Service {
+	return &Service{
+		env:   env,
+		dbgen: dbgen,
+	}
+}
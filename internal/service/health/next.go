@@ -0,0 +1,48 @@
+package health
+
+import "database/sql"
+
+// State is the health of a database or destination with the checks in a row
+// that failed or succeeded, only one of the counters is non-zero.
+type State struct {
+	Ok                   sql.NullBool
+	ConsecutiveFailures  int32
+	ConsecutiveSuccesses int32
+}
+
+// Thresholds are how many checks in a row must fail or succeed to change
+// the health, so a single failed check doesn't flip it.
+type Thresholds struct {
+	Failures  int32
+	Successes int32
+}
+
+// Next returns the state after a check and whether the check changed the
+// health. The first check of a database or destination sets its health
+// right away without counting as a change.
+func Next(state State, ok bool, thresholds Thresholds) (State, bool) {
+	next := State{Ok: state.Ok}
+	if ok {
+		next.ConsecutiveSuccesses = state.ConsecutiveSuccesses + 1
+	} else {
+		next.ConsecutiveFailures = state.ConsecutiveFailures + 1
+	}
+
+	if !state.Ok.Valid {
+		next.Ok = sql.NullBool{Bool: ok, Valid: true}
+		return next, false
+	}
+	if state.Ok.Bool == ok {
+		return next, false
+	}
+
+	if ok && next.ConsecutiveSuccesses < max(thresholds.Successes, 1) {
+		return next, false
+	}
+	if !ok && next.ConsecutiveFailures < max(thresholds.Failures, 1) {
+		return next, false
+	}
+
+	next.Ok = sql.NullBool{Bool: ok, Valid: true}
+	return next, true
+}
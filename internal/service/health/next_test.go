@@ -0,0 +1,81 @@
+package health
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNext(t *testing.T) {
+	healthy := sql.NullBool{Bool: true, Valid: true}
+	unhealthy := sql.NullBool{Bool: false, Valid: true}
+	thresholds := Thresholds{Failures: 3, Successes: 2}
+
+	tests := []struct {
+		name        string
+		state       State
+		ok          bool
+		want        State
+		wantChanged bool
+	}{
+		{
+			name:  "First check sets the health",
+			state: State{},
+			ok:    false,
+			want:  State{Ok: unhealthy, ConsecutiveFailures: 1},
+		},
+		{
+			name:  "Success keeps healthy",
+			state: State{Ok: healthy, ConsecutiveSuccesses: 4},
+			ok:    true,
+			want:  State{Ok: healthy, ConsecutiveSuccesses: 5},
+		},
+		{
+			name:  "Failure below the threshold",
+			state: State{Ok: healthy, ConsecutiveFailures: 1},
+			ok:    false,
+			want:  State{Ok: healthy, ConsecutiveFailures: 2},
+		},
+		{
+			name:        "Failure reaching the threshold",
+			state:       State{Ok: healthy, ConsecutiveFailures: 2},
+			ok:          false,
+			want:        State{Ok: unhealthy, ConsecutiveFailures: 3},
+			wantChanged: true,
+		},
+		{
+			name:  "Success resets the failures",
+			state: State{Ok: healthy, ConsecutiveFailures: 2},
+			ok:    true,
+			want:  State{Ok: healthy, ConsecutiveSuccesses: 1},
+		},
+		{
+			name:  "Success below the threshold",
+			state: State{Ok: unhealthy, ConsecutiveFailures: 7},
+			ok:    true,
+			want:  State{Ok: unhealthy, ConsecutiveSuccesses: 1},
+		},
+		{
+			name:        "Success reaching the threshold",
+			state:       State{Ok: unhealthy, ConsecutiveSuccesses: 1},
+			ok:          true,
+			want:        State{Ok: healthy, ConsecutiveSuccesses: 2},
+			wantChanged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := Next(tt.state, tt.ok, thresholds)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantChanged, changed)
+		})
+	}
+
+	t.Run("Zero thresholds change on the first check", func(t *testing.T) {
+		got, changed := Next(State{Ok: healthy}, false, Thresholds{})
+		assert.Equal(t, State{Ok: unhealthy, ConsecutiveFailures: 1}, got)
+		assert.True(t, changed)
+	})
+}
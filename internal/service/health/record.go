@@ -0,0 +1,45 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/google/uuid"
+)
+
+// Check is a health check of a database or destination to record in the
+// history, only one of the IDs is set.
+type Check struct {
+	DatabaseID    uuid.NullUUID
+	DestinationID uuid.NullUUID
+	Ok            bool
+	Error         string
+	Latency       time.Duration
+	// Changed is whether the check changed the health, see Next.
+	Changed bool
+}
+
+// Record appends the check to the history. A failure to record is logged
+// instead of failing the check.
+func Record(ctx context.Context, q *dbgen.Queries, check Check) {
+	err := q.HealthServiceRecord(
+		context.WithoutCancel(ctx), dbgen.HealthServiceRecordParams{
+			DatabaseID:    check.DatabaseID,
+			DestinationID: check.DestinationID,
+			Ok:            check.Ok,
+			Error:         sql.NullString{String: check.Error, Valid: check.Error != ""},
+			LatencyMs:     int32(check.Latency.Milliseconds()),
+			Changed:       check.Changed,
+		},
+	)
+	if err != nil {
+		logger.Error("failed to record health check", logger.KV{
+			"database_id":    check.DatabaseID,
+			"destination_id": check.DestinationID,
+			"error":          err,
+		})
+	}
+}
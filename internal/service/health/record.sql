@@ -0,0 +1,7 @@
+-- name: HealthServiceRecord :exec
+INSERT INTO health_checks (
+  database_id, destination_id, ok, error, latency_ms, changed
+) VALUES (
+  sqlc.narg('database_id'), sqlc.narg('destination_id'), @ok,
+  sqlc.narg('error'), @latency_ms, @changed
+);
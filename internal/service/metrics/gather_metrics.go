@@ -0,0 +1,167 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/util/promutil"
+)
+
+var (
+	// durationBuckets are the upper bounds in seconds of the execution
+	// duration histogram.
+	durationBuckets = []float64{
+		1, 5, 15, 30, 60, 300, 900, 1800, 3600, 7200, 14400, 28800,
+	}
+
+	// sizeBuckets are the upper bounds in bytes of the execution file size
+	// histogram.
+	sizeBuckets = []float64{
+		1 << 20, 10 << 20, 100 << 20, 1 << 30, 10 << 30, 100 << 30, 1 << 40,
+	}
+)
+
+// GatherMetrics returns all the PG Back Web metrics in the Prometheus text
+// exposition format.
+func (s *Service) GatherMetrics(ctx context.Context) (string, error) {
+	w := promutil.NewWriter()
+
+	executions, err := s.dbgen.MetricsServiceGetExecutionsByStatus(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, ex := range executions {
+		w.Counter(
+			"pbw_executions_total", "Number of backup executions by status.",
+			float64(ex.Qty), promutil.Labels{
+				"backup_id":   ex.BackupID.String(),
+				"backup_name": ex.BackupName,
+				"status":      ex.Status,
+			},
+		)
+	}
+
+	backups, err := s.dbgen.MetricsServiceGetBackupsState(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, backup := range backups {
+		w.Gauge(
+			"pbw_backup_last_success_timestamp_seconds",
+			"Unix timestamp of the last successful execution, 0 if none.",
+			float64(backup.LastSuccessTimestamp), backupLabels(backup),
+		)
+	}
+	for _, backup := range backups {
+		w.Gauge(
+			"pbw_backup_active", "Whether the backup is active (1) or not (0).",
+			boolToFloat(backup.IsActive), backupLabels(backup),
+		)
+	}
+	for _, backup := range backups {
+		w.Gauge(
+			"pbw_backup_stale",
+			"Whether the backup has no successful execution within its RPO.",
+			boolToFloat(backup.IsStale), backupLabels(backup),
+		)
+	}
+
+	observations, err := s.dbgen.MetricsServiceGetExecutionsObservations(ctx)
+	if err != nil {
+		return "", err
+	}
+	durations, sizes := []float64{}, []float64{}
+	for _, obs := range observations {
+		durations = append(durations, obs.DurationSeconds)
+		if obs.Status == "success" && obs.FileSize.Valid {
+			sizes = append(sizes, float64(obs.FileSize.Int64))
+		}
+	}
+	w.Histogram(
+		"pbw_execution_duration_seconds", "Duration of finished backup executions.",
+		durationBuckets, durations, nil,
+	)
+	w.Histogram(
+		"pbw_execution_size_bytes", "File size of successful backup executions.",
+		sizeBuckets, sizes, nil,
+	)
+
+	restorations, err := s.dbgen.MetricsServiceGetRestorationsByStatus(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, res := range restorations {
+		w.Counter(
+			"pbw_restorations_total", "Number of restorations by status.",
+			float64(res.Qty), promutil.Labels{"status": res.Status},
+		)
+	}
+
+	databases, err := s.dbgen.MetricsServiceGetDatabasesHealth(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, db := range databases {
+		if !db.TestOk.Valid {
+			continue
+		}
+		w.Gauge(
+			"pbw_database_healthy",
+			"Result of the last database health check, healthy (1) or not (0).",
+			boolToFloat(db.TestOk.Bool), promutil.Labels{
+				"database_id":   db.ID.String(),
+				"database_name": db.Name,
+			},
+		)
+	}
+
+	destinations, err := s.dbgen.MetricsServiceGetDestinationsHealth(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, dest := range destinations {
+		if !dest.TestOk.Valid {
+			continue
+		}
+		w.Gauge(
+			"pbw_destination_healthy",
+			"Result of the last destination health check, healthy (1) or not (0).",
+			boolToFloat(dest.TestOk.Bool), promutil.Labels{
+				"destination_id":   dest.ID.String(),
+				"destination_name": dest.Name,
+			},
+		)
+	}
+
+	deliveries, err := s.dbgen.MetricsServiceGetWebhookDeliveries(ctx)
+	if err != nil {
+		return "", err
+	}
+	// The deliveries are counted once they are delivered or run out of
+	// attempts, the retries of a delivery are not counted again.
+	for _, del := range deliveries {
+		labels := promutil.Labels{
+			"webhook_id":   del.WebhookID.String(),
+			"webhook_name": del.WebhookName,
+			"event_type":   del.EventType,
+		}
+		labels["result"] = "success"
+		w.Counter(
+			"pbw_webhook_deliveries_total",
+			"Number of webhook deliveries delivered or failed after all the attempts.",
+			float64(del.SuccessQty), labels,
+		)
+		labels["result"] = "failed"
+		w.Counter(
+			"pbw_webhook_deliveries_total",
+			"Number of webhook deliveries delivered or failed after all the attempts.",
+			float64(del.FailedQty), labels,
+		)
+	}
+
+	w.Gauge(
+		"pbw_scheduler_jobs", "Number of jobs registered in the scheduler.",
+		float64(s.cr.JobsQty()), nil,
+	)
+
+	return w.String(), nil
+}
@@ -0,0 +1,68 @@
+-- name: MetricsServiceGetExecutionsByStatus :many
+SELECT
+  backups.id AS backup_id,
+  backups.name AS backup_name,
+  executions.status,
+  COUNT(executions.id) AS qty
+FROM executions
+INNER JOIN backups ON backups.id = executions.backup_id
+GROUP BY backups.id, backups.name, executions.status
+ORDER BY backups.name, executions.status;
+
+-- name: MetricsServiceGetBackupsState :many
+SELECT
+  backups.id AS backup_id,
+  backups.name AS backup_name,
+  backups.is_active,
+  backups.is_stale,
+  COALESCE(
+    EXTRACT(EPOCH FROM MAX(executions.finished_at)), 0
+  )::BIGINT AS last_success_timestamp
+FROM backups
+LEFT JOIN executions
+  ON executions.backup_id = backups.id
+  AND executions.status = 'success'
+GROUP BY backups.id, backups.name, backups.is_active, backups.is_stale
+ORDER BY backups.name;
+
+-- name: MetricsServiceGetExecutionsObservations :many
+SELECT
+  executions.status,
+  EXTRACT(EPOCH FROM (executions.finished_at - executions.started_at))::FLOAT8 AS duration_seconds,
+  executions.file_size
+FROM executions
+WHERE executions.finished_at IS NOT NULL;
+
+-- name: MetricsServiceGetRestorationsByStatus :many
+SELECT
+  status,
+  COUNT(*) AS qty
+FROM restorations
+GROUP BY status
+ORDER BY status;
+
+-- name: MetricsServiceGetDatabasesHealth :many
+SELECT id, name, test_ok
+FROM databases
+ORDER BY name;
+
+-- name: MetricsServiceGetDestinationsHealth :many
+SELECT id, name, test_ok
+FROM destinations
+ORDER BY name;
+
+-- name: MetricsServiceGetWebhookDeliveries :many
+SELECT
+  webhooks.id AS webhook_id,
+  webhooks.name AS webhook_name,
+  webhooks.event_type,
+  COUNT(*) FILTER (
+    WHERE webhook_deliveries.status = 'delivered'
+  ) AS success_qty,
+  COUNT(*) FILTER (
+    WHERE webhook_deliveries.status = 'failed'
+  ) AS failed_qty
+FROM webhooks
+INNER JOIN webhook_deliveries ON webhook_deliveries.webhook_id = webhooks.id
+GROUP BY webhooks.id, webhooks.name, webhooks.event_type
+ORDER BY webhooks.name;
@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/util/promutil"
+)
+
+func backupLabels(backup dbgen.MetricsServiceGetBackupsStateRow) promutil.Labels {
+	return promutil.Labels{
+		"backup_id":   backup.BackupID.String(),
+		"backup_name": backup.BackupName,
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,15 @@
+package metrics
+
+import "crypto/subtle"
+
+// IsValidToken reports whether the given token grants access to the metrics.
+// When PBW_METRICS_TOKEN is not configured every token is accepted.
+func (s *Service) IsValidToken(token string) bool {
+	if s.env.PBW_METRICS_TOKEN == "" {
+		return true
+	}
+
+	return subtle.ConstantTimeCompare(
+		[]byte(token), []byte(s.env.PBW_METRICS_TOKEN),
+	) == 1
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"github.com/eduardolat/pgbackweb/internal/config"
+	"github.com/eduardolat/pgbackweb/internal/cron"
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+)
+
+type Service struct {
+	env   config.Env
+	dbgen *dbgen.Queries
+	cr    *cron.Cron
+}
+
+func New(
+	env config.Env, dbgen *dbgen.Queries, cr *cron.Cron,
+) *Service {
+	return &Service{
+		env:   env,
+		dbgen: dbgen,
+		cr:    cr,
+	}
+}
@@ -0,0 +1,209 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/google/uuid"
+)
+
+// apply runs the changes of the plan. Entities are created and updated
+// before the ones that reference them and removed in the opposite order.
+func (s *Service) apply(
+	ctx context.Context, cfg Config, plan Plan, st state,
+) error {
+	databaseIDs, err := s.applyDatabases(ctx, cfg, plan)
+	if err != nil {
+		return err
+	}
+
+	destinationIDs, err := s.applyDestinations(ctx, cfg, plan)
+	if err != nil {
+		return err
+	}
+
+	// Reload the state so the names can be resolved to the IDs of the
+	// entities created above.
+	current, err := s.getState(ctx)
+	if err != nil {
+		return err
+	}
+
+	backupIDs, err := s.applyBackups(ctx, cfg, plan, current)
+	if err != nil {
+		return err
+	}
+
+	current, err = s.getState(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.applyRunAfter(ctx, cfg, plan, current); err != nil {
+		return err
+	}
+
+	groupIDs, err := s.applyBackupGroups(ctx, cfg, plan, current)
+	if err != nil {
+		return err
+	}
+	// The backups removed from an updated group go back to their own schedule.
+	for _, change := range plan.filter(KindBackupGroup, ActionUpdate) {
+		backupIDs = append(backupIDs, cascadedBackupIDs(current, change)...)
+	}
+
+	if err := s.applyWebhooks(ctx, cfg, plan, current); err != nil {
+		return err
+	}
+
+	reportIDs, err := s.applyReports(ctx, cfg, plan)
+	if err != nil {
+		return err
+	}
+
+	removals := []struct {
+		kind    Kind
+		delete  func(context.Context, uuid.UUID) error
+		release func(context.Context, uuid.UUID) error
+	}{
+		{
+			kind:    KindReport,
+			delete:  s.dbgen.ProvisioningServiceDeleteReport,
+			release: s.dbgen.ProvisioningServiceReleaseReport,
+		},
+		{
+			kind:    KindWebhook,
+			delete:  s.dbgen.ProvisioningServiceDeleteWebhook,
+			release: s.dbgen.ProvisioningServiceReleaseWebhook,
+		},
+		{
+			kind:    KindBackupGroup,
+			delete:  s.dbgen.ProvisioningServiceDeleteBackupGroup,
+			release: s.dbgen.ProvisioningServiceReleaseBackupGroup,
+		},
+		{
+			kind:    KindBackup,
+			delete:  s.dbgen.ProvisioningServiceDeleteBackup,
+			release: s.dbgen.ProvisioningServiceReleaseBackup,
+		},
+		{
+			kind:    KindDestination,
+			delete:  s.dbgen.ProvisioningServiceDeleteDestination,
+			release: s.dbgen.ProvisioningServiceReleaseDestination,
+		},
+		{
+			kind:    KindDatabase,
+			delete:  s.dbgen.ProvisioningServiceDeleteDatabase,
+			release: s.dbgen.ProvisioningServiceReleaseDatabase,
+		},
+	}
+	for _, removal := range removals {
+		for _, change := range plan.filter(removal.kind, ActionDelete, ActionRelease) {
+			fn := removal.release
+			if change.Action == ActionDelete {
+				fn = removal.delete
+				backupIDs = append(backupIDs, cascadedBackupIDs(st, change)...)
+				switch change.Kind {
+				case KindBackupGroup:
+					groupIDs = append(groupIDs, change.id)
+				case KindReport:
+					reportIDs = append(reportIDs, change.id)
+				}
+			}
+
+			if err := fn(ctx, change.id); err != nil {
+				return changeError(change, err)
+			}
+		}
+	}
+
+	// The backups that were changed or deleted, including the ones deleted in
+	// cascade and the ones that ran after them or were in a deleted group,
+	// are rescheduled to match the stored data.
+	for _, id := range backupIDs {
+		if err := s.backupsService.RefreshJob(ctx, id); err != nil {
+			return fmt.Errorf("error scheduling backup %s: %w", id, err)
+		}
+	}
+
+	for _, id := range groupIDs {
+		if err := s.backupsService.RefreshGroupJob(ctx, id); err != nil {
+			return fmt.Errorf("error scheduling backup group %s: %w", id, err)
+		}
+	}
+
+	for _, id := range reportIDs {
+		if err := s.reportsService.RefreshJob(ctx, id); err != nil {
+			return fmt.Errorf("error scheduling report %s: %w", id, err)
+		}
+	}
+
+	// Test the changed connections in the background so unreachable
+	// dependencies do not block the reconciliation.
+	go func() {
+		for _, id := range databaseIDs {
+			err := s.databasesService.TestDatabaseAndStoreResult(
+				users.WithSystem(context.Background()), id,
+			)
+			if err != nil {
+				logger.Error("error testing provisioned database", logger.KV{
+					"database_id": id.String(), "error": err,
+				})
+			}
+		}
+		for _, id := range destinationIDs {
+			err := s.destinationsService.TestDestinationAndStoreResult(
+				users.WithSystem(context.Background()), id,
+			)
+			if err != nil {
+				logger.Error("error testing provisioned destination", logger.KV{
+					"destination_id": id.String(), "error": err,
+				})
+			}
+		}
+	}()
+
+	s.auditPlan(ctx, plan)
+	return nil
+}
+
+// cascadedBackupIDs returns the backups that must be rescheduled when the
+// change removes a backup, a database, a destination or a backup group: the
+// backups deleted by the database, the ones that ran after a deleted backup
+// and the ones of the group.
+func cascadedBackupIDs(st state, change Change) []uuid.UUID {
+	ids := []uuid.UUID{}
+	for _, backup := range st.backups {
+		switch {
+		case change.Kind == KindBackup && backup.ID == change.id,
+			change.Kind == KindBackup && backup.RunAfterBackupID.UUID == change.id,
+			change.Kind == KindDatabase && backup.DatabaseID == change.id,
+			change.Kind == KindDestination && backup.DestinationID.UUID == change.id:
+			ids = append(ids, backup.ID)
+		}
+	}
+	for _, group := range st.backupGroups {
+		if change.Kind == KindBackupGroup && group.ID == change.id {
+			ids = append(ids, group.BackupIds...)
+		}
+	}
+	return ids
+}
+
+// changeError adds the change that failed to the error.
+func changeError(change Change, err error) error {
+	return fmt.Errorf(
+		"error trying to %s %s %q: %w", change.Action, change.Kind, change.Name, err,
+	)
+}
+
+// byName indexes the declared items by name.
+func byName[T any](items []T, nameOf func(T) string) map[string]T {
+	indexed := make(map[string]T, len(items))
+	for _, item := range items {
+		indexed[nameOf(item)] = item
+	}
+	return indexed
+}
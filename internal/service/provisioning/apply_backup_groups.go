@@ -0,0 +1,68 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+// applyBackupGroups creates and updates the declared backup groups and
+// returns the IDs of the changed ones. The backups they reference must be
+// already stored.
+func (s *Service) applyBackupGroups(
+	ctx context.Context, cfg Config, plan Plan, st state,
+) ([]uuid.UUID, error) {
+	declared := byName(cfg.BackupGroups, backupGroupConfigName)
+	changed := []uuid.UUID{}
+
+	for _, change := range plan.filter(KindBackupGroup, ActionCreate, ActionUpdate) {
+		group := declared[change.Name]
+
+		backupIDs := make([]uuid.UUID, 0, len(group.Backups))
+		for _, backup := range group.Backups {
+			idx, err := findByName(KindBackup, st.backups, backup, backupName)
+			if err == nil && idx == -1 {
+				err = fmt.Errorf("backup %q not found", backup)
+			}
+			if err != nil {
+				return nil, changeError(change, err)
+			}
+			backupIDs = append(backupIDs, st.backups[idx].ID)
+		}
+
+		if change.Action == ActionCreate {
+			id, err := s.dbgen.ProvisioningServiceCreateBackupGroup(
+				ctx, dbgen.ProvisioningServiceCreateBackupGroupParams{
+					Name:           group.Name,
+					IsActive:       group.IsActive,
+					CronExpression: group.CronExpression,
+					TimeZone:       group.TimeZone,
+					BackupIds:      backupIDs,
+				},
+			)
+			if err != nil {
+				return nil, changeError(change, err)
+			}
+			changed = append(changed, id)
+			continue
+		}
+
+		err := s.dbgen.ProvisioningServiceUpdateBackupGroup(
+			ctx, dbgen.ProvisioningServiceUpdateBackupGroupParams{
+				IsActive:       group.IsActive,
+				CronExpression: group.CronExpression,
+				TimeZone:       group.TimeZone,
+				BackupIds:      backupIDs,
+				ID:             change.id,
+			},
+		)
+		if err != nil {
+			return nil, changeError(change, err)
+		}
+		changed = append(changed, change.id)
+	}
+
+	return changed, nil
+}
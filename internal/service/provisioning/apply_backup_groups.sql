@@ -0,0 +1,27 @@
+-- name: ProvisioningServiceCreateBackupGroup :one
+INSERT INTO backup_groups (
+  name, is_active, cron_expression, time_zone, backup_ids, is_provisioned
+)
+VALUES (
+  @name, @is_active, @cron_expression, @time_zone, @backup_ids, TRUE
+)
+RETURNING id;
+
+-- name: ProvisioningServiceUpdateBackupGroup :exec
+UPDATE backup_groups
+SET
+  is_active = @is_active,
+  cron_expression = @cron_expression,
+  time_zone = @time_zone,
+  backup_ids = @backup_ids,
+  is_provisioned = TRUE
+WHERE id = @id;
+
+-- name: ProvisioningServiceDeleteBackupGroup :exec
+DELETE FROM backup_groups
+WHERE id = @id AND is_provisioned = TRUE;
+
+-- name: ProvisioningServiceReleaseBackupGroup :exec
+UPDATE backup_groups
+SET is_provisioned = FALSE
+WHERE id = @id;
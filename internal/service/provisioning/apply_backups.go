@@ -0,0 +1,168 @@
+package provisioning
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+// applyBackups creates and updates the declared backups and returns the IDs
+// of the changed ones. The databases and destinations they reference must be
+// already stored.
+func (s *Service) applyBackups(
+	ctx context.Context, cfg Config, plan Plan, st state,
+) ([]uuid.UUID, error) {
+	declared := byName(cfg.Backups, backupConfigName)
+	changed := []uuid.UUID{}
+
+	for _, change := range plan.filter(KindBackup, ActionCreate, ActionUpdate) {
+		backup := declared[change.Name]
+
+		destinationID := uuid.NullUUID{}
+		if !backup.IsLocal {
+			idx, err := findByName(
+				KindDestination, st.destinations, backup.Destination, destinationName,
+			)
+			if err == nil && idx == -1 {
+				err = fmt.Errorf("destination %q not found", backup.Destination)
+			}
+			if err != nil {
+				return nil, changeError(change, err)
+			}
+			destinationID = uuid.NullUUID{UUID: st.destinations[idx].ID, Valid: true}
+		}
+
+		maxPartSizeMb := sql.NullInt32{}
+		if backup.MaxPartSizeMb != nil {
+			maxPartSizeMb = sql.NullInt32{Int32: *backup.MaxPartSizeMb, Valid: true}
+		}
+		compressionLevel := sql.NullInt16{}
+		if backup.CompressionLevel != nil {
+			compressionLevel = sql.NullInt16{Int16: *backup.CompressionLevel, Valid: true}
+		}
+		rpoHours := sql.NullInt16{}
+		if backup.RpoHours != nil {
+			rpoHours = sql.NullInt16{Int16: *backup.RpoHours, Valid: true}
+		}
+
+		if change.Action == ActionCreate {
+			idx, err := findByName(
+				KindDatabase, st.databases, backup.Database, databaseName,
+			)
+			if err == nil && idx == -1 {
+				err = fmt.Errorf("database %q not found", backup.Database)
+			}
+			if err != nil {
+				return nil, changeError(change, err)
+			}
+
+			id, err := s.dbgen.ProvisioningServiceCreateBackup(
+				ctx, dbgen.ProvisioningServiceCreateBackupParams{
+					DatabaseID:             st.databases[idx].ID,
+					DestinationID:          destinationID,
+					IsLocal:                backup.IsLocal,
+					Name:                   backup.Name,
+					CronExpression:         backup.CronExpression,
+					TimeZone:               backup.TimeZone,
+					IsActive:               backup.IsActive,
+					DestDir:                backup.DestDir,
+					RetentionDays:          backup.RetentionDays,
+					OptDataOnly:            backup.OptDataOnly,
+					OptSchemaOnly:          backup.OptSchemaOnly,
+					OptClean:               backup.OptClean,
+					OptIfExists:            backup.OptIfExists,
+					OptCreate:              backup.OptCreate,
+					OptNoComments:          backup.OptNoComments,
+					MaxPartSizeMb:          maxPartSizeMb,
+					CompressionLevel:       compressionLevel,
+					SizeDeviationThreshold: backup.SizeDeviationThreshold,
+					RpoHours:               rpoHours,
+				},
+			)
+			if err != nil {
+				return nil, changeError(change, err)
+			}
+			changed = append(changed, id)
+			continue
+		}
+
+		err := s.dbgen.ProvisioningServiceUpdateBackup(
+			ctx, dbgen.ProvisioningServiceUpdateBackupParams{
+				DestinationID:          destinationID,
+				IsLocal:                backup.IsLocal,
+				CronExpression:         backup.CronExpression,
+				TimeZone:               backup.TimeZone,
+				IsActive:               backup.IsActive,
+				DestDir:                backup.DestDir,
+				RetentionDays:          backup.RetentionDays,
+				OptDataOnly:            backup.OptDataOnly,
+				OptSchemaOnly:          backup.OptSchemaOnly,
+				OptClean:               backup.OptClean,
+				OptIfExists:            backup.OptIfExists,
+				OptCreate:              backup.OptCreate,
+				OptNoComments:          backup.OptNoComments,
+				MaxPartSizeMb:          maxPartSizeMb,
+				CompressionLevel:       compressionLevel,
+				SizeDeviationThreshold: backup.SizeDeviationThreshold,
+				RpoHours:               rpoHours,
+				ID:                     change.id,
+			},
+		)
+		if err != nil {
+			return nil, changeError(change, err)
+		}
+		changed = append(changed, change.id)
+	}
+
+	return changed, nil
+}
+
+// applyRunAfter sets the backup that every created and updated backup runs
+// after. It runs once all the backups are stored because a backup can run
+// after one declared later in the file.
+func (s *Service) applyRunAfter(
+	ctx context.Context, cfg Config, plan Plan, st state,
+) error {
+	declared := byName(cfg.Backups, backupConfigName)
+
+	for _, change := range plan.filter(KindBackup, ActionCreate, ActionUpdate) {
+		backup := declared[change.Name]
+
+		idx, err := findByName(KindBackup, st.backups, backup.Name, backupName)
+		if err == nil && idx == -1 {
+			err = fmt.Errorf("backup %q not found", backup.Name)
+		}
+		if err != nil {
+			return changeError(change, err)
+		}
+
+		runAfterID := uuid.NullUUID{}
+		if backup.RunAfter != "" {
+			runAfterIdx, err := findByName(
+				KindBackup, st.backups, backup.RunAfter, backupName,
+			)
+			if err == nil && runAfterIdx == -1 {
+				err = fmt.Errorf("backup %q not found", backup.RunAfter)
+			}
+			if err != nil {
+				return changeError(change, err)
+			}
+			runAfterID = uuid.NullUUID{UUID: st.backups[runAfterIdx].ID, Valid: true}
+		}
+
+		err = s.dbgen.ProvisioningServiceSetBackupRunAfter(
+			ctx, dbgen.ProvisioningServiceSetBackupRunAfterParams{
+				RunAfterBackupID: runAfterID,
+				ID:               st.backups[idx].ID,
+			},
+		)
+		if err != nil {
+			return changeError(change, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+-- name: ProvisioningServiceCreateBackup :one
+INSERT INTO backups (
+  database_id, destination_id, is_local, name, cron_expression, time_zone,
+  is_active, dest_dir, retention_days, opt_data_only, opt_schema_only,
+  opt_clean, opt_if_exists, opt_create, opt_no_comments,
+  max_part_size_mb, compression_level, size_deviation_threshold, rpo_hours,
+  is_provisioned
+)
+VALUES (
+  @database_id, sqlc.narg('destination_id'), @is_local, @name,
+  @cron_expression, @time_zone, @is_active, @dest_dir, @retention_days,
+  @opt_data_only, @opt_schema_only, @opt_clean, @opt_if_exists, @opt_create,
+  @opt_no_comments, sqlc.narg('max_part_size_mb'),
+  sqlc.narg('compression_level'), @size_deviation_threshold,
+  sqlc.narg('rpo_hours'), TRUE
+)
+RETURNING id;
+
+-- name: ProvisioningServiceUpdateBackup :exec
+UPDATE backups
+SET
+  destination_id = sqlc.narg('destination_id'),
+  is_local = @is_local,
+  cron_expression = @cron_expression,
+  time_zone = @time_zone,
+  is_active = @is_active,
+  dest_dir = @dest_dir,
+  retention_days = @retention_days,
+  opt_data_only = @opt_data_only,
+  opt_schema_only = @opt_schema_only,
+  opt_clean = @opt_clean,
+  opt_if_exists = @opt_if_exists,
+  opt_create = @opt_create,
+  opt_no_comments = @opt_no_comments,
+  max_part_size_mb = sqlc.narg('max_part_size_mb'),
+  compression_level = sqlc.narg('compression_level'),
+  size_deviation_threshold = @size_deviation_threshold,
+  rpo_hours = sqlc.narg('rpo_hours'),
+  is_provisioned = TRUE
+WHERE id = @id;
+
+-- name: ProvisioningServiceDeleteBackup :exec
+DELETE FROM backups
+WHERE id = @id AND is_provisioned = TRUE;
+
+-- name: ProvisioningServiceReleaseBackup :exec
+UPDATE backups
+SET is_provisioned = FALSE
+WHERE id = @id;
+
+-- name: ProvisioningServiceSetBackupRunAfter :exec
+UPDATE backups
+SET run_after_backup_id = sqlc.narg('run_after_backup_id')
+WHERE id = @id;
@@ -0,0 +1,58 @@
+package provisioning
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+// applyDatabases creates and updates the declared databases and returns the
+// IDs of the changed ones.
+func (s *Service) applyDatabases(
+	ctx context.Context, cfg Config, plan Plan,
+) ([]uuid.UUID, error) {
+	declared := byName(cfg.Databases, databaseConfigName)
+	changed := []uuid.UUID{}
+
+	for _, change := range plan.filter(KindDatabase, ActionCreate, ActionUpdate) {
+		db := declared[change.Name]
+
+		if change.Action == ActionCreate {
+			id, err := s.dbgen.ProvisioningServiceCreateDatabase(
+				ctx, dbgen.ProvisioningServiceCreateDatabaseParams{
+					Name:                       db.Name,
+					ConnectionString:           db.ConnectionString.value,
+					EncryptionKey:              s.env.PBW_ENCRYPTION_KEY,
+					PgVersion:                  db.PgVersion,
+					HealthCheckIntervalMinutes: db.HealthCheckIntervalMinutes,
+					HealthFailureThreshold:     db.HealthFailureThreshold,
+					HealthSuccessThreshold:     db.HealthSuccessThreshold,
+				},
+			)
+			if err != nil {
+				return nil, changeError(change, err)
+			}
+			changed = append(changed, id)
+			continue
+		}
+
+		err := s.dbgen.ProvisioningServiceUpdateDatabase(
+			ctx, dbgen.ProvisioningServiceUpdateDatabaseParams{
+				PgVersion:                  db.PgVersion,
+				ConnectionString:           db.ConnectionString.value,
+				EncryptionKey:              s.env.PBW_ENCRYPTION_KEY,
+				HealthCheckIntervalMinutes: db.HealthCheckIntervalMinutes,
+				HealthFailureThreshold:     db.HealthFailureThreshold,
+				HealthSuccessThreshold:     db.HealthSuccessThreshold,
+				ID:                         change.id,
+			},
+		)
+		if err != nil {
+			return nil, changeError(change, err)
+		}
+		changed = append(changed, change.id)
+	}
+
+	return changed, nil
+}
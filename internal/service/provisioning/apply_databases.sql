@@ -0,0 +1,31 @@
+-- name: ProvisioningServiceCreateDatabase :one
+INSERT INTO databases (
+  name, connection_string, pg_version, health_check_interval_minutes,
+  health_failure_threshold, health_success_threshold, is_provisioned
+)
+VALUES (
+  @name, pgp_sym_encrypt(@connection_string, @encryption_key), @pg_version,
+  @health_check_interval_minutes, @health_failure_threshold,
+  @health_success_threshold, TRUE
+)
+RETURNING id;
+
+-- name: ProvisioningServiceUpdateDatabase :exec
+UPDATE databases
+SET
+  pg_version = @pg_version,
+  connection_string = pgp_sym_encrypt(@connection_string, @encryption_key),
+  health_check_interval_minutes = @health_check_interval_minutes,
+  health_failure_threshold = @health_failure_threshold,
+  health_success_threshold = @health_success_threshold,
+  is_provisioned = TRUE
+WHERE id = @id;
+
+-- name: ProvisioningServiceDeleteDatabase :exec
+DELETE FROM databases
+WHERE id = @id AND is_provisioned = TRUE;
+
+-- name: ProvisioningServiceReleaseDatabase :exec
+UPDATE databases
+SET is_provisioned = FALSE
+WHERE id = @id;
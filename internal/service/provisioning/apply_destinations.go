@@ -0,0 +1,68 @@
+package provisioning
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+// applyDestinations creates and updates the declared destinations and
+// returns the IDs of the changed ones.
+func (s *Service) applyDestinations(
+	ctx context.Context, cfg Config, plan Plan,
+) ([]uuid.UUID, error) {
+	declared := byName(cfg.Destinations, destinationConfigName)
+	changed := []uuid.UUID{}
+
+	for _, change := range plan.filter(KindDestination, ActionCreate, ActionUpdate) {
+		dest := declared[change.Name]
+
+		if change.Action == ActionCreate {
+			id, err := s.dbgen.ProvisioningServiceCreateDestination(
+				ctx, dbgen.ProvisioningServiceCreateDestinationParams{
+					Name:                       dest.Name,
+					BucketName:                 dest.BucketName,
+					Region:                     dest.Region,
+					Endpoint:                   dest.Endpoint,
+					ForcePathStyle:             dest.ForcePathStyle,
+					SignatureVersion:           dest.SignatureVersion,
+					AccessKey:                  dest.AccessKey.value,
+					SecretKey:                  dest.SecretKey.value,
+					EncryptionKey:              s.env.PBW_ENCRYPTION_KEY,
+					HealthCheckIntervalMinutes: dest.HealthCheckIntervalMinutes,
+					HealthFailureThreshold:     dest.HealthFailureThreshold,
+					HealthSuccessThreshold:     dest.HealthSuccessThreshold,
+				},
+			)
+			if err != nil {
+				return nil, changeError(change, err)
+			}
+			changed = append(changed, id)
+			continue
+		}
+
+		err := s.dbgen.ProvisioningServiceUpdateDestination(
+			ctx, dbgen.ProvisioningServiceUpdateDestinationParams{
+				BucketName:                 dest.BucketName,
+				Region:                     dest.Region,
+				Endpoint:                   dest.Endpoint,
+				ForcePathStyle:             dest.ForcePathStyle,
+				SignatureVersion:           dest.SignatureVersion,
+				AccessKey:                  dest.AccessKey.value,
+				SecretKey:                  dest.SecretKey.value,
+				EncryptionKey:              s.env.PBW_ENCRYPTION_KEY,
+				HealthCheckIntervalMinutes: dest.HealthCheckIntervalMinutes,
+				HealthFailureThreshold:     dest.HealthFailureThreshold,
+				HealthSuccessThreshold:     dest.HealthSuccessThreshold,
+				ID:                         change.id,
+			},
+		)
+		if err != nil {
+			return nil, changeError(change, err)
+		}
+		changed = append(changed, change.id)
+	}
+
+	return changed, nil
+}
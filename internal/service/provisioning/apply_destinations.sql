@@ -0,0 +1,39 @@
+-- name: ProvisioningServiceCreateDestination :one
+INSERT INTO destinations (
+  name, bucket_name, region, endpoint, force_path_style, signature_version,
+  access_key, secret_key, health_check_interval_minutes,
+  health_failure_threshold, health_success_threshold, is_provisioned
+)
+VALUES (
+  @name, @bucket_name, @region, @endpoint, @force_path_style, @signature_version,
+  pgp_sym_encrypt(@access_key, @encryption_key),
+  pgp_sym_encrypt(@secret_key, @encryption_key),
+  @health_check_interval_minutes, @health_failure_threshold,
+  @health_success_threshold, TRUE
+)
+RETURNING id;
+
+-- name: ProvisioningServiceUpdateDestination :exec
+UPDATE destinations
+SET
+  bucket_name = @bucket_name,
+  region = @region,
+  endpoint = @endpoint,
+  force_path_style = @force_path_style,
+  signature_version = @signature_version,
+  access_key = pgp_sym_encrypt(@access_key, @encryption_key),
+  secret_key = pgp_sym_encrypt(@secret_key, @encryption_key),
+  health_check_interval_minutes = @health_check_interval_minutes,
+  health_failure_threshold = @health_failure_threshold,
+  health_success_threshold = @health_success_threshold,
+  is_provisioned = TRUE
+WHERE id = @id;
+
+-- name: ProvisioningServiceDeleteDestination :exec
+DELETE FROM destinations
+WHERE id = @id AND is_provisioned = TRUE;
+
+-- name: ProvisioningServiceReleaseDestination :exec
+UPDATE destinations
+SET is_provisioned = FALSE
+WHERE id = @id;
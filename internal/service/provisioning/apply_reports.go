@@ -0,0 +1,58 @@
+package provisioning
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+// applyReports creates and updates the declared reports and returns the IDs
+// of the changed ones.
+func (s *Service) applyReports(
+	ctx context.Context, cfg Config, plan Plan,
+) ([]uuid.UUID, error) {
+	declared := byName(cfg.Reports, reportConfigName)
+	changed := []uuid.UUID{}
+
+	for _, change := range plan.filter(KindReport, ActionCreate, ActionUpdate) {
+		report := declared[change.Name]
+
+		if change.Action == ActionCreate {
+			id, err := s.dbgen.ProvisioningServiceCreateReport(
+				ctx, dbgen.ProvisioningServiceCreateReportParams{
+					Name:           report.Name,
+					IsActive:       report.IsActive,
+					Period:         report.Period,
+					CronExpression: report.CronExpression,
+					TimeZone:       report.TimeZone,
+					Channel:        report.Channel,
+					Url:            report.Url,
+				},
+			)
+			if err != nil {
+				return nil, changeError(change, err)
+			}
+			changed = append(changed, id)
+			continue
+		}
+
+		err := s.dbgen.ProvisioningServiceUpdateReport(
+			ctx, dbgen.ProvisioningServiceUpdateReportParams{
+				IsActive:       report.IsActive,
+				Period:         report.Period,
+				CronExpression: report.CronExpression,
+				TimeZone:       report.TimeZone,
+				Channel:        report.Channel,
+				Url:            report.Url,
+				ID:             change.id,
+			},
+		)
+		if err != nil {
+			return nil, changeError(change, err)
+		}
+		changed = append(changed, change.id)
+	}
+
+	return changed, nil
+}
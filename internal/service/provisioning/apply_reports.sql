@@ -0,0 +1,31 @@
+-- name: ProvisioningServiceCreateReport :one
+INSERT INTO reports (
+  name, is_active, period, cron_expression, time_zone, channel, url,
+  is_provisioned
+)
+VALUES (
+  @name, @is_active, @period, @cron_expression, @time_zone, @channel, @url,
+  TRUE
+)
+RETURNING id;
+
+-- name: ProvisioningServiceUpdateReport :exec
+UPDATE reports
+SET
+  is_active = @is_active,
+  period = @period,
+  cron_expression = @cron_expression,
+  time_zone = @time_zone,
+  channel = @channel,
+  url = @url,
+  is_provisioned = TRUE
+WHERE id = @id;
+
+-- name: ProvisioningServiceDeleteReport :exec
+DELETE FROM reports
+WHERE id = @id AND is_provisioned = TRUE;
+
+-- name: ProvisioningServiceReleaseReport :exec
+UPDATE reports
+SET is_provisioned = FALSE
+WHERE id = @id;
@@ -0,0 +1,94 @@
+package provisioning
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+)
+
+// applyWebhooks creates and updates the declared webhooks. The entities they
+// target must be already stored.
+func (s *Service) applyWebhooks(
+	ctx context.Context, cfg Config, plan Plan, st state,
+) error {
+	declared := byName(cfg.Webhooks, webhookConfigName)
+
+	for _, change := range plan.filter(KindWebhook, ActionCreate, ActionUpdate) {
+		webhook := declared[change.Name]
+
+		targetIDs, err := webhookTargetIDs(st, webhook.EventType, webhook.Targets)
+		if err != nil {
+			return changeError(change, err)
+		}
+
+		headers := sql.NullString{}
+		if len(webhook.Headers) > 0 {
+			b, err := json.Marshal(webhook.Headers)
+			if err != nil {
+				return changeError(change, err)
+			}
+			headers = sql.NullString{String: string(b), Valid: true}
+		}
+		body := sql.NullString{String: webhook.Body, Valid: webhook.Body != ""}
+		signingSecret := sql.NullString{
+			String: webhook.signingSecret(), Valid: webhook.signingSecret() != "",
+		}
+		caCertificate := sql.NullString{
+			String: webhook.CACertificate, Valid: webhook.CACertificate != "",
+		}
+		proxyURL := sql.NullString{String: webhook.ProxyURL, Valid: webhook.ProxyURL != ""}
+
+		if change.Action == ActionCreate {
+			_, err := s.dbgen.ProvisioningServiceCreateWebhook(
+				ctx, dbgen.ProvisioningServiceCreateWebhookParams{
+					Name:           webhook.Name,
+					IsActive:       webhook.IsActive,
+					EventType:      webhook.EventType,
+					TargetIds:      targetIDs,
+					Channel:        webhook.Channel,
+					Url:            webhook.Url,
+					Method:         webhook.Method,
+					Headers:        headers,
+					Body:           body,
+					TimeoutSeconds: webhook.TimeoutSeconds,
+					TlsSkipVerify:  webhook.TLSSkipVerify,
+					CaCertificate:  caCertificate,
+					ProxyUrl:       proxyURL,
+					SigningSecret:  signingSecret,
+					EncryptionKey:  s.env.PBW_ENCRYPTION_KEY,
+				},
+			)
+			if err != nil {
+				return changeError(change, err)
+			}
+			continue
+		}
+
+		err = s.dbgen.ProvisioningServiceUpdateWebhook(
+			ctx, dbgen.ProvisioningServiceUpdateWebhookParams{
+				IsActive:       webhook.IsActive,
+				EventType:      webhook.EventType,
+				TargetIds:      targetIDs,
+				Channel:        webhook.Channel,
+				Url:            webhook.Url,
+				Method:         webhook.Method,
+				Headers:        headers,
+				Body:           body,
+				TimeoutSeconds: webhook.TimeoutSeconds,
+				TlsSkipVerify:  webhook.TLSSkipVerify,
+				CaCertificate:  caCertificate,
+				ProxyUrl:       proxyURL,
+				SigningSecret:  signingSecret,
+				EncryptionKey:  s.env.PBW_ENCRYPTION_KEY,
+				ID:             change.id,
+			},
+		)
+		if err != nil {
+			return changeError(change, err)
+		}
+	}
+
+	return nil
+}
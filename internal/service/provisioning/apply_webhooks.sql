@@ -0,0 +1,48 @@
+-- name: ProvisioningServiceCreateWebhook :one
+INSERT INTO webhooks (
+  name, is_active, event_type, target_ids, channel, url, method, headers,
+  body, timeout_seconds, tls_skip_verify, ca_certificate, proxy_url,
+  signing_secret, is_provisioned
+)
+VALUES (
+  @name, @is_active, @event_type, @target_ids, @channel, @url, @method,
+  sqlc.narg('headers'), sqlc.narg('body'), @timeout_seconds, @tls_skip_verify,
+  sqlc.narg('ca_certificate'), sqlc.narg('proxy_url'),
+  CASE
+    WHEN sqlc.narg('signing_secret')::TEXT IS NOT NULL
+    THEN pgp_sym_encrypt(sqlc.narg('signing_secret')::TEXT, sqlc.arg('encryption_key')::TEXT)
+  END,
+  TRUE
+)
+RETURNING id;
+
+-- name: ProvisioningServiceUpdateWebhook :exec
+UPDATE webhooks
+SET
+  is_active = @is_active,
+  event_type = @event_type,
+  target_ids = @target_ids,
+  channel = @channel,
+  url = @url,
+  method = @method,
+  headers = sqlc.narg('headers'),
+  body = sqlc.narg('body'),
+  timeout_seconds = @timeout_seconds,
+  tls_skip_verify = @tls_skip_verify,
+  ca_certificate = sqlc.narg('ca_certificate'),
+  proxy_url = sqlc.narg('proxy_url'),
+  signing_secret = CASE
+    WHEN sqlc.narg('signing_secret')::TEXT IS NOT NULL
+    THEN pgp_sym_encrypt(sqlc.narg('signing_secret')::TEXT, sqlc.arg('encryption_key')::TEXT)
+  END,
+  is_provisioned = TRUE
+WHERE id = @id;
+
+-- name: ProvisioningServiceDeleteWebhook :exec
+DELETE FROM webhooks
+WHERE id = @id AND is_provisioned = TRUE;
+
+-- name: ProvisioningServiceReleaseWebhook :exec
+UPDATE webhooks
+SET is_provisioned = FALSE
+WHERE id = @id;
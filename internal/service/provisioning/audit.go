@@ -0,0 +1,36 @@
+package provisioning
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+)
+
+// auditChange is recorded in the audit log for every applied change, the
+// values are left out because the file may hold secrets.
+type auditChange struct {
+	Provisioning  Action
+	ChangedFields []string
+}
+
+// auditPlan records the applied changes of the plan, the releases are
+// recorded as updates of the entities.
+func (s *Service) auditPlan(ctx context.Context, plan Plan) {
+	for _, change := range plan.Changes {
+		action := string(change.Action)
+		if change.Action == ActionRelease {
+			action = audit.ActionUpdate
+		}
+
+		audit.Record(ctx, s.dbgen, audit.Entry{
+			Action:     action,
+			EntityType: string(change.Kind),
+			EntityID:   change.id,
+			EntityName: change.Name,
+			After: auditChange{
+				Provisioning:  change.Action,
+				ChangedFields: change.Fields,
+			},
+		})
+	}
+}
@@ -0,0 +1,448 @@
+package provisioning
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/integration/postgres"
+	"github.com/eduardolat/pgbackweb/internal/integration/secrets"
+	"github.com/eduardolat/pgbackweb/internal/service/backups"
+	"github.com/eduardolat/pgbackweb/internal/service/reports"
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the declarative configuration file referenced by the
+// PBW_CONFIG_FILE environment variable.
+type Config struct {
+	// Prune deletes the provisioned entities that are no longer declared in
+	// the file. When false they are released and become editable in the UI.
+	Prune        bool                `yaml:"prune" json:"prune"`
+	Databases    []DatabaseConfig    `yaml:"databases" json:"databases"`
+	Destinations []DestinationConfig `yaml:"destinations" json:"destinations"`
+	Backups      []BackupConfig      `yaml:"backups" json:"backups"`
+	BackupGroups []BackupGroupConfig `yaml:"backup_groups" json:"backup_groups"`
+	Webhooks     []WebhookConfig     `yaml:"webhooks" json:"webhooks"`
+	Reports      []ReportConfig      `yaml:"reports" json:"reports"`
+}
+
+// Secret is a sensitive value read from an environment variable or a file,
+// so it is never written in the configuration file itself. Ref stores a
+// secret reference instead, e.g. vault://secret/pg#conn, which is resolved
+// every time the secret is used so the value is not stored at all.
+type Secret struct {
+	Env  string `yaml:"env" json:"env"`
+	File string `yaml:"file" json:"file"`
+	Ref  string `yaml:"ref" json:"ref"`
+
+	value string
+}
+
+// resolve reads the secret from its environment variable or file.
+func (s *Secret) resolve() error {
+	set := 0
+	for _, source := range []string{s.Env, s.File, s.Ref} {
+		if source != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return fmt.Errorf("only one of env, file or ref can be set")
+	}
+
+	switch {
+	case s.Env != "":
+		value, ok := os.LookupEnv(s.Env)
+		if !ok {
+			return fmt.Errorf("environment variable %s is not set", s.Env)
+		}
+		s.value = value
+
+	case s.File != "":
+		content, err := os.ReadFile(s.File)
+		if err != nil {
+			return fmt.Errorf("error reading secret file: %w", err)
+		}
+		s.value = strings.TrimRight(string(content), "\r\n")
+
+	case s.Ref != "":
+		if !secrets.IsReference(s.Ref) {
+			return fmt.Errorf(
+				"ref must start with %s, %s or %s",
+				secrets.PrefixEnv, secrets.PrefixFile, secrets.PrefixVault,
+			)
+		}
+		s.value = s.Ref
+
+	default:
+		return fmt.Errorf("env, file or ref is required")
+	}
+
+	if s.value == "" {
+		return fmt.Errorf("the secret is empty")
+	}
+	return nil
+}
+
+type DatabaseConfig struct {
+	Name             string `yaml:"name" json:"name" validate:"required"`
+	PgVersion        string `yaml:"pg_version" json:"pg_version" validate:"required"`
+	ConnectionString Secret `yaml:"connection_string" json:"connection_string"`
+	// The health check interval defaults to 10 minutes and the thresholds to
+	// 1 check.
+	HealthCheckIntervalMinutes int32 `yaml:"health_check_interval_minutes" json:"health_check_interval_minutes" validate:"omitempty,min=1,max=1440"`
+	HealthFailureThreshold     int32 `yaml:"health_failure_threshold" json:"health_failure_threshold" validate:"omitempty,min=1,max=100"`
+	HealthSuccessThreshold     int32 `yaml:"health_success_threshold" json:"health_success_threshold" validate:"omitempty,min=1,max=100"`
+}
+
+type DestinationConfig struct {
+	Name             string `yaml:"name" json:"name" validate:"required"`
+	BucketName       string `yaml:"bucket_name" json:"bucket_name" validate:"required"`
+	Region           string `yaml:"region" json:"region" validate:"required"`
+	Endpoint         string `yaml:"endpoint" json:"endpoint" validate:"required"`
+	ForcePathStyle   bool   `yaml:"force_path_style" json:"force_path_style"`
+	SignatureVersion string `yaml:"signature_version" json:"signature_version" validate:"omitempty,oneof=v2 v4"`
+	AccessKey        Secret `yaml:"access_key" json:"access_key"`
+	SecretKey        Secret `yaml:"secret_key" json:"secret_key"`
+	// The health check interval defaults to 10 minutes and the thresholds to
+	// 1 check.
+	HealthCheckIntervalMinutes int32 `yaml:"health_check_interval_minutes" json:"health_check_interval_minutes" validate:"omitempty,min=1,max=1440"`
+	HealthFailureThreshold     int32 `yaml:"health_failure_threshold" json:"health_failure_threshold" validate:"omitempty,min=1,max=100"`
+	HealthSuccessThreshold     int32 `yaml:"health_success_threshold" json:"health_success_threshold" validate:"omitempty,min=1,max=100"`
+}
+
+type BackupConfig struct {
+	Name                   string `yaml:"name" json:"name" validate:"required"`
+	Database               string `yaml:"database" json:"database" validate:"required"`
+	IsLocal                bool   `yaml:"is_local" json:"is_local"`
+	Destination            string `yaml:"destination" json:"destination" validate:"required_if=IsLocal false,excluded_if=IsLocal true"`
+	CronExpression         string `yaml:"cron_expression" json:"cron_expression" validate:"required"`
+	TimeZone               string `yaml:"time_zone" json:"time_zone"`
+	IsActive               bool   `yaml:"is_active" json:"is_active"`
+	DestDir                string `yaml:"dest_dir" json:"dest_dir" validate:"required"`
+	RetentionDays          int16  `yaml:"retention_days" json:"retention_days" validate:"min=0"`
+	OptDataOnly            bool   `yaml:"opt_data_only" json:"opt_data_only"`
+	OptSchemaOnly          bool   `yaml:"opt_schema_only" json:"opt_schema_only"`
+	OptClean               bool   `yaml:"opt_clean" json:"opt_clean"`
+	OptIfExists            bool   `yaml:"opt_if_exists" json:"opt_if_exists"`
+	OptCreate              bool   `yaml:"opt_create" json:"opt_create"`
+	OptNoComments          bool   `yaml:"opt_no_comments" json:"opt_no_comments"`
+	MaxPartSizeMb          *int32 `yaml:"max_part_size_mb" json:"max_part_size_mb" validate:"omitempty,min=1"`
+	CompressionLevel       *int16 `yaml:"compression_level" json:"compression_level" validate:"omitempty,min=0,max=9"`
+	SizeDeviationThreshold int16  `yaml:"size_deviation_threshold" json:"size_deviation_threshold" validate:"min=0,max=1000"`
+	RpoHours               *int16 `yaml:"rpo_hours" json:"rpo_hours" validate:"omitempty,min=1,max=8760"`
+	// RunAfter is the name of the backup that this one runs after, its own
+	// cron expression is ignored when set.
+	RunAfter string `yaml:"run_after" json:"run_after"`
+}
+
+type BackupGroupConfig struct {
+	Name           string `yaml:"name" json:"name" validate:"required"`
+	IsActive       bool   `yaml:"is_active" json:"is_active"`
+	CronExpression string `yaml:"cron_expression" json:"cron_expression" validate:"required"`
+	// TimeZone defaults to UTC.
+	TimeZone string `yaml:"time_zone" json:"time_zone"`
+	// Backups are the names of the backups of the group in the order they run.
+	Backups []string `yaml:"backups" json:"backups" validate:"required,gt=0"`
+}
+
+type WebhookConfig struct {
+	Name      string   `yaml:"name" json:"name" validate:"required"`
+	EventType string   `yaml:"event_type" json:"event_type" validate:"required"`
+	Targets   []string `yaml:"targets" json:"targets" validate:"required,gt=0"`
+	IsActive  bool     `yaml:"is_active" json:"is_active"`
+	// Channel defaults to webhook, the chat channels ignore method and body.
+	Channel string `yaml:"channel" json:"channel" validate:"omitempty,oneof=webhook slack discord teams telegram email"`
+	Url     string `yaml:"url" json:"url" validate:"required"`
+	// Method defaults to POST.
+	Method  string            `yaml:"method" json:"method" validate:"omitempty,oneof=GET POST PUT PATCH DELETE"`
+	Headers map[string]string `yaml:"headers" json:"headers"`
+	Body    string            `yaml:"body" json:"body"`
+	// TimeoutSeconds defaults to 30, the CA certificate is PEM encoded.
+	TimeoutSeconds int32  `yaml:"timeout_seconds" json:"timeout_seconds" validate:"omitempty,min=1,max=300"`
+	TLSSkipVerify  bool   `yaml:"tls_skip_verify" json:"tls_skip_verify"`
+	CACertificate  string `yaml:"ca_certificate" json:"ca_certificate"`
+	ProxyURL       string `yaml:"proxy_url" json:"proxy_url"`
+	// SigningSecret is optional, when set the requests are signed with it.
+	SigningSecret *Secret `yaml:"signing_secret" json:"signing_secret"`
+}
+
+type ReportConfig struct {
+	Name     string `yaml:"name" json:"name" validate:"required"`
+	IsActive bool   `yaml:"is_active" json:"is_active"`
+	// Period defaults to daily, the time zone to UTC and the channel to
+	// webhook.
+	Period         string `yaml:"period" json:"period"`
+	CronExpression string `yaml:"cron_expression" json:"cron_expression" validate:"required"`
+	TimeZone       string `yaml:"time_zone" json:"time_zone"`
+	Channel        string `yaml:"channel" json:"channel"`
+	Url            string `yaml:"url" json:"url" validate:"required"`
+}
+
+// signingSecret returns the resolved signing secret, empty if not set.
+func (w WebhookConfig) signingSecret() string {
+	if w.SigningSecret == nil {
+		return ""
+	}
+	return w.SigningSecret.value
+}
+
+// LoadConfig reads, validates and resolves the secrets of the configuration
+// file. Files with the .json extension are parsed as JSON, any other file is
+// parsed as YAML. Unknown fields are rejected to catch typos early.
+func LoadConfig(path string) (Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("error reading configuration file: %w", err)
+	}
+
+	var cfg Config
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(content))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&cfg)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(content))
+		dec.KnownFields(true)
+		err = dec.Decode(&cfg)
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, fmt.Errorf("error parsing configuration file: %w", err)
+	}
+
+	if err := cfg.prepare(); err != nil {
+		return Config{}, fmt.Errorf("invalid configuration file: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// prepare validates the configuration, sets the default values and resolves
+// the secrets.
+func (cfg *Config) prepare() error {
+	if err := checkUniqueNames("database", cfg.Databases, databaseConfigName); err != nil {
+		return err
+	}
+	if err := checkUniqueNames("destination", cfg.Destinations, destinationConfigName); err != nil {
+		return err
+	}
+	if err := checkUniqueNames("backup", cfg.Backups, backupConfigName); err != nil {
+		return err
+	}
+	if err := checkUniqueNames("backup group", cfg.BackupGroups, backupGroupConfigName); err != nil {
+		return err
+	}
+	if err := checkUniqueNames("webhook", cfg.Webhooks, webhookConfigName); err != nil {
+		return err
+	}
+	if err := checkUniqueNames("report", cfg.Reports, reportConfigName); err != nil {
+		return err
+	}
+
+	for i := range cfg.Databases {
+		db := &cfg.Databases[i]
+		if err := validate.Struct(db); err != nil {
+			return fmt.Errorf("database %q: %w", db.Name, err)
+		}
+		if _, err := postgres.New().ParseVersion(db.PgVersion); err != nil {
+			return fmt.Errorf("database %q: %w", db.Name, err)
+		}
+		if err := db.ConnectionString.resolve(); err != nil {
+			return fmt.Errorf("database %q: connection_string: %w", db.Name, err)
+		}
+		setHealthCheckDefaults(
+			&db.HealthCheckIntervalMinutes, &db.HealthFailureThreshold,
+			&db.HealthSuccessThreshold,
+		)
+	}
+
+	for i := range cfg.Destinations {
+		dest := &cfg.Destinations[i]
+		if dest.SignatureVersion == "" {
+			dest.SignatureVersion = "v4"
+		}
+		if err := validate.Struct(dest); err != nil {
+			return fmt.Errorf("destination %q: %w", dest.Name, err)
+		}
+		if err := dest.AccessKey.resolve(); err != nil {
+			return fmt.Errorf("destination %q: access_key: %w", dest.Name, err)
+		}
+		if err := dest.SecretKey.resolve(); err != nil {
+			return fmt.Errorf("destination %q: secret_key: %w", dest.Name, err)
+		}
+		setHealthCheckDefaults(
+			&dest.HealthCheckIntervalMinutes, &dest.HealthFailureThreshold,
+			&dest.HealthSuccessThreshold,
+		)
+	}
+
+	for i := range cfg.Backups {
+		backup := &cfg.Backups[i]
+		if backup.TimeZone == "" {
+			backup.TimeZone = "UTC"
+		}
+		if err := validate.Struct(backup); err != nil {
+			return fmt.Errorf("backup %q: %w", backup.Name, err)
+		}
+		if !validate.CronExpression(backup.CronExpression) {
+			return fmt.Errorf("backup %q: invalid cron expression", backup.Name)
+		}
+		if _, err := time.LoadLocation(backup.TimeZone); err != nil {
+			return fmt.Errorf("backup %q: invalid time zone: %w", backup.Name, err)
+		}
+	}
+
+	for i := range cfg.BackupGroups {
+		group := &cfg.BackupGroups[i]
+		if group.TimeZone == "" {
+			group.TimeZone = "UTC"
+		}
+		if err := validate.Struct(group); err != nil {
+			return fmt.Errorf("backup group %q: %w", group.Name, err)
+		}
+		if !validate.CronExpression(group.CronExpression) {
+			return fmt.Errorf("backup group %q: invalid cron expression", group.Name)
+		}
+		if _, err := time.LoadLocation(group.TimeZone); err != nil {
+			return fmt.Errorf("backup group %q: invalid time zone: %w", group.Name, err)
+		}
+		seen := map[string]bool{}
+		for _, backup := range group.Backups {
+			if seen[backup] {
+				return fmt.Errorf(
+					"backup group %q: a backup can only be once in the group", group.Name,
+				)
+			}
+			seen[backup] = true
+		}
+	}
+
+	runAfter := map[string]string{}
+	for _, backup := range cfg.Backups {
+		if backup.RunAfter != "" {
+			runAfter[backup.Name] = backup.RunAfter
+		}
+	}
+	groups := map[string][]string{}
+	for _, group := range cfg.BackupGroups {
+		groups[group.Name] = group.Backups
+	}
+	if err := backups.ValidateChainNames(runAfter, groups); err != nil {
+		return err
+	}
+
+	for i := range cfg.Webhooks {
+		webhook := &cfg.Webhooks[i]
+		if err := validate.Struct(webhook); err != nil {
+			return fmt.Errorf("webhook %q: %w", webhook.Name, err)
+		}
+		if webhook.Channel == "" {
+			webhook.Channel = webhooks.ChannelWebhook.Value.Key
+		}
+		if webhook.Method == "" {
+			webhook.Method = http.MethodPost
+		}
+		if webhook.TimeoutSeconds == 0 {
+			webhook.TimeoutSeconds = webhooks.DefaultTimeoutSeconds
+		}
+		err := webhooks.ValidateHTTPSettings(
+			webhook.TimeoutSeconds, webhook.CACertificate, webhook.ProxyURL,
+		)
+		if err != nil {
+			return fmt.Errorf("webhook %q: %w", webhook.Name, err)
+		}
+		if webhookTargetKind(webhook.EventType) == "" {
+			return fmt.Errorf(
+				"webhook %q: invalid event type %s", webhook.Name, webhook.EventType,
+			)
+		}
+		if webhook.SigningSecret != nil {
+			// The signing secret is used as is to sign the requests, so it
+			// can't be an external secret reference.
+			if webhook.SigningSecret.Ref != "" {
+				return fmt.Errorf(
+					"webhook %q: signing_secret: ref is not supported, use env or file",
+					webhook.Name,
+				)
+			}
+			if err := webhook.SigningSecret.resolve(); err != nil {
+				return fmt.Errorf("webhook %q: signing_secret: %w", webhook.Name, err)
+			}
+		}
+		headers, err := json.Marshal(webhook.Headers)
+		if err != nil {
+			return fmt.Errorf("webhook %q: %w", webhook.Name, err)
+		}
+		err = webhooks.ValidateTemplates(
+			webhook.Channel, webhook.EventType, webhook.Url, string(headers),
+			webhook.Body,
+		)
+		if err != nil {
+			return fmt.Errorf("webhook %q: %w", webhook.Name, err)
+		}
+	}
+
+	for i := range cfg.Reports {
+		report := &cfg.Reports[i]
+		if report.Period == "" {
+			report.Period = reports.PeriodDaily.Value.Key
+		}
+		if report.TimeZone == "" {
+			report.TimeZone = "UTC"
+		}
+		if report.Channel == "" {
+			report.Channel = webhooks.ChannelWebhook.Value.Key
+		}
+		if err := validate.Struct(report); err != nil {
+			return fmt.Errorf("report %q: %w", report.Name, err)
+		}
+		err := reports.ValidateReport(
+			report.Period, report.CronExpression, report.TimeZone, report.Channel,
+			report.Url,
+		)
+		if err != nil {
+			return fmt.Errorf("report %q: %w", report.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// setHealthCheckDefaults sets the defaults of the health check settings that
+// are not declared, they match the defaults of the database columns.
+func setHealthCheckDefaults(intervalMinutes, failures, successes *int32) {
+	if *intervalMinutes == 0 {
+		*intervalMinutes = 10
+	}
+	if *failures == 0 {
+		*failures = 1
+	}
+	if *successes == 0 {
+		*successes = 1
+	}
+}
+
+// checkUniqueNames returns an error if two items of the same kind have the
+// same name, because names are used to match them with the stored ones.
+func checkUniqueNames[T any](
+	kind string, items []T, nameOf func(T) string,
+) error {
+	seen := map[string]bool{}
+	for _, item := range items {
+		name := nameOf(item)
+		if seen[name] {
+			return fmt.Errorf("%s %q is declared more than once", kind, name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
@@ -0,0 +1,666 @@
+package provisioning
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
+	"github.com/google/uuid"
+)
+
+// diff computes the changes needed to make the stored entities match the
+// configuration. Entities are matched by name and the stored entities that
+// were not provisioned are adopted when the file declares them.
+func diff(cfg Config, st state) (Plan, error) {
+	plan := Plan{Changes: []Change{}}
+
+	steps := []func(Config, state) ([]Change, error){
+		diffDatabases, diffDestinations, diffBackups, diffBackupGroups,
+		diffWebhooks, diffReports,
+	}
+	for _, step := range steps {
+		changes, err := step(cfg, st)
+		if err != nil {
+			return Plan{}, err
+		}
+		plan.Changes = append(plan.Changes, changes...)
+	}
+
+	return plan, nil
+}
+
+func diffDatabases(cfg Config, st state) ([]Change, error) {
+	changes := []Change{}
+	declared := map[string]bool{}
+
+	for _, db := range cfg.Databases {
+		declared[db.Name] = true
+
+		idx, err := findByName(KindDatabase, st.databases, db.Name, databaseName)
+		if err != nil {
+			return nil, err
+		}
+		if idx == -1 {
+			changes = append(changes, newChange(ActionCreate, KindDatabase, db.Name))
+			continue
+		}
+
+		current := st.databases[idx]
+		fields := changedFields{}
+		fields.add("is_provisioned", !current.IsProvisioned)
+		fields.add("pg_version", current.PgVersion != db.PgVersion)
+		fields.add(
+			"connection_string",
+			current.DecryptedConnectionString != db.ConnectionString.value,
+		)
+		fields.add(
+			"health_check_interval_minutes",
+			current.HealthCheckIntervalMinutes != db.HealthCheckIntervalMinutes,
+		)
+		fields.add(
+			"health_failure_threshold",
+			current.HealthFailureThreshold != db.HealthFailureThreshold,
+		)
+		fields.add(
+			"health_success_threshold",
+			current.HealthSuccessThreshold != db.HealthSuccessThreshold,
+		)
+		changes = appendUpdate(changes, KindDatabase, db.Name, current.ID, fields)
+	}
+
+	for _, current := range st.databases {
+		changes = appendRemoval(
+			changes, cfg.Prune, declared, KindDatabase,
+			current.ID, current.Name, current.IsProvisioned,
+		)
+	}
+
+	return changes, nil
+}
+
+func diffDestinations(cfg Config, st state) ([]Change, error) {
+	changes := []Change{}
+	declared := map[string]bool{}
+
+	for _, dest := range cfg.Destinations {
+		declared[dest.Name] = true
+
+		idx, err := findByName(
+			KindDestination, st.destinations, dest.Name, destinationName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if idx == -1 {
+			changes = append(changes, newChange(ActionCreate, KindDestination, dest.Name))
+			continue
+		}
+
+		current := st.destinations[idx]
+		fields := changedFields{}
+		fields.add("is_provisioned", !current.IsProvisioned)
+		fields.add("bucket_name", current.BucketName != dest.BucketName)
+		fields.add("region", current.Region != dest.Region)
+		fields.add("endpoint", current.Endpoint != dest.Endpoint)
+		fields.add("force_path_style", current.ForcePathStyle != dest.ForcePathStyle)
+		fields.add(
+			"signature_version", current.SignatureVersion != dest.SignatureVersion,
+		)
+		fields.add("access_key", current.DecryptedAccessKey != dest.AccessKey.value)
+		fields.add("secret_key", current.DecryptedSecretKey != dest.SecretKey.value)
+		fields.add(
+			"health_check_interval_minutes",
+			current.HealthCheckIntervalMinutes != dest.HealthCheckIntervalMinutes,
+		)
+		fields.add(
+			"health_failure_threshold",
+			current.HealthFailureThreshold != dest.HealthFailureThreshold,
+		)
+		fields.add(
+			"health_success_threshold",
+			current.HealthSuccessThreshold != dest.HealthSuccessThreshold,
+		)
+		changes = appendUpdate(changes, KindDestination, dest.Name, current.ID, fields)
+	}
+
+	for _, current := range st.destinations {
+		changes = appendRemoval(
+			changes, cfg.Prune, declared, KindDestination,
+			current.ID, current.Name, current.IsProvisioned,
+		)
+	}
+
+	return changes, nil
+}
+
+func diffBackups(cfg Config, st state) ([]Change, error) {
+	changes := []Change{}
+	declared := map[string]bool{}
+
+	for _, backup := range cfg.Backups {
+		declared[backup.Name] = true
+
+		isDatabaseFound := isDeclaredOrStored(
+			cfg.Databases, databaseConfigName,
+			st.databases, databaseName, backup.Database,
+		)
+		if !isDatabaseFound {
+			return nil, fmt.Errorf(
+				"backup %q: database %q not found", backup.Name, backup.Database,
+			)
+		}
+		isDestinationFound := backup.IsLocal || isDeclaredOrStored(
+			cfg.Destinations, destinationConfigName,
+			st.destinations, destinationName, backup.Destination,
+		)
+		if !isDestinationFound {
+			return nil, fmt.Errorf(
+				"backup %q: destination %q not found", backup.Name, backup.Destination,
+			)
+		}
+		isRunAfterFound := backup.RunAfter == "" || isDeclaredOrStored(
+			cfg.Backups, backupConfigName, st.backups, backupName, backup.RunAfter,
+		)
+		if !isRunAfterFound {
+			return nil, fmt.Errorf(
+				"backup %q: run after backup %q not found", backup.Name, backup.RunAfter,
+			)
+		}
+
+		idx, err := findByName(KindBackup, st.backups, backup.Name, backupName)
+		if err != nil {
+			return nil, err
+		}
+		if idx == -1 {
+			changes = append(changes, newChange(ActionCreate, KindBackup, backup.Name))
+			continue
+		}
+
+		current := st.backups[idx]
+		if current.DatabaseName != backup.Database {
+			return nil, fmt.Errorf(
+				"backup %q: the database of a backup can't be changed, "+
+					"declare a backup with a new name instead", backup.Name,
+			)
+		}
+
+		fields := changedFields{}
+		fields.add("is_provisioned", !current.IsProvisioned)
+		fields.add("is_local", current.IsLocal != backup.IsLocal)
+		fields.add(
+			"destination", current.DestinationName.String != backup.Destination,
+		)
+		fields.add("cron_expression", current.CronExpression != backup.CronExpression)
+		fields.add("time_zone", current.TimeZone != backup.TimeZone)
+		fields.add("is_active", current.IsActive != backup.IsActive)
+		fields.add("dest_dir", current.DestDir != backup.DestDir)
+		fields.add("retention_days", current.RetentionDays != backup.RetentionDays)
+		fields.add("opt_data_only", current.OptDataOnly != backup.OptDataOnly)
+		fields.add("opt_schema_only", current.OptSchemaOnly != backup.OptSchemaOnly)
+		fields.add("opt_clean", current.OptClean != backup.OptClean)
+		fields.add("opt_if_exists", current.OptIfExists != backup.OptIfExists)
+		fields.add("opt_create", current.OptCreate != backup.OptCreate)
+		fields.add("opt_no_comments", current.OptNoComments != backup.OptNoComments)
+		fields.add("max_part_size_mb", !equalNullable(
+			current.MaxPartSizeMb.Valid, current.MaxPartSizeMb.Int32,
+			backup.MaxPartSizeMb,
+		))
+		fields.add("compression_level", !equalNullable(
+			current.CompressionLevel.Valid, current.CompressionLevel.Int16,
+			backup.CompressionLevel,
+		))
+		fields.add(
+			"size_deviation_threshold",
+			current.SizeDeviationThreshold != backup.SizeDeviationThreshold,
+		)
+		fields.add("rpo_hours", !equalNullable(
+			current.RpoHours.Valid, current.RpoHours.Int16, backup.RpoHours,
+		))
+		fields.add("run_after", current.RunAfterName.String != backup.RunAfter)
+		changes = appendUpdate(changes, KindBackup, backup.Name, current.ID, fields)
+	}
+
+	for _, current := range st.backups {
+		changes = appendRemoval(
+			changes, cfg.Prune, declared, KindBackup,
+			current.ID, current.Name, current.IsProvisioned,
+		)
+	}
+
+	return changes, nil
+}
+
+func diffBackupGroups(cfg Config, st state) ([]Change, error) {
+	changes := []Change{}
+	declared := map[string]bool{}
+
+	for _, group := range cfg.BackupGroups {
+		declared[group.Name] = true
+
+		for _, backup := range group.Backups {
+			isFound := isDeclaredOrStored(
+				cfg.Backups, backupConfigName, st.backups, backupName, backup,
+			)
+			if !isFound {
+				return nil, fmt.Errorf(
+					"backup group %q: backup %q not found", group.Name, backup,
+				)
+			}
+		}
+
+		idx, err := findByName(
+			KindBackupGroup, st.backupGroups, group.Name, backupGroupName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if idx == -1 {
+			changes = append(changes, newChange(ActionCreate, KindBackupGroup, group.Name))
+			continue
+		}
+
+		current := st.backupGroups[idx]
+		fields := changedFields{}
+		fields.add("is_provisioned", !current.IsProvisioned)
+		fields.add("is_active", current.IsActive != group.IsActive)
+		fields.add("cron_expression", current.CronExpression != group.CronExpression)
+		fields.add("time_zone", current.TimeZone != group.TimeZone)
+		fields.add("backups", !slices.Equal(current.BackupNames, group.Backups))
+		changes = appendUpdate(
+			changes, KindBackupGroup, group.Name, current.ID, fields,
+		)
+	}
+
+	for _, current := range st.backupGroups {
+		changes = appendRemoval(
+			changes, cfg.Prune, declared, KindBackupGroup,
+			current.ID, current.Name, current.IsProvisioned,
+		)
+	}
+
+	return changes, nil
+}
+
+func diffWebhooks(cfg Config, st state) ([]Change, error) {
+	changes := []Change{}
+	declared := map[string]bool{}
+
+	for _, webhook := range cfg.Webhooks {
+		declared[webhook.Name] = true
+
+		for _, target := range webhook.Targets {
+			if !isWebhookTargetDeclaredOrStored(cfg, st, webhook.EventType, target) {
+				return nil, fmt.Errorf(
+					"webhook %q: %s %q not found",
+					webhook.Name, webhookTargetKind(webhook.EventType), target,
+				)
+			}
+		}
+
+		idx, err := findByName(KindWebhook, st.webhooks, webhook.Name, webhookName)
+		if err != nil {
+			return nil, err
+		}
+		if idx == -1 {
+			changes = append(changes, newChange(ActionCreate, KindWebhook, webhook.Name))
+			continue
+		}
+
+		current := st.webhooks[idx]
+		currentTargets := webhookTargetNames(st, current.EventType, current.TargetIds)
+
+		fields := changedFields{}
+		fields.add("is_provisioned", !current.IsProvisioned)
+		fields.add("event_type", current.EventType != webhook.EventType)
+		fields.add("targets", !equalSets(currentTargets, webhook.Targets))
+		fields.add("is_active", current.IsActive != webhook.IsActive)
+		fields.add("channel", current.Channel != webhook.Channel)
+		fields.add("url", current.Url != webhook.Url)
+		fields.add("method", current.Method != webhook.Method)
+		fields.add("headers", !equalHeaders(current.Headers.String, webhook.Headers))
+		fields.add("timeout_seconds", current.TimeoutSeconds != webhook.TimeoutSeconds)
+		fields.add("tls_skip_verify", current.TlsSkipVerify != webhook.TLSSkipVerify)
+		fields.add(
+			"ca_certificate",
+			strings.TrimSpace(current.CaCertificate.String) != strings.TrimSpace(webhook.CACertificate),
+		)
+		fields.add("proxy_url", current.ProxyUrl.String != webhook.ProxyURL)
+		fields.add(
+			"body",
+			strings.TrimSpace(current.Body.String) != strings.TrimSpace(webhook.Body),
+		)
+		fields.add(
+			"signing_secret",
+			st.webhookSecrets[current.ID] != webhook.signingSecret(),
+		)
+		changes = appendUpdate(changes, KindWebhook, webhook.Name, current.ID, fields)
+	}
+
+	for _, current := range st.webhooks {
+		changes = appendRemoval(
+			changes, cfg.Prune, declared, KindWebhook,
+			current.ID, current.Name, current.IsProvisioned,
+		)
+	}
+
+	return changes, nil
+}
+
+func diffReports(cfg Config, st state) ([]Change, error) {
+	changes := []Change{}
+	declared := map[string]bool{}
+
+	for _, report := range cfg.Reports {
+		declared[report.Name] = true
+
+		idx, err := findByName(KindReport, st.reports, report.Name, reportName)
+		if err != nil {
+			return nil, err
+		}
+		if idx == -1 {
+			changes = append(changes, newChange(ActionCreate, KindReport, report.Name))
+			continue
+		}
+
+		current := st.reports[idx]
+		fields := changedFields{}
+		fields.add("is_provisioned", !current.IsProvisioned)
+		fields.add("is_active", current.IsActive != report.IsActive)
+		fields.add("period", current.Period != report.Period)
+		fields.add("cron_expression", current.CronExpression != report.CronExpression)
+		fields.add("time_zone", current.TimeZone != report.TimeZone)
+		fields.add("channel", current.Channel != report.Channel)
+		fields.add("url", current.Url != report.Url)
+		changes = appendUpdate(changes, KindReport, report.Name, current.ID, fields)
+	}
+
+	for _, current := range st.reports {
+		changes = appendRemoval(
+			changes, cfg.Prune, declared, KindReport,
+			current.ID, current.Name, current.IsProvisioned,
+		)
+	}
+
+	return changes, nil
+}
+
+func newChange(action Action, kind Kind, name string) Change {
+	return Change{Action: action, Kind: kind, Name: name}
+}
+
+// appendUpdate appends an update change only if some field changed.
+func appendUpdate(
+	changes []Change, kind Kind, name string, id uuid.UUID, fields changedFields,
+) []Change {
+	if len(fields) == 0 {
+		return changes
+	}
+
+	change := newChange(ActionUpdate, kind, name)
+	change.id = id
+	change.Fields = fields
+	return append(changes, change)
+}
+
+// appendRemoval appends the deletion or release of a provisioned entity that
+// is no longer declared. Entities created from the UI are never touched.
+func appendRemoval(
+	changes []Change, prune bool, declared map[string]bool, kind Kind,
+	id uuid.UUID, name string, isProvisioned bool,
+) []Change {
+	if !isProvisioned || declared[name] {
+		return changes
+	}
+
+	action := ActionRelease
+	if prune {
+		action = ActionDelete
+	}
+
+	change := newChange(action, kind, name)
+	change.id = id
+	return append(changes, change)
+}
+
+// findByName returns the index of the stored entity with the given name or
+// -1 if there is none. Backups and webhooks names are not unique, so an
+// error is returned if the name is ambiguous.
+func findByName[T any](
+	kind Kind, items []T, name string, nameOf func(T) string,
+) (int, error) {
+	found := -1
+	for idx, item := range items {
+		if nameOf(item) != name {
+			continue
+		}
+		if found != -1 {
+			return -1, fmt.Errorf(
+				"there are multiple %ss named %q, rename them so the "+
+					"configuration file can match only one", kind, name,
+			)
+		}
+		found = idx
+	}
+	return found, nil
+}
+
+// isDeclaredOrStored returns true if the entity referenced by name is
+// declared in the file or already stored.
+func isDeclaredOrStored[C, S any](
+	declared []C, declaredName func(C) string,
+	stored []S, storedName func(S) string,
+	name string,
+) bool {
+	for _, item := range declared {
+		if declaredName(item) == name {
+			return true
+		}
+	}
+	for _, item := range stored {
+		if storedName(item) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookTargetKind returns the kind of the entities that the webhooks of
+// the given event type target, or an empty kind for unknown event types.
+func webhookTargetKind(eventType string) Kind {
+	switch eventType {
+	case webhooks.EventTypeDatabaseHealthy.Value.Key,
+		webhooks.EventTypeDatabaseUnhealthy.Value.Key,
+		webhooks.EventTypeRestorationStarted.Value.Key,
+		webhooks.EventTypeRestorationSuccess.Value.Key,
+		webhooks.EventTypeRestorationFailed.Value.Key:
+		return KindDatabase
+	case webhooks.EventTypeDestinationHealthy.Value.Key,
+		webhooks.EventTypeDestinationUnhealthy.Value.Key,
+		webhooks.EventTypeDestinationUploadSlow.Value.Key:
+		return KindDestination
+	case webhooks.EventTypeExecutionStarted.Value.Key,
+		webhooks.EventTypeExecutionSuccess.Value.Key,
+		webhooks.EventTypeExecutionFailed.Value.Key,
+		webhooks.EventTypeExecutionSuspicious.Value.Key,
+		webhooks.EventTypeExecutionDeletedByRetention.Value.Key,
+		webhooks.EventTypeBackupStale.Value.Key:
+		return KindBackup
+	default:
+		return ""
+	}
+}
+
+func isWebhookTargetDeclaredOrStored(
+	cfg Config, st state, eventType string, target string,
+) bool {
+	switch webhookTargetKind(eventType) {
+	case KindDatabase:
+		return isDeclaredOrStored(
+			cfg.Databases, databaseConfigName, st.databases, databaseName, target,
+		)
+	case KindDestination:
+		return isDeclaredOrStored(
+			cfg.Destinations, destinationConfigName,
+			st.destinations, destinationName, target,
+		)
+	case KindBackup:
+		return isDeclaredOrStored(
+			cfg.Backups, backupConfigName, st.backups, backupName, target,
+		)
+	default:
+		return false
+	}
+}
+
+// webhookTargetNames returns the names of the stored targets of a webhook.
+func webhookTargetNames(
+	st state, eventType string, targetIDs []uuid.UUID,
+) []string {
+	names := map[uuid.UUID]string{}
+	switch webhookTargetKind(eventType) {
+	case KindDatabase:
+		for _, db := range st.databases {
+			names[db.ID] = db.Name
+		}
+	case KindDestination:
+		for _, dest := range st.destinations {
+			names[dest.ID] = dest.Name
+		}
+	case KindBackup:
+		for _, backup := range st.backups {
+			names[backup.ID] = backup.Name
+		}
+	}
+
+	targets := make([]string, 0, len(targetIDs))
+	for _, id := range targetIDs {
+		if name, ok := names[id]; ok {
+			targets = append(targets, name)
+		}
+	}
+	return targets
+}
+
+// webhookTargetIDs resolves the names of the targets of a webhook to the IDs
+// of the stored entities.
+func webhookTargetIDs(
+	st state, eventType string, targets []string,
+) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(targets))
+	for _, target := range targets {
+		var (
+			idx int
+			err error
+			id  uuid.UUID
+		)
+
+		switch kind := webhookTargetKind(eventType); kind {
+		case KindDatabase:
+			if idx, err = findByName(kind, st.databases, target, databaseName); idx != -1 {
+				id = st.databases[idx].ID
+			}
+		case KindDestination:
+			if idx, err = findByName(kind, st.destinations, target, destinationName); idx != -1 {
+				id = st.destinations[idx].ID
+			}
+		case KindBackup:
+			if idx, err = findByName(kind, st.backups, target, backupName); idx != -1 {
+				id = st.backups[idx].ID
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		if id == uuid.Nil {
+			return nil, fmt.Errorf("webhook target %q not found", target)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// equalNullable compares a nullable stored value with an optional declared
+// one.
+func equalNullable[T comparable](valid bool, stored T, declared *T) bool {
+	if declared == nil {
+		return !valid
+	}
+	return valid && stored == *declared
+}
+
+// equalSets returns true if both slices contain the same values regardless
+// of the order.
+func equalSets(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// equalHeaders compares the stored JSON headers of a webhook with the
+// declared ones.
+func equalHeaders(stored string, declared map[string]string) bool {
+	current := map[string]string{}
+	if strings.TrimSpace(stored) != "" {
+		if err := json.Unmarshal([]byte(stored), &current); err != nil {
+			return false
+		}
+	}
+	if declared == nil {
+		declared = map[string]string{}
+	}
+	return maps.Equal(current, declared)
+}
+
+func databaseName(db dbgen.ProvisioningServiceGetDatabasesRow) string {
+	return db.Name
+}
+
+func destinationName(dest dbgen.ProvisioningServiceGetDestinationsRow) string {
+	return dest.Name
+}
+
+func backupName(backup dbgen.ProvisioningServiceGetBackupsRow) string {
+	return backup.Name
+}
+
+func backupGroupName(group dbgen.ProvisioningServiceGetBackupGroupsRow) string {
+	return group.Name
+}
+
+func webhookName(webhook dbgen.Webhook) string {
+	return webhook.Name
+}
+
+func reportName(report dbgen.Report) string {
+	return report.Name
+}
+
+func databaseConfigName(db DatabaseConfig) string {
+	return db.Name
+}
+
+func destinationConfigName(dest DestinationConfig) string {
+	return dest.Name
+}
+
+func backupConfigName(backup BackupConfig) string {
+	return backup.Name
+}
+
+func backupGroupConfigName(group BackupGroupConfig) string {
+	return group.Name
+}
+
+func webhookConfigName(webhook WebhookConfig) string {
+	return webhook.Name
+}
+
+func reportConfigName(report ReportConfig) string {
+	return report.Name
+}
@@ -0,0 +1,274 @@
+package provisioning
+
+import (
+	"database/sql"
+	"slices"
+	"testing"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	dbID, destID, backupID, webhookID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	groupID, reportID := uuid.New(), uuid.New()
+
+	secret := func(value string) Secret { return Secret{Env: "X", value: value} }
+
+	cfg := Config{
+		Databases: []DatabaseConfig{{
+			Name: "main", PgVersion: "16", ConnectionString: secret("conn"),
+		}},
+		Destinations: []DestinationConfig{{
+			Name: "s3", BucketName: "bucket", Region: "us-east-1",
+			Endpoint: "https://s3", SignatureVersion: "v4",
+			AccessKey: secret("access"), SecretKey: secret("secret"),
+		}},
+		Backups: []BackupConfig{{
+			Name: "daily", Database: "main", Destination: "s3",
+			CronExpression: "0 3 * * *", TimeZone: "UTC", IsActive: true,
+			DestDir: "/main",
+		}},
+		BackupGroups: []BackupGroupConfig{{
+			Name: "nightly", IsActive: true, CronExpression: "0 1 * * *",
+			TimeZone: "UTC", Backups: []string{"daily"},
+		}},
+		Webhooks: []WebhookConfig{{
+			Name: "failures", EventType: "execution_failed",
+			Targets: []string{"daily"}, Url: "https://example.com", Method: "POST",
+			Headers: map[string]string{"Content-Type": "application/json"},
+		}},
+		Reports: []ReportConfig{{
+			Name: "weekly", IsActive: true, Period: "weekly",
+			CronExpression: "0 8 * * 1", TimeZone: "UTC", Channel: "webhook",
+			Url: "https://example.com/report",
+		}},
+	}
+
+	// upToDate is the state that matches cfg.
+	upToDate := func() state {
+		return state{
+			databases: []dbgen.ProvisioningServiceGetDatabasesRow{{
+				ID: dbID, Name: "main", PgVersion: "16", IsProvisioned: true,
+				DecryptedConnectionString: "conn",
+			}},
+			destinations: []dbgen.ProvisioningServiceGetDestinationsRow{{
+				ID: destID, Name: "s3", BucketName: "bucket", Region: "us-east-1",
+				Endpoint: "https://s3", SignatureVersion: "v4", IsProvisioned: true,
+				DecryptedAccessKey: "access", DecryptedSecretKey: "secret",
+			}},
+			backups: []dbgen.ProvisioningServiceGetBackupsRow{{
+				ID: backupID, DatabaseID: dbID,
+				DestinationID: uuid.NullUUID{UUID: destID, Valid: true},
+				Name:          "daily", CronExpression: "0 3 * * *", TimeZone: "UTC",
+				IsActive: true, DestDir: "/main", IsProvisioned: true,
+				DatabaseName:    "main",
+				DestinationName: sql.NullString{String: "s3", Valid: true},
+			}},
+			backupGroups: []dbgen.ProvisioningServiceGetBackupGroupsRow{{
+				ID: groupID, Name: "nightly", IsActive: true,
+				CronExpression: "0 1 * * *", TimeZone: "UTC",
+				BackupIds: []uuid.UUID{backupID}, IsProvisioned: true,
+				BackupNames: []string{"daily"},
+			}},
+			webhooks: []dbgen.Webhook{{
+				ID: webhookID, Name: "failures", EventType: "execution_failed",
+				TargetIds: []uuid.UUID{backupID}, Url: "https://example.com",
+				Method: "POST", IsProvisioned: true,
+				Headers: sql.NullString{
+					String: `{"Content-Type":"application/json"}`, Valid: true,
+				},
+			}},
+			reports: []dbgen.Report{{
+				ID: reportID, Name: "weekly", IsActive: true, Period: "weekly",
+				CronExpression: "0 8 * * 1", TimeZone: "UTC", Channel: "webhook",
+				Url: "https://example.com/report", IsProvisioned: true,
+			}},
+		}
+	}
+
+	t.Run("Create everything", func(t *testing.T) {
+		plan, err := diff(cfg, state{})
+		assert.NoError(t, err)
+		assert.Equal(t, []Change{
+			{Action: ActionCreate, Kind: KindDatabase, Name: "main"},
+			{Action: ActionCreate, Kind: KindDestination, Name: "s3"},
+			{Action: ActionCreate, Kind: KindBackup, Name: "daily"},
+			{Action: ActionCreate, Kind: KindBackupGroup, Name: "nightly"},
+			{Action: ActionCreate, Kind: KindWebhook, Name: "failures"},
+			{Action: ActionCreate, Kind: KindReport, Name: "weekly"},
+		}, plan.Changes)
+	})
+
+	t.Run("Up to date", func(t *testing.T) {
+		plan, err := diff(cfg, upToDate())
+		assert.NoError(t, err)
+		assert.True(t, plan.IsEmpty())
+	})
+
+	t.Run("Update changed fields", func(t *testing.T) {
+		st := upToDate()
+		st.databases[0].DecryptedConnectionString = "old"
+		st.destinations[0].HealthFailureThreshold = 3
+		st.backups[0].CronExpression = "0 4 * * *"
+		st.backups[0].RpoHours = sql.NullInt16{Int16: 24, Valid: true}
+		st.backups[0].RunAfterName = sql.NullString{String: "other", Valid: true}
+		st.backupGroups[0].BackupNames = []string{"daily", "other"}
+		st.webhooks[0].Headers = sql.NullString{}
+		st.webhookSecrets = map[uuid.UUID]string{webhookID: "removed"}
+		st.reports[0].CronExpression = "0 9 * * 1"
+
+		plan, err := diff(cfg, st)
+		assert.NoError(t, err)
+		assert.Equal(t, []Change{
+			{
+				Action: ActionUpdate, Kind: KindDatabase, Name: "main",
+				Fields: []string{"connection_string"}, id: dbID,
+			},
+			{
+				Action: ActionUpdate, Kind: KindDestination, Name: "s3",
+				Fields: []string{"health_failure_threshold"}, id: destID,
+			},
+			{
+				Action: ActionUpdate, Kind: KindBackup, Name: "daily",
+				Fields: []string{"cron_expression", "rpo_hours", "run_after"},
+				id:     backupID,
+			},
+			{
+				Action: ActionUpdate, Kind: KindBackupGroup, Name: "nightly",
+				Fields: []string{"backups"}, id: groupID,
+			},
+			{
+				Action: ActionUpdate, Kind: KindWebhook, Name: "failures",
+				Fields: []string{"headers", "signing_secret"}, id: webhookID,
+			},
+			{
+				Action: ActionUpdate, Kind: KindReport, Name: "weekly",
+				Fields: []string{"cron_expression"}, id: reportID,
+			},
+		}, plan.Changes)
+	})
+
+	t.Run("Adopt entities created from the UI", func(t *testing.T) {
+		st := upToDate()
+		st.databases[0].IsProvisioned = false
+
+		plan, err := diff(cfg, st)
+		assert.NoError(t, err)
+		assert.Equal(t, []Change{{
+			Action: ActionUpdate, Kind: KindDatabase, Name: "main",
+			Fields: []string{"is_provisioned"}, id: dbID,
+		}}, plan.Changes)
+	})
+
+	t.Run("Release or delete undeclared entities", func(t *testing.T) {
+		st := upToDate()
+		oldID, uiID := uuid.New(), uuid.New()
+		st.webhooks = append(st.webhooks,
+			dbgen.Webhook{ID: oldID, Name: "old", IsProvisioned: true},
+			dbgen.Webhook{ID: uiID, Name: "from-ui"},
+		)
+
+		plan, err := diff(cfg, st)
+		assert.NoError(t, err)
+		assert.Equal(t, []Change{
+			{Action: ActionRelease, Kind: KindWebhook, Name: "old", id: oldID},
+		}, plan.Changes)
+
+		pruneCfg := cfg
+		pruneCfg.Prune = true
+		plan, err = diff(pruneCfg, st)
+		assert.NoError(t, err)
+		assert.Equal(t, []Change{
+			{Action: ActionDelete, Kind: KindWebhook, Name: "old", id: oldID},
+		}, plan.Changes)
+	})
+
+	t.Run("Reference stored entities", func(t *testing.T) {
+		st := upToDate()
+		st.databases[0].IsProvisioned = false
+
+		onlyBackups := Config{Backups: cfg.Backups}
+		plan, err := diff(onlyBackups, st)
+		assert.NoError(t, err)
+		assert.Equal(t, []Change{
+			{Action: ActionRelease, Kind: KindDestination, Name: "s3", id: destID},
+			{Action: ActionRelease, Kind: KindBackupGroup, Name: "nightly", id: groupID},
+			{Action: ActionRelease, Kind: KindWebhook, Name: "failures", id: webhookID},
+			{Action: ActionRelease, Kind: KindReport, Name: "weekly", id: reportID},
+		}, plan.Changes)
+	})
+
+	t.Run("Missing references", func(t *testing.T) {
+		_, err := diff(Config{Backups: cfg.Backups}, state{})
+		assert.ErrorContains(t, err, `database "main" not found`)
+
+		_, err = diff(Config{Webhooks: cfg.Webhooks}, state{})
+		assert.ErrorContains(t, err, `backup "daily" not found`)
+
+		_, err = diff(Config{BackupGroups: cfg.BackupGroups}, state{})
+		assert.ErrorContains(t, err, `backup "daily" not found`)
+
+		runAfter := cfg
+		runAfter.Backups = slices.Clone(cfg.Backups)
+		runAfter.Backups[0].RunAfter = "hourly"
+		_, err = diff(runAfter, upToDate())
+		assert.ErrorContains(t, err, `run after backup "hourly" not found`)
+	})
+
+	t.Run("Ambiguous names", func(t *testing.T) {
+		st := upToDate()
+		st.backups = append(st.backups, st.backups[0])
+		st.backups[1].ID = uuid.New()
+
+		_, err := diff(cfg, st)
+		assert.ErrorContains(t, err, `multiple backups named "daily"`)
+	})
+
+	t.Run("Backup database can't change", func(t *testing.T) {
+		st := upToDate()
+		st.backups[0].DatabaseName = "other"
+
+		_, err := diff(cfg, st)
+		assert.ErrorContains(t, err, "can't be changed")
+	})
+}
+
+func TestPlanString(t *testing.T) {
+	assert.Equal(t, "no changes, everything is up to date\n", Plan{}.String())
+
+	plan := Plan{Changes: []Change{
+		{Action: ActionCreate, Kind: KindDatabase, Name: "main"},
+		{
+			Action: ActionUpdate, Kind: KindBackup, Name: "daily",
+			Fields: []string{"cron_expression", "is_active"},
+		},
+		{Action: ActionDelete, Kind: KindWebhook, Name: "old"},
+		{Action: ActionRelease, Kind: KindDestination, Name: "s3"},
+	}}
+	assert.Equal(t, ""+
+		"+ create database \"main\"\n"+
+		"~ update backup \"daily\" (cron_expression, is_active)\n"+
+		"- delete webhook \"old\"\n"+
+		"! release destination \"s3\"\n",
+		plan.String(),
+	)
+}
+
+func TestEqualHeaders(t *testing.T) {
+	assert.True(t, equalHeaders("", nil))
+	assert.True(t, equalHeaders("{}", map[string]string{}))
+	assert.True(t, equalHeaders(`{"a":"1","b":"2"}`, map[string]string{"b": "2", "a": "1"}))
+	assert.False(t, equalHeaders(`{"a":"1"}`, nil))
+	assert.False(t, equalHeaders("not json", nil))
+}
+
+func TestEqualNullable(t *testing.T) {
+	v := int16(5)
+	assert.True(t, equalNullable(false, int16(0), nil))
+	assert.True(t, equalNullable(true, int16(5), &v))
+	assert.False(t, equalNullable(true, int16(5), nil))
+	assert.False(t, equalNullable(false, int16(0), &v))
+	assert.False(t, equalNullable(true, int16(4), &v))
+}
@@ -0,0 +1,74 @@
+package provisioning
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+// state is a snapshot of the stored entities that can be provisioned.
+type state struct {
+	databases    []dbgen.ProvisioningServiceGetDatabasesRow
+	destinations []dbgen.ProvisioningServiceGetDestinationsRow
+	backups      []dbgen.ProvisioningServiceGetBackupsRow
+	backupGroups []dbgen.ProvisioningServiceGetBackupGroupsRow
+	webhooks     []dbgen.Webhook
+	reports      []dbgen.Report
+	// webhookSecrets are the decrypted signing secrets by webhook ID.
+	webhookSecrets map[uuid.UUID]string
+}
+
+func (s *Service) getState(ctx context.Context) (state, error) {
+	var (
+		st  state
+		err error
+	)
+
+	st.databases, err = s.dbgen.ProvisioningServiceGetDatabases(
+		ctx, s.env.PBW_ENCRYPTION_KEY,
+	)
+	if err != nil {
+		return state{}, err
+	}
+
+	st.destinations, err = s.dbgen.ProvisioningServiceGetDestinations(
+		ctx, s.env.PBW_ENCRYPTION_KEY,
+	)
+	if err != nil {
+		return state{}, err
+	}
+
+	st.backups, err = s.dbgen.ProvisioningServiceGetBackups(ctx)
+	if err != nil {
+		return state{}, err
+	}
+
+	st.backupGroups, err = s.dbgen.ProvisioningServiceGetBackupGroups(ctx)
+	if err != nil {
+		return state{}, err
+	}
+
+	st.webhooks, err = s.dbgen.ProvisioningServiceGetWebhooks(ctx)
+	if err != nil {
+		return state{}, err
+	}
+
+	st.reports, err = s.dbgen.ProvisioningServiceGetReports(ctx)
+	if err != nil {
+		return state{}, err
+	}
+
+	secrets, err := s.dbgen.ProvisioningServiceGetWebhookSigningSecrets(
+		ctx, s.env.PBW_ENCRYPTION_KEY,
+	)
+	if err != nil {
+		return state{}, err
+	}
+	st.webhookSecrets = map[uuid.UUID]string{}
+	for _, secret := range secrets {
+		st.webhookSecrets[secret.ID] = secret.DecryptedSigningSecret
+	}
+
+	return st, nil
+}
@@ -0,0 +1,69 @@
+-- name: ProvisioningServiceGetDatabases :many
+SELECT
+  id,
+  name,
+  pg_version,
+  health_check_interval_minutes,
+  health_failure_threshold,
+  health_success_threshold,
+  is_provisioned,
+  pgp_sym_decrypt(connection_string, @encryption_key) AS decrypted_connection_string
+FROM databases
+ORDER BY created_at;
+
+-- name: ProvisioningServiceGetDestinations :many
+SELECT
+  id,
+  name,
+  bucket_name,
+  region,
+  endpoint,
+  force_path_style,
+  signature_version,
+  health_check_interval_minutes,
+  health_failure_threshold,
+  health_success_threshold,
+  is_provisioned,
+  pgp_sym_decrypt(access_key, @encryption_key) AS decrypted_access_key,
+  pgp_sym_decrypt(secret_key, @encryption_key) AS decrypted_secret_key
+FROM destinations
+ORDER BY created_at;
+
+-- name: ProvisioningServiceGetBackups :many
+SELECT
+  backups.*,
+  databases.name AS database_name,
+  destinations.name AS destination_name,
+  run_after.name AS run_after_name
+FROM backups
+INNER JOIN databases ON backups.database_id = databases.id
+LEFT JOIN destinations ON backups.destination_id = destinations.id
+LEFT JOIN backups AS run_after ON backups.run_after_backup_id = run_after.id
+ORDER BY backups.created_at;
+
+-- name: ProvisioningServiceGetBackupGroups :many
+SELECT
+  backup_groups.*,
+  ARRAY(
+    SELECT backups.name
+    FROM unnest(backup_groups.backup_ids) WITH ORDINALITY AS member(id, position)
+    INNER JOIN backups ON backups.id = member.id
+    ORDER BY member.position
+  )::TEXT[] AS backup_names
+FROM backup_groups
+ORDER BY backup_groups.created_at;
+
+-- name: ProvisioningServiceGetWebhooks :many
+SELECT * FROM webhooks
+ORDER BY created_at;
+
+-- name: ProvisioningServiceGetReports :many
+SELECT * FROM reports
+ORDER BY created_at;
+
+-- name: ProvisioningServiceGetWebhookSigningSecrets :many
+SELECT
+  id,
+  pgp_sym_decrypt(signing_secret, @encryption_key) AS decrypted_signing_secret
+FROM webhooks
+WHERE signing_secret IS NOT NULL;
@@ -0,0 +1,108 @@
+package provisioning
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+	// ActionRelease marks a provisioned entity that is no longer declared in
+	// the file as editable again, it is used instead of deleting it when the
+	// configuration doesn't enable pruning.
+	ActionRelease Action = "release"
+)
+
+type Kind string
+
+const (
+	KindDatabase    Kind = "database"
+	KindDestination Kind = "destination"
+	KindBackup      Kind = "backup"
+	KindBackupGroup Kind = "backup_group"
+	KindWebhook     Kind = "webhook"
+	KindReport      Kind = "report"
+)
+
+// Change is a single operation needed to make the stored entities match the
+// configuration file.
+type Change struct {
+	Action Action `json:"action"`
+	Kind   Kind   `json:"kind"`
+	Name   string `json:"name"`
+	// Fields are the changed fields of updates. Secrets are listed by name
+	// only so they never leak in the diff.
+	Fields []string `json:"fields,omitempty"`
+
+	// id is the stored entity, it is empty for creations.
+	id uuid.UUID
+}
+
+// Plan is the list of changes of a reconciliation.
+type Plan struct {
+	Changes []Change `json:"changes"`
+}
+
+// IsEmpty returns true when the stored entities already match the file.
+func (p Plan) IsEmpty() bool {
+	return len(p.Changes) == 0
+}
+
+// String returns the plan as a human readable diff, one change per line.
+func (p Plan) String() string {
+	if p.IsEmpty() {
+		return "no changes, everything is up to date\n"
+	}
+
+	symbols := map[Action]string{
+		ActionCreate:  "+",
+		ActionUpdate:  "~",
+		ActionDelete:  "-",
+		ActionRelease: "!",
+	}
+
+	var sb strings.Builder
+	for _, change := range p.Changes {
+		fmt.Fprintf(
+			&sb, "%s %s %s %q", symbols[change.Action], change.Action,
+			change.Kind, change.Name,
+		)
+		if len(change.Fields) > 0 {
+			fmt.Fprintf(&sb, " (%s)", strings.Join(change.Fields, ", "))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// filter returns the changes of the given kind and actions.
+func (p Plan) filter(kind Kind, actions ...Action) []Change {
+	changes := []Change{}
+	for _, change := range p.Changes {
+		if change.Kind != kind {
+			continue
+		}
+		for _, action := range actions {
+			if change.Action == action {
+				changes = append(changes, change)
+				break
+			}
+		}
+	}
+	return changes
+}
+
+// changedFields collects the names of the fields that differ in an update.
+type changedFields []string
+
+func (f *changedFields) add(name string, changed bool) {
+	if changed {
+		*f = append(*f, name)
+	}
+}
@@ -0,0 +1,47 @@
+package provisioning
+
+import (
+	"sync"
+
+	"github.com/eduardolat/pgbackweb/internal/config"
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/backups"
+	"github.com/eduardolat/pgbackweb/internal/service/databases"
+	"github.com/eduardolat/pgbackweb/internal/service/destinations"
+	"github.com/eduardolat/pgbackweb/internal/service/reports"
+)
+
+type Service struct {
+	env                 config.Env
+	dbgen               *dbgen.Queries
+	databasesService    *databases.Service
+	destinationsService *destinations.Service
+	backupsService      *backups.Service
+	reportsService      *reports.Service
+
+	// mu prevents concurrent reconciliations, e.g. a reload requested while
+	// the startup one is still running.
+	mu sync.Mutex
+}
+
+func New(
+	env config.Env, dbgen *dbgen.Queries,
+	databasesService *databases.Service,
+	destinationsService *destinations.Service,
+	backupsService *backups.Service,
+	reportsService *reports.Service,
+) *Service {
+	return &Service{
+		env:                 env,
+		dbgen:               dbgen,
+		databasesService:    databasesService,
+		destinationsService: destinationsService,
+		backupsService:      backupsService,
+		reportsService:      reportsService,
+	}
+}
+
+// IsEnabled returns true when a configuration file is set.
+func (s *Service) IsEnabled() bool {
+	return s.env.PBW_CONFIG_FILE != ""
+}
@@ -0,0 +1,52 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/logger"
+)
+
+// Reconcile makes the stored databases, destinations, backups, backup groups,
+// webhooks and reports match the configuration file and returns the plan with
+// the changes. When dryRun is true the plan is only computed, so it can be
+// used as a diff.
+func (s *Service) Reconcile(ctx context.Context, dryRun bool) (Plan, error) {
+	if !s.IsEnabled() {
+		return Plan{}, fmt.Errorf(
+			"configuration file provisioning is disabled, set PBW_CONFIG_FILE to enable it",
+		)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cfg, err := LoadConfig(s.env.PBW_CONFIG_FILE)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	st, err := s.getState(ctx)
+	if err != nil {
+		return Plan{}, fmt.Errorf("error getting the current state: %w", err)
+	}
+
+	plan, err := diff(cfg, st)
+	if err != nil {
+		return Plan{}, fmt.Errorf("invalid configuration file: %w", err)
+	}
+
+	if dryRun || plan.IsEmpty() {
+		return plan, nil
+	}
+
+	if err := s.apply(ctx, cfg, plan, st); err != nil {
+		return plan, err
+	}
+
+	logger.Info("configuration file reconciled", logger.KV{
+		"file":    s.env.PBW_CONFIG_FILE,
+		"changes": len(plan.Changes),
+	})
+	return plan, nil
+}
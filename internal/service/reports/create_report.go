@@ -0,0 +1,44 @@
+package reports
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
+)
+
+func (s *Service) CreateReport(
+	ctx context.Context, params dbgen.ReportsServiceCreateReportParams,
+) (dbgen.Report, error) {
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return dbgen.Report{}, err
+	}
+
+	err := ValidateReport(
+		params.Period, params.CronExpression, params.TimeZone, params.Channel,
+		params.Url,
+	)
+	if err != nil {
+		return dbgen.Report{}, err
+	}
+
+	if !params.WorkspaceID.Valid {
+		params.WorkspaceID = workspaces.FromContext(ctx)
+	}
+	report, err := s.dbgen.ReportsServiceCreateReport(ctx, params)
+	if err != nil {
+		return report, err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionCreate,
+		EntityType: audit.EntityReport,
+		EntityID:   report.ID,
+		EntityName: report.Name,
+		After:      report,
+	})
+
+	return report, s.refreshJob(report)
+}
@@ -0,0 +1,8 @@
+-- name: ReportsServiceCreateReport :one
+INSERT INTO reports (
+  name, is_active, period, cron_expression, time_zone, channel, url,
+  workspace_id
+) VALUES (
+  @name, @is_active, @period, @cron_expression, @time_zone, @channel, @url,
+  COALESCE(sqlc.narg('workspace_id')::UUID, pbw_default_workspace_id())
+) RETURNING *;
@@ -0,0 +1,37 @@
+package reports
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/google/uuid"
+)
+
+func (s *Service) DeleteReport(ctx context.Context, id uuid.UUID) error {
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return err
+	}
+	if err := s.ensureNotProvisioned(ctx, id); err != nil {
+		return err
+	}
+
+	report, err := s.GetReport(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.dbgen.ReportsServiceDeleteReport(ctx, id); err != nil {
+		return err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionDelete,
+		EntityType: audit.EntityReport,
+		EntityID:   report.ID,
+		EntityName: report.Name,
+		Before:     report,
+	})
+
+	return s.jobRemove(id)
+}
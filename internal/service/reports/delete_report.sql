@@ -0,0 +1,2 @@
+-- name: ReportsServiceDeleteReport :exec
+DELETE FROM reports WHERE id = @report_id;
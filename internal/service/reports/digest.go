@@ -0,0 +1,344 @@
+package reports
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/restorations"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/google/uuid"
+)
+
+// largestGrowthLimit is how many backups the largest growth of a digest
+// lists.
+const largestGrowthLimit = 5
+
+// Digest is the backup activity of the workspace of a report in its period,
+// it is the JSON body of the reports sent with the webhook channel.
+type Digest struct {
+	Report                string              `json:"report"`
+	Period                string              `json:"period"`
+	From                  time.Time           `json:"from"`
+	To                    time.Time           `json:"to"`
+	Totals                Totals              `json:"totals"`
+	Backups               []BackupActivity    `json:"backups"`
+	LargestGrowth         []BackupActivity    `json:"largest_growth"`
+	StaleBackups          []string            `json:"stale_backups"`
+	UnhealthyDatabases    []UnhealthyTarget   `json:"unhealthy_databases"`
+	UnhealthyDestinations []UnhealthyTarget   `json:"unhealthy_destinations"`
+	Restorations          []RestorationRecord `json:"restorations"`
+	DashboardURL          string              `json:"dashboard_url,omitempty"`
+}
+
+// Totals are the counts of the whole workspace, SizeStored is the size in
+// bytes of the files of the successful executions that are still stored.
+type Totals struct {
+	Executions         int32 `json:"executions"`
+	Success            int32 `json:"success"`
+	Failed             int32 `json:"failed"`
+	SizeStored         int64 `json:"size_stored"`
+	Restorations       int32 `json:"restorations"`
+	RestorationsFailed int32 `json:"restorations_failed"`
+}
+
+// BackupActivity are the executions of a backup in the period. Growth is how
+// many bytes the latest file of the period grew compared to the latest file
+// before it, zero when there is nothing to compare.
+type BackupActivity struct {
+	Name       string `json:"name"`
+	Database   string `json:"database"`
+	Success    int32  `json:"success"`
+	Failed     int32  `json:"failed"`
+	SizeStored int64  `json:"size_stored"`
+	Growth     int64  `json:"growth"`
+}
+
+// UnhealthyTarget is a database or destination whose latest health check
+// failed.
+type UnhealthyTarget struct {
+	Name       string     `json:"name"`
+	Error      string     `json:"error"`
+	LastTestAt *time.Time `json:"last_test_at"`
+}
+
+// RestorationRecord is a restoration started in the period, Database is
+// empty when it was restored to a connection string.
+type RestorationRecord struct {
+	Backup    string    `json:"backup"`
+	Database  string    `json:"database"`
+	Status    string    `json:"status"`
+	Message   string    `json:"message"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// digestData are the rows of the workspace of a report that its digest is
+// built from, read with the same queries of the summary page.
+type digestData struct {
+	executionsQty   dbgen.ExecutionsServiceGetExecutionsQtyRow
+	restorationsQty dbgen.RestorationsServiceGetRestorationsQtyRow
+	backups         []dbgen.Backup
+	// executions are the executions of every backup, newest first.
+	executions   map[uuid.UUID][]dbgen.Execution
+	databases    []dbgen.DatabasesServiceGetAllDatabasesRow
+	destinations []dbgen.DestinationsServiceGetAllDestinationsRow
+	staleBackups []dbgen.Backup
+	// restorations are the restorations started in the period, newest first.
+	restorations []dbgen.RestorationsServicePaginateRestorationsRow
+}
+
+// GetDigest returns the digest of the report for the period that ends now.
+func (s *Service) GetDigest(
+	ctx context.Context, report dbgen.Report, now time.Time,
+) (Digest, error) {
+	ctx = workspaces.WithWorkspace(ctx, report.WorkspaceID)
+	since := now.Add(-periodDuration(report.Period))
+
+	var (
+		data digestData
+		err  error
+	)
+
+	data.executionsQty, err = s.executionsService.GetExecutionsQtySince(ctx, since)
+	if err != nil {
+		return Digest{}, fmt.Errorf("error counting the executions: %w", err)
+	}
+
+	data.restorationsQty, err = s.restorationsService.GetRestorationsQtySince(
+		ctx, since,
+	)
+	if err != nil {
+		return Digest{}, fmt.Errorf("error counting the restorations: %w", err)
+	}
+
+	data.backups, err = s.backupsService.GetAllBackups(ctx)
+	if err != nil {
+		return Digest{}, fmt.Errorf("error getting the backups: %w", err)
+	}
+
+	data.executions = make(map[uuid.UUID][]dbgen.Execution, len(data.backups))
+	for _, backup := range data.backups {
+		executions, err := s.executionsService.ListBackupExecutions(ctx, backup.ID)
+		if err != nil {
+			return Digest{}, fmt.Errorf(
+				"error getting the executions of backup %s: %w", backup.Name, err,
+			)
+		}
+		data.executions[backup.ID] = executions
+	}
+
+	data.databases, err = s.databasesService.GetAllDatabases(ctx)
+	if err != nil {
+		return Digest{}, fmt.Errorf("error getting the databases: %w", err)
+	}
+
+	data.destinations, err = s.destinationsService.GetAllDestinations(ctx)
+	if err != nil {
+		return Digest{}, fmt.Errorf("error getting the destinations: %w", err)
+	}
+
+	data.staleBackups, err = s.backupsService.GetStaleBackups(ctx)
+	if err != nil {
+		return Digest{}, fmt.Errorf("error getting the stale backups: %w", err)
+	}
+
+	data.restorations, err = s.getRestorationsSince(ctx, since)
+	if err != nil {
+		return Digest{}, fmt.Errorf("error getting the restorations: %w", err)
+	}
+
+	digest := newDigest(report, since, now, data)
+	if s.env.PBW_PUBLIC_URL != "" {
+		digest.DashboardURL = strings.TrimSuffix(s.env.PBW_PUBLIC_URL, "/") +
+			pathutil.BuildPath("/dashboard")
+	}
+
+	return digest, nil
+}
+
+// getRestorationsSince returns the restorations of the workspace of ctx
+// started since the given time, newest first.
+func (s *Service) getRestorationsSince(
+	ctx context.Context, since time.Time,
+) ([]dbgen.RestorationsServicePaginateRestorationsRow, error) {
+	records := []dbgen.RestorationsServicePaginateRestorationsRow{}
+	for page := 1; ; page++ {
+		pagination, rows, err := s.restorationsService.PaginateRestorations(
+			ctx, restorations.PaginateRestorationsParams{Page: page, Limit: 100},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			if row.StartedAt.Before(since) {
+				return records, nil
+			}
+			records = append(records, row)
+		}
+
+		if !pagination.HasNextPage {
+			return records, nil
+		}
+	}
+}
+
+// newDigest builds the digest of the report from the rows of its workspace.
+func newDigest(
+	report dbgen.Report, from, to time.Time, data digestData,
+) Digest {
+	digest := Digest{
+		Report: report.Name,
+		Period: report.Period,
+		From:   from,
+		To:     to,
+		Totals: Totals{
+			Executions:         int32(data.executionsQty.All),
+			Success:            data.executionsQty.Success,
+			Failed:             data.executionsQty.Failed,
+			Restorations:       int32(data.restorationsQty.All),
+			RestorationsFailed: data.restorationsQty.Failed,
+		},
+		Backups:               []BackupActivity{},
+		LargestGrowth:         []BackupActivity{},
+		StaleBackups:          []string{},
+		UnhealthyDatabases:    []UnhealthyTarget{},
+		UnhealthyDestinations: []UnhealthyTarget{},
+		Restorations:          []RestorationRecord{},
+	}
+
+	databaseNames := make(map[uuid.UUID]string, len(data.databases))
+	for _, db := range data.databases {
+		databaseNames[db.ID] = db.Name
+	}
+
+	for _, backup := range data.backups {
+		activity := newBackupActivity(from, data.executions[backup.ID])
+		activity.Name = backup.Name
+		activity.Database = databaseNames[backup.DatabaseID]
+
+		digest.Backups = append(digest.Backups, activity)
+		digest.Totals.SizeStored += activity.SizeStored
+	}
+	slices.SortStableFunc(digest.Backups, func(a, b BackupActivity) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, activity := range digest.Backups {
+		if activity.Growth > 0 {
+			digest.LargestGrowth = append(digest.LargestGrowth, activity)
+		}
+	}
+	slices.SortStableFunc(digest.LargestGrowth, func(a, b BackupActivity) int {
+		switch {
+		case a.Growth > b.Growth:
+			return -1
+		case a.Growth < b.Growth:
+			return 1
+		}
+		return 0
+	})
+	if len(digest.LargestGrowth) > largestGrowthLimit {
+		digest.LargestGrowth = digest.LargestGrowth[:largestGrowthLimit]
+	}
+
+	for _, backup := range data.staleBackups {
+		digest.StaleBackups = append(digest.StaleBackups, backup.Name)
+	}
+
+	for _, db := range data.databases {
+		if isUnhealthy(db.TestOk) {
+			digest.UnhealthyDatabases = append(
+				digest.UnhealthyDatabases,
+				newUnhealthyTarget(db.Name, db.TestError, db.LastTestAt),
+			)
+		}
+	}
+	for _, dest := range data.destinations {
+		if isUnhealthy(dest.TestOk) {
+			digest.UnhealthyDestinations = append(
+				digest.UnhealthyDestinations,
+				newUnhealthyTarget(dest.Name, dest.TestError, dest.LastTestAt),
+			)
+		}
+	}
+	sortTargets := func(a, b UnhealthyTarget) int {
+		return strings.Compare(a.Name, b.Name)
+	}
+	slices.SortStableFunc(digest.UnhealthyDatabases, sortTargets)
+	slices.SortStableFunc(digest.UnhealthyDestinations, sortTargets)
+
+	for _, row := range data.restorations {
+		digest.Restorations = append(digest.Restorations, RestorationRecord{
+			Backup:    row.BackupName,
+			Database:  row.DatabaseName.String,
+			Status:    row.Status,
+			Message:   row.Message.String,
+			StartedAt: row.StartedAt,
+		})
+	}
+
+	return digest
+}
+
+// newBackupActivity counts the executions of a backup started since the
+// given time and compares its latest file with the latest one before it.
+// The stored size includes the files of the older executions that are
+// still stored.
+func newBackupActivity(
+	since time.Time, executions []dbgen.Execution,
+) BackupActivity {
+	activity := BackupActivity{}
+	var last, previous *dbgen.Execution
+
+	for i, execution := range executions {
+		inPeriod := !execution.StartedAt.Before(since)
+		if inPeriod && execution.Status == "success" {
+			activity.Success++
+		}
+		if inPeriod && execution.Status == "failed" {
+			activity.Failed++
+		}
+		if execution.Status == "success" && execution.FileSize.Valid {
+			activity.SizeStored += execution.FileSize.Int64
+		}
+
+		hasFile := execution.FileSize.Valid &&
+			(execution.Status == "success" || execution.Status == "deleted")
+		if !hasFile {
+			continue
+		}
+		switch {
+		case inPeriod && (last == nil || execution.StartedAt.After(last.StartedAt)):
+			last = &executions[i]
+		case !inPeriod && (previous == nil || execution.StartedAt.After(previous.StartedAt)):
+			previous = &executions[i]
+		}
+	}
+
+	if last != nil && previous != nil {
+		activity.Growth = last.FileSize.Int64 - previous.FileSize.Int64
+	}
+	return activity
+}
+
+// isUnhealthy reports whether the latest health check failed, a database or
+// destination that was never checked is not unhealthy.
+func isUnhealthy(testOk sql.NullBool) bool {
+	return testOk.Valid && !testOk.Bool
+}
+
+func newUnhealthyTarget(
+	name string, testError sql.NullString, lastTestAt sql.NullTime,
+) UnhealthyTarget {
+	target := UnhealthyTarget{Name: name, Error: testError.String}
+	if lastTestAt.Valid {
+		target.LastTestAt = &lastTestAt.Time
+	}
+	return target
+}
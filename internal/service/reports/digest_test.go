@@ -0,0 +1,207 @@
+package reports
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDigest(t *testing.T) {
+	to := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	from := to.Add(-24 * time.Hour)
+	report := dbgen.Report{Name: "Ops", Period: PeriodDaily.Value.Key}
+	mainDB, otherDB := uuid.New(), uuid.New()
+
+	// growth returns executions of a backup whose latest file grew from
+	// before to after.
+	growth := func(before, after int64) []dbgen.Execution {
+		return []dbgen.Execution{
+			{
+				Status: "success", StartedAt: to.Add(-time.Hour),
+				FileSize: sql.NullInt64{Int64: after, Valid: true},
+			},
+			{
+				Status: "deleted", StartedAt: from.Add(-time.Hour),
+				FileSize: sql.NullInt64{Int64: before, Valid: true},
+			},
+		}
+	}
+
+	backups := []dbgen.Backup{
+		{ID: uuid.New(), Name: "shrinking", DatabaseID: mainDB},
+		{ID: uuid.New(), Name: "main", DatabaseID: mainDB},
+		{ID: uuid.New(), Name: "growing", DatabaseID: otherDB},
+	}
+	data := digestData{
+		executionsQty: dbgen.ExecutionsServiceGetExecutionsQtyRow{
+			All: 7, Success: 5, Failed: 1,
+		},
+		restorationsQty: dbgen.RestorationsServiceGetRestorationsQtyRow{
+			All: 2, Failed: 1,
+		},
+		backups: backups,
+		executions: map[uuid.UUID][]dbgen.Execution{
+			backups[0].ID: growth(800, 500),
+			backups[1].ID: growth(1000, 1500),
+			backups[2].ID: growth(1000, 9000),
+		},
+		databases: []dbgen.DatabasesServiceGetAllDatabasesRow{
+			{
+				ID: mainDB, Name: "main-db",
+				TestOk:    sql.NullBool{Bool: false, Valid: true},
+				TestError: sql.NullString{String: "timeout", Valid: true},
+			},
+			{ID: otherDB, Name: "other-db"},
+		},
+		destinations: []dbgen.DestinationsServiceGetAllDestinationsRow{
+			{Name: "s3", TestOk: sql.NullBool{Bool: false, Valid: true}},
+			{Name: "healthy", TestOk: sql.NullBool{Bool: true, Valid: true}},
+		},
+		staleBackups: []dbgen.Backup{{Name: "main"}},
+		restorations: []dbgen.RestorationsServicePaginateRestorationsRow{
+			{Status: "success", BackupName: "main", StartedAt: to.Add(-time.Hour)},
+			{Status: "failed", BackupName: "main", StartedAt: to.Add(-2 * time.Hour)},
+		},
+	}
+
+	digest := newDigest(report, from, to, data)
+
+	assert.Equal(t, "Ops", digest.Report)
+	assert.Equal(t, from, digest.From)
+	assert.Equal(t, to, digest.To)
+	assert.Equal(t, Totals{
+		Executions:         7,
+		Success:            5,
+		Failed:             1,
+		SizeStored:         11000,
+		Restorations:       2,
+		RestorationsFailed: 1,
+	}, digest.Totals)
+
+	require.Len(t, digest.Backups, 3)
+	assert.Equal(t, BackupActivity{
+		Name: "growing", Database: "other-db", Success: 1, SizeStored: 9000,
+		Growth: 8000,
+	}, digest.Backups[0])
+	assert.Equal(t, "main", digest.Backups[1].Name)
+	assert.Equal(t, int64(-300), digest.Backups[2].Growth)
+
+	require.Len(t, digest.LargestGrowth, 2)
+	assert.Equal(t, "growing", digest.LargestGrowth[0].Name)
+	assert.Equal(t, "main", digest.LargestGrowth[1].Name)
+
+	require.Len(t, digest.UnhealthyDatabases, 1)
+	assert.Equal(t, "timeout", digest.UnhealthyDatabases[0].Error)
+	assert.Nil(t, digest.UnhealthyDatabases[0].LastTestAt)
+	require.Len(t, digest.UnhealthyDestinations, 1)
+	assert.Equal(t, "s3", digest.UnhealthyDestinations[0].Name)
+	assert.Len(t, digest.Restorations, 2)
+	assert.Equal(t, []string{"main"}, digest.StaleBackups)
+
+	t.Run("Largest growth limit", func(t *testing.T) {
+		data := digestData{executions: map[uuid.UUID][]dbgen.Execution{}}
+		for i := range largestGrowthLimit + 2 {
+			backup := dbgen.Backup{ID: uuid.New(), Name: fmt.Sprintf("backup-%d", i)}
+			data.backups = append(data.backups, backup)
+			data.executions[backup.ID] = growth(100, int64(100+i))
+		}
+
+		digest := newDigest(report, from, to, data)
+		require.Len(t, digest.LargestGrowth, largestGrowthLimit)
+		assert.Equal(t, "backup-6", digest.LargestGrowth[0].Name)
+	})
+}
+
+func TestNewBackupActivity(t *testing.T) {
+	since := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	size := func(n int64) sql.NullInt64 {
+		return sql.NullInt64{Int64: n, Valid: true}
+	}
+
+	tests := []struct {
+		name       string
+		executions []dbgen.Execution
+		want       BackupActivity
+	}{
+		{
+			name: "No executions",
+			want: BackupActivity{},
+		},
+		{
+			name: "Counts only the period",
+			executions: []dbgen.Execution{
+				{Status: "success", StartedAt: since.Add(time.Hour), FileSize: size(300)},
+				{Status: "failed", StartedAt: since.Add(time.Minute)},
+				{Status: "running", StartedAt: since.Add(2 * time.Hour)},
+				{Status: "failed", StartedAt: since.Add(-time.Hour)},
+				{Status: "success", StartedAt: since.Add(-2 * time.Hour), FileSize: size(200)},
+			},
+			want: BackupActivity{Success: 1, Failed: 1, SizeStored: 500, Growth: 100},
+		},
+		{
+			name: "Growth uses the latest file of each side",
+			executions: []dbgen.Execution{
+				{Status: "deleted", StartedAt: since.Add(-3 * time.Hour), FileSize: size(50)},
+				{Status: "success", StartedAt: since.Add(time.Hour), FileSize: size(100)},
+				{Status: "deleted", StartedAt: since.Add(-time.Hour), FileSize: size(400)},
+				{Status: "success", StartedAt: since.Add(2 * time.Hour), FileSize: size(700)},
+			},
+			want: BackupActivity{Success: 2, SizeStored: 800, Growth: 300},
+		},
+		{
+			name: "Nothing to compare",
+			executions: []dbgen.Execution{
+				{Status: "success", StartedAt: since.Add(time.Hour), FileSize: size(100)},
+			},
+			want: BackupActivity{Success: 1, SizeStored: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newBackupActivity(since, tt.executions))
+		})
+	}
+}
+
+func TestRenderEmail(t *testing.T) {
+	to := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	digest := Digest{
+		Report: "Ops <weekly>",
+		Period: PeriodWeekly.Value.Key,
+		From:   to.Add(-PeriodWeekly.Value.Duration),
+		To:     to,
+		Totals: Totals{Executions: 3, Success: 2, Failed: 1, SizeStored: 2048},
+		Backups: []BackupActivity{
+			{Name: "main", Database: "main-db", Success: 2, Failed: 1, SizeStored: 2048},
+		},
+		StaleBackups:       []string{"nightly"},
+		UnhealthyDatabases: []UnhealthyTarget{{Name: "db", Error: "timeout"}},
+		DashboardURL:       "https://pbw.example.com/dashboard",
+	}
+
+	msg, err := RenderEmail("mailto:ops@example.com,cto@example.com", digest)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ops@example.com", "cto@example.com"}, msg.To)
+	assert.Equal(t, "Weekly backup report: Ops <weekly>", msg.Subject)
+
+	assert.Contains(t, msg.Text, "2026-10-12 08:00 to 2026-10-19 08:00 UTC")
+	assert.Contains(t, msg.Text, "Executions: 3 (2 successful, 1 failed)")
+	assert.Contains(t, msg.Text, "- main (main-db): 2 successful, 1 failed, 2.00 KB stored")
+	assert.Contains(t, msg.Text, "Stale backups:\n- nightly")
+	assert.Contains(t, msg.Text, "- db: timeout")
+	assert.NotContains(t, msg.Text, "Restorations:\n")
+
+	assert.Contains(t, msg.HTML, "Ops &lt;weekly&gt;")
+	assert.Contains(t, msg.HTML, "<li>nightly</li>")
+	assert.Contains(t, msg.HTML, `href="https://pbw.example.com/dashboard"`)
+
+	_, err = RenderEmail("https://example.com", digest)
+	assert.Error(t, err)
+}
@@ -0,0 +1,27 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ensureNotProvisioned returns an error when the report is provisioned from
+// the configuration file, because those can only be changed by editing the
+// file.
+func (s *Service) ensureNotProvisioned(ctx context.Context, id uuid.UUID) error {
+	report, err := s.GetReport(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if report.IsProvisioned {
+		return fmt.Errorf(
+			"report %q is provisioned from the configuration file and is read-only",
+			report.Name,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package reports
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
+	"github.com/google/uuid"
+)
+
+func (s *Service) GetReport(
+	ctx context.Context, id uuid.UUID,
+) (dbgen.Report, error) {
+	return s.dbgen.ReportsServiceGetReport(
+		ctx, dbgen.ReportsServiceGetReportParams{
+			ReportID:    id,
+			WorkspaceID: workspaces.FromContext(ctx),
+		},
+	)
+}
@@ -0,0 +1,8 @@
+-- name: ReportsServiceGetReport :one
+SELECT * FROM reports
+WHERE id = @report_id
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+);
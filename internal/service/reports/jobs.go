@@ -0,0 +1,89 @@
+package reports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/google/uuid"
+)
+
+func (s *Service) jobUpsert(
+	reportID uuid.UUID, timeZone string, cronExpression string,
+) error {
+	return s.cr.UpsertJob(reportID, timeZone, cronExpression, s.runReport, reportID)
+}
+
+func (s *Service) jobRemove(reportID uuid.UUID) error {
+	return s.cr.RemoveJob(reportID)
+}
+
+// RefreshJob schedules or unschedules the report to match what is stored in
+// the database. It is used when reports are changed without going through
+// this service, e.g. by the configuration file provisioning.
+func (s *Service) RefreshJob(ctx context.Context, reportID uuid.UUID) error {
+	report, err := s.GetReport(ctx, reportID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return s.jobRemove(reportID)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.refreshJob(report)
+}
+
+// refreshJob schedules or unschedules the report after it is changed.
+func (s *Service) refreshJob(report dbgen.Report) error {
+	if !report.IsActive {
+		return s.jobRemove(report.ID)
+	}
+	return s.jobUpsert(report.ID, report.TimeZone, report.CronExpression)
+}
+
+func (s *Service) ScheduleAll() {
+	reports, err := s.dbgen.ReportsServiceGetScheduleAllData(
+		context.Background(),
+	)
+	if err != nil {
+		logger.Error("error getting all reports", logger.KV{"error": err})
+	}
+
+	for _, report := range reports {
+		if !report.IsActive {
+			continue
+		}
+
+		err := s.jobUpsert(report.ID, report.TimeZone, report.CronExpression)
+		if err != nil {
+			logger.Error("error scheduling report", logger.KV{"error": err})
+		}
+	}
+
+	logger.Info("all active reports scheduled")
+}
+
+// runReport sends the report from its scheduled job.
+func (s *Service) runReport(reportID uuid.UUID) {
+	ctx := users.WithSystem(context.Background())
+
+	report, err := s.dbgen.ReportsServiceGetReport(
+		ctx, dbgen.ReportsServiceGetReportParams{ReportID: reportID},
+	)
+	if err != nil {
+		logger.Error("error getting report to send", logger.KV{
+			"report_id": reportID,
+			"error":     err,
+		})
+		return
+	}
+	if !report.IsActive {
+		return
+	}
+
+	// The error is already logged and stored in the report.
+	_ = s.sendReport(ctx, report)
+}
@@ -0,0 +1,48 @@
+package reports
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
+	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
+)
+
+type PaginateReportsParams struct {
+	Page  int
+	Limit int
+}
+
+func (s *Service) PaginateReports(
+	ctx context.Context, params PaginateReportsParams,
+) (paginateutil.PaginateResponse, []dbgen.Report, error) {
+	page := max(params.Page, 1)
+	limit := min(max(params.Limit, 1), 100)
+
+	count, err := s.dbgen.ReportsServicePaginateReportsCount(
+		ctx, workspaces.FromContext(ctx),
+	)
+	if err != nil {
+		return paginateutil.PaginateResponse{}, nil, err
+	}
+
+	paginateParams := paginateutil.PaginateParams{
+		Page:  page,
+		Limit: limit,
+	}
+	offset := paginateutil.CreateOffsetFromParams(paginateParams)
+	paginateResponse := paginateutil.CreatePaginateResponse(paginateParams, int(count))
+
+	reports, err := s.dbgen.ReportsServicePaginateReports(
+		ctx, dbgen.ReportsServicePaginateReportsParams{
+			Limit:       int32(limit),
+			Offset:      int32(offset),
+			WorkspaceID: workspaces.FromContext(ctx),
+		},
+	)
+	if err != nil {
+		return paginateutil.PaginateResponse{}, nil, err
+	}
+
+	return paginateResponse, reports, nil
+}
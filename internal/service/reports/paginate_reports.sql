@@ -0,0 +1,17 @@
+-- name: ReportsServicePaginateReportsCount :one
+SELECT COUNT(*) FROM reports
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+);
+
+-- name: ReportsServicePaginateReports :many
+SELECT * FROM reports
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+)
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -0,0 +1,192 @@
+package reports
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"text/template"
+
+	"github.com/eduardolat/pgbackweb/internal/integration/email"
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
+	"github.com/eduardolat/pgbackweb/internal/util/strutil"
+)
+
+var templateFuncs = map[string]any{
+	"fileSize": strutil.FormatFileSize,
+	"date": func(d Digest) string {
+		return fmt.Sprintf(
+			"%s to %s",
+			d.From.UTC().Format("2006-01-02 15:04"),
+			d.To.UTC().Format("2006-01-02 15:04 MST"),
+		)
+	},
+}
+
+var emailTextTemplate = template.Must(template.New("text").Funcs(templateFuncs).Parse(
+	`{{ .Report }}, {{ date . }}
+
+Executions: {{ .Totals.Executions }} ({{ .Totals.Success }} successful, {{ .Totals.Failed }} failed)
+Size stored: {{ fileSize .Totals.SizeStored }}
+Restorations: {{ .Totals.Restorations }} ({{ .Totals.RestorationsFailed }} failed)
+
+Backups:
+{{- range .Backups }}
+- {{ .Name }} ({{ .Database }}): {{ .Success }} successful, {{ .Failed }} failed, {{ fileSize .SizeStored }} stored
+{{- else }}
+None
+{{- end }}
+{{- with .LargestGrowth }}
+
+Largest growth:
+{{- range . }}
+- {{ .Name }}: +{{ fileSize .Growth }}
+{{- end }}
+{{- end }}
+{{- with .StaleBackups }}
+
+Stale backups:
+{{- range . }}
+- {{ . }}
+{{- end }}
+{{- end }}
+{{- with .UnhealthyDatabases }}
+
+Unhealthy databases:
+{{- range . }}
+- {{ .Name }}: {{ .Error }}
+{{- end }}
+{{- end }}
+{{- with .UnhealthyDestinations }}
+
+Unhealthy destinations:
+{{- range . }}
+- {{ .Name }}: {{ .Error }}
+{{- end }}
+{{- end }}
+{{- with .Restorations }}
+
+Restorations:
+{{- range . }}
+- {{ .Backup }}{{ with .Database }} to {{ . }}{{ end }}: {{ .Status }}, {{ .StartedAt.UTC.Format "2006-01-02 15:04 MST" }}
+{{- end }}
+{{- end }}
+{{- with .DashboardURL }}
+
+Open dashboard: {{ . }}
+{{- end }}
+`,
+))
+
+var emailHTMLTemplate = htmltemplate.Must(htmltemplate.New("html").Funcs(templateFuncs).Parse(
+	`<!DOCTYPE html>
+<html>
+<body style="margin:0;padding:24px;background:#f3f4f6;font-family:Arial,Helvetica,sans-serif;color:#111827;">
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" style="max-width:700px;margin:0 auto;background:#ffffff;border-radius:8px;border-top:6px solid #111827;">
+<tr><td style="padding:24px;font-size:14px;">
+<h2 style="margin:0 0 4px 0;font-size:20px;">{{ .Report }}</h2>
+<p style="margin:0 0 16px 0;color:#6b7280;">{{ date . }}</p>
+<table role="presentation" cellpadding="0" cellspacing="0" style="width:100%;margin-bottom:16px;text-align:center;">
+<tr>
+<td style="padding:8px;background:#f3f4f6;border-radius:4px;"><div style="font-size:20px;font-weight:bold;">{{ .Totals.Executions }}</div><div style="color:#6b7280;">Executions</div></td>
+<td style="width:8px;"></td>
+<td style="padding:8px;background:#f3f4f6;border-radius:4px;"><div style="font-size:20px;font-weight:bold;color:#00a96e;">{{ .Totals.Success }}</div><div style="color:#6b7280;">Successful</div></td>
+<td style="width:8px;"></td>
+<td style="padding:8px;background:#f3f4f6;border-radius:4px;"><div style="font-size:20px;font-weight:bold;color:#ff5861;">{{ .Totals.Failed }}</div><div style="color:#6b7280;">Failed</div></td>
+<td style="width:8px;"></td>
+<td style="padding:8px;background:#f3f4f6;border-radius:4px;"><div style="font-size:20px;font-weight:bold;">{{ fileSize .Totals.SizeStored }}</div><div style="color:#6b7280;">Stored</div></td>
+<td style="width:8px;"></td>
+<td style="padding:8px;background:#f3f4f6;border-radius:4px;"><div style="font-size:20px;font-weight:bold;">{{ .Totals.Restorations }}</div><div style="color:#6b7280;">Restorations</div></td>
+</tr>
+</table>
+<h3 style="margin:16px 0 8px 0;font-size:16px;">Backups</h3>
+{{- if .Backups }}
+<table role="presentation" cellpadding="0" cellspacing="0" style="width:100%;">
+<tr style="color:#6b7280;text-align:left;"><th style="padding:4px 8px 4px 0;">Backup</th><th style="padding:4px 8px;">Database</th><th style="padding:4px 8px;">Successful</th><th style="padding:4px 8px;">Failed</th><th style="padding:4px 0 4px 8px;">Stored</th></tr>
+{{- range .Backups }}
+<tr><td style="padding:4px 8px 4px 0;">{{ .Name }}</td><td style="padding:4px 8px;">{{ .Database }}</td><td style="padding:4px 8px;">{{ .Success }}</td><td style="padding:4px 8px;{{ if .Failed }}color:#ff5861;font-weight:bold;{{ end }}">{{ .Failed }}</td><td style="padding:4px 0 4px 8px;">{{ fileSize .SizeStored }}</td></tr>
+{{- end }}
+</table>
+{{- else }}
+<p style="margin:0;color:#6b7280;">No backups</p>
+{{- end }}
+{{- with .LargestGrowth }}
+<h3 style="margin:16px 0 8px 0;font-size:16px;">Largest growth</h3>
+<table role="presentation" cellpadding="0" cellspacing="0">
+{{- range . }}
+<tr><td style="padding:4px 16px 4px 0;">{{ .Name }}</td><td style="padding:4px 0;">+{{ fileSize .Growth }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- with .StaleBackups }}
+<h3 style="margin:16px 0 8px 0;font-size:16px;color:#ffbe00;">Stale backups</h3>
+<ul style="margin:0;padding-left:20px;">
+{{- range . }}
+<li>{{ . }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{- with .UnhealthyDatabases }}
+<h3 style="margin:16px 0 8px 0;font-size:16px;color:#ff5861;">Unhealthy databases</h3>
+<ul style="margin:0;padding-left:20px;">
+{{- range . }}
+<li>{{ .Name }}{{ with .Error }}: <span style="color:#6b7280;">{{ . }}</span>{{ end }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{- with .UnhealthyDestinations }}
+<h3 style="margin:16px 0 8px 0;font-size:16px;color:#ff5861;">Unhealthy destinations</h3>
+<ul style="margin:0;padding-left:20px;">
+{{- range . }}
+<li>{{ .Name }}{{ with .Error }}: <span style="color:#6b7280;">{{ . }}</span>{{ end }}</li>
+{{- end }}
+</ul>
+{{- end }}
+{{- with .Restorations }}
+<h3 style="margin:16px 0 8px 0;font-size:16px;">Restorations</h3>
+<table role="presentation" cellpadding="0" cellspacing="0">
+{{- range . }}
+<tr><td style="padding:4px 16px 4px 0;">{{ .Backup }}{{ with .Database }} to {{ . }}{{ end }}</td><td style="padding:4px 16px 4px 0;">{{ .Status }}</td><td style="padding:4px 0;color:#6b7280;">{{ .StartedAt.UTC.Format "2006-01-02 15:04 MST" }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- with .DashboardURL }}
+<p style="margin:24px 0 0 0;"><a href="{{ . }}" style="display:inline-block;padding:10px 16px;background:#111827;color:#ffffff;border-radius:4px;text-decoration:none;">Open dashboard</a></p>
+{{- end }}
+</td></tr>
+</table>
+</body>
+</html>
+`,
+))
+
+// RenderEmail renders the digest as an email with an HTML and a text
+// version, to the recipients of the mailto: URL of the report.
+func RenderEmail(rawURL string, digest Digest) (email.Message, error) {
+	recipients, err := webhooks.ParseEmailRecipients(rawURL)
+	if err != nil {
+		return email.Message{}, err
+	}
+
+	var text, html bytes.Buffer
+	if err := emailTextTemplate.Execute(&text, digest); err != nil {
+		return email.Message{}, fmt.Errorf("error rendering the email: %w", err)
+	}
+	if err := emailHTMLTemplate.Execute(&html, digest); err != nil {
+		return email.Message{}, fmt.Errorf("error rendering the email: %w", err)
+	}
+
+	return email.Message{
+		To:      recipients,
+		Subject: fmt.Sprintf("%s backup report: %s", periodName(digest.Period), digest.Report),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// periodName returns the name of the period, the key for unknown periods.
+func periodName(key string) string {
+	if name, ok := FullPeriods[key]; ok {
+		return name
+	}
+	return key
+}
@@ -0,0 +1,88 @@
+package reports
+
+import (
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/config"
+	"github.com/eduardolat/pgbackweb/internal/cron"
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/backups"
+	"github.com/eduardolat/pgbackweb/internal/service/databases"
+	"github.com/eduardolat/pgbackweb/internal/service/destinations"
+	"github.com/eduardolat/pgbackweb/internal/service/executions"
+	"github.com/eduardolat/pgbackweb/internal/service/restorations"
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
+	"github.com/orsinium-labs/enum"
+)
+
+type (
+	period     = enum.Member[periodData]
+	periodData struct {
+		Key      string
+		Name     string
+		Duration time.Duration
+	}
+)
+
+var (
+	PeriodDaily = period{
+		Value: periodData{Key: "daily", Name: "Daily", Duration: 24 * time.Hour},
+	}
+	PeriodWeekly = period{
+		Value: periodData{Key: "weekly", Name: "Weekly", Duration: 7 * 24 * time.Hour},
+	}
+)
+
+var FullPeriods = map[string]string{
+	PeriodDaily.Value.Key:  PeriodDaily.Value.Name,
+	PeriodWeekly.Value.Key: PeriodWeekly.Value.Name,
+}
+
+// The reports are sent as JSON with the webhook channel and as an HTML email
+// with the email channel, the same channels of the webhooks.
+var Channels = []string{
+	webhooks.ChannelWebhook.Value.Key,
+	webhooks.ChannelEmail.Value.Key,
+}
+
+// periodDuration returns how far back the report of the period looks, a day
+// for unknown periods.
+func periodDuration(key string) time.Duration {
+	if key == PeriodWeekly.Value.Key {
+		return PeriodWeekly.Value.Duration
+	}
+	return PeriodDaily.Value.Duration
+}
+
+type Service struct {
+	env                 config.Env
+	dbgen               *dbgen.Queries
+	cr                  *cron.Cron
+	webhooksService     *webhooks.Service
+	backupsService      *backups.Service
+	databasesService    *databases.Service
+	destinationsService *destinations.Service
+	executionsService   *executions.Service
+	restorationsService *restorations.Service
+}
+
+func New(
+	env config.Env, dbgen *dbgen.Queries, cr *cron.Cron,
+	webhooksService *webhooks.Service, backupsService *backups.Service,
+	databasesService *databases.Service,
+	destinationsService *destinations.Service,
+	executionsService *executions.Service,
+	restorationsService *restorations.Service,
+) *Service {
+	return &Service{
+		env:                 env,
+		dbgen:               dbgen,
+		cr:                  cr,
+		webhooksService:     webhooksService,
+		backupsService:      backupsService,
+		databasesService:    databasesService,
+		destinationsService: destinationsService,
+		executionsService:   executionsService,
+		restorationsService: restorationsService,
+	}
+}
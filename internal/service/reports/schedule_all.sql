@@ -0,0 +1,8 @@
+-- name: ReportsServiceGetScheduleAllData :many
+SELECT
+  id,
+  is_active,
+  cron_expression,
+  time_zone
+FROM reports
+ORDER BY created_at DESC;
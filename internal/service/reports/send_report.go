@@ -0,0 +1,100 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
+	"github.com/google/uuid"
+)
+
+// SendReport sends the report right away, for the period that ends now, and
+// returns the error of the delivery, if any.
+func (s *Service) SendReport(ctx context.Context, reportID uuid.UUID) error {
+	if err := users.Authorize(ctx, users.PermissionRun); err != nil {
+		return err
+	}
+
+	report, err := s.GetReport(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	return s.sendReport(ctx, report)
+}
+
+// sendReport sends the digest of the report and stores the result in the
+// report, the error is also logged.
+func (s *Service) sendReport(ctx context.Context, report dbgen.Report) error {
+	sendErr := s.deliver(ctx, report)
+
+	result := dbgen.ReportsServiceSetSendResultParams{ReportID: report.ID}
+	if sendErr != nil {
+		result.Error = sql.NullString{String: sendErr.Error(), Valid: true}
+		logger.Error("error sending report", logger.KV{
+			"report_id": report.ID,
+			"error":     sendErr,
+		})
+	}
+	if err := s.dbgen.ReportsServiceSetSendResult(ctx, result); err != nil {
+		logger.Error("error storing report result", logger.KV{
+			"report_id": report.ID,
+			"error":     err,
+		})
+	}
+
+	return sendErr
+}
+
+// deliver sends the digest of the report as an email with the email channel
+// and as a JSON POST request with the webhook channel.
+func (s *Service) deliver(ctx context.Context, report dbgen.Report) error {
+	digest, err := s.GetDigest(ctx, report, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if report.Channel == webhooks.ChannelEmail.Value.Key {
+		msg, err := RenderEmail(report.Url, digest)
+		if err != nil {
+			return err
+		}
+		return s.webhooksService.SendEmail(ctx, msg)
+	}
+
+	body, err := json.Marshal(digest)
+	if err != nil {
+		return fmt.Errorf("error marshalling report: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, report.Url, bytes.NewReader(body),
+	)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := http.Client{Timeout: webhooks.DefaultTimeoutSeconds * time.Second}
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request: %w", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,6 @@
+-- name: ReportsServiceSetSendResult :exec
+UPDATE reports
+SET
+  last_sent_at = NOW(),
+  last_error = sqlc.narg('error')
+WHERE id = @report_id;
@@ -0,0 +1,61 @@
+package reports
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+)
+
+func (s *Service) UpdateReport(
+	ctx context.Context, params dbgen.ReportsServiceUpdateReportParams,
+) (dbgen.Report, error) {
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return dbgen.Report{}, err
+	}
+	if err := s.ensureNotProvisioned(ctx, params.ReportID); err != nil {
+		return dbgen.Report{}, err
+	}
+
+	current, err := s.GetReport(ctx, params.ReportID)
+	if err != nil {
+		return dbgen.Report{}, err
+	}
+
+	// The fields that aren't updated keep their current value, they are
+	// validated together because the URL depends on the channel.
+	orCurrent := func(value sql.NullString, currentValue string) string {
+		if value.Valid {
+			return value.String
+		}
+		return currentValue
+	}
+	err = ValidateReport(
+		orCurrent(params.Period, current.Period),
+		orCurrent(params.CronExpression, current.CronExpression),
+		orCurrent(params.TimeZone, current.TimeZone),
+		orCurrent(params.Channel, current.Channel),
+		orCurrent(params.Url, current.Url),
+	)
+	if err != nil {
+		return dbgen.Report{}, err
+	}
+
+	report, err := s.dbgen.ReportsServiceUpdateReport(ctx, params)
+	if err != nil {
+		return report, err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionUpdate,
+		EntityType: audit.EntityReport,
+		EntityID:   report.ID,
+		EntityName: report.Name,
+		Before:     current,
+		After:      report,
+	})
+
+	return report, s.refreshJob(report)
+}
@@ -0,0 +1,12 @@
+-- name: ReportsServiceUpdateReport :one
+UPDATE reports
+SET
+  name = COALESCE(sqlc.narg('name'), name),
+  is_active = COALESCE(sqlc.narg('is_active'), is_active),
+  period = COALESCE(sqlc.narg('period'), period),
+  cron_expression = COALESCE(sqlc.narg('cron_expression'), cron_expression),
+  time_zone = COALESCE(sqlc.narg('time_zone'), time_zone),
+  channel = COALESCE(sqlc.narg('channel'), channel),
+  url = COALESCE(sqlc.narg('url'), url)
+WHERE id = @report_id
+RETURNING *;
@@ -0,0 +1,43 @@
+package reports
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+)
+
+// ValidateReport checks the settings of a report, the URL must be an http or
+// https URL for the webhook channel and a mailto: URL for the email channel.
+func ValidateReport(
+	period, cronExpression, timeZone, channel, rawURL string,
+) error {
+	if _, ok := FullPeriods[period]; !ok {
+		return fmt.Errorf("invalid period %s", period)
+	}
+	if !validate.CronExpression(cronExpression) {
+		return errors.New("invalid cron expression")
+	}
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return fmt.Errorf("invalid time zone: %w", err)
+	}
+	if !slices.Contains(Channels, channel) {
+		return fmt.Errorf("invalid channel %s", channel)
+	}
+
+	if channel == webhooks.ChannelEmail.Value.Key {
+		_, err := webhooks.ParseEmailRecipients(rawURL)
+		return err
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("the URL must be an http or https URL")
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package reports
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateReport(t *testing.T) {
+	tests := []struct {
+		name     string
+		period   string
+		cron     string
+		timeZone string
+		channel  string
+		url      string
+		wantErr  bool
+	}{
+		{
+			name: "daily webhook", period: "daily", cron: "0 8 * * *",
+			timeZone: "UTC", channel: "webhook", url: "https://example.com/digest",
+		},
+		{
+			name: "weekly email", period: "weekly", cron: "0 8 * * 1",
+			timeZone: "Europe/Madrid", channel: "email",
+			url: "mailto:ops@example.com,cto@example.com",
+		},
+		{
+			name: "invalid period", period: "monthly", cron: "0 8 * * *",
+			timeZone: "UTC", channel: "webhook", url: "https://example.com",
+			wantErr: true,
+		},
+		{
+			name: "invalid cron expression", period: "daily", cron: "every day",
+			timeZone: "UTC", channel: "webhook", url: "https://example.com",
+			wantErr: true,
+		},
+		{
+			name: "invalid time zone", period: "daily", cron: "0 8 * * *",
+			timeZone: "Mars/Olympus", channel: "webhook", url: "https://example.com",
+			wantErr: true,
+		},
+		{
+			name: "chat channel", period: "daily", cron: "0 8 * * *",
+			timeZone: "UTC", channel: "slack", url: "https://hooks.slack.com/x",
+			wantErr: true,
+		},
+		{
+			name: "webhook without http URL", period: "daily", cron: "0 8 * * *",
+			timeZone: "UTC", channel: "webhook", url: "mailto:ops@example.com",
+			wantErr: true,
+		},
+		{
+			name: "email without recipients", period: "daily", cron: "0 8 * * *",
+			timeZone: "UTC", channel: "email", url: "https://example.com",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReport(tt.period, tt.cron, tt.timeZone, tt.channel, tt.url)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
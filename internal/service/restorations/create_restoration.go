@@ -4,10 +4,14 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 )
 
+// CreateRestoration creates the restoration of the execution, which must
+// belong to the workspace carried by ctx, like the database to restore to.
 func (s *Service) CreateRestoration(
 	ctx context.Context, params dbgen.RestorationsServiceCreateRestorationParams,
 ) (dbgen.Restoration, error) {
+	params.WorkspaceID = workspaces.FromContext(ctx)
 	return s.dbgen.RestorationsServiceCreateRestoration(ctx, params)
 }
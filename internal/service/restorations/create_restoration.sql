@@ -1,4 +1,21 @@
 -- name: RestorationsServiceCreateRestoration :one
 INSERT INTO restorations (execution_id, database_id, status, message)
-VALUES (@execution_id, @database_id, @status, @message)
+SELECT @execution_id, sqlc.narg('database_id'), @status, @message
+FROM executions
+INNER JOIN backups ON backups.id = executions.backup_id
+WHERE executions.id = @execution_id
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  backups.workspace_id = sqlc.narg('workspace_id')::UUID
+)
+AND (
+  sqlc.narg('database_id')::UUID IS NULL
+  OR
+  EXISTS (
+    SELECT 1 FROM databases
+    WHERE databases.id = sqlc.narg('database_id')::UUID
+    AND databases.workspace_id = backups.workspace_id
+  )
+)
 RETURNING *;
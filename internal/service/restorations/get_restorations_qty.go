@@ -2,12 +2,32 @@ package restorations
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 )
 
 func (s *Service) GetRestorationsQty(
 	ctx context.Context,
 ) (dbgen.RestorationsServiceGetRestorationsQtyRow, error) {
-	return s.dbgen.RestorationsServiceGetRestorationsQty(ctx)
+	return s.dbgen.RestorationsServiceGetRestorationsQty(
+		ctx, dbgen.RestorationsServiceGetRestorationsQtyParams{
+			WorkspaceID: workspaces.FromContext(ctx),
+		},
+	)
+}
+
+// GetRestorationsQtySince counts the restorations like GetRestorationsQty,
+// but only the ones started since the given time.
+func (s *Service) GetRestorationsQtySince(
+	ctx context.Context, since time.Time,
+) (dbgen.RestorationsServiceGetRestorationsQtyRow, error) {
+	return s.dbgen.RestorationsServiceGetRestorationsQty(
+		ctx, dbgen.RestorationsServiceGetRestorationsQtyParams{
+			WorkspaceID: workspaces.FromContext(ctx),
+			Since:       sql.NullTime{Time: since, Valid: true},
+		},
+	)
 }
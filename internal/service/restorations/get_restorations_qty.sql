@@ -1,7 +1,19 @@
 -- name: RestorationsServiceGetRestorationsQty :one
 SELECT 
   COUNT(*) AS all,
-  COALESCE(SUM(CASE WHEN status = 'running' THEN 1 ELSE 0 END), 0)::INTEGER AS running,
-  COALESCE(SUM(CASE WHEN status = 'success' THEN 1 ELSE 0 END), 0)::INTEGER AS success,
-  COALESCE(SUM(CASE WHEN status = 'failed' THEN 1 ELSE 0 END), 0)::INTEGER AS failed
-FROM restorations;
+  COALESCE(SUM(CASE WHEN restorations.status = 'running' THEN 1 ELSE 0 END), 0)::INTEGER AS running,
+  COALESCE(SUM(CASE WHEN restorations.status = 'success' THEN 1 ELSE 0 END), 0)::INTEGER AS success,
+  COALESCE(SUM(CASE WHEN restorations.status = 'failed' THEN 1 ELSE 0 END), 0)::INTEGER AS failed
+FROM restorations
+INNER JOIN executions ON executions.id = restorations.execution_id
+INNER JOIN backups ON backups.id = executions.backup_id
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  backups.workspace_id = sqlc.narg('workspace_id')::UUID
+)
+AND (
+  sqlc.narg('since')::TIMESTAMPTZ IS NULL
+  OR
+  restorations.started_at >= sqlc.narg('since')::TIMESTAMPTZ
+);
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
 	"github.com/google/uuid"
 )
@@ -25,6 +26,7 @@ func (s *Service) PaginateRestorations(
 		ctx, dbgen.RestorationsServicePaginateRestorationsCountParams{
 			ExecutionID: params.ExecutionFilter,
 			DatabaseID:  params.DatabaseFilter,
+			WorkspaceID: workspaces.FromContext(ctx),
 		},
 	)
 	if err != nil {
@@ -44,6 +46,7 @@ func (s *Service) PaginateRestorations(
 			DatabaseID:  params.DatabaseFilter,
 			Limit:       int32(params.Limit),
 			Offset:      int32(offset),
+			WorkspaceID: workspaces.FromContext(ctx),
 		},
 	)
 	if err != nil {
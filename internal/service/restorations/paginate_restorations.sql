@@ -15,6 +15,12 @@ AND
   sqlc.narg('database_id')::UUID IS NULL
   OR
   restorations.database_id = sqlc.narg('database_id')::UUID
+)
+AND
+(
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  backups.workspace_id = sqlc.narg('workspace_id')::UUID
 );
 
 -- name: RestorationsServicePaginateRestorations :many
@@ -38,5 +44,11 @@ AND
   OR
   restorations.database_id = sqlc.narg('database_id')::UUID
 )
+AND
+(
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  backups.workspace_id = sqlc.narg('workspace_id')::UUID
+)
 ORDER BY restorations.started_at DESC
 LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
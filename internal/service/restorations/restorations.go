@@ -6,6 +6,7 @@ import (
 	"github.com/eduardolat/pgbackweb/internal/service/databases"
 	"github.com/eduardolat/pgbackweb/internal/service/destinations"
 	"github.com/eduardolat/pgbackweb/internal/service/executions"
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
 )
 
 type Service struct {
@@ -14,12 +15,13 @@ type Service struct {
 	executionsService   *executions.Service
 	databasesService    *databases.Service
 	destinationsService *destinations.Service
+	webhooksService     *webhooks.Service
 }
 
 func New(
 	dbgen *dbgen.Queries, ints *integration.Integration,
 	executionsService *executions.Service, databasesService *databases.Service,
-	destinationsService *destinations.Service,
+	destinationsService *destinations.Service, webhooksService *webhooks.Service,
 ) *Service {
 	return &Service{
 		dbgen:               dbgen,
@@ -27,5 +29,6 @@ func New(
 		executionsService:   executionsService,
 		databasesService:    databasesService,
 		destinationsService: destinationsService,
+		webhooksService:     webhooksService,
 	}
 }
@@ -7,7 +7,10 @@ import (
 	"time"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/integration/secrets"
 	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/google/uuid"
 )
 
@@ -18,10 +21,24 @@ func (s *Service) RunRestoration(
 	databaseID uuid.NullUUID,
 	connString string,
 ) error {
+	if err := users.Authorize(ctx, users.PermissionRun); err != nil {
+		return err
+	}
+
 	updateRes := func(params dbgen.RestorationsServiceUpdateRestorationParams) error {
 		_, err := s.dbgen.RestorationsServiceUpdateRestoration(
 			ctx, params,
 		)
+
+		// The webhooks run after the update so their event includes it.
+		if params.Status.String == "success" {
+			s.webhooksService.RunRestorationSuccess(params.ID)
+		}
+
+		if params.Status.String == "failed" {
+			s.webhooksService.RunRestorationFailed(params.ID)
+		}
+
 		return err
 	}
 
@@ -37,6 +54,14 @@ func (s *Service) RunRestoration(
 		})
 	}
 
+	// The execution is checked before the restoration is created, so no
+	// restoration nor webhook is created for executions of other workspaces.
+	execution, err := s.executionsService.GetExecution(ctx, executionID)
+	if err != nil {
+		logError(err)
+		return err
+	}
+
 	res, err := s.CreateRestoration(ctx, dbgen.RestorationsServiceCreateRestorationParams{
 		ExecutionID: executionID,
 		DatabaseID:  databaseID,
@@ -47,6 +72,14 @@ func (s *Service) RunRestoration(
 		return err
 	}
 
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionRun,
+		EntityType: audit.EntityRestoration,
+		EntityID:   res.ID,
+		After:      res,
+	})
+	s.webhooksService.RunRestorationStarted(res.ID)
+
 	if !databaseID.Valid && connString == "" {
 		err := fmt.Errorf("database_id or connection_string must be provided")
 		logError(err)
@@ -58,17 +91,6 @@ func (s *Service) RunRestoration(
 		})
 	}
 
-	execution, err := s.executionsService.GetExecution(ctx, executionID)
-	if err != nil {
-		logError(err)
-		return updateRes(dbgen.RestorationsServiceUpdateRestorationParams{
-			ID:         res.ID,
-			Status:     sql.NullString{Valid: true, String: "failed"},
-			Message:    sql.NullString{Valid: true, String: err.Error()},
-			FinishedAt: sql.NullTime{Valid: true, Time: time.Now()},
-		})
-	}
-
 	if execution.Status != "success" || !execution.Path.Valid {
 		err := fmt.Errorf("backup execution must be successful")
 		logError(err)
@@ -94,6 +116,19 @@ func (s *Service) RunRestoration(
 		connString = db.DecryptedConnectionString
 	}
 
+	// The errors of the commands that use a resolved secret are only logged.
+	reference := connString
+	connString, err = s.ints.SecretsClient.Resolve(ctx, connString)
+	if err != nil {
+		logError(err)
+		return updateRes(dbgen.RestorationsServiceUpdateRestorationParams{
+			ID:         res.ID,
+			Status:     sql.NullString{Valid: true, String: "failed"},
+			Message:    sql.NullString{Valid: true, String: err.Error()},
+			FinishedAt: sql.NullTime{Valid: true, Time: time.Now()},
+		})
+	}
+
 	pgVersion, err := s.ints.PGClient.ParseVersion(execution.DatabasePgVersion)
 	if err != nil {
 		logError(err)
@@ -108,6 +143,7 @@ func (s *Service) RunRestoration(
 	err = s.ints.PGClient.Test(pgVersion, connString)
 	if err != nil {
 		logError(err)
+		err = secrets.HideOutput(reference, err)
 		return updateRes(dbgen.RestorationsServiceUpdateRestorationParams{
 			ID:         res.ID,
 			Status:     sql.NullString{Valid: true, String: "failed"},
@@ -134,6 +170,7 @@ func (s *Service) RunRestoration(
 	)
 	if err != nil {
 		logError(err)
+		err = secrets.HideOutput(reference, err)
 		return updateRes(dbgen.RestorationsServiceUpdateRestorationParams{
 			ID:         res.ID,
 			Status:     sql.NullString{Valid: true, String: "failed"},
@@ -1,54 +1,100 @@
 package service
 
 import (
+	"database/sql"
+
 	"github.com/eduardolat/pgbackweb/internal/config"
 	"github.com/eduardolat/pgbackweb/internal/cron"
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
 	"github.com/eduardolat/pgbackweb/internal/integration"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
 	"github.com/eduardolat/pgbackweb/internal/service/auth"
 	"github.com/eduardolat/pgbackweb/internal/service/backups"
+	"github.com/eduardolat/pgbackweb/internal/service/bundles"
 	"github.com/eduardolat/pgbackweb/internal/service/databases"
 	"github.com/eduardolat/pgbackweb/internal/service/destinations"
+	"github.com/eduardolat/pgbackweb/internal/service/encryption"
 	"github.com/eduardolat/pgbackweb/internal/service/executions"
+	"github.com/eduardolat/pgbackweb/internal/service/health"
+	"github.com/eduardolat/pgbackweb/internal/service/metrics"
+	"github.com/eduardolat/pgbackweb/internal/service/provisioning"
+	"github.com/eduardolat/pgbackweb/internal/service/reports"
 	"github.com/eduardolat/pgbackweb/internal/service/restorations"
 	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 )
 
 type Service struct {
+	AuditService        *audit.Service
 	AuthService         *auth.Service
 	BackupsService      *backups.Service
+	BundlesService      *bundles.Service
 	DatabasesService    *databases.Service
 	DestinationsService *destinations.Service
+	EncryptionService   *encryption.Service
 	ExecutionsService   *executions.Service
+	HealthService       *health.Service
+	MetricsService      *metrics.Service
+	ProvisioningService *provisioning.Service
+	ReportsService      *reports.Service
 	UsersService        *users.Service
 	RestorationsService *restorations.Service
 	WebhooksService     *webhooks.Service
+	WorkspacesService   *workspaces.Service
 }
 
 func New(
-	env config.Env, dbgen *dbgen.Queries,
+	env config.Env, db *sql.DB, dbgen *dbgen.Queries,
 	cr *cron.Cron, ints *integration.Integration,
 ) *Service {
-	webhooksService := webhooks.New(dbgen)
+	auditService := audit.New(env, dbgen)
+	webhooksService := webhooks.New(env, dbgen, ints)
 	authService := auth.New(env, dbgen)
 	databasesService := databases.New(env, dbgen, ints, webhooksService)
 	destinationsService := destinations.New(env, dbgen, ints, webhooksService)
+	encryptionService := encryption.New(env, db, dbgen)
 	executionsService := executions.New(env, dbgen, ints, webhooksService)
+	healthService := health.New(env, dbgen)
+	metricsService := metrics.New(env, dbgen, cr)
 	usersService := users.New(dbgen)
-	backupsService := backups.New(dbgen, cr, executionsService)
+	workspacesService := workspaces.New(dbgen)
+	backupsService := backups.New(
+		dbgen, cr, executionsService, webhooksService,
+	)
 	restorationsService := restorations.New(
 		dbgen, ints, executionsService, databasesService, destinationsService,
+		webhooksService,
+	)
+	reportsService := reports.New(
+		env, dbgen, cr, webhooksService, backupsService, databasesService,
+		destinationsService, executionsService, restorationsService,
+	)
+	bundlesService := bundles.New(
+		env, db, dbgen, databasesService, destinationsService, backupsService,
+		reportsService,
+	)
+	provisioningService := provisioning.New(
+		env, dbgen, databasesService, destinationsService, backupsService,
+		reportsService,
 	)
 
 	return &Service{
+		AuditService:        auditService,
 		AuthService:         authService,
 		BackupsService:      backupsService,
+		BundlesService:      bundlesService,
 		DatabasesService:    databasesService,
 		DestinationsService: destinationsService,
+		EncryptionService:   encryptionService,
 		ExecutionsService:   executionsService,
+		HealthService:       healthService,
+		MetricsService:      metricsService,
+		ProvisioningService: provisioningService,
+		ReportsService:      reportsService,
 		UsersService:        usersService,
 		RestorationsService: restorationsService,
 		WebhooksService:     webhooksService,
+		WorkspacesService:   workspacesService,
 	}
 }
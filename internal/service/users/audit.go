@@ -0,0 +1,33 @@
+package users
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/google/uuid"
+)
+
+// auditSnapshot returns the user to record its changes in the audit log, or
+// nil if it can't be read.
+func (s *Service) auditSnapshot(ctx context.Context, id uuid.UUID) *dbgen.User {
+	user, err := s.dbgen.UsersServiceGetUser(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return &user
+}
+
+// auditUpdate records the update of the user from before to after.
+func (s *Service) auditUpdate(
+	ctx context.Context, before *dbgen.User, after dbgen.User,
+) {
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionUpdate,
+		EntityType: audit.EntityUser,
+		EntityID:   after.ID,
+		EntityName: after.Email,
+		Before:     before,
+		After:      after,
+	})
+}
@@ -9,5 +9,14 @@ import (
 func (s *Service) ChangePassword(
 	ctx context.Context, params dbgen.UsersServiceChangePasswordParams,
 ) error {
-	return s.dbgen.UsersServiceChangePassword(ctx, params)
+	before := s.auditSnapshot(ctx, params.ID)
+	if err := s.dbgen.UsersServiceChangePassword(ctx, params); err != nil {
+		return err
+	}
+
+	if after := s.auditSnapshot(ctx, params.ID); after != nil {
+		s.auditUpdate(ctx, before, *after)
+	}
+
+	return nil
 }
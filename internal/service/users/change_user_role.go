@@ -0,0 +1,40 @@
+package users
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+func (s *Service) ChangeUserRole(
+	ctx context.Context, id uuid.UUID, role string,
+) (dbgen.User, error) {
+	if err := Authorize(ctx, PermissionManageInstance); err != nil {
+		return dbgen.User{}, err
+	}
+	if !IsValidRole(role) {
+		return dbgen.User{}, fmt.Errorf("invalid role %q", role)
+	}
+
+	if role != RoleAdmin {
+		if _, err := s.ensureAnotherAdmin(ctx, id); err != nil {
+			return dbgen.User{}, err
+		}
+	}
+
+	before := s.auditSnapshot(ctx, id)
+	user, err := s.dbgen.UsersServiceChangeUserRole(
+		ctx, dbgen.UsersServiceChangeUserRoleParams{
+			ID:   id,
+			Role: role,
+		},
+	)
+	if err != nil {
+		return dbgen.User{}, err
+	}
+
+	s.auditUpdate(ctx, before, user)
+	return user, nil
+}
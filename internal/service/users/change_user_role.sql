@@ -0,0 +1,5 @@
+-- name: UsersServiceChangeUserRole :one
+UPDATE users
+SET role = @role
+WHERE id = @id
+RETURNING *;
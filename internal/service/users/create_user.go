@@ -2,19 +2,38 @@ package users
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
 	"github.com/eduardolat/pgbackweb/internal/util/cryptoutil"
 )
 
 func (s *Service) CreateUser(
 	ctx context.Context, params dbgen.UsersServiceCreateUserParams,
 ) (dbgen.User, error) {
+	if !IsValidRole(params.Role) {
+		return dbgen.User{}, fmt.Errorf("invalid role %q", params.Role)
+	}
+
 	hash, err := cryptoutil.CreateBcryptHash(params.Password)
 	if err != nil {
 		return dbgen.User{}, err
 	}
 	params.Password = hash
 
-	return s.dbgen.UsersServiceCreateUser(ctx, params)
+	user, err := s.dbgen.UsersServiceCreateUser(ctx, params)
+	if err != nil {
+		return dbgen.User{}, err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionCreate,
+		EntityType: audit.EntityUser,
+		EntityID:   user.ID,
+		EntityName: user.Email,
+		After:      user,
+	})
+
+	return user, nil
 }
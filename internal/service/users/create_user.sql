@@ -1,4 +1,4 @@
 -- name: UsersServiceCreateUser :one
-INSERT INTO users (name, email, password)
-VALUES (@name, lower(@email), @password)
+INSERT INTO users (name, email, password, role)
+VALUES (@name, lower(@email), @password, @role)
 RETURNING *;
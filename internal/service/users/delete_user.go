@@ -0,0 +1,36 @@
+package users
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/google/uuid"
+)
+
+func (s *Service) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	if err := Authorize(ctx, PermissionManageInstance); err != nil {
+		return err
+	}
+
+	if _, err := s.ensureAnotherAdmin(ctx, id); err != nil {
+		return err
+	}
+
+	before := s.auditSnapshot(ctx, id)
+	if err := s.dbgen.UsersServiceDeleteUser(ctx, id); err != nil {
+		return err
+	}
+
+	entry := audit.Entry{
+		Action:     audit.ActionDelete,
+		EntityType: audit.EntityUser,
+		EntityID:   id,
+		Before:     before,
+	}
+	if before != nil {
+		entry.EntityName = before.Email
+	}
+	audit.Record(ctx, s.dbgen, entry)
+
+	return nil
+}
@@ -0,0 +1,2 @@
+-- name: UsersServiceDeleteUser :exec
+DELETE FROM users WHERE id = @id;
@@ -0,0 +1,33 @@
+package users
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+// ensureAnotherAdmin returns an error when the user is the last active admin,
+// so the instance can't be left without anyone able to manage it.
+func (s *Service) ensureAnotherAdmin(
+	ctx context.Context, id uuid.UUID,
+) (dbgen.User, error) {
+	user, err := s.dbgen.UsersServiceGetUser(ctx, id)
+	if err != nil {
+		return dbgen.User{}, err
+	}
+	if user.Role != RoleAdmin || user.DisabledAt.Valid {
+		return user, nil
+	}
+
+	others, err := s.dbgen.UsersServiceCountOtherActiveAdmins(ctx, id)
+	if err != nil {
+		return dbgen.User{}, err
+	}
+	if others == 0 {
+		return dbgen.User{}, errors.New("there must be at least one active admin")
+	}
+
+	return user, nil
+}
@@ -0,0 +1,6 @@
+-- name: UsersServiceGetUser :one
+SELECT * FROM users WHERE id = @id;
+
+-- name: UsersServiceCountOtherActiveAdmins :one
+SELECT COUNT(*) FROM users
+WHERE role = 'admin' AND disabled_at IS NULL AND id <> @id;
@@ -0,0 +1,14 @@
+package users
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+)
+
+func (s *Service) GetAllUsers(ctx context.Context) ([]dbgen.User, error) {
+	if err := Authorize(ctx, PermissionManageInstance); err != nil {
+		return nil, err
+	}
+	return s.dbgen.UsersServiceGetAllUsers(ctx)
+}
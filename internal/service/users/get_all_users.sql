@@ -0,0 +1,2 @@
+-- name: UsersServiceGetAllUsers :many
+SELECT * FROM users ORDER BY created_at ASC;
@@ -0,0 +1,44 @@
+package users
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+)
+
+// InviteUser creates a user with the given role and a random temporary
+// password, which is returned so it can be shared with the invited user.
+func (s *Service) InviteUser(
+	ctx context.Context, name, email, role string,
+) (dbgen.User, string, error) {
+	if err := Authorize(ctx, PermissionManageInstance); err != nil {
+		return dbgen.User{}, "", err
+	}
+
+	password, err := temporaryPassword()
+	if err != nil {
+		return dbgen.User{}, "", err
+	}
+
+	user, err := s.CreateUser(ctx, dbgen.UsersServiceCreateUserParams{
+		Name:     name,
+		Email:    email,
+		Password: password,
+		Role:     role,
+	})
+	if err != nil {
+		return dbgen.User{}, "", err
+	}
+
+	return user, password, nil
+}
+
+func temporaryPassword() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,30 @@
+package users
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+)
+
+// SetRequireTwoFactor requires, or stops requiring, two-factor
+// authentication for everyone. Users without it are asked to set it up
+// before using the dashboard.
+func (s *Service) SetRequireTwoFactor(
+	ctx context.Context, required bool,
+) error {
+	if err := Authorize(ctx, PermissionManageInstance); err != nil {
+		return err
+	}
+
+	if err := s.dbgen.UsersServiceSetRequireTwoFactor(ctx, required); err != nil {
+		return err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionUpdate,
+		EntityType: audit.EntityInstance,
+		After:      struct{ RequireTwoFactor bool }{required},
+	})
+
+	return nil
+}
@@ -0,0 +1,3 @@
+-- name: UsersServiceSetRequireTwoFactor :exec
+UPDATE instance_settings
+SET require_two_factor = @require_two_factor, updated_at = NOW();
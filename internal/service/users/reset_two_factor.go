@@ -0,0 +1,31 @@
+package users
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+// ResetTwoFactor disables the two-factor authentication of the user and
+// deletes its recovery codes, for users that lost their authenticator.
+func (s *Service) ResetTwoFactor(
+	ctx context.Context, id uuid.UUID,
+) (dbgen.User, error) {
+	if err := Authorize(ctx, PermissionManageInstance); err != nil {
+		return dbgen.User{}, err
+	}
+
+	before := s.auditSnapshot(ctx, id)
+	user, err := s.dbgen.UsersServiceResetTwoFactor(ctx, id)
+	if err != nil {
+		return dbgen.User{}, err
+	}
+
+	if err := s.dbgen.UsersServiceDeleteUserRecoveryCodes(ctx, id); err != nil {
+		return dbgen.User{}, err
+	}
+
+	s.auditUpdate(ctx, before, user)
+	return user, nil
+}
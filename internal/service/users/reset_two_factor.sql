@@ -0,0 +1,8 @@
+-- name: UsersServiceResetTwoFactor :one
+UPDATE users
+SET totp_secret = NULL, totp_enabled_at = NULL, totp_last_counter = 0
+WHERE id = @id
+RETURNING *;
+
+-- name: UsersServiceDeleteUserRecoveryCodes :exec
+DELETE FROM user_recovery_codes WHERE user_id = @user_id;
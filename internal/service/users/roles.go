@@ -0,0 +1,130 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// The roles are given to the users for the whole instance and to the members
+// of every workspace. Users with the admin role manage the instance and act
+// as admins in every workspace, the other users get the role of their
+// membership in the current workspace.
+const (
+	// RoleAdmin can do everything, including managing the users.
+	RoleAdmin = "admin"
+	// RoleOperator can see everything and run backups, restorations and
+	// connection tests, but can't change the configuration.
+	RoleOperator = "operator"
+	// RoleViewer has read-only access and can't see the secrets.
+	RoleViewer = "viewer"
+)
+
+// Roles are all the valid user roles.
+var Roles = []string{RoleAdmin, RoleOperator, RoleViewer}
+
+// Permission is an action that a role may be allowed to perform.
+type Permission string
+
+const (
+	// PermissionRead allows to see the databases, destinations, backups,
+	// executions, restorations and webhooks.
+	PermissionRead Permission = "read"
+	// PermissionReadSecrets allows to see the decrypted connection strings
+	// and keys.
+	PermissionReadSecrets Permission = "read_secrets"
+	// PermissionRun allows to run backups, restorations and tests.
+	PermissionRun Permission = "run"
+	// PermissionManage allows to create, edit and delete the databases,
+	// destinations, backups, executions and webhooks.
+	PermissionManage Permission = "manage"
+	// PermissionManageInstance allows to manage the users and the workspaces
+	// and to export and import bundles. It is checked against the role of the
+	// user instead of the role in the current workspace.
+	PermissionManageInstance Permission = "manage_instance"
+)
+
+var rolePermissions = map[string][]Permission{
+	RoleAdmin: {
+		PermissionRead, PermissionReadSecrets, PermissionRun, PermissionManage,
+		PermissionManageInstance,
+	},
+	RoleOperator: {
+		PermissionRead, PermissionReadSecrets, PermissionRun,
+	},
+	RoleViewer: {
+		PermissionRead,
+	},
+}
+
+// ErrForbidden is returned when the role of the user doesn't have the
+// required permission.
+var ErrForbidden = errors.New("you don't have permission to perform this action")
+
+// IsValidRole reports whether role is one of Roles.
+func IsValidRole(role string) bool {
+	return slices.Contains(Roles, role)
+}
+
+// HasPermission reports whether role has the given permission.
+func HasPermission(role string, permission Permission) bool {
+	return slices.Contains(rolePermissions[role], permission)
+}
+
+type rolesCtxKey struct{}
+
+type roles struct {
+	role          string
+	workspaceRole string
+}
+
+// WithRole returns a copy of ctx that carries the role of the user that
+// started the request, the services use it to enforce the permissions.
+func WithRole(ctx context.Context, role string) context.Context {
+	return WithRoles(ctx, role, role)
+}
+
+// WithRoles is like WithRole but also carries the role of the user in the
+// current workspace, which is the one checked for every permission except
+// PermissionManageInstance.
+func WithRoles(
+	ctx context.Context, role, workspaceRole string,
+) context.Context {
+	return context.WithValue(ctx, rolesCtxKey{}, roles{
+		role:          role,
+		workspaceRole: workspaceRole,
+	})
+}
+
+type systemCtxKey struct{}
+
+// WithSystem returns a copy of ctx that marks the caller as an internal task
+// like the scheduler, the CLI or the provisioning, which is allowed to do
+// everything unless a role is also carried.
+func WithSystem(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemCtxKey{}, true)
+}
+
+// Can reports whether the roles carried by ctx have the given permission.
+// Contexts without roles are only allowed when they are marked with
+// WithSystem.
+func Can(ctx context.Context, permission Permission) bool {
+	r, ok := ctx.Value(rolesCtxKey{}).(roles)
+	if !ok {
+		system, _ := ctx.Value(systemCtxKey{}).(bool)
+		return system
+	}
+	if permission == PermissionManageInstance {
+		return HasPermission(r.role, permission)
+	}
+	return HasPermission(r.workspaceRole, permission)
+}
+
+// Authorize returns ErrForbidden when the role carried by ctx doesn't have
+// the given permission.
+func Authorize(ctx context.Context, permission Permission) error {
+	if !Can(ctx, permission) {
+		return ErrForbidden
+	}
+	return nil
+}
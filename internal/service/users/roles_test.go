@@ -0,0 +1,92 @@
+package users
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasPermission(t *testing.T) {
+	tests := []struct {
+		role       string
+		permission Permission
+		want       bool
+	}{
+		{RoleAdmin, PermissionRead, true},
+		{RoleAdmin, PermissionReadSecrets, true},
+		{RoleAdmin, PermissionRun, true},
+		{RoleAdmin, PermissionManage, true},
+		{RoleAdmin, PermissionManageInstance, true},
+		{RoleOperator, PermissionRead, true},
+		{RoleOperator, PermissionReadSecrets, true},
+		{RoleOperator, PermissionRun, true},
+		{RoleOperator, PermissionManage, false},
+		{RoleOperator, PermissionManageInstance, false},
+		{RoleViewer, PermissionRead, true},
+		{RoleViewer, PermissionReadSecrets, false},
+		{RoleViewer, PermissionRun, false},
+		{RoleViewer, PermissionManage, false},
+		{"unknown", PermissionRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.role+"/"+string(tt.permission), func(t *testing.T) {
+			assert.Equal(t, tt.want, HasPermission(tt.role, tt.permission))
+		})
+	}
+}
+
+func TestIsValidRole(t *testing.T) {
+	assert.True(t, IsValidRole(RoleAdmin))
+	assert.True(t, IsValidRole(RoleOperator))
+	assert.True(t, IsValidRole(RoleViewer))
+	assert.False(t, IsValidRole(""))
+	assert.False(t, IsValidRole("Admin"))
+}
+
+func TestAuthorize(t *testing.T) {
+	t.Run("Context without role", func(t *testing.T) {
+		ctx := context.Background()
+		assert.ErrorIs(t, Authorize(ctx, PermissionRead), ErrForbidden)
+		assert.False(t, Can(ctx, PermissionRead))
+	})
+
+	t.Run("System context", func(t *testing.T) {
+		ctx := WithSystem(context.Background())
+		assert.NoError(t, Authorize(ctx, PermissionManage))
+		assert.NoError(t, Authorize(ctx, PermissionManageInstance))
+	})
+
+	t.Run("Role wins over system", func(t *testing.T) {
+		ctx := WithRole(WithSystem(context.Background()), RoleViewer)
+		assert.ErrorIs(t, Authorize(ctx, PermissionRun), ErrForbidden)
+	})
+
+	t.Run("Allowed role", func(t *testing.T) {
+		ctx := WithRole(context.Background(), RoleOperator)
+		assert.NoError(t, Authorize(ctx, PermissionRun))
+	})
+
+	t.Run("Forbidden role", func(t *testing.T) {
+		ctx := WithRole(context.Background(), RoleViewer)
+		assert.ErrorIs(t, Authorize(ctx, PermissionRun), ErrForbidden)
+		assert.False(t, Can(ctx, PermissionReadSecrets))
+	})
+
+	t.Run("Workspace role", func(t *testing.T) {
+		ctx := WithRoles(context.Background(), RoleViewer, RoleAdmin)
+		assert.NoError(t, Authorize(ctx, PermissionManage))
+		assert.ErrorIs(t, Authorize(ctx, PermissionManageInstance), ErrForbidden)
+
+		ctx = WithRoles(context.Background(), RoleAdmin, RoleViewer)
+		assert.NoError(t, Authorize(ctx, PermissionManageInstance))
+		assert.ErrorIs(t, Authorize(ctx, PermissionManage), ErrForbidden)
+	})
+
+	t.Run("Values are kept without cancel", func(t *testing.T) {
+		ctx := WithRole(context.Background(), RoleViewer)
+		ctx = context.WithoutCancel(ctx)
+		assert.ErrorIs(t, Authorize(ctx, PermissionRun), ErrForbidden)
+	})
+}
@@ -0,0 +1,44 @@
+package users
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+// SetUserDisabled disables or enables the user. Disabled users can't log in
+// and their sessions are closed, their API tokens stop working too.
+func (s *Service) SetUserDisabled(
+	ctx context.Context, id uuid.UUID, disabled bool,
+) (dbgen.User, error) {
+	if err := Authorize(ctx, PermissionManageInstance); err != nil {
+		return dbgen.User{}, err
+	}
+
+	if disabled {
+		if _, err := s.ensureAnotherAdmin(ctx, id); err != nil {
+			return dbgen.User{}, err
+		}
+	}
+
+	before := s.auditSnapshot(ctx, id)
+	user, err := s.dbgen.UsersServiceSetUserDisabled(
+		ctx, dbgen.UsersServiceSetUserDisabledParams{
+			ID:       id,
+			Disabled: disabled,
+		},
+	)
+	if err != nil {
+		return dbgen.User{}, err
+	}
+	s.auditUpdate(ctx, before, user)
+
+	if disabled {
+		if err := s.dbgen.UsersServiceDeleteUserSessions(ctx, id); err != nil {
+			return dbgen.User{}, err
+		}
+	}
+
+	return user, nil
+}
@@ -0,0 +1,8 @@
+-- name: UsersServiceSetUserDisabled :one
+UPDATE users
+SET disabled_at = CASE WHEN @disabled::BOOLEAN THEN NOW() ELSE NULL END
+WHERE id = @id
+RETURNING *;
+
+-- name: UsersServiceDeleteUserSessions :exec
+DELETE FROM sessions WHERE user_id = @user_id;
@@ -18,5 +18,12 @@ func (s *Service) UpdateUser(
 		params.Password.String = hashedPassword
 	}
 
-	return s.dbgen.UsersServiceUpdateUser(ctx, params)
+	before := s.auditSnapshot(ctx, params.ID)
+	user, err := s.dbgen.UsersServiceUpdateUser(ctx, params)
+	if err != nil {
+		return dbgen.User{}, err
+	}
+
+	s.auditUpdate(ctx, before, user)
+	return user, nil
 }
@@ -0,0 +1,22 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/google/uuid"
+)
+
+// auditSnapshot returns the webhook to record its changes in the audit log,
+// or nil if it can't be read.
+func (s *Service) auditSnapshot(
+	ctx context.Context, id uuid.UUID,
+) *dbgen.Webhook {
+	webhook, err := s.dbgen.WebhooksServiceGetWebhook(
+		ctx, dbgen.WebhooksServiceGetWebhookParams{WebhookID: id},
+	)
+	if err != nil {
+		return nil
+	}
+	return &webhook
+}
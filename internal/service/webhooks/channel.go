@@ -0,0 +1,411 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/util/strutil"
+	"github.com/orsinium-labs/enum"
+)
+
+type (
+	channel     = enum.Member[channelData]
+	channelData struct {
+		Key  string
+		Name string
+	}
+)
+
+var (
+	// ChannelWebhook sends the rendered body template as is.
+	ChannelWebhook = channel{
+		Value: channelData{Key: "webhook", Name: "Webhook"},
+	}
+
+	// The chat channels send a message formatted for the incoming webhook of
+	// the app instead of the body template.
+	ChannelSlack = channel{
+		Value: channelData{Key: "slack", Name: "Slack"},
+	}
+	ChannelDiscord = channel{
+		Value: channelData{Key: "discord", Name: "Discord"},
+	}
+	ChannelTeams = channel{
+		Value: channelData{Key: "teams", Name: "Microsoft Teams"},
+	}
+	ChannelTelegram = channel{
+		Value: channelData{Key: "telegram", Name: "Telegram"},
+	}
+
+	// ChannelEmail sends an email through the SMTP server of the instance to
+	// the recipients of a mailto: URL.
+	ChannelEmail = channel{
+		Value: channelData{Key: "email", Name: "Email"},
+	}
+)
+
+var FullChannels = map[string]string{
+	ChannelWebhook.Value.Key:  ChannelWebhook.Value.Name,
+	ChannelSlack.Value.Key:    ChannelSlack.Value.Name,
+	ChannelDiscord.Value.Key:  ChannelDiscord.Value.Name,
+	ChannelTeams.Value.Key:    ChannelTeams.Value.Name,
+	ChannelTelegram.Value.Key: ChannelTelegram.Value.Name,
+	ChannelEmail.Value.Key:    ChannelEmail.Value.Name,
+}
+
+// IsChatChannel returns true for the channels that build the body of the
+// request, they are always sent with POST.
+func IsChatChannel(channelKey string) bool {
+	_, ok := FullChannels[channelKey]
+	return ok &&
+		channelKey != ChannelWebhook.Value.Key &&
+		channelKey != ChannelEmail.Value.Key
+}
+
+// RequestMethod returns the HTTP method the webhook is sent with, empty for
+// the email channel.
+func RequestMethod(webhook dbgen.Webhook) string {
+	if webhook.Channel == ChannelEmail.Value.Key {
+		return ""
+	}
+	if IsChatChannel(webhook.Channel) {
+		return http.MethodPost
+	}
+	return webhook.Method
+}
+
+// RenderChannelRequest renders the request of a webhook for the event. The
+// webhook channel renders the body template, the chat channels render the
+// URL and the headers and format the event as a message. The email channel
+// returns the recipients and the subject as headers and the text version of
+// the email as body.
+func RenderChannelRequest(
+	channelKey, rawURL, headers, body string, event Event,
+) (Request, error) {
+	if channelKey == "" || channelKey == ChannelWebhook.Value.Key {
+		return RenderRequest(rawURL, headers, body, event)
+	}
+	if channelKey == ChannelEmail.Value.Key {
+		msg, err := RenderEmail(rawURL, event)
+		if err != nil {
+			return Request{}, err
+		}
+		return emailRequest(msg), nil
+	}
+
+	req, err := RenderRequest(rawURL, headers, "", event)
+	if err != nil {
+		return Request{}, err
+	}
+	msg := newChatMessage(event)
+
+	var payload any
+	switch channelKey {
+	case ChannelSlack.Value.Key:
+		payload = slackPayload(msg)
+	case ChannelDiscord.Value.Key:
+		payload = discordPayload(msg)
+	case ChannelTeams.Value.Key:
+		payload = teamsPayload(msg)
+	case ChannelTelegram.Value.Key:
+		// The chat is a query param of the URL so the bot token and the chat
+		// are configured together, it is moved to the body.
+		parsedURL, err := url.Parse(req.URL)
+		if err != nil {
+			return Request{}, err
+		}
+		query := parsedURL.Query()
+		chatID := query.Get("chat_id")
+		if chatID == "" {
+			return Request{}, fmt.Errorf(
+				"the Telegram URL must have the chat_id query param",
+			)
+		}
+		query.Del("chat_id")
+		parsedURL.RawQuery = query.Encode()
+		req.URL = parsedURL.String()
+		payload = telegramPayload(chatID, msg)
+	default:
+		return Request{}, fmt.Errorf("unknown channel %s", channelKey)
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return Request{}, fmt.Errorf("error marshalling message: %w", err)
+	}
+	req.Body = string(b)
+
+	return req, nil
+}
+
+// maxErrorExcerpt is the maximum number of characters of the error message
+// included in the chat messages.
+const maxErrorExcerpt = 500
+
+type chatLevel int
+
+const (
+	chatLevelInfo chatLevel = iota
+	chatLevelGood
+	chatLevelWarning
+	chatLevelDanger
+)
+
+type chatField struct {
+	Name  string
+	Value string
+}
+
+// chatMessage is the event prepared to be formatted by the chat channels.
+type chatMessage struct {
+	Title  string
+	Level  chatLevel
+	Fields []chatField
+	Error  string
+	URL    string
+	Time   time.Time
+}
+
+func newChatMessage(event Event) chatMessage {
+	msg := chatMessage{
+		Title: event.TypeName,
+		Level: eventLevel(event.Type),
+		URL:   event.DashboardURL,
+		Time:  event.Time,
+	}
+	if event.TargetName != "" {
+		msg.Title += ": " + event.TargetName
+	}
+	if event.Test {
+		msg.Title = "[Test] " + msg.Title
+	}
+
+	if event.Status != "" {
+		msg.Fields = append(msg.Fields, chatField{"Status", event.Status})
+	}
+	if event.FileSize > 0 {
+		msg.Fields = append(msg.Fields, chatField{
+			"Size", strutil.FormatFileSize(event.FileSize),
+		})
+	}
+	if duration := event.Duration(); duration > 0 {
+		msg.Fields = append(msg.Fields, chatField{
+			"Duration", duration.Round(time.Second).String(),
+		})
+	}
+
+	msg.Error = event.Message
+	if utf8.RuneCountInString(msg.Error) > maxErrorExcerpt {
+		msg.Error = string([]rune(msg.Error)[:maxErrorExcerpt]) + "…"
+	}
+
+	return msg
+}
+
+// eventLevel returns how good or bad the event is, for the color of the
+// messages.
+func eventLevel(eventType string) chatLevel {
+	switch eventType {
+	case EventTypeDatabaseHealthy.Value.Key,
+		EventTypeDestinationHealthy.Value.Key,
+		EventTypeExecutionSuccess.Value.Key,
+		EventTypeRestorationSuccess.Value.Key:
+		return chatLevelGood
+	case EventTypeExecutionSuspicious.Value.Key,
+		EventTypeBackupStale.Value.Key,
+		EventTypeDestinationUploadSlow.Value.Key:
+		return chatLevelWarning
+	case EventTypeDatabaseUnhealthy.Value.Key,
+		EventTypeDestinationUnhealthy.Value.Key,
+		EventTypeExecutionFailed.Value.Key,
+		EventTypeRestorationFailed.Value.Key:
+		return chatLevelDanger
+	default:
+		return chatLevelInfo
+	}
+}
+
+// hexColor returns the color of the level as #rrggbb.
+func (l chatLevel) hexColor() string {
+	switch l {
+	case chatLevelGood:
+		return "#22c55e"
+	case chatLevelWarning:
+		return "#f59e0b"
+	case chatLevelDanger:
+		return "#ef4444"
+	default:
+		return "#3b82f6"
+	}
+}
+
+const dashboardLinkText = "Open in PG Back Web"
+
+// slackEscaper escapes the control characters of the Slack mrkdwn.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func slackPayload(msg chatMessage) map[string]any {
+	fields := []map[string]any{}
+	for _, field := range msg.Fields {
+		fields = append(fields, map[string]any{
+			"title": field.Name,
+			"value": slackEscaper.Replace(field.Value),
+			"short": true,
+		})
+	}
+
+	attachment := map[string]any{
+		"color":    msg.Level.hexColor(),
+		"fallback": slackEscaper.Replace(msg.Title),
+		"title":    slackEscaper.Replace(msg.Title),
+		"fields":   fields,
+		"ts":       msg.Time.Unix(),
+	}
+	if msg.Error != "" {
+		attachment["text"] = "```" + slackEscaper.Replace(msg.Error) + "```"
+	}
+	if msg.URL != "" {
+		attachment["title_link"] = msg.URL
+	}
+
+	return map[string]any{
+		"text":        slackEscaper.Replace(msg.Title),
+		"attachments": []any{attachment},
+	}
+}
+
+func discordPayload(msg chatMessage) map[string]any {
+	fields := []map[string]any{}
+	for _, field := range msg.Fields {
+		fields = append(fields, map[string]any{
+			"name":   field.Name,
+			"value":  field.Value,
+			"inline": true,
+		})
+	}
+
+	color, _ := strconv.ParseInt(
+		strings.TrimPrefix(msg.Level.hexColor(), "#"), 16, 64,
+	)
+
+	embed := map[string]any{
+		"title":     msg.Title,
+		"color":     color,
+		"fields":    fields,
+		"timestamp": msg.Time.UTC().Format(time.RFC3339),
+	}
+	if msg.Error != "" {
+		embed["description"] = "```\n" + strings.ReplaceAll(msg.Error, "```", "'''") + "\n```"
+	}
+	if msg.URL != "" {
+		embed["url"] = msg.URL
+	}
+
+	return map[string]any{
+		"embeds":           []any{embed},
+		"allowed_mentions": map[string]any{"parse": []string{}},
+	}
+}
+
+// teamsPayload returns an Adaptive Card, the format of the Teams workflows
+// that receive webhooks.
+func teamsPayload(msg chatMessage) map[string]any {
+	color := map[chatLevel]string{
+		chatLevelInfo:    "Accent",
+		chatLevelGood:    "Good",
+		chatLevelWarning: "Warning",
+		chatLevelDanger:  "Attention",
+	}[msg.Level]
+
+	facts := []map[string]any{}
+	for _, field := range msg.Fields {
+		facts = append(facts, map[string]any{
+			"title": field.Name,
+			"value": field.Value,
+		})
+	}
+
+	body := []any{
+		map[string]any{
+			"type":   "TextBlock",
+			"text":   msg.Title,
+			"weight": "Bolder",
+			"size":   "Medium",
+			"color":  color,
+			"wrap":   true,
+		},
+		map[string]any{"type": "FactSet", "facts": facts},
+	}
+	if msg.Error != "" {
+		body = append(body, map[string]any{
+			"type":     "TextBlock",
+			"text":     msg.Error,
+			"fontType": "Monospace",
+			"wrap":     true,
+		})
+	}
+
+	card := map[string]any{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if msg.URL != "" {
+		card["actions"] = []any{map[string]any{
+			"type":  "Action.OpenUrl",
+			"title": dashboardLinkText,
+			"url":   msg.URL,
+		}}
+	}
+
+	return map[string]any{
+		"type": "message",
+		"attachments": []any{map[string]any{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content":     card,
+		}},
+	}
+}
+
+func telegramPayload(chatID string, msg chatMessage) map[string]any {
+	emoji := map[chatLevel]string{
+		chatLevelInfo:    "ℹ️",
+		chatLevelGood:    "✅",
+		chatLevelWarning: "⚠️",
+		chatLevelDanger:  "❌",
+	}[msg.Level]
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s <b>%s</b>\n", emoji, html.EscapeString(msg.Title))
+	for _, field := range msg.Fields {
+		fmt.Fprintf(
+			&text, "\n<b>%s:</b> %s",
+			html.EscapeString(field.Name), html.EscapeString(field.Value),
+		)
+	}
+	if msg.Error != "" {
+		fmt.Fprintf(&text, "\n\n<pre>%s</pre>", html.EscapeString(msg.Error))
+	}
+	if msg.URL != "" {
+		fmt.Fprintf(
+			&text, "\n\n<a href=\"%s\">%s</a>",
+			html.EscapeString(msg.URL), dashboardLinkText,
+		)
+	}
+
+	return map[string]any{
+		"chat_id":                  chatID,
+		"text":                     text.String(),
+		"parse_mode":               "HTML",
+		"disable_web_page_preview": true,
+	}
+}
@@ -0,0 +1,180 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"net/mail"
+	"strings"
+	"text/template"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/integration/email"
+)
+
+// emailData is what the email templates are rendered with.
+type emailData struct {
+	chatMessage
+	Event Event
+	Color string
+}
+
+var emailTextTemplate = template.Must(template.New("text").Parse(
+	`{{ .Title }}
+
+Event: {{ .Event.TypeName }}
+{{- with .Event.TargetName }}
+Target: {{ . }}{{ end }}
+{{- with .Event.ExecutionID }}
+Execution: {{ . }}{{ end }}
+{{- range .Fields }}
+{{ .Name }}: {{ .Value }}{{ end }}
+Time: {{ .Time.UTC.Format "2006-01-02 15:04:05 MST" }}
+{{- with .Error }}
+
+{{ . }}{{ end }}
+{{- with .URL }}
+
+` + dashboardLinkText + `: {{ . }}{{ end }}
+`,
+))
+
+var emailHTMLTemplate = htmltemplate.Must(htmltemplate.New("html").Parse(
+	`<!DOCTYPE html>
+<html>
+<body style="margin:0;padding:24px;background:#f3f4f6;font-family:Arial,Helvetica,sans-serif;color:#111827;">
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" style="max-width:600px;margin:0 auto;background:#ffffff;border-radius:8px;border-top:6px solid {{ .Color }};">
+<tr><td style="padding:24px;">
+<h2 style="margin:0 0 16px 0;font-size:20px;">{{ .Title }}</h2>
+<table role="presentation" cellpadding="0" cellspacing="0" style="font-size:14px;">
+<tr><td style="padding:4px 16px 4px 0;color:#6b7280;">Event</td><td style="padding:4px 0;">{{ .Event.TypeName }}</td></tr>
+{{- with .Event.TargetName }}
+<tr><td style="padding:4px 16px 4px 0;color:#6b7280;">Target</td><td style="padding:4px 0;">{{ . }}</td></tr>
+{{- end }}
+{{- with .Event.ExecutionID }}
+<tr><td style="padding:4px 16px 4px 0;color:#6b7280;">Execution</td><td style="padding:4px 0;">{{ . }}</td></tr>
+{{- end }}
+{{- range .Fields }}
+<tr><td style="padding:4px 16px 4px 0;color:#6b7280;">{{ .Name }}</td><td style="padding:4px 0;">{{ .Value }}</td></tr>
+{{- end }}
+<tr><td style="padding:4px 16px 4px 0;color:#6b7280;">Time</td><td style="padding:4px 0;">{{ .Time.UTC.Format "2006-01-02 15:04:05 MST" }}</td></tr>
+</table>
+{{- with .Error }}
+<pre style="margin:16px 0 0 0;padding:12px;background:#f3f4f6;border-radius:4px;font-size:12px;white-space:pre-wrap;word-break:break-all;">{{ . }}</pre>
+{{- end }}
+{{- with .URL }}
+<p style="margin:24px 0 0 0;"><a href="{{ . }}" style="display:inline-block;padding:10px 16px;background:#111827;color:#ffffff;border-radius:4px;text-decoration:none;">` + dashboardLinkText + `</a></p>
+{{- end }}
+</td></tr>
+</table>
+</body>
+</html>
+`,
+))
+
+// ParseEmailRecipients returns the addresses of a mailto: URL with the
+// recipients separated by commas, e.g. mailto:ops@example.com,me@example.com.
+func ParseEmailRecipients(rawURL string) ([]string, error) {
+	list, ok := strings.CutPrefix(strings.TrimSpace(rawURL), "mailto:")
+	if !ok {
+		return nil, fmt.Errorf(
+			"the email URL must be mailto: followed by the recipients separated by commas",
+		)
+	}
+
+	recipients := []string{}
+	for _, part := range strings.Split(list, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		address, err := mail.ParseAddress(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid email recipient %q: %w", part, err)
+		}
+		recipients = append(recipients, address.Address)
+	}
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("the email URL has no recipients")
+	}
+
+	return recipients, nil
+}
+
+// RenderEmail renders the email of the email channel for the event, with a
+// text and an HTML version of the execution details. The URL can use the
+// template variables, it is rendered before parsing the recipients.
+func RenderEmail(rawURL string, event Event) (email.Message, error) {
+	renderedURL, err := renderTemplate("url", rawURL, event)
+	if err != nil {
+		return email.Message{}, fmt.Errorf("error rendering the URL: %w", err)
+	}
+	recipients, err := ParseEmailRecipients(renderedURL)
+	if err != nil {
+		return email.Message{}, err
+	}
+
+	msg := newChatMessage(event)
+	data := emailData{
+		chatMessage: msg,
+		Event:       event,
+		Color:       msg.Level.hexColor(),
+	}
+
+	var text, html bytes.Buffer
+	if err := emailTextTemplate.Execute(&text, data); err != nil {
+		return email.Message{}, fmt.Errorf("error rendering the email: %w", err)
+	}
+	if err := emailHTMLTemplate.Execute(&html, data); err != nil {
+		return email.Message{}, fmt.Errorf("error rendering the email: %w", err)
+	}
+
+	return email.Message{
+		To:      recipients,
+		Subject: msg.Title,
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// emailRequest returns the email as a request, the recipients and the
+// subject are the headers and the text version is the body.
+func emailRequest(msg email.Message) Request {
+	return Request{
+		URL: "mailto:" + strings.Join(msg.To, ","),
+		Headers: map[string]string{
+			"To":      strings.Join(msg.To, ", "),
+			"Subject": msg.Subject,
+		},
+		Body: msg.Text,
+	}
+}
+
+// sendEmail sends the email of the webhook for the event through the SMTP
+// server of the instance, filling the request of the execution.
+func (s *Service) sendEmail(
+	ctx context.Context, webhook dbgen.Webhook, event Event,
+	exec *dbgen.WebhooksServiceCreateWebhookExecutionParams,
+) error {
+	msg, err := RenderEmail(webhook.Url, event)
+	if err != nil {
+		return err
+	}
+
+	req := emailRequest(msg)
+	reqHeaders, err := json.Marshal(req.Headers)
+	if err != nil {
+		return fmt.Errorf("error marshalling request headers: %w", err)
+	}
+	exec.ReqHeaders = sql.NullString{String: string(reqHeaders), Valid: true}
+	exec.ReqBody = sql.NullString{String: req.Body, Valid: true}
+
+	cfg, err := s.smtpConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.ints.EmailClient.Send(ctx, cfg, msg)
+}
@@ -0,0 +1,248 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderChannelRequest(t *testing.T) {
+	event := SampleEvent(EventTypeExecutionFailed.Value.Key)
+
+	t.Run("Webhook", func(t *testing.T) {
+		req, err := RenderChannelRequest(
+			ChannelWebhook.Value.Key, "https://example.com", "",
+			`{"text": {{ json .TargetName }}}`, event,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"text": "My target"}`, req.Body)
+	})
+
+	t.Run("Telegram chat", func(t *testing.T) {
+		req, err := RenderChannelRequest(
+			ChannelTelegram.Value.Key,
+			"https://api.telegram.org/bot123:abc/sendMessage?chat_id=-100", "",
+			"", event,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://api.telegram.org/bot123:abc/sendMessage", req.URL)
+		assert.Contains(t, req.Body, `"chat_id":"-100"`)
+
+		_, err = RenderChannelRequest(
+			ChannelTelegram.Value.Key,
+			"https://api.telegram.org/bot123:abc/sendMessage", "", "", event,
+		)
+		assert.Error(t, err)
+	})
+
+	t.Run("Email", func(t *testing.T) {
+		req, err := RenderChannelRequest(
+			ChannelEmail.Value.Key, "mailto:ops@example.com, me@example.com", "",
+			"", event,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, "mailto:ops@example.com,me@example.com", req.URL)
+		assert.Equal(t, "ops@example.com, me@example.com", req.Headers["To"])
+		assert.Equal(t, "[Test] Execution failed: My target", req.Headers["Subject"])
+		assert.Contains(t, req.Body, "Status: failed")
+	})
+
+	t.Run("Unknown channel", func(t *testing.T) {
+		_, err := RenderChannelRequest("pager", "https://example.com", "", "", event)
+		assert.Error(t, err)
+	})
+
+	t.Run("Error excerpt", func(t *testing.T) {
+		long := event
+		long.Message = strings.Repeat("x", 2*maxErrorExcerpt)
+		msg := newChatMessage(long)
+		assert.Equal(t, maxErrorExcerpt+1, len([]rune(msg.Error)))
+	})
+}
+
+func TestSendRequestChannels(t *testing.T) {
+	var (
+		gotMethod string
+		gotPath   string
+		gotBody   map[string]any
+		status    = http.StatusOK
+	)
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			gotMethod, gotPath = r.Method, r.URL.Path
+			b, _ := io.ReadAll(r.Body)
+			gotBody = map[string]any{}
+			_ = json.Unmarshal(b, &gotBody)
+			w.WriteHeader(status)
+		},
+	))
+	defer server.Close()
+
+	event := SampleEvent(EventTypeExecutionFailed.Value.Key)
+	title := "[Test] Execution failed: My target"
+
+	tests := []struct {
+		channel string
+		url     string
+		check   func(t *testing.T, body map[string]any)
+	}{
+		{
+			channel: ChannelSlack.Value.Key,
+			url:     "/services/T000/B000/XXXX",
+			check: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, title, body["text"])
+				attachment := body["attachments"].([]any)[0].(map[string]any)
+				assert.Equal(t, "#ef4444", attachment["color"])
+				assert.Equal(t, event.DashboardURL, attachment["title_link"])
+				assert.Contains(t, attachment["text"], event.Message)
+			},
+		},
+		{
+			channel: ChannelDiscord.Value.Key,
+			url:     "/api/webhooks/000/XXXX",
+			check: func(t *testing.T, body map[string]any) {
+				embed := body["embeds"].([]any)[0].(map[string]any)
+				assert.Equal(t, title, embed["title"])
+				assert.Equal(t, float64(0xef4444), embed["color"])
+				assert.Equal(t, event.DashboardURL, embed["url"])
+				assert.Contains(t, embed["description"], event.Message)
+			},
+		},
+		{
+			channel: ChannelTeams.Value.Key,
+			url:     "/workflows/000",
+			check: func(t *testing.T, body map[string]any) {
+				attachment := body["attachments"].([]any)[0].(map[string]any)
+				card := attachment["content"].(map[string]any)
+				heading := card["body"].([]any)[0].(map[string]any)
+				assert.Equal(t, title, heading["text"])
+				assert.Equal(t, "Attention", heading["color"])
+				action := card["actions"].([]any)[0].(map[string]any)
+				assert.Equal(t, event.DashboardURL, action["url"])
+			},
+		},
+		{
+			channel: ChannelTelegram.Value.Key,
+			url:     "/bot123:abc/sendMessage?chat_id=42",
+			check: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "42", body["chat_id"])
+				assert.Equal(t, "HTML", body["parse_mode"])
+				assert.Contains(t, body["text"], "<b>"+title+"</b>")
+				assert.Contains(t, body["text"], event.Message)
+				assert.Contains(t, body["text"], event.DashboardURL)
+			},
+		},
+	}
+
+	s := &Service{}
+	for _, tt := range tests {
+		t.Run(tt.channel, func(t *testing.T) {
+			webhook := dbgen.Webhook{
+				Channel: tt.channel,
+				Url:     server.URL + tt.url,
+				Method:  http.MethodGet,
+			}
+			exec := dbgen.WebhooksServiceCreateWebhookExecutionParams{}
+
+			err := s.sendRequest(context.Background(), webhook, event, &exec)
+			require.NoError(t, err)
+			assert.Equal(t, http.MethodPost, gotMethod)
+			assert.Equal(t, strings.Split(tt.url, "?")[0], gotPath)
+			assert.Equal(t, int16(http.StatusOK), exec.ResStatus.Int16)
+			tt.check(t, gotBody)
+		})
+	}
+
+	t.Run("Non 2xx status", func(t *testing.T) {
+		status = http.StatusBadRequest
+		defer func() { status = http.StatusOK }()
+
+		webhook := dbgen.Webhook{
+			Channel: ChannelSlack.Value.Key,
+			Url:     server.URL,
+		}
+		exec := dbgen.WebhooksServiceCreateWebhookExecutionParams{}
+
+		err := s.sendRequest(context.Background(), webhook, event, &exec)
+		assert.EqualError(t, err, "unexpected response status 400")
+		assert.Equal(t, int16(http.StatusBadRequest), exec.ResStatus.Int16)
+	})
+}
+
+func TestParseEmailRecipients(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "single recipient",
+			url:  "mailto:ops@example.com",
+			want: []string{"ops@example.com"},
+		},
+		{
+			name: "several recipients with names",
+			url:  "mailto:Ops <ops@example.com>, me@example.com,",
+			want: []string{"ops@example.com", "me@example.com"},
+		},
+		{
+			name:    "without mailto",
+			url:     "ops@example.com",
+			wantErr: true,
+		},
+		{
+			name:    "invalid address",
+			url:     "mailto:ops",
+			wantErr: true,
+		},
+		{
+			name:    "no recipients",
+			url:     "mailto:",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEmailRecipients(tt.url)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRenderEmail(t *testing.T) {
+	event := SampleEvent(EventTypeExecutionFailed.Value.Key)
+	event.TargetName = "<db>"
+	event.Message = "pg_dump: error"
+	event.DashboardURL = "https://pbw.example.com/dashboard/executions"
+
+	msg, err := RenderEmail("mailto:ops@example.com", event)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"ops@example.com"}, msg.To)
+	assert.Equal(t, "[Test] Execution failed: <db>", msg.Subject)
+
+	assert.Contains(t, msg.Text, "Target: <db>")
+	assert.Contains(t, msg.Text, "Execution: "+event.ExecutionID)
+	assert.Contains(t, msg.Text, "pg_dump: error")
+	assert.Contains(t, msg.Text, dashboardLinkText+": "+event.DashboardURL)
+
+	assert.Contains(t, msg.HTML, "&lt;db&gt;")
+	assert.NotContains(t, msg.HTML, "<db>")
+	assert.Contains(t, msg.HTML, "#ef4444")
+	assert.Contains(t, msg.HTML, `href="`+event.DashboardURL+`"`)
+}
@@ -4,10 +4,60 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 )
 
 func (s *Service) CreateWebhook(
 	ctx context.Context, params dbgen.WebhooksServiceCreateWebhookParams,
 ) (dbgen.Webhook, error) {
-	return s.dbgen.WebhooksServiceCreateWebhook(ctx, params)
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return dbgen.Webhook{}, err
+	}
+
+	err := ValidateTemplates(
+		params.Channel, params.EventType, params.Url, params.Headers.String,
+		params.Body.String,
+	)
+	if err != nil {
+		return dbgen.Webhook{}, err
+	}
+
+	if params.TimeoutSeconds == 0 {
+		params.TimeoutSeconds = DefaultTimeoutSeconds
+	}
+	err = ValidateHTTPSettings(
+		params.TimeoutSeconds, params.CaCertificate.String, params.ProxyUrl.String,
+	)
+	if err != nil {
+		return dbgen.Webhook{}, err
+	}
+
+	if !params.WorkspaceID.Valid {
+		params.WorkspaceID = workspaces.FromContext(ctx)
+	}
+	workspaceID, err := s.validateTargets(
+		ctx, params.TargetIds, params.WorkspaceID,
+	)
+	if err != nil {
+		return dbgen.Webhook{}, err
+	}
+	params.WorkspaceID = workspaceID
+	params.EncryptionKey = s.env.PBW_ENCRYPTION_KEY
+
+	webhook, err := s.dbgen.WebhooksServiceCreateWebhook(ctx, params)
+	if err != nil {
+		return webhook, err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionCreate,
+		EntityType: audit.EntityWebhook,
+		EntityID:   webhook.ID,
+		EntityName: webhook.Name,
+		After:      webhook,
+	})
+
+	return webhook, nil
 }
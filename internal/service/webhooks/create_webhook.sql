@@ -1,8 +1,16 @@
 -- name: WebhooksServiceCreateWebhook :one
 INSERT INTO webhooks (
-  name, is_active, event_type, target_ids,
-  url, method, headers, body
+  name, is_active, event_type, target_ids, channel,
+  url, method, headers, body, timeout_seconds, tls_skip_verify,
+  ca_certificate, proxy_url, signing_secret, workspace_id
 ) VALUES (
-  @name, @is_active, @event_type, @target_ids,
-  @url, @method, @headers, @body
+  @name, @is_active, @event_type, @target_ids, @channel,
+  @url, @method, @headers, @body, @timeout_seconds, @tls_skip_verify,
+  NULLIF(sqlc.narg('ca_certificate')::TEXT, ''),
+  NULLIF(sqlc.narg('proxy_url')::TEXT, ''),
+  CASE
+    WHEN sqlc.narg('signing_secret')::TEXT <> ''
+    THEN pgp_sym_encrypt(sqlc.narg('signing_secret')::TEXT, sqlc.arg('encryption_key')::TEXT)
+  END,
+  COALESCE(sqlc.narg('workspace_id')::UUID, pbw_default_workspace_id())
 ) RETURNING *;
@@ -3,11 +3,37 @@ package webhooks
 import (
 	"context"
 
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/google/uuid"
 )
 
 func (s *Service) DeleteWebhook(
 	ctx context.Context, id uuid.UUID,
 ) error {
-	return s.dbgen.WebhooksServiceDeleteWebhook(ctx, id)
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return err
+	}
+
+	if err := s.ensureNotProvisioned(ctx, id); err != nil {
+		return err
+	}
+
+	before := s.auditSnapshot(ctx, id)
+	if err := s.dbgen.WebhooksServiceDeleteWebhook(ctx, id); err != nil {
+		return err
+	}
+
+	entry := audit.Entry{
+		Action:     audit.ActionDelete,
+		EntityType: audit.EntityWebhook,
+		EntityID:   id,
+		Before:     before,
+	}
+	if before != nil {
+		entry.EntityName = before.Name
+	}
+	audit.Record(ctx, s.dbgen, entry)
+
+	return nil
 }
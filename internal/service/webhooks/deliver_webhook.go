@@ -0,0 +1,169 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	deliveryStatusPending   = "pending"
+	deliveryStatusDelivered = "delivered"
+	deliveryStatusFailed    = "failed"
+)
+
+// deliveryLease is how long a delivery is reserved for the attempt that is
+// running. It is longer than the longest request timeout so an attempt that
+// is still waiting for the response is never delivered twice, and if the
+// process stops in the middle of an attempt the delivery is retried when the
+// lease expires.
+const deliveryLease = MaxTimeoutSeconds*time.Second + time.Minute
+
+// maxRetryBackoff caps the wait between two attempts of a delivery.
+const maxRetryBackoff = time.Hour
+
+// errWebhookInactive fails a delivery without retrying it.
+var errWebhookInactive = errors.New("the webhook is not active")
+
+// retryBackoff returns how long to wait after the given failed attempt, the
+// base backoff doubled on each attempt up to maxRetryBackoff.
+func retryBackoff(base time.Duration, attempt int32) time.Duration {
+	backoff := base
+	for i := int32(1); i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxRetryBackoff)
+}
+
+// enqueueDelivery stores the delivery of the event to the webhook, reserved
+// for the first attempt that is made right away.
+func (s *Service) enqueueDelivery(
+	ctx context.Context, webhookID uuid.UUID, event Event,
+) (dbgen.WebhookDelivery, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return dbgen.WebhookDelivery{}, fmt.Errorf(
+			"error marshalling webhook event: %w", err,
+		)
+	}
+
+	return s.dbgen.WebhooksServiceCreateDelivery(
+		ctx, dbgen.WebhooksServiceCreateDeliveryParams{
+			WebhookID:     webhookID,
+			Event:         payload,
+			NextAttemptAt: time.Now().Add(deliveryLease),
+		},
+	)
+}
+
+// deliver makes the next attempt of the delivery. A failed attempt is retried
+// later with backoff until PBW_WEBHOOK_MAX_ATTEMPTS is reached, then the
+// delivery is marked as failed.
+func (s *Service) deliver(
+	ctx context.Context, delivery dbgen.WebhookDelivery,
+) error {
+	attempt := delivery.Attempts + 1
+
+	sendErr := func() error {
+		webhook, err := s.dbgen.WebhooksServiceGetWebhook(
+			ctx, dbgen.WebhooksServiceGetWebhookParams{
+				WebhookID: delivery.WebhookID,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("error getting webhook: %w", err)
+		}
+		if !webhook.IsActive {
+			return errWebhookInactive
+		}
+
+		var event Event
+		if err := json.Unmarshal(delivery.Event, &event); err != nil {
+			return fmt.Errorf("error unmarshalling webhook event: %w", err)
+		}
+
+		return s.sendAttempt(
+			ctx, webhook, event,
+			uuid.NullUUID{UUID: delivery.ID, Valid: true}, attempt,
+		)
+	}()
+
+	params := dbgen.WebhooksServiceUpdateDeliveryParams{
+		ID:            delivery.ID,
+		Status:        deliveryStatusDelivered,
+		Attempts:      attempt,
+		NextAttemptAt: time.Now(),
+	}
+	if sendErr != nil {
+		params.Status = deliveryStatusFailed
+		params.LastError = sql.NullString{String: sendErr.Error(), Valid: true}
+
+		retry := !errors.Is(sendErr, errWebhookInactive) &&
+			attempt < int32(s.env.PBW_WEBHOOK_MAX_ATTEMPTS)
+		if retry {
+			params.Status = deliveryStatusPending
+			params.NextAttemptAt = time.Now().Add(
+				retryBackoff(s.env.PBW_WEBHOOK_RETRY_BACKOFF, attempt),
+			)
+		}
+	}
+
+	if err := s.dbgen.WebhooksServiceUpdateDelivery(ctx, params); err != nil {
+		return fmt.Errorf(
+			"error updating webhook delivery: %w", errors.Join(err, sendErr),
+		)
+	}
+
+	return sendErr
+}
+
+// ProcessDeliveryQueue makes the next attempt of the pending deliveries that
+// are due, including the ones interrupted by a restart.
+func (s *Service) ProcessDeliveryQueue() {
+	ctx := context.Background()
+
+	deliveries, err := s.dbgen.WebhooksServiceClaimDueDeliveries(
+		ctx, dbgen.WebhooksServiceClaimDueDeliveriesParams{
+			LeaseUntil: time.Now().Add(deliveryLease),
+			Limit:      20,
+		},
+	)
+	if err != nil {
+		logger.Error("error getting webhook deliveries to retry", logger.KV{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	eg := errgroup.Group{}
+	eg.SetLimit(5)
+
+	for _, delivery := range deliveries {
+		eg.Go(func() error {
+			logDeliveryError(delivery, s.deliver(ctx, delivery))
+			return nil
+		})
+	}
+
+	_ = eg.Wait()
+}
+
+func logDeliveryError(delivery dbgen.WebhookDelivery, err error) {
+	if err == nil {
+		return
+	}
+	logger.Error("error delivering webhook", logger.KV{
+		"webhook_id":  delivery.WebhookID,
+		"delivery_id": delivery.ID,
+		"attempt":     delivery.Attempts + 1,
+		"error":       err.Error(),
+	})
+}
@@ -0,0 +1,25 @@
+-- name: WebhooksServiceCreateDelivery :one
+INSERT INTO webhook_deliveries (webhook_id, event, next_attempt_at)
+VALUES (@webhook_id, @event, @next_attempt_at)
+RETURNING *;
+
+-- name: WebhooksServiceClaimDueDeliveries :many
+UPDATE webhook_deliveries
+SET next_attempt_at = @lease_until
+WHERE id IN (
+  SELECT id FROM webhook_deliveries
+  WHERE status = 'pending' AND next_attempt_at <= NOW()
+  ORDER BY next_attempt_at ASC
+  LIMIT sqlc.arg('limit')
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: WebhooksServiceUpdateDelivery :exec
+UPDATE webhook_deliveries
+SET
+  status = @status,
+  attempts = @attempts,
+  next_attempt_at = @next_attempt_at,
+  last_error = @last_error
+WHERE id = @id;
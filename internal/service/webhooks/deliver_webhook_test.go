@@ -0,0 +1,34 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    time.Duration
+		attempt int32
+		want    time.Duration
+	}{
+		{name: "First attempt", base: time.Minute, attempt: 1, want: time.Minute},
+		{name: "Second attempt", base: time.Minute, attempt: 2, want: 2 * time.Minute},
+		{name: "Fifth attempt", base: time.Minute, attempt: 5, want: 16 * time.Minute},
+		{name: "Capped", base: time.Minute, attempt: 8, want: time.Hour},
+		{name: "Many attempts", base: time.Minute, attempt: 1000, want: time.Hour},
+		{name: "Base over cap", base: 2 * time.Hour, attempt: 1, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, retryBackoff(tt.base, tt.attempt))
+		})
+	}
+}
+
+func TestDeliveryLeaseOutlastsRequests(t *testing.T) {
+	assert.Greater(t, deliveryLease, MaxTimeoutSeconds*time.Second)
+}
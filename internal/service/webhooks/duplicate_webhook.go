@@ -4,11 +4,36 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/google/uuid"
 )
 
 func (s *Service) DuplicateWebhook(
 	ctx context.Context, webhookID uuid.UUID,
 ) (dbgen.Webhook, error) {
-	return s.dbgen.WebhooksServiceDuplicateWebhook(ctx, webhookID)
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return dbgen.Webhook{}, err
+	}
+
+	webhook, err := s.dbgen.WebhooksServiceDuplicateWebhook(
+		ctx, dbgen.WebhooksServiceDuplicateWebhookParams{
+			WebhookID:   webhookID,
+			WorkspaceID: workspaces.FromContext(ctx),
+		},
+	)
+	if err != nil {
+		return webhook, err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionDuplicate,
+		EntityType: audit.EntityWebhook,
+		EntityID:   webhook.ID,
+		EntityName: webhook.Name,
+		After:      webhook,
+	})
+
+	return webhook, nil
 }
@@ -5,9 +5,15 @@ SELECT (
   #= hstore('id', uuid_generate_v4()::text)
   #= hstore('name', (webhooks.name || ' (copy)')::text)
   #= hstore('is_active', false::text)
+  #= hstore('is_provisioned', false::text)
   #= hstore('created_at', now()::text)
   #= hstore('updated_at', now()::text)
 ).*
 FROM webhooks
 WHERE webhooks.id = @webhook_id
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  webhooks.workspace_id = sqlc.narg('workspace_id')::UUID
+)
 RETURNING *;
@@ -0,0 +1,26 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ensureNotProvisioned returns an error when the webhook is provisioned from the
+// configuration file, because those can only be changed by editing the file.
+func (s *Service) ensureNotProvisioned(ctx context.Context, id uuid.UUID) error {
+	webhook, err := s.GetWebhook(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if webhook.IsProvisioned {
+		return fmt.Errorf(
+			"webhook %q is provisioned from the configuration file and is read-only",
+			webhook.Name,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/util/strutil"
+	"github.com/google/uuid"
+)
+
+// Event is the data of the event that runs the webhooks, the URL, the header
+// values and the body of the webhooks are rendered with it as templates. It
+// is stored as JSON with the deliveries so the retries send the same data.
+type Event struct {
+	// Type is the key of the event type, e.g. execution_failed.
+	Type string `json:"type"`
+	// TypeName is the human readable event type, e.g. Execution failed.
+	TypeName string `json:"type_name"`
+	// TargetID and TargetName are the database, destination or backup that
+	// triggered the event.
+	TargetID   string `json:"target_id"`
+	TargetName string `json:"target_name"`
+	// ExecutionID is empty for the events that aren't about an execution,
+	// for the restoration events it is the restored execution.
+	ExecutionID string `json:"execution_id"`
+	// RestorationID is empty for the events that aren't about a restoration.
+	RestorationID string `json:"restoration_id"`
+	// Status is the status of the execution or the restoration, or healthy,
+	// unhealthy or stale for the other events.
+	Status string `json:"status"`
+	// Message is the message of the execution or the restoration, the error
+	// of the health check or why the upload was slow, if any.
+	Message string `json:"message"`
+	// FileSize is the size of the backup file in bytes, zero if unknown.
+	FileSize int64 `json:"file_size"`
+	// StartedAt and FinishedAt are the times of the execution or the
+	// restoration, zero for the other events and until it finishes.
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	// DashboardURL links to the target in the dashboard, it is empty when
+	// PBW_PUBLIC_URL is not set.
+	DashboardURL string `json:"dashboard_url"`
+	// Time is when the event happened.
+	Time time.Time `json:"time"`
+	// Test is true for the sample events sent to test the webhooks.
+	Test bool `json:"test"`
+}
+
+// Duration returns how long the execution took, zero if it hasn't finished.
+func (e Event) Duration() time.Duration {
+	if e.StartedAt.IsZero() || e.FinishedAt.IsZero() {
+		return 0
+	}
+	return e.FinishedAt.Sub(e.StartedAt)
+}
+
+// SampleEvent returns an event of the given type with example values, to
+// preview and validate the templates.
+func SampleEvent(eventType string) Event {
+	event := Event{
+		Type:       eventType,
+		TypeName:   FullEventTypes[eventType],
+		TargetID:   "00000000-0000-0000-0000-000000000000",
+		TargetName: "My target",
+		Time:       time.Date(2025, 1, 1, 3, 5, 0, 0, time.UTC),
+		Test:       true,
+	}
+
+	switch eventType {
+	case EventTypeDatabaseHealthy.Value.Key, EventTypeDestinationHealthy.Value.Key:
+		event.Status = "healthy"
+	case EventTypeDatabaseUnhealthy.Value.Key, EventTypeDestinationUnhealthy.Value.Key:
+		event.Status = "unhealthy"
+		event.Message = "connection refused"
+	case EventTypeBackupStale.Value.Key:
+		event.Status = "stale"
+	default:
+		event.ExecutionID = "11111111-1111-1111-1111-111111111111"
+		event.Status = "success"
+		event.FileSize = 1_048_576
+		event.StartedAt = event.Time.Add(-5 * time.Minute)
+		event.FinishedAt = event.Time
+
+		switch eventType {
+		case EventTypeExecutionStarted.Value.Key:
+			event.Status = "running"
+			event.FileSize = 0
+			event.FinishedAt = time.Time{}
+		case EventTypeExecutionFailed.Value.Key:
+			event.Status = "failed"
+			event.Message = "pg_dump: error: connection refused"
+			event.FileSize = 0
+		case EventTypeExecutionDeletedByRetention.Value.Key:
+			event.Status = "deleted"
+		case EventTypeDestinationUploadSlow.Value.Key:
+			event.Message = SlowUploadMessage(
+				event.FileSize, 45*time.Minute, 30*time.Minute,
+			)
+		case EventTypeRestorationStarted.Value.Key,
+			EventTypeRestorationSuccess.Value.Key,
+			EventTypeRestorationFailed.Value.Key:
+			event.RestorationID = "22222222-2222-2222-2222-222222222222"
+			event.Message = "Backup restored successfully"
+			if eventType == EventTypeRestorationStarted.Value.Key {
+				event.Status = "running"
+				event.Message = ""
+				event.FinishedAt = time.Time{}
+			}
+			if eventType == EventTypeRestorationFailed.Value.Key {
+				event.Status = "failed"
+				event.Message = "pg_restore: error: connection refused"
+			}
+		}
+	}
+
+	event.DashboardURL = "https://pgbackweb.example.com" + dashboardPath(eventType, uuid.Nil)
+	return event
+}
+
+// SlowUploadMessage returns the message of the destination_upload_slow
+// events.
+func SlowUploadMessage(fileSize int64, took, threshold time.Duration) string {
+	return fmt.Sprintf(
+		"Uploading %s took %s, longer than the %s threshold",
+		strutil.FormatFileSize(fileSize), took.Round(time.Second), threshold,
+	)
+}
+
+// newEvent returns the event of the given type for the source, reading the
+// details of the target and of the execution and the restoration, if any.
+func (s *Service) newEvent(
+	ctx context.Context, eventType eventType, source eventSource,
+) (Event, error) {
+	targetID := source.TargetID
+	event := Event{
+		Type:     eventType.Value.Key,
+		TypeName: eventType.Value.Name,
+		TargetID: targetID.String(),
+		Time:     time.Now(),
+	}
+	if s.env.PBW_PUBLIC_URL != "" {
+		event.DashboardURL = strings.TrimSuffix(s.env.PBW_PUBLIC_URL, "/") +
+			dashboardPath(event.Type, targetID)
+	}
+
+	target, err := s.dbgen.WebhooksServiceGetEventTarget(ctx, targetID)
+	if err != nil {
+		return event, err
+	}
+	event.TargetName = target.Name
+	event.Message = target.Message.String
+
+	switch eventType {
+	case EventTypeDatabaseHealthy, EventTypeDestinationHealthy:
+		event.Status = "healthy"
+	case EventTypeDatabaseUnhealthy, EventTypeDestinationUnhealthy:
+		event.Status = "unhealthy"
+	case EventTypeBackupStale:
+		event.Status = "stale"
+	}
+
+	if source.ExecutionID.Valid {
+		execution, err := s.dbgen.WebhooksServiceGetEventExecution(
+			ctx, source.ExecutionID.UUID,
+		)
+		if err != nil {
+			return event, err
+		}
+		event.ExecutionID = execution.ID.String()
+		event.Status = execution.Status
+		event.Message = execution.Message.String
+		event.FileSize = execution.FileSize.Int64
+		event.StartedAt = execution.StartedAt
+		if execution.FinishedAt.Valid {
+			event.FinishedAt = execution.FinishedAt.Time
+		}
+	}
+
+	if source.RestorationID.Valid {
+		restoration, err := s.dbgen.WebhooksServiceGetEventRestoration(
+			ctx, source.RestorationID.UUID,
+		)
+		if err != nil {
+			return event, err
+		}
+		event.RestorationID = restoration.ID.String()
+		event.Status = restoration.Status
+		event.Message = restoration.Message.String
+		event.StartedAt = restoration.StartedAt
+		event.FinishedAt = time.Time{}
+		if restoration.FinishedAt.Valid {
+			event.FinishedAt = restoration.FinishedAt.Time
+		}
+	}
+
+	return event, nil
+}
+
+// dashboardPath returns the page of the dashboard that shows the target of
+// the event type.
+func dashboardPath(eventType string, targetID uuid.UUID) string {
+	switch eventType {
+	case EventTypeDatabaseHealthy.Value.Key, EventTypeDatabaseUnhealthy.Value.Key:
+		return pathutil.BuildPath("/dashboard/databases")
+	case EventTypeDestinationHealthy.Value.Key, EventTypeDestinationUnhealthy.Value.Key,
+		EventTypeDestinationUploadSlow.Value.Key:
+		return pathutil.BuildPath("/dashboard/destinations")
+	case EventTypeRestorationStarted.Value.Key, EventTypeRestorationSuccess.Value.Key,
+		EventTypeRestorationFailed.Value.Key:
+		return pathutil.BuildPath("/dashboard/restorations?database=" + targetID.String())
+	default:
+		return pathutil.BuildPath("/dashboard/executions?backup=" + targetID.String())
+	}
+}
@@ -0,0 +1,39 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampleEvent(t *testing.T) {
+	for key := range FullEventTypes {
+		t.Run(key, func(t *testing.T) {
+			event := SampleEvent(key)
+			assert.Equal(t, key, event.Type)
+			assert.NotEmpty(t, event.TypeName)
+			assert.NotEmpty(t, event.Status)
+
+			err := ValidateTemplates(
+				ChannelWebhook.Value.Key, key, "https://example.com", "",
+				`{"restoration": {{ json .RestorationID }}, "text": {{ json .Message }}}`,
+			)
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("Restoration events", func(t *testing.T) {
+		event := SampleEvent(EventTypeRestorationFailed.Value.Key)
+		assert.NotEmpty(t, event.RestorationID)
+		assert.Equal(t, "failed", event.Status)
+	})
+}
+
+func TestSlowUploadMessage(t *testing.T) {
+	assert.Equal(
+		t,
+		"Uploading 1.00 MB took 45m3s, longer than the 30m0s threshold",
+		SlowUploadMessage(1024*1024, 45*time.Minute+3200*time.Millisecond, 30*time.Minute),
+	)
+}
@@ -4,11 +4,17 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/google/uuid"
 )
 
 func (s *Service) GetWebhook(
 	ctx context.Context, id uuid.UUID,
 ) (dbgen.Webhook, error) {
-	return s.dbgen.WebhooksServiceGetWebhook(ctx, id)
+	return s.dbgen.WebhooksServiceGetWebhook(
+		ctx, dbgen.WebhooksServiceGetWebhookParams{
+			WebhookID:   id,
+			WorkspaceID: workspaces.FromContext(ctx),
+		},
+	)
 }
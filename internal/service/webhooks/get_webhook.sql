@@ -1,2 +1,8 @@
 -- name: WebhooksServiceGetWebhook :one
-SELECT * FROM webhooks WHERE id = @webhook_id;
+SELECT * FROM webhooks
+WHERE id = @webhook_id
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+);
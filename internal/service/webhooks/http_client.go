@@ -0,0 +1,124 @@
+package webhooks
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+)
+
+const (
+	DefaultTimeoutSeconds = 30
+	MaxTimeoutSeconds     = 300
+)
+
+// Methods are the HTTP methods the webhook channel can send the requests
+// with.
+var Methods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete,
+}
+
+// ValidateHTTPSettings checks the HTTP client settings of a webhook, the CA
+// certificate must be PEM encoded and the proxy URL must be an http, https
+// or socks5 URL. Empty values are not set.
+func ValidateHTTPSettings(
+	timeoutSeconds int32, caCertificate string, proxyURL string,
+) error {
+	if timeoutSeconds < 1 || timeoutSeconds > MaxTimeoutSeconds {
+		return fmt.Errorf(
+			"timeout must be between 1 and %d seconds", MaxTimeoutSeconds,
+		)
+	}
+
+	if _, err := certPool(caCertificate); err != nil {
+		return err
+	}
+
+	if _, err := parseProxyURL(proxyURL); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// newHTTPClient returns the client the requests of the webhook are sent
+// with. Without a proxy URL the proxy of the environment is used, like the
+// default client does.
+func newHTTPClient(webhook dbgen.Webhook) (*http.Client, error) {
+	roots, err := certPool(webhook.CaCertificate.String)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := parseProxyURL(webhook.ProxyUrl.String)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: webhook.TlsSkipVerify,
+		RootCAs:            roots,
+	}
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	timeout := webhook.TimeoutSeconds
+	if timeout < 1 {
+		timeout = DefaultTimeoutSeconds
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(timeout) * time.Second,
+	}, nil
+}
+
+// certPool returns the system certificates with the PEM encoded CA
+// certificate added, nil to use the system certificates when it is empty.
+func certPool(caCertificate string) (*x509.CertPool, error) {
+	if strings.TrimSpace(caCertificate) == "" {
+		return nil, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM([]byte(caCertificate)) {
+		return nil, errors.New("the CA certificate is not a valid PEM certificate")
+	}
+
+	return pool, nil
+}
+
+// parseProxyURL parses the proxy URL of a webhook, nil when it is empty.
+func parseProxyURL(proxyURL string) (*url.URL, error) {
+	proxyURL = strings.TrimSpace(proxyURL)
+	if proxyURL == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, errors.New("the proxy URL must be an http, https or socks5 URL")
+	}
+	if u.Host == "" {
+		return nil, errors.New("the proxy URL must have a host")
+	}
+
+	return u, nil
+}
@@ -0,0 +1,54 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
+	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
+)
+
+type PaginateFailedDeliveriesParams struct {
+	Page  int
+	Limit int
+}
+
+// PaginateFailedDeliveries returns the deliveries that ran out of attempts,
+// the most recently failed first.
+func (s *Service) PaginateFailedDeliveries(
+	ctx context.Context, params PaginateFailedDeliveriesParams,
+) (
+	paginateutil.PaginateResponse,
+	[]dbgen.WebhooksServicePaginateFailedDeliveriesRow,
+	error,
+) {
+	page := max(params.Page, 1)
+	limit := min(max(params.Limit, 1), 100)
+
+	count, err := s.dbgen.WebhooksServicePaginateFailedDeliveriesCount(
+		ctx, workspaces.FromContext(ctx),
+	)
+	if err != nil {
+		return paginateutil.PaginateResponse{}, nil, err
+	}
+
+	paginateParams := paginateutil.PaginateParams{
+		Page:  page,
+		Limit: limit,
+	}
+	offset := paginateutil.CreateOffsetFromParams(paginateParams)
+	paginateResponse := paginateutil.CreatePaginateResponse(paginateParams, int(count))
+
+	deliveries, err := s.dbgen.WebhooksServicePaginateFailedDeliveries(
+		ctx, dbgen.WebhooksServicePaginateFailedDeliveriesParams{
+			Limit:       int32(limit),
+			Offset:      int32(offset),
+			WorkspaceID: workspaces.FromContext(ctx),
+		},
+	)
+	if err != nil {
+		return paginateutil.PaginateResponse{}, nil, err
+	}
+
+	return paginateResponse, deliveries, nil
+}
@@ -0,0 +1,25 @@
+-- name: WebhooksServicePaginateFailedDeliveriesCount :one
+SELECT COUNT(webhook_deliveries.*) FROM webhook_deliveries
+INNER JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id
+WHERE webhook_deliveries.status = 'failed'
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  webhooks.workspace_id = sqlc.narg('workspace_id')::UUID
+);
+
+-- name: WebhooksServicePaginateFailedDeliveries :many
+SELECT
+  webhook_deliveries.*,
+  webhooks.name AS webhook_name,
+  webhooks.event_type AS webhook_event_type
+FROM webhook_deliveries
+INNER JOIN webhooks ON webhooks.id = webhook_deliveries.webhook_id
+WHERE webhook_deliveries.status = 'failed'
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  webhooks.workspace_id = sqlc.narg('workspace_id')::UUID
+)
+ORDER BY webhook_deliveries.updated_at DESC NULLS LAST
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
 	"github.com/google/uuid"
 )
@@ -21,7 +22,10 @@ func (s *Service) PaginateWebhookExecutions(
 	limit := min(max(params.Limit, 1), 100)
 
 	count, err := s.dbgen.WebhooksServicePaginateWebhookExecutionsCount(
-		ctx, params.WebhookID,
+		ctx, dbgen.WebhooksServicePaginateWebhookExecutionsCountParams{
+			WebhookID:   params.WebhookID,
+			WorkspaceID: workspaces.FromContext(ctx),
+		},
 	)
 	if err != nil {
 		return paginateutil.PaginateResponse{}, nil, err
@@ -36,9 +40,10 @@ func (s *Service) PaginateWebhookExecutions(
 
 	webhookExecutions, err := s.dbgen.WebhooksServicePaginateWebhookExecutions(
 		ctx, dbgen.WebhooksServicePaginateWebhookExecutionsParams{
-			WebhookID: params.WebhookID,
-			Limit:     int32(params.Limit),
-			Offset:    int32(offset),
+			WebhookID:   params.WebhookID,
+			Limit:       int32(params.Limit),
+			Offset:      int32(offset),
+			WorkspaceID: workspaces.FromContext(ctx),
 		},
 	)
 	if err != nil {
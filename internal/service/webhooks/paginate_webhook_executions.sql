@@ -1,9 +1,21 @@
 -- name: WebhooksServicePaginateWebhookExecutionsCount :one
-SELECT COUNT(*) FROM webhook_executions
-WHERE webhook_id = @webhook_id;
+SELECT COUNT(webhook_executions.*) FROM webhook_executions
+INNER JOIN webhooks ON webhooks.id = webhook_executions.webhook_id
+WHERE webhook_executions.webhook_id = @webhook_id
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  webhooks.workspace_id = sqlc.narg('workspace_id')::UUID
+);
 
 -- name: WebhooksServicePaginateWebhookExecutions :many
-SELECT * FROM webhook_executions
-WHERE webhook_id = @webhook_id
-ORDER BY created_at DESC
+SELECT webhook_executions.* FROM webhook_executions
+INNER JOIN webhooks ON webhooks.id = webhook_executions.webhook_id
+WHERE webhook_executions.webhook_id = @webhook_id
+AND (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  webhooks.workspace_id = sqlc.narg('workspace_id')::UUID
+)
+ORDER BY webhook_executions.created_at DESC
 LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
\ No newline at end of file
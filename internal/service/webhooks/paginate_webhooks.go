@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
 )
 
@@ -18,7 +19,9 @@ func (s *Service) PaginateWebhooks(
 	page := max(params.Page, 1)
 	limit := min(max(params.Limit, 1), 100)
 
-	count, err := s.dbgen.WebhooksServicePaginateWebhooksCount(ctx)
+	count, err := s.dbgen.WebhooksServicePaginateWebhooksCount(
+		ctx, workspaces.FromContext(ctx),
+	)
 	if err != nil {
 		return paginateutil.PaginateResponse{}, nil, err
 	}
@@ -32,8 +35,9 @@ func (s *Service) PaginateWebhooks(
 
 	webhooks, err := s.dbgen.WebhooksServicePaginateWebhooks(
 		ctx, dbgen.WebhooksServicePaginateWebhooksParams{
-			Limit:  int32(params.Limit),
-			Offset: int32(offset),
+			Limit:       int32(params.Limit),
+			Offset:      int32(offset),
+			WorkspaceID: workspaces.FromContext(ctx),
 		},
 	)
 	if err != nil {
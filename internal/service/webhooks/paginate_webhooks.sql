@@ -1,7 +1,17 @@
 -- name: WebhooksServicePaginateWebhooksCount :one
-SELECT COUNT(*) FROM webhooks;
+SELECT COUNT(*) FROM webhooks
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+);
 
 -- name: WebhooksServicePaginateWebhooks :many
 SELECT * FROM webhooks
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspace_id = sqlc.narg('workspace_id')::UUID
+)
 ORDER BY created_at DESC
 LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -0,0 +1,46 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
+	"github.com/google/uuid"
+)
+
+// RedeliverWebhook queues a failed delivery again with all its attempts and
+// makes the first one in background.
+func (s *Service) RedeliverWebhook(
+	ctx context.Context, deliveryID uuid.UUID,
+) error {
+	if err := users.Authorize(ctx, users.PermissionRun); err != nil {
+		return err
+	}
+
+	delivery, err := s.dbgen.WebhooksServiceRedeliverDelivery(
+		ctx, dbgen.WebhooksServiceRedeliverDeliveryParams{
+			DeliveryID:    deliveryID,
+			NextAttemptAt: time.Now().Add(deliveryLease),
+			WorkspaceID:   workspaces.FromContext(ctx),
+		},
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("the delivery was not found or has not failed")
+	}
+	if err != nil {
+		return err
+	}
+
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		logDeliveryError(delivery, s.deliver(context.Background(), delivery))
+	}()
+
+	return nil
+}
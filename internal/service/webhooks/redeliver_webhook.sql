@@ -0,0 +1,19 @@
+-- name: WebhooksServiceRedeliverDelivery :one
+UPDATE webhook_deliveries
+SET
+  status = 'pending',
+  attempts = 0,
+  next_attempt_at = @next_attempt_at,
+  last_error = NULL
+WHERE webhook_deliveries.id = @delivery_id
+AND webhook_deliveries.status = 'failed'
+AND EXISTS (
+  SELECT 1 FROM webhooks
+  WHERE webhooks.id = webhook_deliveries.webhook_id
+  AND (
+    sqlc.narg('workspace_id')::UUID IS NULL
+    OR
+    webhooks.workspace_id = sqlc.narg('workspace_id')::UUID
+  )
+)
+RETURNING webhook_deliveries.*;
@@ -11,61 +11,167 @@ import (
 
 // RunDatabaseHealthy runs the healthy webhooks for the given database ID.
 func (s *Service) RunDatabaseHealthy(databaseID uuid.UUID) {
-	go func() {
-		ctx := context.Background()
-		runWebhook(s, ctx, EventTypeDatabaseHealthy, databaseID)
-	}()
+	s.runInBackground(EventTypeDatabaseHealthy, eventSource{TargetID: databaseID})
 }
 
 // RunDatabaseUnhealthy runs the unhealthy webhooks for the given database ID.
 func (s *Service) RunDatabaseUnhealthy(databaseID uuid.UUID) {
-	go func() {
-		ctx := context.Background()
-		runWebhook(s, ctx, EventTypeDatabaseUnhealthy, databaseID)
-	}()
+	s.runInBackground(EventTypeDatabaseUnhealthy, eventSource{TargetID: databaseID})
 }
 
 // RunDestinationHealthy runs the healthy webhooks for the given destination ID.
 func (s *Service) RunDestinationHealthy(destinationID uuid.UUID) {
-	go func() {
-		ctx := context.Background()
-		runWebhook(s, ctx, EventTypeDestinationHealthy, destinationID)
-	}()
+	s.runInBackground(EventTypeDestinationHealthy, eventSource{TargetID: destinationID})
 }
 
 // RunDestinationUnhealthy runs the unhealthy webhooks for the given
 // destination ID.
 func (s *Service) RunDestinationUnhealthy(destinationID uuid.UUID) {
-	go func() {
-		ctx := context.Background()
-		runWebhook(s, ctx, EventTypeDestinationUnhealthy, destinationID)
-	}()
+	s.runInBackground(EventTypeDestinationUnhealthy, eventSource{TargetID: destinationID})
+}
+
+// RunDestinationUploadSlow runs the upload slow webhooks of the destination
+// for the given execution, with a message that explains how slow it was.
+func (s *Service) RunDestinationUploadSlow(
+	destinationID, executionID uuid.UUID, message string,
+) {
+	s.runInBackground(EventTypeDestinationUploadSlow, eventSource{
+		TargetID:    destinationID,
+		ExecutionID: uuid.NullUUID{UUID: executionID, Valid: true},
+		Message:     message,
+	})
+}
+
+// RunExecutionStarted runs the started webhooks of the backup for the given
+// execution.
+func (s *Service) RunExecutionStarted(backupID, executionID uuid.UUID) {
+	s.runExecutionInBackground(EventTypeExecutionStarted, backupID, executionID)
+}
+
+// RunExecutionSuccess runs the success webhooks of the backup for the given
+// execution.
+func (s *Service) RunExecutionSuccess(backupID, executionID uuid.UUID) {
+	s.runExecutionInBackground(EventTypeExecutionSuccess, backupID, executionID)
+}
+
+// RunExecutionFailed runs the failed webhooks of the backup for the given
+// execution.
+func (s *Service) RunExecutionFailed(backupID, executionID uuid.UUID) {
+	s.runExecutionInBackground(EventTypeExecutionFailed, backupID, executionID)
+}
+
+// RunExecutionSuspicious runs the suspicious webhooks of the backup for the given
+// execution.
+func (s *Service) RunExecutionSuspicious(backupID, executionID uuid.UUID) {
+	s.runExecutionInBackground(EventTypeExecutionSuspicious, backupID, executionID)
+}
+
+// RunExecutionDeletedByRetention runs the deleted by retention webhooks of the
+// backup for the given execution.
+func (s *Service) RunExecutionDeletedByRetention(backupID, executionID uuid.UUID) {
+	s.runExecutionInBackground(
+		EventTypeExecutionDeletedByRetention, backupID, executionID,
+	)
 }
 
-// RunExecutionSuccess runs the success webhooks for the given execution ID.
-func (s *Service) RunExecutionSuccess(backupID uuid.UUID) {
+// RunBackupStale runs the stale webhooks for the given backup ID.
+func (s *Service) RunBackupStale(backupID uuid.UUID) {
+	s.runInBackground(EventTypeBackupStale, eventSource{TargetID: backupID})
+}
+
+// RunRestorationStarted runs the started webhooks of the database of the
+// given restoration.
+func (s *Service) RunRestorationStarted(restorationID uuid.UUID) {
+	s.runRestorationInBackground(EventTypeRestorationStarted, restorationID)
+}
+
+// RunRestorationSuccess runs the success webhooks of the database of the
+// given restoration.
+func (s *Service) RunRestorationSuccess(restorationID uuid.UUID) {
+	s.runRestorationInBackground(EventTypeRestorationSuccess, restorationID)
+}
+
+// RunRestorationFailed runs the failed webhooks of the database of the given
+// restoration.
+func (s *Service) RunRestorationFailed(restorationID uuid.UUID) {
+	s.runRestorationInBackground(EventTypeRestorationFailed, restorationID)
+}
+
+// eventSource is what an event is about: the target the webhooks are
+// attached to and the execution or the restoration, if any.
+type eventSource struct {
+	TargetID      uuid.UUID
+	ExecutionID   uuid.NullUUID
+	RestorationID uuid.NullUUID
+	// Message replaces the message of the target, the execution or the
+	// restoration when it is not empty.
+	Message string
+}
+
+func (s *Service) runExecutionInBackground(
+	eventType eventType, backupID, executionID uuid.UUID,
+) {
+	s.runInBackground(eventType, eventSource{
+		TargetID:    backupID,
+		ExecutionID: uuid.NullUUID{UUID: executionID, Valid: true},
+	})
+}
+
+// runRestorationInBackground runs the webhooks of the database of the
+// restoration in a goroutine tracked by Wait.
+func (s *Service) runRestorationInBackground(
+	eventType eventType, restorationID uuid.UUID,
+) {
+	s.running.Add(1)
 	go func() {
+		defer s.running.Done()
 		ctx := context.Background()
-		runWebhook(s, ctx, EventTypeExecutionSuccess, backupID)
+
+		restoration, err := s.dbgen.WebhooksServiceGetRestorationSource(
+			ctx, restorationID,
+		)
+		if err != nil {
+			logger.Error("error getting webhook restoration", logger.KV{
+				"restoration_id": restorationID,
+				"error":          err.Error(),
+			})
+			return
+		}
+
+		runWebhook(s, ctx, eventType, eventSource{
+			TargetID:      restoration.DatabaseID,
+			ExecutionID:   uuid.NullUUID{UUID: restoration.ExecutionID, Valid: true},
+			RestorationID: uuid.NullUUID{UUID: restorationID, Valid: true},
+		})
 	}()
 }
 
-// RunExecutionFailed runs the failed webhooks for the given execution ID.
-func (s *Service) RunExecutionFailed(backupID uuid.UUID) {
+// runInBackground runs the webhooks for the given event type and source in a
+// goroutine tracked by Wait.
+func (s *Service) runInBackground(eventType eventType, source eventSource) {
+	s.running.Add(1)
 	go func() {
-		ctx := context.Background()
-		runWebhook(s, ctx, EventTypeExecutionFailed, backupID)
+		defer s.running.Done()
+		runWebhook(s, context.Background(), eventType, source)
 	}()
 }
 
-// runWebhook runs the webhooks for the given event type and target ID.
+// Wait blocks until all the webhooks running in background finish. Short
+// lived processes like the CLI must call it before exiting so no webhook is
+// lost.
+func (s *Service) Wait() {
+	s.running.Wait()
+}
+
+// runWebhook queues a delivery of the event for each webhook of the given
+// event type and target of the source and makes its first attempt.
 func runWebhook(
-	s *Service, ctx context.Context, eventType eventType, targetID uuid.UUID,
+	s *Service, ctx context.Context, eventType eventType, source eventSource,
 ) {
 	webhooks, err := s.dbgen.WebhooksServiceGetWebhooksToRun(
 		ctx, dbgen.WebhooksServiceGetWebhooksToRunParams{
 			EventType: eventType.Value.Key,
-			TargetID:  targetID,
+			TargetID:  source.TargetID,
 		},
 	)
 	if err != nil {
@@ -76,18 +182,33 @@ func runWebhook(
 		return
 	}
 
+	// A missing detail shouldn't stop the notification, the webhooks are sent
+	// with the part of the event that could be read.
+	event, err := s.newEvent(ctx, eventType, source)
+	if err != nil {
+		logger.Error("error getting webhook event details", logger.KV{
+			"target_id": source.TargetID,
+			"error":     err.Error(),
+		})
+	}
+	if source.Message != "" {
+		event.Message = source.Message
+	}
+
 	eg, ctx := errgroup.WithContext(ctx)
 	eg.SetLimit(5)
 
 	for _, webhook := range webhooks {
 		eg.Go(func() error {
-			err := s.SendWebhookRequest(ctx, webhook)
+			delivery, err := s.enqueueDelivery(ctx, webhook.ID, event)
 			if err != nil {
-				logger.Error("error sending webhook request", logger.KV{
+				logger.Error("error creating webhook delivery", logger.KV{
 					"webhook_id": webhook.ID,
 					"error":      err.Error(),
 				})
+				return nil
 			}
+			logDeliveryError(delivery, s.deliver(ctx, delivery))
 			return nil
 		})
 	}
@@ -6,11 +6,43 @@ AND @target_id::UUID = ANY(target_ids);
 
 -- name: WebhooksServiceCreateWebhookExecution :one
 INSERT INTO webhook_executions (
-  webhook_id, req_method, req_headers, req_body,
+  webhook_id, delivery_id, attempt, error, req_method, req_headers, req_body,
   res_status, res_headers, res_body, res_duration
 )
 VALUES (
-  @webhook_id, @req_method, @req_headers, @req_body,
+  @webhook_id, @delivery_id, @attempt, @error, @req_method, @req_headers, @req_body,
   @res_status, @res_headers, @res_body, @res_duration
 )
 RETURNING *;
+
+-- name: WebhooksServiceGetEventTarget :one
+SELECT name, test_error AS message FROM databases WHERE id = @target_id
+UNION ALL
+SELECT name, test_error AS message FROM destinations WHERE id = @target_id
+UNION ALL
+SELECT name, NULL::TEXT AS message FROM backups WHERE id = @target_id
+LIMIT 1;
+
+-- name: WebhooksServiceGetRestorationSource :one
+SELECT
+  COALESCE(restorations.database_id, backups.database_id)::UUID AS database_id,
+  restorations.execution_id
+FROM restorations
+INNER JOIN executions ON executions.id = restorations.execution_id
+INNER JOIN backups ON backups.id = executions.backup_id
+WHERE restorations.id = @restoration_id;
+
+-- name: WebhooksServiceGetEventRestoration :one
+SELECT id, status, message, started_at, finished_at
+FROM restorations
+WHERE id = @id;
+
+-- name: WebhooksServiceGetEventExecution :one
+SELECT id, status, message, file_size, started_at, finished_at
+FROM executions
+WHERE id = @id;
+
+-- name: WebhooksServiceGetSigningSecret :one
+SELECT pgp_sym_decrypt(signing_secret, @encryption_key)::TEXT
+FROM webhooks
+WHERE id = @webhook_id AND signing_secret IS NOT NULL;
@@ -0,0 +1,24 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/google/uuid"
+)
+
+// SendTestMessage sends a sample event of the webhook event type right away
+// and returns the error of the request, if any. The message is marked as a
+// test and stored as an execution of the webhook.
+func (s *Service) SendTestMessage(ctx context.Context, webhookID uuid.UUID) error {
+	if err := users.Authorize(ctx, users.PermissionRun); err != nil {
+		return err
+	}
+
+	webhook, err := s.GetWebhook(ctx, webhookID)
+	if err != nil {
+		return err
+	}
+
+	return s.SendWebhookRequest(ctx, webhook, SampleEvent(webhook.EventType))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,39 +13,115 @@ import (
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
 	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/google/uuid"
 )
 
-// SendWebhookRequest sends a webhook request to the given webhook and
-// stores the result in the database.
+// SendWebhookRequest sends a single request of the webhook for the event,
+// without retries, and stores it as an execution of the webhook.
 func (s *Service) SendWebhookRequest(
-	ctx context.Context, webhook dbgen.Webhook,
+	ctx context.Context, webhook dbgen.Webhook, event Event,
+) error {
+	return s.sendAttempt(ctx, webhook, event, uuid.NullUUID{}, 1)
+}
+
+// sendAttempt sends the request of the webhook for the event and stores it
+// as an execution, also when it fails, so every attempt of a delivery can be
+// inspected. A request that can't be sent or gets a non 2xx response status
+// returns an error.
+func (s *Service) sendAttempt(
+	ctx context.Context, webhook dbgen.Webhook, event Event,
+	deliveryID uuid.NullUUID, attempt int32,
 ) error {
 	timeStart := time.Now()
 
-	if !webhook.Body.Valid || webhook.Body.String == "" {
-		webhook.Body = sql.NullString{String: "{}", Valid: true}
+	exec := dbgen.WebhooksServiceCreateWebhookExecutionParams{
+		WebhookID:  webhook.ID,
+		DeliveryID: deliveryID,
+		Attempt:    attempt,
+	}
+	if method := RequestMethod(webhook); method != "" {
+		exec.ReqMethod = sql.NullString{String: method, Valid: true}
+	}
+
+	sendErr := s.sendRequest(ctx, webhook, event, &exec)
+	exec.ResDuration = sql.NullInt32{
+		Int32: int32(time.Since(timeStart).Milliseconds()),
+		Valid: true,
+	}
+	if sendErr != nil {
+		exec.Error = sql.NullString{String: sendErr.Error(), Valid: true}
+	}
+
+	_, err := s.dbgen.WebhooksServiceCreateWebhookExecution(ctx, exec)
+	if err != nil {
+		return fmt.Errorf(
+			"error updating webhook result: %w", errors.Join(err, sendErr),
+		)
+	}
+	if sendErr != nil {
+		return sendErr
+	}
+
+	logger.Info("webhook sent successfully", logger.KV{
+		"webhook_id": webhook.ID,
+		"status":     exec.ResStatus.Int16,
+	})
+
+	return nil
+}
+
+// sendRequest renders the webhook templates with the event and sends the
+// request, filling the request and the response of the execution with the
+// parts it gets to.
+func (s *Service) sendRequest(
+	ctx context.Context, webhook dbgen.Webhook, event Event,
+	exec *dbgen.WebhooksServiceCreateWebhookExecutionParams,
+) error {
+	if webhook.Channel == ChannelEmail.Value.Key {
+		return s.sendEmail(ctx, webhook, event, exec)
 	}
-	bodyReader := strings.NewReader(webhook.Body.String)
 
-	if !webhook.Headers.Valid || webhook.Headers.String == "" {
-		webhook.Headers = sql.NullString{String: "{}", Valid: true}
+	rendered, err := RenderChannelRequest(
+		webhook.Channel, webhook.Url, webhook.Headers.String,
+		webhook.Body.String, event,
+	)
+	if err != nil {
+		return err
 	}
-	headers := map[string]string{}
-	err := json.Unmarshal([]byte(webhook.Headers.String), &headers)
+
+	if webhook.SigningSecret != nil {
+		secret, err := s.dbgen.WebhooksServiceGetSigningSecret(
+			ctx, dbgen.WebhooksServiceGetSigningSecretParams{
+				EncryptionKey: s.env.PBW_ENCRYPTION_KEY,
+				WebhookID:     webhook.ID,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("error getting signing secret: %w", err)
+		}
+		rendered.Headers[SignatureHeader] = Sign(secret, time.Now(), rendered.Body)
+	}
+
+	reqHeaders, err := json.Marshal(rendered.Headers)
 	if err != nil {
-		return fmt.Errorf("error parsing headers: %w", err)
+		return fmt.Errorf("error marshalling request headers: %w", err)
 	}
+	exec.ReqHeaders = sql.NullString{String: string(reqHeaders), Valid: true}
+	exec.ReqBody = sql.NullString{String: rendered.Body, Valid: true}
 
-	client := http.Client{Timeout: time.Second * 30}
+	client, err := newHTTPClient(webhook)
+	if err != nil {
+		return err
+	}
 	req, err := http.NewRequestWithContext(
-		ctx, webhook.Method, webhook.Url, bodyReader,
+		ctx, RequestMethod(webhook), rendered.URL, strings.NewReader(rendered.Body),
 	)
 	if err != nil {
 		return fmt.Errorf("error creating request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	for k, v := range headers {
+	for k, v := range rendered.Headers {
 		req.Header.Set(k, v)
 	}
 
@@ -53,40 +130,23 @@ func (s *Service) SendWebhookRequest(
 		return fmt.Errorf("error sending request: %w", err)
 	}
 	defer res.Body.Close()
+	exec.ResStatus = sql.NullInt16{Int16: int16(res.StatusCode), Valid: true}
 
 	resHeaders, err := json.Marshal(res.Header)
 	if err != nil {
 		return fmt.Errorf("error marshalling response headers: %w", err)
 	}
+	exec.ResHeaders = sql.NullString{String: string(resHeaders), Valid: true}
 
 	resBody, err := io.ReadAll(res.Body)
 	if err != nil {
 		return fmt.Errorf("error reading response body: %w", err)
 	}
+	exec.ResBody = sql.NullString{String: string(resBody), Valid: true}
 
-	_, err = s.dbgen.WebhooksServiceCreateWebhookExecution(
-		ctx, dbgen.WebhooksServiceCreateWebhookExecutionParams{
-			WebhookID:  webhook.ID,
-			ReqMethod:  sql.NullString{String: req.Method, Valid: true},
-			ReqHeaders: webhook.Headers,
-			ReqBody:    webhook.Body,
-			ResStatus:  sql.NullInt16{Int16: int16(res.StatusCode), Valid: true},
-			ResHeaders: sql.NullString{String: string(resHeaders), Valid: true},
-			ResBody:    sql.NullString{String: string(resBody), Valid: true},
-			ResDuration: sql.NullInt32{
-				Int32: int32(time.Since(timeStart).Milliseconds()),
-				Valid: true,
-			},
-		},
-	)
-	if err != nil {
-		return fmt.Errorf("error updating webhook result: %w", err)
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %d", res.StatusCode)
 	}
 
-	logger.Info("webhook sent successfully", logger.KV{
-		"webhook_id": webhook.ID,
-		"status":     res.Status,
-	})
-
 	return nil
 }
@@ -0,0 +1,27 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SignatureHeader is the header with the signature of the webhooks that have
+// a signing secret. Its value is t=<unix timestamp>,v1=<signature> where the
+// signature is the hex encoded HMAC-SHA256 of "<timestamp>.<body>" with the
+// signing secret as the key.
+const SignatureHeader = "X-PBW-Signature"
+
+// Sign returns the value of the signature header for the body sent at the
+// given time.
+func Sign(secret string, timestamp time.Time, body string) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix + "." + body))
+
+	return fmt.Sprintf("t=%s,v1=%s", unix, hex.EncodeToString(mac.Sum(nil)))
+}
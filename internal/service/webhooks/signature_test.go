@@ -0,0 +1,26 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	timestamp := time.Date(2025, 1, 1, 3, 5, 0, 0, time.UTC)
+
+	assert.Equal(
+		t,
+		"t=1735700700,v1=724535dec6570a0988d9591e5855aca99851ba7ab0c82728abf9f33349e926d3",
+		Sign("whsec_test", timestamp, `{"a":1}`),
+	)
+	assert.NotEqual(
+		t, Sign("whsec_test", timestamp, `{"a":1}`),
+		Sign("whsec_other", timestamp, `{"a":1}`),
+	)
+	assert.NotEqual(
+		t, Sign("whsec_test", timestamp, `{"a":1}`),
+		Sign("whsec_test", timestamp.Add(time.Second), `{"a":1}`),
+	)
+}
@@ -0,0 +1,128 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/integration/email"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+)
+
+// GetSMTPSettings returns the SMTP server the email channel sends the
+// emails through, with the password decrypted.
+func (s *Service) GetSMTPSettings(
+	ctx context.Context,
+) (dbgen.WebhooksServiceGetSMTPSettingsRow, error) {
+	if err := users.Authorize(ctx, users.PermissionManageInstance); err != nil {
+		return dbgen.WebhooksServiceGetSMTPSettingsRow{}, err
+	}
+
+	return s.dbgen.WebhooksServiceGetSMTPSettings(ctx, s.env.PBW_ENCRYPTION_KEY)
+}
+
+// UpdateSMTPSettings updates the SMTP server of the email channel. A null
+// password keeps the current one and an empty password removes it.
+func (s *Service) UpdateSMTPSettings(
+	ctx context.Context, params dbgen.WebhooksServiceUpdateSMTPSettingsParams,
+) error {
+	if err := users.Authorize(ctx, users.PermissionManageInstance); err != nil {
+		return err
+	}
+
+	switch params.SmtpTlsMode {
+	case email.TLSModeNone, email.TLSModeStartTLS, email.TLSModeTLS:
+	default:
+		return fmt.Errorf("invalid TLS mode %s", params.SmtpTlsMode)
+	}
+	if params.SmtpPort < 1 || params.SmtpPort > 65535 {
+		return fmt.Errorf("invalid port %d", params.SmtpPort)
+	}
+	if params.SmtpFrom != "" {
+		if _, err := mail.ParseAddress(params.SmtpFrom); err != nil {
+			return fmt.Errorf("invalid from address: %w", err)
+		}
+	}
+
+	before, err := s.dbgen.WebhooksServiceGetSMTPSettings(
+		ctx, s.env.PBW_ENCRYPTION_KEY,
+	)
+	if err != nil {
+		return err
+	}
+
+	params.EncryptionKey = s.env.PBW_ENCRYPTION_KEY
+	if err := s.dbgen.WebhooksServiceUpdateSMTPSettings(ctx, params); err != nil {
+		return err
+	}
+
+	after, err := s.dbgen.WebhooksServiceGetSMTPSettings(
+		ctx, s.env.PBW_ENCRYPTION_KEY,
+	)
+	if err != nil {
+		return err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionUpdate,
+		EntityType: audit.EntityInstance,
+		Before:     before,
+		After:      after,
+	})
+
+	return nil
+}
+
+// SendTestEmail sends an email with a sample event to the address, to check
+// the SMTP settings.
+func (s *Service) SendTestEmail(ctx context.Context, to string) error {
+	if err := users.Authorize(ctx, users.PermissionManageInstance); err != nil {
+		return err
+	}
+
+	msg, err := RenderEmail(
+		"mailto:"+to, SampleEvent(EventTypeExecutionFailed.Value.Key),
+	)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := s.smtpConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.ints.EmailClient.Send(ctx, cfg, msg)
+}
+
+// SendEmail sends the email through the SMTP server of the instance, for
+// the services that send emails outside of the webhooks.
+func (s *Service) SendEmail(ctx context.Context, msg email.Message) error {
+	cfg, err := s.smtpConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.ints.EmailClient.Send(ctx, cfg, msg)
+}
+
+// smtpConfig returns the SMTP settings of the instance to send the emails.
+func (s *Service) smtpConfig(ctx context.Context) (email.Config, error) {
+	settings, err := s.dbgen.WebhooksServiceGetSMTPSettings(
+		ctx, s.env.PBW_ENCRYPTION_KEY,
+	)
+	if err != nil {
+		return email.Config{}, fmt.Errorf("error getting SMTP settings: %w", err)
+	}
+
+	return email.Config{
+		Host:     settings.SmtpHost,
+		Port:     int(settings.SmtpPort),
+		TLSMode:  settings.SmtpTlsMode,
+		Username: settings.SmtpUsername,
+		Password: settings.DecryptedSmtpPassword,
+		From:     settings.SmtpFrom,
+	}, nil
+}
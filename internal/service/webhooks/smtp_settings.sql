@@ -0,0 +1,26 @@
+-- name: WebhooksServiceGetSMTPSettings :one
+SELECT
+  COALESCE(smtp_host, '')::TEXT AS smtp_host,
+  smtp_port,
+  smtp_tls_mode,
+  COALESCE(smtp_username, '')::TEXT AS smtp_username,
+  COALESCE(
+    pgp_sym_decrypt(smtp_password, @encryption_key), ''
+  )::TEXT AS decrypted_smtp_password,
+  COALESCE(smtp_from, '')::TEXT AS smtp_from
+FROM instance_settings;
+
+-- name: WebhooksServiceUpdateSMTPSettings :exec
+UPDATE instance_settings
+SET
+  smtp_host = NULLIF(@smtp_host::TEXT, ''),
+  smtp_port = @smtp_port,
+  smtp_tls_mode = @smtp_tls_mode,
+  smtp_username = NULLIF(@smtp_username::TEXT, ''),
+  smtp_password = CASE
+    WHEN sqlc.narg('smtp_password')::TEXT IS NULL THEN smtp_password
+    WHEN sqlc.narg('smtp_password')::TEXT = '' THEN NULL
+    ELSE pgp_sym_encrypt(sqlc.narg('smtp_password')::TEXT, sqlc.arg('encryption_key')::TEXT)
+  END,
+  smtp_from = NULLIF(@smtp_from::TEXT, ''),
+  updated_at = NOW();
@@ -0,0 +1,114 @@
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+
+	"github.com/eduardolat/pgbackweb/internal/validate"
+)
+
+// templateFuncs are available in the templates besides the builtin functions
+// of text/template. json escapes a value to be used in the JSON body.
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// Request is the URL, headers and body of a webhook rendered for an event.
+type Request struct {
+	URL     string
+	Headers map[string]string
+	Body    string
+}
+
+// RenderRequest renders the URL, the header values and the body of a webhook
+// as templates with the event. Empty headers and body are rendered as an
+// empty JSON object.
+func RenderRequest(rawURL, headers, body string, event Event) (Request, error) {
+	renderedURL, err := renderTemplate("url", rawURL, event)
+	if err != nil {
+		return Request{}, fmt.Errorf("error rendering the URL: %w", err)
+	}
+	parsedURL, err := url.Parse(renderedURL)
+	if err != nil || parsedURL.Host == "" ||
+		(parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		return Request{}, fmt.Errorf("the rendered URL %q is not valid", renderedURL)
+	}
+
+	if strings.TrimSpace(headers) == "" {
+		headers = "{}"
+	}
+	renderedHeaders := map[string]string{}
+	if err := json.Unmarshal([]byte(headers), &renderedHeaders); err != nil {
+		return Request{}, fmt.Errorf("error parsing headers: %w", err)
+	}
+	for key, value := range renderedHeaders {
+		renderedHeaders[key], err = renderTemplate(key, value, event)
+		if err != nil {
+			return Request{}, fmt.Errorf("error rendering the header %s: %w", key, err)
+		}
+	}
+
+	if strings.TrimSpace(body) == "" {
+		body = "{}"
+	}
+	renderedBody, err := renderTemplate("body", body, event)
+	if err != nil {
+		return Request{}, fmt.Errorf("error rendering the body: %w", err)
+	}
+
+	return Request{
+		URL:     renderedURL,
+		Headers: renderedHeaders,
+		Body:    renderedBody,
+	}, nil
+}
+
+// ValidateTemplates checks that the templates of a webhook render a valid
+// request for a sample event of the event type. The body must be valid JSON
+// once rendered, the values inserted in it should be escaped with json. The
+// chat and email channels ignore the body template.
+func ValidateTemplates(
+	channelKey, eventType, rawURL, headers, body string,
+) error {
+	if _, ok := FullChannels[channelKey]; !ok {
+		return fmt.Errorf("invalid channel %s", channelKey)
+	}
+
+	req, err := RenderChannelRequest(
+		channelKey, rawURL, headers, body, SampleEvent(eventType),
+	)
+	if err != nil {
+		return err
+	}
+	if channelKey == ChannelWebhook.Value.Key && !validate.JSON(req.Body) {
+		return fmt.Errorf(
+			"the rendered body is not valid JSON, escape the values with " +
+				"{{ json .Field }} instead of writing them between quotes",
+		)
+	}
+	return nil
+}
+
+func renderTemplate(name, text string, event Event) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, event); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
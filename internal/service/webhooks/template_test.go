@@ -0,0 +1,84 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderRequest(t *testing.T) {
+	event := SampleEvent(EventTypeExecutionFailed.Value.Key)
+
+	t.Run("Templates", func(t *testing.T) {
+		req, err := RenderRequest(
+			"https://example.com/hooks/{{ .Type }}",
+			`{"X-Target": "{{ .TargetName }}", "Authorization": "Bearer token"}`,
+			`{"text": {{ json .Message }}, "size": {{ .FileSize }}, "took": "{{ .Duration }}"}`,
+			event,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/hooks/execution_failed", req.URL)
+		assert.Equal(t, map[string]string{
+			"X-Target":      "My target",
+			"Authorization": "Bearer token",
+		}, req.Headers)
+		assert.Equal(
+			t,
+			`{"text": "pg_dump: error: connection refused", "size": 0, "took": "5m0s"}`,
+			req.Body,
+		)
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		req, err := RenderRequest("https://example.com", "", "", event)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{}, req.Headers)
+		assert.Equal(t, "{}", req.Body)
+	})
+
+	tests := []struct {
+		name    string
+		url     string
+		headers string
+		body    string
+	}{
+		{name: "Invalid URL", url: "{{ .TargetName }}"},
+		{name: "Invalid headers", url: "https://example.com", headers: "[]"},
+		{name: "Unknown field", url: "https://example.com", body: "{{ .Unknown }}"},
+		{name: "Parse error", url: "https://example.com", body: "{{ .Type "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RenderRequest(tt.url, tt.headers, tt.body, event)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidateTemplates(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{name: "Empty body", body: ""},
+		{name: "Escaped value", body: `{"text": {{ json .Message }}}`},
+		{name: "Number", body: `{"size": {{ .FileSize }}}`},
+		{name: "Not JSON", body: `{"text": {{ .TargetName }}}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTemplates(
+				ChannelWebhook.Value.Key, EventTypeExecutionSuccess.Value.Key,
+				"https://example.com", "", tt.body,
+			)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -2,12 +2,86 @@ package webhooks
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/google/uuid"
 )
 
 func (s *Service) UpdateWebhook(
 	ctx context.Context, params dbgen.WebhooksServiceUpdateWebhookParams,
 ) (dbgen.Webhook, error) {
-	return s.dbgen.WebhooksServiceUpdateWebhook(ctx, params)
+	if err := users.Authorize(ctx, users.PermissionManage); err != nil {
+		return dbgen.Webhook{}, err
+	}
+
+	if err := s.ensureNotProvisioned(ctx, params.WebhookID); err != nil {
+		return dbgen.Webhook{}, err
+	}
+
+	current, err := s.GetWebhook(ctx, params.WebhookID)
+	if err != nil {
+		return dbgen.Webhook{}, err
+	}
+
+	if len(params.TargetIds) > 0 {
+		_, err = s.validateTargets(ctx, params.TargetIds, uuid.NullUUID{
+			UUID: current.WorkspaceID, Valid: true,
+		})
+		if err != nil {
+			return dbgen.Webhook{}, err
+		}
+	}
+
+	// The fields that aren't updated keep their current value, the templates
+	// are validated together because they depend on the event type.
+	orCurrent := func(value sql.NullString, currentValue string) string {
+		if value.Valid {
+			return value.String
+		}
+		return currentValue
+	}
+	err = ValidateTemplates(
+		orCurrent(params.Channel, current.Channel),
+		orCurrent(params.EventType, current.EventType),
+		orCurrent(params.Url, current.Url),
+		orCurrent(params.Headers, current.Headers.String),
+		orCurrent(params.Body, current.Body.String),
+	)
+	if err != nil {
+		return dbgen.Webhook{}, err
+	}
+
+	timeoutSeconds := current.TimeoutSeconds
+	if params.TimeoutSeconds.Valid {
+		timeoutSeconds = params.TimeoutSeconds.Int32
+	}
+	err = ValidateHTTPSettings(
+		timeoutSeconds,
+		orCurrent(params.CaCertificate, current.CaCertificate.String),
+		orCurrent(params.ProxyUrl, current.ProxyUrl.String),
+	)
+	if err != nil {
+		return dbgen.Webhook{}, err
+	}
+
+	params.EncryptionKey = s.env.PBW_ENCRYPTION_KEY
+	before := s.auditSnapshot(ctx, params.WebhookID)
+	webhook, err := s.dbgen.WebhooksServiceUpdateWebhook(ctx, params)
+	if err != nil {
+		return webhook, err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionUpdate,
+		EntityType: audit.EntityWebhook,
+		EntityID:   webhook.ID,
+		EntityName: webhook.Name,
+		Before:     before,
+		After:      webhook,
+	})
+
+	return webhook, nil
 }
@@ -5,9 +5,25 @@ SET
   is_active = COALESCE(sqlc.narg('is_active'), is_active),
   event_type = COALESCE(sqlc.narg('event_type'), event_type),
   target_ids = COALESCE(sqlc.narg('target_ids'), target_ids),
+  channel = COALESCE(sqlc.narg('channel'), channel),
   url = COALESCE(sqlc.narg('url'), url),
   method = COALESCE(sqlc.narg('method'), method),
   headers = COALESCE(sqlc.narg('headers'), headers),
-  body = COALESCE(sqlc.narg('body'), body)
+  body = COALESCE(sqlc.narg('body'), body),
+  timeout_seconds = COALESCE(sqlc.narg('timeout_seconds'), timeout_seconds),
+  tls_skip_verify = COALESCE(sqlc.narg('tls_skip_verify'), tls_skip_verify),
+  ca_certificate = CASE
+    WHEN sqlc.narg('ca_certificate')::TEXT IS NULL THEN ca_certificate
+    ELSE NULLIF(sqlc.narg('ca_certificate')::TEXT, '')
+  END,
+  proxy_url = CASE
+    WHEN sqlc.narg('proxy_url')::TEXT IS NULL THEN proxy_url
+    ELSE NULLIF(sqlc.narg('proxy_url')::TEXT, '')
+  END,
+  signing_secret = CASE
+    WHEN sqlc.narg('signing_secret')::TEXT IS NULL THEN signing_secret
+    WHEN sqlc.narg('signing_secret')::TEXT = '' THEN NULL
+    ELSE pgp_sym_encrypt(sqlc.narg('signing_secret')::TEXT, sqlc.arg('encryption_key')::TEXT)
+  END
 WHERE id = @webhook_id
 RETURNING *;
\ No newline at end of file
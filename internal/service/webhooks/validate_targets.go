@@ -0,0 +1,38 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// validateTargets checks that all the targets belong to the same workspace and
+// that it is the given one, returning the workspace of the targets. When
+// workspaceID is null the targets may belong to any workspace.
+func (s *Service) validateTargets(
+	ctx context.Context, targetIDs []uuid.UUID, workspaceID uuid.NullUUID,
+) (uuid.NullUUID, error) {
+	workspaceIDs, err := s.dbgen.WebhooksServiceGetTargetsWorkspaces(
+		ctx, targetIDs,
+	)
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+
+	if len(workspaceIDs) == 0 {
+		return workspaceID, nil
+	}
+	if len(workspaceIDs) > 1 {
+		return uuid.NullUUID{}, fmt.Errorf(
+			"all the targets must belong to the same workspace",
+		)
+	}
+	if workspaceID.Valid && workspaceIDs[0] != workspaceID.UUID {
+		return uuid.NullUUID{}, fmt.Errorf(
+			"the targets don't belong to the workspace of the webhook",
+		)
+	}
+
+	return uuid.NullUUID{UUID: workspaceIDs[0], Valid: true}, nil
+}
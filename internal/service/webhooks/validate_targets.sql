@@ -0,0 +1,10 @@
+-- name: WebhooksServiceGetTargetsWorkspaces :many
+SELECT DISTINCT targets.workspace_id
+FROM (
+  SELECT id, workspace_id FROM databases
+  UNION ALL
+  SELECT id, workspace_id FROM destinations
+  UNION ALL
+  SELECT id, workspace_id FROM backups
+) AS targets
+WHERE targets.id = ANY(@target_ids::UUID[]);
@@ -1,7 +1,11 @@
 package webhooks
 
 import (
+	"sync"
+
+	"github.com/eduardolat/pgbackweb/internal/config"
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/integration"
 	"github.com/orsinium-labs/enum"
 )
 
@@ -27,32 +31,74 @@ var (
 	EventTypeDestinationUnhealthy = eventType{
 		Value: eventTypeData{Key: "destination_unhealthy", Name: "Destination unhealthy"},
 	}
+	EventTypeDestinationUploadSlow = eventType{
+		Value: eventTypeData{Key: "destination_upload_slow", Name: "Destination upload slow"},
+	}
 
+	EventTypeExecutionStarted = eventType{
+		Value: eventTypeData{Key: "execution_started", Name: "Execution started"},
+	}
 	EventTypeExecutionSuccess = eventType{
 		Value: eventTypeData{Key: "execution_success", Name: "Execution success"},
 	}
 	EventTypeExecutionFailed = eventType{
 		Value: eventTypeData{Key: "execution_failed", Name: "Execution failed"},
 	}
+	EventTypeExecutionSuspicious = eventType{
+		Value: eventTypeData{Key: "execution_suspicious", Name: "Execution suspicious"},
+	}
+	EventTypeExecutionDeletedByRetention = eventType{
+		Value: eventTypeData{Key: "execution_deleted_by_retention", Name: "Execution deleted by retention"},
+	}
+
+	EventTypeBackupStale = eventType{
+		Value: eventTypeData{Key: "backup_stale", Name: "Backup stale"},
+	}
+
+	// The restoration events target the database the backup is restored to,
+	// or the database of the backup when it is restored to a connection
+	// string.
+	EventTypeRestorationStarted = eventType{
+		Value: eventTypeData{Key: "restoration_started", Name: "Restoration started"},
+	}
+	EventTypeRestorationSuccess = eventType{
+		Value: eventTypeData{Key: "restoration_success", Name: "Restoration success"},
+	}
+	EventTypeRestorationFailed = eventType{
+		Value: eventTypeData{Key: "restoration_failed", Name: "Restoration failed"},
+	}
 )
 
 var FullEventTypes = map[string]string{
-	EventTypeDatabaseHealthy.Value.Key:      EventTypeDatabaseHealthy.Value.Name,
-	EventTypeDatabaseUnhealthy.Value.Key:    EventTypeDatabaseUnhealthy.Value.Name,
-	EventTypeDestinationHealthy.Value.Key:   EventTypeDestinationHealthy.Value.Name,
-	EventTypeDestinationUnhealthy.Value.Key: EventTypeDestinationUnhealthy.Value.Name,
-	EventTypeExecutionSuccess.Value.Key:     EventTypeExecutionSuccess.Value.Name,
-	EventTypeExecutionFailed.Value.Key:      EventTypeExecutionFailed.Value.Name,
+	EventTypeDatabaseHealthy.Value.Key:             EventTypeDatabaseHealthy.Value.Name,
+	EventTypeDatabaseUnhealthy.Value.Key:           EventTypeDatabaseUnhealthy.Value.Name,
+	EventTypeDestinationHealthy.Value.Key:          EventTypeDestinationHealthy.Value.Name,
+	EventTypeDestinationUnhealthy.Value.Key:        EventTypeDestinationUnhealthy.Value.Name,
+	EventTypeDestinationUploadSlow.Value.Key:       EventTypeDestinationUploadSlow.Value.Name,
+	EventTypeExecutionStarted.Value.Key:            EventTypeExecutionStarted.Value.Name,
+	EventTypeExecutionSuccess.Value.Key:            EventTypeExecutionSuccess.Value.Name,
+	EventTypeExecutionFailed.Value.Key:             EventTypeExecutionFailed.Value.Name,
+	EventTypeExecutionSuspicious.Value.Key:         EventTypeExecutionSuspicious.Value.Name,
+	EventTypeExecutionDeletedByRetention.Value.Key: EventTypeExecutionDeletedByRetention.Value.Name,
+	EventTypeBackupStale.Value.Key:                 EventTypeBackupStale.Value.Name,
+	EventTypeRestorationStarted.Value.Key:          EventTypeRestorationStarted.Value.Name,
+	EventTypeRestorationSuccess.Value.Key:          EventTypeRestorationSuccess.Value.Name,
+	EventTypeRestorationFailed.Value.Key:           EventTypeRestorationFailed.Value.Name,
 }
 
 type Service struct {
-	dbgen *dbgen.Queries
+	env     config.Env
+	dbgen   *dbgen.Queries
+	ints    *integration.Integration
+	running sync.WaitGroup
 }
 
 func New(
-	dbgen *dbgen.Queries,
+	env config.Env, dbgen *dbgen.Queries, ints *integration.Integration,
 ) *Service {
 	return &Service{
+		env:   env,
 		dbgen: dbgen,
+		ints:  ints,
 	}
 }
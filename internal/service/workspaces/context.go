@@ -0,0 +1,24 @@
+package workspaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type workspaceCtxKey struct{}
+
+// WithWorkspace returns a copy of ctx that carries the current workspace of
+// the user, the services only read and write the entities of that workspace.
+func WithWorkspace(ctx context.Context, workspaceID uuid.UUID) context.Context {
+	return context.WithValue(ctx, workspaceCtxKey{}, workspaceID)
+}
+
+// FromContext returns the workspace carried by ctx, to be used as the
+// workspace filter of the queries. It is null for the contexts without a
+// workspace, like the ones of the admins viewing all the workspaces and the
+// internal tasks, which can access every entity.
+func FromContext(ctx context.Context) uuid.NullUUID {
+	workspaceID, ok := ctx.Value(workspaceCtxKey{}).(uuid.UUID)
+	return uuid.NullUUID{UUID: workspaceID, Valid: ok}
+}
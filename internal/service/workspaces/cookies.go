@@ -0,0 +1,46 @@
+package workspaces
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	workspaceCookieName = "pbw_workspace_id"
+	maxWorkspaceAge     = 365 * 24 * 60 * 60
+)
+
+// SetWorkspaceCookie stores the workspace selected by the user, a null
+// workspaceID selects all the workspaces.
+func (s *Service) SetWorkspaceCookie(c echo.Context, workspaceID uuid.NullUUID) {
+	cookie := http.Cookie{
+		Name:     workspaceCookieName,
+		Value:    "",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Path:     "/",
+	}
+	if workspaceID.Valid {
+		cookie.Value = workspaceID.UUID.String()
+		cookie.MaxAge = maxWorkspaceAge
+	}
+	c.SetCookie(&cookie)
+}
+
+// GetWorkspaceFromCookie returns the workspace selected by the user, it is
+// null when nothing was selected.
+func (s *Service) GetWorkspaceFromCookie(c echo.Context) uuid.NullUUID {
+	cookie, err := c.Cookie(workspaceCookieName)
+	if err != nil {
+		return uuid.NullUUID{}
+	}
+
+	workspaceID, err := uuid.Parse(cookie.Value)
+	if err != nil {
+		return uuid.NullUUID{}
+	}
+
+	return uuid.NullUUID{UUID: workspaceID, Valid: true}
+}
@@ -0,0 +1,32 @@
+package workspaces
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+)
+
+func (s *Service) CreateWorkspace(
+	ctx context.Context, name string,
+) (dbgen.Workspace, error) {
+	if err := users.Authorize(ctx, users.PermissionManageInstance); err != nil {
+		return dbgen.Workspace{}, err
+	}
+
+	workspace, err := s.dbgen.WorkspacesServiceCreateWorkspace(ctx, name)
+	if err != nil {
+		return dbgen.Workspace{}, err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionCreate,
+		EntityType: audit.EntityWorkspace,
+		EntityID:   workspace.ID,
+		EntityName: workspace.Name,
+		After:      workspace,
+	})
+
+	return workspace, nil
+}
@@ -0,0 +1,4 @@
+-- name: WorkspacesServiceCreateWorkspace :one
+INSERT INTO workspaces (name)
+VALUES (@name)
+RETURNING *;
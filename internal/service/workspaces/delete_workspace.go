@@ -0,0 +1,50 @@
+package workspaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/google/uuid"
+)
+
+// DeleteWorkspace deletes an empty workspace, the default workspace can't be
+// deleted.
+func (s *Service) DeleteWorkspace(ctx context.Context, id uuid.UUID) error {
+	if err := users.Authorize(ctx, users.PermissionManageInstance); err != nil {
+		return err
+	}
+
+	workspace, err := s.dbgen.WorkspacesServiceGetWorkspace(ctx, id)
+	if err != nil {
+		return err
+	}
+	if workspace.IsDefault {
+		return errors.New("the default workspace can't be deleted")
+	}
+
+	isEmpty, err := s.dbgen.WorkspacesServiceIsWorkspaceEmpty(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !isEmpty {
+		return errors.New(
+			"the workspace still has databases, destinations, backups, backup groups, webhooks or reports",
+		)
+	}
+
+	if err := s.dbgen.WorkspacesServiceDeleteWorkspace(ctx, id); err != nil {
+		return err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionDelete,
+		EntityType: audit.EntityWorkspace,
+		EntityID:   workspace.ID,
+		EntityName: workspace.Name,
+		Before:     workspace,
+	})
+
+	return nil
+}
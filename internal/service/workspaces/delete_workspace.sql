@@ -0,0 +1,15 @@
+-- name: WorkspacesServiceGetWorkspace :one
+SELECT * FROM workspaces WHERE id = @id;
+
+-- name: WorkspacesServiceIsWorkspaceEmpty :one
+SELECT NOT (
+  EXISTS (SELECT 1 FROM databases WHERE workspace_id = @workspace_id)
+  OR EXISTS (SELECT 1 FROM destinations WHERE workspace_id = @workspace_id)
+  OR EXISTS (SELECT 1 FROM backups WHERE workspace_id = @workspace_id)
+  OR EXISTS (SELECT 1 FROM backup_groups WHERE workspace_id = @workspace_id)
+  OR EXISTS (SELECT 1 FROM webhooks WHERE workspace_id = @workspace_id)
+  OR EXISTS (SELECT 1 FROM reports WHERE workspace_id = @workspace_id)
+)::BOOLEAN;
+
+-- name: WorkspacesServiceDeleteWorkspace :exec
+DELETE FROM workspaces WHERE id = @id;
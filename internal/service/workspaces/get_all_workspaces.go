@@ -0,0 +1,17 @@
+package workspaces
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+)
+
+func (s *Service) GetAllWorkspaces(
+	ctx context.Context,
+) ([]dbgen.Workspace, error) {
+	if err := users.Authorize(ctx, users.PermissionManageInstance); err != nil {
+		return nil, err
+	}
+	return s.dbgen.WorkspacesServiceGetAllWorkspaces(ctx)
+}
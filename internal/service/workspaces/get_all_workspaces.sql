@@ -0,0 +1,3 @@
+-- name: WorkspacesServiceGetAllWorkspaces :many
+SELECT * FROM workspaces
+ORDER BY is_default DESC, name ASC;
@@ -0,0 +1,51 @@
+package workspaces
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/google/uuid"
+)
+
+// UserWorkspace is a workspace the user can access and the role the user
+// has in it.
+type UserWorkspace struct {
+	ID   uuid.UUID
+	Name string
+	Role string
+}
+
+// GetUserWorkspaces returns the workspaces the user can access, admins can
+// access every workspace.
+func (s *Service) GetUserWorkspaces(
+	ctx context.Context, user dbgen.User,
+) ([]UserWorkspace, error) {
+	if user.Role == users.RoleAdmin {
+		all, err := s.dbgen.WorkspacesServiceGetAllWorkspaces(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		userWorkspaces := make([]UserWorkspace, 0, len(all))
+		for _, ws := range all {
+			userWorkspaces = append(userWorkspaces, UserWorkspace{
+				ID: ws.ID, Name: ws.Name, Role: users.RoleAdmin,
+			})
+		}
+		return userWorkspaces, nil
+	}
+
+	memberships, err := s.dbgen.WorkspacesServiceGetUserWorkspaces(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	userWorkspaces := make([]UserWorkspace, 0, len(memberships))
+	for _, m := range memberships {
+		userWorkspaces = append(userWorkspaces, UserWorkspace{
+			ID: m.ID, Name: m.Name, Role: m.MemberRole,
+		})
+	}
+	return userWorkspaces, nil
+}
@@ -0,0 +1,8 @@
+-- name: WorkspacesServiceGetUserWorkspaces :many
+SELECT
+  workspaces.*,
+  workspace_members.role AS member_role
+FROM workspace_members
+INNER JOIN workspaces ON workspaces.id = workspace_members.workspace_id
+WHERE workspace_members.user_id = @user_id
+ORDER BY workspaces.is_default DESC, workspaces.name ASC;
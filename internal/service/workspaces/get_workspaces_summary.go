@@ -0,0 +1,15 @@
+package workspaces
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+)
+
+// GetWorkspacesSummary returns the number of entities and the unhealthy ones
+// of every workspace, or only of the current workspace if ctx carries one.
+func (s *Service) GetWorkspacesSummary(
+	ctx context.Context,
+) ([]dbgen.WorkspacesServiceGetWorkspacesSummaryRow, error) {
+	return s.dbgen.WorkspacesServiceGetWorkspacesSummary(ctx, FromContext(ctx))
+}
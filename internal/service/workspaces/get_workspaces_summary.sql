@@ -0,0 +1,36 @@
+-- name: WorkspacesServiceGetWorkspacesSummary :many
+SELECT
+  workspaces.id,
+  workspaces.name,
+  (
+    SELECT COUNT(*) FROM databases
+    WHERE databases.workspace_id = workspaces.id
+  ) AS databases,
+  (
+    SELECT COUNT(*) FROM databases
+    WHERE databases.workspace_id = workspaces.id AND databases.test_ok = false
+  ) AS unhealthy_databases,
+  (
+    SELECT COUNT(*) FROM destinations
+    WHERE destinations.workspace_id = workspaces.id
+  ) AS destinations,
+  (
+    SELECT COUNT(*) FROM destinations
+    WHERE destinations.workspace_id = workspaces.id
+    AND destinations.test_ok = false
+  ) AS unhealthy_destinations,
+  (
+    SELECT COUNT(*) FROM backups
+    WHERE backups.workspace_id = workspaces.id
+  ) AS backups,
+  (
+    SELECT COUNT(*) FROM backups
+    WHERE backups.workspace_id = workspaces.id AND backups.is_stale = true
+  ) AS stale_backups
+FROM workspaces
+WHERE (
+  sqlc.narg('workspace_id')::UUID IS NULL
+  OR
+  workspaces.id = sqlc.narg('workspace_id')::UUID
+)
+ORDER BY workspaces.is_default DESC, workspaces.name ASC;
@@ -0,0 +1,80 @@
+package workspaces
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/google/uuid"
+)
+
+func (s *Service) GetMembers(
+	ctx context.Context, workspaceID uuid.UUID,
+) ([]dbgen.WorkspacesServiceGetMembersRow, error) {
+	if err := users.Authorize(ctx, users.PermissionManageInstance); err != nil {
+		return nil, err
+	}
+	return s.dbgen.WorkspacesServiceGetMembers(ctx, workspaceID)
+}
+
+// SetMember adds the user to the workspace with the given role, or changes
+// its role if it is already a member.
+func (s *Service) SetMember(
+	ctx context.Context, workspaceID, userID uuid.UUID, role string,
+) (dbgen.WorkspaceMember, error) {
+	if err := users.Authorize(ctx, users.PermissionManageInstance); err != nil {
+		return dbgen.WorkspaceMember{}, err
+	}
+	if !users.IsValidRole(role) {
+		return dbgen.WorkspaceMember{}, fmt.Errorf("invalid role %q", role)
+	}
+
+	member, err := s.dbgen.WorkspacesServiceSetMember(
+		ctx, dbgen.WorkspacesServiceSetMemberParams{
+			WorkspaceID: workspaceID,
+			UserID:      userID,
+			Role:        role,
+		},
+	)
+	if err != nil {
+		return dbgen.WorkspaceMember{}, err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionUpdate,
+		EntityType: audit.EntityWorkspace,
+		EntityID:   workspaceID,
+		After:      member,
+	})
+
+	return member, nil
+}
+
+func (s *Service) RemoveMember(
+	ctx context.Context, workspaceID, userID uuid.UUID,
+) error {
+	if err := users.Authorize(ctx, users.PermissionManageInstance); err != nil {
+		return err
+	}
+
+	err := s.dbgen.WorkspacesServiceRemoveMember(
+		ctx, dbgen.WorkspacesServiceRemoveMemberParams{
+			WorkspaceID: workspaceID,
+			UserID:      userID,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionUpdate,
+		EntityType: audit.EntityWorkspace,
+		EntityID:   workspaceID,
+		Before:     dbgen.WorkspaceMember{WorkspaceID: workspaceID, UserID: userID},
+	})
+
+	return nil
+}
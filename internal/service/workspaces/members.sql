@@ -0,0 +1,19 @@
+-- name: WorkspacesServiceGetMembers :many
+SELECT
+  workspace_members.*,
+  users.name AS user_name,
+  users.email AS user_email
+FROM workspace_members
+INNER JOIN users ON users.id = workspace_members.user_id
+WHERE workspace_members.workspace_id = @workspace_id
+ORDER BY users.name ASC;
+
+-- name: WorkspacesServiceSetMember :one
+INSERT INTO workspace_members (workspace_id, user_id, role)
+VALUES (@workspace_id, @user_id, @role)
+ON CONFLICT (workspace_id, user_id) DO UPDATE SET role = EXCLUDED.role
+RETURNING *;
+
+-- name: WorkspacesServiceRemoveMember :exec
+DELETE FROM workspace_members
+WHERE workspace_id = @workspace_id AND user_id = @user_id;
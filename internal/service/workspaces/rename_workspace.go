@@ -0,0 +1,44 @@
+package workspaces
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/google/uuid"
+)
+
+func (s *Service) RenameWorkspace(
+	ctx context.Context, id uuid.UUID, name string,
+) (dbgen.Workspace, error) {
+	if err := users.Authorize(ctx, users.PermissionManageInstance); err != nil {
+		return dbgen.Workspace{}, err
+	}
+
+	before, err := s.dbgen.WorkspacesServiceGetWorkspace(ctx, id)
+	if err != nil {
+		return dbgen.Workspace{}, err
+	}
+
+	workspace, err := s.dbgen.WorkspacesServiceRenameWorkspace(
+		ctx, dbgen.WorkspacesServiceRenameWorkspaceParams{
+			ID:   id,
+			Name: name,
+		},
+	)
+	if err != nil {
+		return dbgen.Workspace{}, err
+	}
+
+	audit.Record(ctx, s.dbgen, audit.Entry{
+		Action:     audit.ActionUpdate,
+		EntityType: audit.EntityWorkspace,
+		EntityID:   workspace.ID,
+		EntityName: workspace.Name,
+		Before:     before,
+		After:      workspace,
+	})
+
+	return workspace, nil
+}
@@ -0,0 +1,5 @@
+-- name: WorkspacesServiceRenameWorkspace :one
+UPDATE workspaces
+SET name = @name
+WHERE id = @id
+RETURNING *;
@@ -0,0 +1,72 @@
+package workspaces
+
+import (
+	"context"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/google/uuid"
+)
+
+// Access is the workspace a request works on and the role the user has in
+// it. A null WorkspaceID means every workspace, which only admins get.
+type Access struct {
+	WorkspaceID uuid.NullUUID
+	Role        string
+	Workspaces  []UserWorkspace
+}
+
+// Context returns a copy of ctx that carries the access of the user, so the
+// services filter by the workspace and enforce the role.
+func (a Access) Context(ctx context.Context, user dbgen.User) context.Context {
+	ctx = users.WithRoles(ctx, user.Role, a.Role)
+	if a.WorkspaceID.Valid {
+		ctx = WithWorkspace(ctx, a.WorkspaceID.UUID)
+	}
+	return ctx
+}
+
+// ResolveAccess returns the access of the user to the requested workspace.
+// When the workspace is not requested or the user can't access it, admins
+// get every workspace and the other users their first workspace.
+func (s *Service) ResolveAccess(
+	ctx context.Context, user dbgen.User, requested uuid.NullUUID,
+) (Access, error) {
+	userWorkspaces, err := s.GetUserWorkspaces(ctx, user)
+	if err != nil {
+		return Access{}, err
+	}
+	return resolveAccess(user.Role, userWorkspaces, requested), nil
+}
+
+func resolveAccess(
+	role string, userWorkspaces []UserWorkspace, requested uuid.NullUUID,
+) Access {
+	access := Access{Workspaces: userWorkspaces}
+
+	if requested.Valid {
+		for _, ws := range userWorkspaces {
+			if ws.ID == requested.UUID {
+				access.WorkspaceID = uuid.NullUUID{UUID: ws.ID, Valid: true}
+				access.Role = ws.Role
+				return access
+			}
+		}
+	}
+
+	if role == users.RoleAdmin {
+		access.Role = users.RoleAdmin
+		return access
+	}
+
+	if len(userWorkspaces) > 0 {
+		access.WorkspaceID = uuid.NullUUID{UUID: userWorkspaces[0].ID, Valid: true}
+		access.Role = userWorkspaces[0].Role
+		return access
+	}
+
+	// Users without workspaces get an empty one, so they see nothing.
+	access.WorkspaceID = uuid.NullUUID{UUID: uuid.Nil, Valid: true}
+	access.Role = users.RoleViewer
+	return access
+}
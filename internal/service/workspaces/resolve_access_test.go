@@ -0,0 +1,79 @@
+package workspaces
+
+import (
+	"testing"
+
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveAccess(t *testing.T) {
+	teamA := UserWorkspace{ID: uuid.New(), Name: "Team A", Role: users.RoleOperator}
+	teamB := UserWorkspace{ID: uuid.New(), Name: "Team B", Role: users.RoleViewer}
+	member := []UserWorkspace{teamA, teamB}
+	requested := func(id uuid.UUID) uuid.NullUUID {
+		return uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	tests := []struct {
+		name       string
+		role       string
+		workspaces []UserWorkspace
+		requested  uuid.NullUUID
+		wantID     uuid.NullUUID
+		wantRole   string
+	}{
+		{
+			name:       "member of the requested workspace",
+			role:       users.RoleViewer,
+			workspaces: member,
+			requested:  requested(teamB.ID),
+			wantID:     requested(teamB.ID),
+			wantRole:   users.RoleViewer,
+		},
+		{
+			name:       "not member of the requested workspace",
+			role:       users.RoleViewer,
+			workspaces: member,
+			requested:  requested(uuid.New()),
+			wantID:     requested(teamA.ID),
+			wantRole:   users.RoleOperator,
+		},
+		{
+			name:       "no requested workspace",
+			role:       users.RoleOperator,
+			workspaces: member,
+			wantID:     requested(teamA.ID),
+			wantRole:   users.RoleOperator,
+		},
+		{
+			name:     "without workspaces",
+			role:     users.RoleOperator,
+			wantID:   requested(uuid.Nil),
+			wantRole: users.RoleViewer,
+		},
+		{
+			name:       "admin requesting a workspace",
+			role:       users.RoleAdmin,
+			workspaces: []UserWorkspace{{ID: teamA.ID, Role: users.RoleAdmin}},
+			requested:  requested(teamA.ID),
+			wantID:     requested(teamA.ID),
+			wantRole:   users.RoleAdmin,
+		},
+		{
+			name:       "admin without requested workspace",
+			role:       users.RoleAdmin,
+			workspaces: []UserWorkspace{{ID: teamA.ID, Role: users.RoleAdmin}},
+			wantRole:   users.RoleAdmin,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			access := resolveAccess(tt.role, tt.workspaces, tt.requested)
+			assert.Equal(t, tt.wantID, access.WorkspaceID)
+			assert.Equal(t, tt.wantRole, access.Role)
+		})
+	}
+}
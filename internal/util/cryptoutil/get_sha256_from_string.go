@@ -0,0 +1,12 @@
+package cryptoutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// GetSHA256FromString returns the hex encoded SHA256 hash of the given string.
+func GetSHA256FromString(s string) string {
+	hash := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,19 @@
+package numutil
+
+import "math"
+
+// PercentDeviation returns the absolute deviation of value from baseline
+// expressed as a percentage of baseline. It returns 0 when baseline is not
+// positive because no meaningful percentage can be computed.
+//
+// Example:
+//
+//	(100, 20) -> 80
+//	(100, 150) -> 50
+func PercentDeviation(baseline int64, value int64) float64 {
+	if baseline <= 0 {
+		return 0
+	}
+	diff := math.Abs(float64(value - baseline))
+	return diff / float64(baseline) * 100
+}
@@ -0,0 +1,65 @@
+package numutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPercentDeviation(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseline int64
+		value    int64
+		expected float64
+	}{
+		{
+			name:     "same value",
+			baseline: 100,
+			value:    100,
+			expected: 0,
+		},
+		{
+			name:     "shrink",
+			baseline: 100,
+			value:    20,
+			expected: 80,
+		},
+		{
+			name:     "growth",
+			baseline: 100,
+			value:    150,
+			expected: 50,
+		},
+		{
+			name:     "more than double",
+			baseline: 100,
+			value:    350,
+			expected: 250,
+		},
+		{
+			name:     "empty value",
+			baseline: 2048,
+			value:    0,
+			expected: 100,
+		},
+		{
+			name:     "zero baseline",
+			baseline: 0,
+			value:    100,
+			expected: 0,
+		},
+		{
+			name:     "negative baseline",
+			baseline: -10,
+			value:    100,
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, PercentDeviation(tt.baseline, tt.value), 0.0001)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package promutil
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Labels are the labels of a single metric sample.
+type Labels map[string]string
+
+// Writer builds a document in the Prometheus text exposition format.
+//
+// Samples of the same metric must be written one after the other, the
+// HELP and TYPE lines are written only before the first sample of each
+// metric.
+type Writer struct {
+	sb       strings.Builder
+	declared map[string]bool
+}
+
+// NewWriter creates a new empty Writer.
+func NewWriter() *Writer {
+	return &Writer{declared: map[string]bool{}}
+}
+
+// Counter writes a counter sample.
+func (w *Writer) Counter(name, help string, value float64, labels Labels) {
+	w.declare(name, help, "counter")
+	w.sample(name, value, labels)
+}
+
+// Gauge writes a gauge sample.
+func (w *Writer) Gauge(name, help string, value float64, labels Labels) {
+	w.declare(name, help, "gauge")
+	w.sample(name, value, labels)
+}
+
+// Histogram writes a histogram computed from the given observations using
+// the given upper bounds. The +Inf bucket is always added.
+func (w *Writer) Histogram(
+	name, help string, buckets []float64, observations []float64, labels Labels,
+) {
+	w.declare(name, help, "histogram")
+
+	sorted := append([]float64{}, buckets...)
+	sort.Float64s(sorted)
+
+	sum := float64(0)
+	counts := make([]int, len(sorted))
+	for _, obs := range observations {
+		sum += obs
+		for i, bound := range sorted {
+			if obs <= bound {
+				counts[i]++
+			}
+		}
+	}
+
+	for i, bound := range sorted {
+		w.sample(
+			name+"_bucket", float64(counts[i]), withLabel(labels, "le", formatValue(bound)),
+		)
+	}
+	w.sample(
+		name+"_bucket", float64(len(observations)), withLabel(labels, "le", "+Inf"),
+	)
+	w.sample(name+"_sum", sum, labels)
+	w.sample(name+"_count", float64(len(observations)), labels)
+}
+
+// String returns the document written so far.
+func (w *Writer) String() string {
+	return w.sb.String()
+}
+
+func (w *Writer) declare(name, help, metricType string) {
+	if w.declared[name] {
+		return
+	}
+	w.declared[name] = true
+	fmt.Fprintf(&w.sb, "# HELP %s %s\n", name, escapeHelp(help))
+	fmt.Fprintf(&w.sb, "# TYPE %s %s\n", name, metricType)
+}
+
+func (w *Writer) sample(name string, value float64, labels Labels) {
+	w.sb.WriteString(name)
+	w.sb.WriteString(formatLabels(labels))
+	w.sb.WriteString(" ")
+	w.sb.WriteString(formatValue(value))
+	w.sb.WriteString("\n")
+}
+
+func withLabel(labels Labels, key, value string) Labels {
+	res := Labels{key: value}
+	for k, v := range labels {
+		res[k] = v
+	}
+	return res
+}
+
+func formatLabels(labels Labels) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, k, escapeLabelValue(labels[k])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func escapeHelp(help string) string {
+	help = strings.ReplaceAll(help, `\`, `\\`)
+	return strings.ReplaceAll(help, "\n", `\n`)
+}
+
+func escapeLabelValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
@@ -0,0 +1,69 @@
+package promutil
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriterCounterAndGauge(t *testing.T) {
+	w := NewWriter()
+	w.Counter("pbw_things_total", "Things.", 3, Labels{"b": "2", "a": "1"})
+	w.Counter("pbw_things_total", "Things.", 4, Labels{"a": "3"})
+	w.Gauge("pbw_up", "Up.", 1, nil)
+
+	expected := "# HELP pbw_things_total Things.\n" +
+		"# TYPE pbw_things_total counter\n" +
+		"pbw_things_total{a=\"1\",b=\"2\"} 3\n" +
+		"pbw_things_total{a=\"3\"} 4\n" +
+		"# HELP pbw_up Up.\n" +
+		"# TYPE pbw_up gauge\n" +
+		"pbw_up 1\n"
+	assert.Equal(t, expected, w.String())
+}
+
+func TestWriterHistogram(t *testing.T) {
+	w := NewWriter()
+	w.Histogram(
+		"pbw_duration_seconds", "Duration.",
+		[]float64{10, 1}, []float64{0.5, 1, 5, 20}, Labels{"x": "y"},
+	)
+
+	expected := "# HELP pbw_duration_seconds Duration.\n" +
+		"# TYPE pbw_duration_seconds histogram\n" +
+		"pbw_duration_seconds_bucket{le=\"1\",x=\"y\"} 2\n" +
+		"pbw_duration_seconds_bucket{le=\"10\",x=\"y\"} 3\n" +
+		"pbw_duration_seconds_bucket{le=\"+Inf\",x=\"y\"} 4\n" +
+		"pbw_duration_seconds_sum{x=\"y\"} 26.5\n" +
+		"pbw_duration_seconds_count{x=\"y\"} 4\n"
+	assert.Equal(t, expected, w.String())
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    float64
+		expected string
+	}{
+		{name: "zero", input: 0, expected: "0"},
+		{name: "integer", input: 42, expected: "42"},
+		{name: "decimal", input: 1.5, expected: "1.5"},
+		{name: "large", input: 1e10, expected: "1e+10"},
+		{name: "positive infinity", input: math.Inf(1), expected: "+Inf"},
+		{name: "negative infinity", input: math.Inf(-1), expected: "-Inf"},
+		{name: "not a number", input: math.NaN(), expected: "NaN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatValue(tt.input))
+		})
+	}
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	assert.Equal(t, `my \"db\"`, escapeLabelValue(`my "db"`))
+	assert.Equal(t, `a\\b`, escapeLabelValue(`a\b`))
+	assert.Equal(t, `a\nb`, escapeLabelValue("a\nb"))
+}
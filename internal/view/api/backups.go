@@ -0,0 +1,348 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/backups"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type backupResponse struct {
+	ID                     uuid.UUID  `json:"id"`
+	DatabaseID             uuid.UUID  `json:"database_id"`
+	DestinationID          *uuid.UUID `json:"destination_id"`
+	IsLocal                bool       `json:"is_local"`
+	Name                   string     `json:"name"`
+	CronExpression         string     `json:"cron_expression"`
+	TimeZone               string     `json:"time_zone"`
+	IsActive               bool       `json:"is_active"`
+	DestDir                string     `json:"dest_dir"`
+	RetentionDays          int16      `json:"retention_days"`
+	OptDataOnly            bool       `json:"opt_data_only"`
+	OptSchemaOnly          bool       `json:"opt_schema_only"`
+	OptClean               bool       `json:"opt_clean"`
+	OptIfExists            bool       `json:"opt_if_exists"`
+	OptCreate              bool       `json:"opt_create"`
+	OptNoComments          bool       `json:"opt_no_comments"`
+	MaxPartSizeMb          *int32     `json:"max_part_size_mb"`
+	CompressionLevel       *int16     `json:"compression_level"`
+	SizeDeviationThreshold int16      `json:"size_deviation_threshold"`
+	RpoHours               *int16     `json:"rpo_hours"`
+	RunAfterBackupID       *uuid.UUID `json:"run_after_backup_id"`
+	IsStale                bool       `json:"is_stale"`
+	IsProvisioned          bool       `json:"is_provisioned"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              *time.Time `json:"updated_at"`
+}
+
+func newBackupResponse(backup dbgen.Backup) backupResponse {
+	return backupResponse{
+		ID:                     backup.ID,
+		DatabaseID:             backup.DatabaseID,
+		DestinationID:          nullUUID(backup.DestinationID),
+		IsLocal:                backup.IsLocal,
+		Name:                   backup.Name,
+		CronExpression:         backup.CronExpression,
+		TimeZone:               backup.TimeZone,
+		IsActive:               backup.IsActive,
+		DestDir:                backup.DestDir,
+		RetentionDays:          backup.RetentionDays,
+		OptDataOnly:            backup.OptDataOnly,
+		OptSchemaOnly:          backup.OptSchemaOnly,
+		OptClean:               backup.OptClean,
+		OptIfExists:            backup.OptIfExists,
+		OptCreate:              backup.OptCreate,
+		OptNoComments:          backup.OptNoComments,
+		MaxPartSizeMb:          nullInt32(backup.MaxPartSizeMb),
+		CompressionLevel:       nullInt16(backup.CompressionLevel),
+		SizeDeviationThreshold: backup.SizeDeviationThreshold,
+		RpoHours:               nullInt16(backup.RpoHours),
+		RunAfterBackupID:       nullUUID(backup.RunAfterBackupID),
+		IsStale:                backup.IsStale,
+		IsProvisioned:          backup.IsProvisioned,
+		CreatedAt:              backup.CreatedAt,
+		UpdatedAt:              nullTime(backup.UpdatedAt),
+	}
+}
+
+type backupRequest struct {
+	IsLocal                bool       `json:"is_local"`
+	DestinationID          *uuid.UUID `json:"destination_id" validate:"required_if=IsLocal false"`
+	Name                   string     `json:"name" validate:"required"`
+	CronExpression         string     `json:"cron_expression" validate:"required"`
+	TimeZone               string     `json:"time_zone" validate:"required"`
+	IsActive               bool       `json:"is_active"`
+	DestDir                string     `json:"dest_dir" validate:"required"`
+	RetentionDays          int16      `json:"retention_days" validate:"min=0"`
+	OptDataOnly            bool       `json:"opt_data_only"`
+	OptSchemaOnly          bool       `json:"opt_schema_only"`
+	OptClean               bool       `json:"opt_clean"`
+	OptIfExists            bool       `json:"opt_if_exists"`
+	OptCreate              bool       `json:"opt_create"`
+	OptNoComments          bool       `json:"opt_no_comments"`
+	MaxPartSizeMb          *int32     `json:"max_part_size_mb" validate:"omitempty,min=1"`
+	CompressionLevel       *int16     `json:"compression_level" validate:"omitempty,min=0,max=9"`
+	SizeDeviationThreshold int16      `json:"size_deviation_threshold" validate:"min=0,max=1000"`
+	RpoHours               *int16     `json:"rpo_hours" validate:"omitempty,min=1,max=8760"`
+	RunAfterBackupID       *uuid.UUID `json:"run_after_backup_id"`
+}
+
+// createBackupRequest adds the database to the backup request because it
+// can't be changed once the backup is created.
+type createBackupRequest struct {
+	DatabaseID uuid.UUID `json:"database_id" validate:"required"`
+	backupRequest
+}
+
+// destinationID returns the destination of the backup, local backups never
+// have one even if it is sent in the request.
+func (r backupRequest) destinationID() uuid.NullUUID {
+	if r.IsLocal {
+		return uuid.NullUUID{}
+	}
+	return toNullUUID(r.DestinationID)
+}
+
+func (h *handlers) listBackupsHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params, err := bindPagination(c)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	pagination, items, err := h.servs.BackupsService.PaginateBackups(
+		ctx, backups.PaginateBackupsParams{
+			Page:  params.Page,
+			Limit: params.Limit,
+		},
+	)
+	if err != nil {
+		return respondError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, paginatedResponse[backupResponse]{
+		Pagination: pagination,
+		Items: mapItems(
+			items, func(item dbgen.BackupsServicePaginateBackupsRow) backupResponse {
+				return newBackupResponse(dbgen.Backup{
+					ID:                     item.ID,
+					DatabaseID:             item.DatabaseID,
+					DestinationID:          item.DestinationID,
+					Name:                   item.Name,
+					CronExpression:         item.CronExpression,
+					TimeZone:               item.TimeZone,
+					IsActive:               item.IsActive,
+					DestDir:                item.DestDir,
+					RetentionDays:          item.RetentionDays,
+					OptDataOnly:            item.OptDataOnly,
+					OptSchemaOnly:          item.OptSchemaOnly,
+					OptClean:               item.OptClean,
+					OptIfExists:            item.OptIfExists,
+					OptCreate:              item.OptCreate,
+					OptNoComments:          item.OptNoComments,
+					CreatedAt:              item.CreatedAt,
+					UpdatedAt:              item.UpdatedAt,
+					IsLocal:                item.IsLocal,
+					MaxPartSizeMb:          item.MaxPartSizeMb,
+					CompressionLevel:       item.CompressionLevel,
+					SizeDeviationThreshold: item.SizeDeviationThreshold,
+					RpoHours:               item.RpoHours,
+					RunAfterBackupID:       item.RunAfterBackupID,
+					IsStale:                item.IsStale,
+					IsProvisioned:          item.IsProvisioned,
+				})
+			},
+		),
+	})
+}
+
+func (h *handlers) getBackupHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	backupID, err := uuid.Parse(c.Param("backupID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	backup, err := h.servs.BackupsService.GetBackup(ctx, backupID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, newBackupResponse(backup))
+}
+
+func (h *handlers) createBackupHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var reqData createBackupRequest
+	if err := c.Bind(&reqData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+	if err := validate.Struct(&reqData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	backup, err := h.servs.BackupsService.CreateBackup(
+		ctx, dbgen.BackupsServiceCreateBackupParams{
+			DatabaseID:             reqData.DatabaseID,
+			DestinationID:          reqData.destinationID(),
+			IsLocal:                reqData.IsLocal,
+			Name:                   reqData.Name,
+			CronExpression:         reqData.CronExpression,
+			TimeZone:               reqData.TimeZone,
+			IsActive:               reqData.IsActive,
+			DestDir:                reqData.DestDir,
+			RetentionDays:          reqData.RetentionDays,
+			OptDataOnly:            reqData.OptDataOnly,
+			OptSchemaOnly:          reqData.OptSchemaOnly,
+			OptClean:               reqData.OptClean,
+			OptIfExists:            reqData.OptIfExists,
+			OptCreate:              reqData.OptCreate,
+			OptNoComments:          reqData.OptNoComments,
+			MaxPartSizeMb:          toNullInt32(reqData.MaxPartSizeMb),
+			CompressionLevel:       toNullInt16(reqData.CompressionLevel),
+			SizeDeviationThreshold: reqData.SizeDeviationThreshold,
+			RpoHours:               toNullInt16(reqData.RpoHours),
+			RunAfterBackupID:       toNullUUID(reqData.RunAfterBackupID),
+		},
+	)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	return c.JSON(http.StatusCreated, newBackupResponse(backup))
+}
+
+func (h *handlers) updateBackupHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	backupID, err := uuid.Parse(c.Param("backupID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	var reqData backupRequest
+	if err := c.Bind(&reqData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+	if err := validate.Struct(&reqData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	backup, err := h.servs.BackupsService.UpdateBackup(
+		ctx, dbgen.BackupsServiceUpdateBackupParams{
+			ID:               backupID,
+			Name:             sql.NullString{String: reqData.Name, Valid: true},
+			CronExpression:   sql.NullString{String: reqData.CronExpression, Valid: true},
+			TimeZone:         sql.NullString{String: reqData.TimeZone, Valid: true},
+			IsActive:         sql.NullBool{Bool: reqData.IsActive, Valid: true},
+			DestDir:          sql.NullString{String: reqData.DestDir, Valid: true},
+			RetentionDays:    sql.NullInt16{Int16: reqData.RetentionDays, Valid: true},
+			OptDataOnly:      sql.NullBool{Bool: reqData.OptDataOnly, Valid: true},
+			OptSchemaOnly:    sql.NullBool{Bool: reqData.OptSchemaOnly, Valid: true},
+			OptClean:         sql.NullBool{Bool: reqData.OptClean, Valid: true},
+			OptIfExists:      sql.NullBool{Bool: reqData.OptIfExists, Valid: true},
+			OptCreate:        sql.NullBool{Bool: reqData.OptCreate, Valid: true},
+			OptNoComments:    sql.NullBool{Bool: reqData.OptNoComments, Valid: true},
+			IsLocal:          sql.NullBool{Bool: reqData.IsLocal, Valid: true},
+			DestinationID:    reqData.destinationID(),
+			MaxPartSizeMb:    toNullInt32(reqData.MaxPartSizeMb),
+			CompressionLevel: toNullInt16(reqData.CompressionLevel),
+			SizeDeviationThreshold: sql.NullInt16{
+				Int16: reqData.SizeDeviationThreshold, Valid: true,
+			},
+			RpoHours:         toNullInt16(reqData.RpoHours),
+			RunAfterBackupID: toNullUUID(reqData.RunAfterBackupID),
+		},
+	)
+	if err != nil {
+		return respondServiceError(c, http.StatusBadRequest, err)
+	}
+
+	return c.JSON(http.StatusOK, newBackupResponse(backup))
+}
+
+func (h *handlers) deleteBackupHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	backupID, err := uuid.Parse(c.Param("backupID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	err = h.servs.BackupsService.DeleteBackup(ctx, backupID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *handlers) duplicateBackupHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	backupID, err := uuid.Parse(c.Param("backupID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	backup, err := h.servs.BackupsService.DuplicateBackup(ctx, backupID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusCreated, newBackupResponse(backup))
+}
+
+func (h *handlers) toggleBackupActiveHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	backupID, err := uuid.Parse(c.Param("backupID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	err = h.servs.BackupsService.ToggleIsActive(ctx, backupID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	backup, err := h.servs.BackupsService.GetBackup(ctx, backupID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, newBackupResponse(backup))
+}
+
+func (h *handlers) runBackupHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	backupID, err := uuid.Parse(c.Param("backupID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	if _, err := h.servs.BackupsService.GetBackup(ctx, backupID); err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	// WithoutCancel keeps the role of the user in the context
+	go func() {
+		_ = h.servs.ExecutionsService.RunExecution(
+			context.WithoutCancel(ctx), backupID,
+		)
+	}()
+
+	return c.JSON(http.StatusAccepted, messageResponse{
+		Message: "backup execution started",
+	})
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/service/provisioning"
+	"github.com/labstack/echo/v4"
+)
+
+type configChangeResponse struct {
+	Action string   `json:"action"`
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	Fields []string `json:"fields"`
+}
+
+type configPlanResponse struct {
+	Changes []configChangeResponse `json:"changes"`
+}
+
+func newConfigPlanResponse(plan provisioning.Plan) configPlanResponse {
+	changes := make([]configChangeResponse, 0, len(plan.Changes))
+	for _, change := range plan.Changes {
+		fields := change.Fields
+		if fields == nil {
+			fields = []string{}
+		}
+		changes = append(changes, configChangeResponse{
+			Action: string(change.Action),
+			Kind:   string(change.Kind),
+			Name:   change.Name,
+			Fields: fields,
+		})
+	}
+	return configPlanResponse{Changes: changes}
+}
+
+func (h *handlers) diffConfigHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	plan, err := h.servs.ProvisioningService.Reconcile(ctx, true)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	return c.JSON(http.StatusOK, newConfigPlanResponse(plan))
+}
+
+func (h *handlers) reloadConfigHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	plan, err := h.servs.ProvisioningService.Reconcile(ctx, false)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	return c.JSON(http.StatusOK, newConfigPlanResponse(plan))
+}
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/databases"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// databaseResponse never includes the connection string because it contains
+// the database credentials.
+type databaseResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	Name          string     `json:"name"`
+	PgVersion     string     `json:"pg_version"`
+	TestOk        *bool      `json:"test_ok"`
+	TestError     *string    `json:"test_error"`
+	LastTestAt    *time.Time `json:"last_test_at"`
+	IsProvisioned bool       `json:"is_provisioned"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+
+	HealthCheckIntervalMinutes int32 `json:"health_check_interval_minutes"`
+	HealthFailureThreshold     int32 `json:"health_failure_threshold"`
+	HealthSuccessThreshold     int32 `json:"health_success_threshold"`
+}
+
+func newDatabaseResponse(
+	database dbgen.DatabasesServiceGetDatabaseRow,
+) databaseResponse {
+	return databaseResponse{
+		ID:            database.ID,
+		Name:          database.Name,
+		PgVersion:     database.PgVersion,
+		TestOk:        nullBool(database.TestOk),
+		TestError:     nullString(database.TestError),
+		LastTestAt:    nullTime(database.LastTestAt),
+		IsProvisioned: database.IsProvisioned,
+		CreatedAt:     database.CreatedAt,
+		UpdatedAt:     nullTime(database.UpdatedAt),
+
+		HealthCheckIntervalMinutes: database.HealthCheckIntervalMinutes,
+		HealthFailureThreshold:     database.HealthFailureThreshold,
+		HealthSuccessThreshold:     database.HealthSuccessThreshold,
+	}
+}
+
+type databaseRequest struct {
+	Name             string `json:"name" validate:"required"`
+	PgVersion        string `json:"pg_version" validate:"required"`
+	ConnectionString string `json:"connection_string" validate:"required"`
+
+	// The health settings keep their current values, or the defaults on
+	// create, when they are omitted.
+	HealthCheckIntervalMinutes *int32 `json:"health_check_interval_minutes" validate:"omitempty,min=1,max=1440"`
+	HealthFailureThreshold     *int32 `json:"health_failure_threshold" validate:"omitempty,min=1,max=100"`
+	HealthSuccessThreshold     *int32 `json:"health_success_threshold" validate:"omitempty,min=1,max=100"`
+}
+
+func (h *handlers) listDatabasesHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params, err := bindPagination(c)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	pagination, items, err := h.servs.DatabasesService.PaginateDatabases(
+		ctx, databases.PaginateDatabasesParams{
+			Page:  params.Page,
+			Limit: params.Limit,
+		},
+	)
+	if err != nil {
+		return respondError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, paginatedResponse[databaseResponse]{
+		Pagination: pagination,
+		Items: mapItems(
+			items, func(item dbgen.DatabasesServicePaginateDatabasesRow) databaseResponse {
+				return newDatabaseResponse(dbgen.DatabasesServiceGetDatabaseRow(item))
+			},
+		),
+	})
+}
+
+func (h *handlers) getDatabaseHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	databaseID, err := uuid.Parse(c.Param("databaseID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	database, err := h.servs.DatabasesService.GetDatabase(ctx, databaseID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, newDatabaseResponse(database))
+}
+
+func (h *handlers) createDatabaseHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var reqData databaseRequest
+	if err := c.Bind(&reqData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+	if err := validate.Struct(&reqData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	created, err := h.servs.DatabasesService.CreateDatabase(
+		ctx, dbgen.DatabasesServiceCreateDatabaseParams{
+			Name:                       reqData.Name,
+			PgVersion:                  reqData.PgVersion,
+			ConnectionString:           reqData.ConnectionString,
+			HealthCheckIntervalMinutes: toNullInt32(reqData.HealthCheckIntervalMinutes),
+			HealthFailureThreshold:     toNullInt32(reqData.HealthFailureThreshold),
+			HealthSuccessThreshold:     toNullInt32(reqData.HealthSuccessThreshold),
+		},
+	)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	database, err := h.servs.DatabasesService.GetDatabase(ctx, created.ID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusCreated, newDatabaseResponse(database))
+}
+
+func (h *handlers) updateDatabaseHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	databaseID, err := uuid.Parse(c.Param("databaseID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	var reqData databaseRequest
+	if err := c.Bind(&reqData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+	if err := validate.Struct(&reqData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	_, err = h.servs.DatabasesService.UpdateDatabase(
+		ctx, dbgen.DatabasesServiceUpdateDatabaseParams{
+			ID:                         databaseID,
+			Name:                       sql.NullString{String: reqData.Name, Valid: true},
+			PgVersion:                  sql.NullString{String: reqData.PgVersion, Valid: true},
+			ConnectionString:           sql.NullString{String: reqData.ConnectionString, Valid: true},
+			HealthCheckIntervalMinutes: toNullInt32(reqData.HealthCheckIntervalMinutes),
+			HealthFailureThreshold:     toNullInt32(reqData.HealthFailureThreshold),
+			HealthSuccessThreshold:     toNullInt32(reqData.HealthSuccessThreshold),
+		},
+	)
+	if err != nil {
+		return respondServiceError(c, http.StatusBadRequest, err)
+	}
+
+	database, err := h.servs.DatabasesService.GetDatabase(ctx, databaseID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, newDatabaseResponse(database))
+}
+
+func (h *handlers) deleteDatabaseHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	databaseID, err := uuid.Parse(c.Param("databaseID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	err = h.servs.DatabasesService.DeleteDatabase(ctx, databaseID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *handlers) testDatabaseHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	databaseID, err := uuid.Parse(c.Param("databaseID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	// The result is stored in the database and returned in the response, a
+	// failed test is not an error of the request itself.
+	_ = h.servs.DatabasesService.TestDatabaseAndStoreResult(ctx, databaseID)
+
+	database, err := h.servs.DatabasesService.GetDatabase(ctx, databaseID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, newDatabaseResponse(database))
+}
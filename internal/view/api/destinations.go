@@ -0,0 +1,249 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/destinations"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// destinationResponse never includes the access and secret keys.
+type destinationResponse struct {
+	ID               uuid.UUID  `json:"id"`
+	Name             string     `json:"name"`
+	BucketName       string     `json:"bucket_name"`
+	Region           string     `json:"region"`
+	Endpoint         string     `json:"endpoint"`
+	ForcePathStyle   bool       `json:"force_path_style"`
+	SignatureVersion string     `json:"signature_version"`
+	TestOk           *bool      `json:"test_ok"`
+	TestError        *string    `json:"test_error"`
+	LastTestAt       *time.Time `json:"last_test_at"`
+	IsProvisioned    bool       `json:"is_provisioned"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
+
+	HealthCheckIntervalMinutes int32 `json:"health_check_interval_minutes"`
+	HealthFailureThreshold     int32 `json:"health_failure_threshold"`
+	HealthSuccessThreshold     int32 `json:"health_success_threshold"`
+}
+
+func newDestinationResponse(
+	destination dbgen.DestinationsServiceGetDestinationRow,
+) destinationResponse {
+	return destinationResponse{
+		ID:               destination.ID,
+		Name:             destination.Name,
+		BucketName:       destination.BucketName,
+		Region:           destination.Region,
+		Endpoint:         destination.Endpoint,
+		ForcePathStyle:   destination.ForcePathStyle,
+		SignatureVersion: destination.SignatureVersion,
+		TestOk:           nullBool(destination.TestOk),
+		TestError:        nullString(destination.TestError),
+		LastTestAt:       nullTime(destination.LastTestAt),
+		IsProvisioned:    destination.IsProvisioned,
+		CreatedAt:        destination.CreatedAt,
+		UpdatedAt:        nullTime(destination.UpdatedAt),
+
+		HealthCheckIntervalMinutes: destination.HealthCheckIntervalMinutes,
+		HealthFailureThreshold:     destination.HealthFailureThreshold,
+		HealthSuccessThreshold:     destination.HealthSuccessThreshold,
+	}
+}
+
+type destinationRequest struct {
+	Name             string `json:"name" validate:"required"`
+	BucketName       string `json:"bucket_name" validate:"required"`
+	AccessKey        string `json:"access_key" validate:"required"`
+	SecretKey        string `json:"secret_key" validate:"required"`
+	Region           string `json:"region" validate:"required"`
+	Endpoint         string `json:"endpoint" validate:"required"`
+	ForcePathStyle   bool   `json:"force_path_style"`
+	SignatureVersion string `json:"signature_version" validate:"required,oneof=v2 v4"`
+
+	// The health settings keep their current values, or the defaults on
+	// create, when they are omitted.
+	HealthCheckIntervalMinutes *int32 `json:"health_check_interval_minutes" validate:"omitempty,min=1,max=1440"`
+	HealthFailureThreshold     *int32 `json:"health_failure_threshold" validate:"omitempty,min=1,max=100"`
+	HealthSuccessThreshold     *int32 `json:"health_success_threshold" validate:"omitempty,min=1,max=100"`
+}
+
+func (h *handlers) listDestinationsHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params, err := bindPagination(c)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	pagination, items, err := h.servs.DestinationsService.PaginateDestinations(
+		ctx, destinations.PaginateDestinationsParams{
+			Page:  params.Page,
+			Limit: params.Limit,
+		},
+	)
+	if err != nil {
+		return respondError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, paginatedResponse[destinationResponse]{
+		Pagination: pagination,
+		Items: mapItems(
+			items, func(item dbgen.DestinationsServicePaginateDestinationsRow) destinationResponse {
+				return newDestinationResponse(
+					dbgen.DestinationsServiceGetDestinationRow(item),
+				)
+			},
+		),
+	})
+}
+
+func (h *handlers) getDestinationHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	destinationID, err := uuid.Parse(c.Param("destinationID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	destination, err := h.servs.DestinationsService.GetDestination(
+		ctx, destinationID,
+	)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, newDestinationResponse(destination))
+}
+
+func (h *handlers) createDestinationHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var reqData destinationRequest
+	if err := c.Bind(&reqData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+	if err := validate.Struct(&reqData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	created, err := h.servs.DestinationsService.CreateDestination(
+		ctx, dbgen.DestinationsServiceCreateDestinationParams{
+			Name:                       reqData.Name,
+			BucketName:                 reqData.BucketName,
+			AccessKey:                  reqData.AccessKey,
+			SecretKey:                  reqData.SecretKey,
+			Region:                     reqData.Region,
+			Endpoint:                   reqData.Endpoint,
+			ForcePathStyle:             reqData.ForcePathStyle,
+			SignatureVersion:           reqData.SignatureVersion,
+			HealthCheckIntervalMinutes: toNullInt32(reqData.HealthCheckIntervalMinutes),
+			HealthFailureThreshold:     toNullInt32(reqData.HealthFailureThreshold),
+			HealthSuccessThreshold:     toNullInt32(reqData.HealthSuccessThreshold),
+		},
+	)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	destination, err := h.servs.DestinationsService.GetDestination(
+		ctx, created.ID,
+	)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusCreated, newDestinationResponse(destination))
+}
+
+func (h *handlers) updateDestinationHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	destinationID, err := uuid.Parse(c.Param("destinationID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	var reqData destinationRequest
+	if err := c.Bind(&reqData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+	if err := validate.Struct(&reqData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	_, err = h.servs.DestinationsService.UpdateDestination(
+		ctx, dbgen.DestinationsServiceUpdateDestinationParams{
+			ID:                         destinationID,
+			Name:                       sql.NullString{String: reqData.Name, Valid: true},
+			BucketName:                 sql.NullString{String: reqData.BucketName, Valid: true},
+			Region:                     sql.NullString{String: reqData.Region, Valid: true},
+			Endpoint:                   sql.NullString{String: reqData.Endpoint, Valid: true},
+			ForcePathStyle:             sql.NullBool{Bool: reqData.ForcePathStyle, Valid: true},
+			SignatureVersion:           sql.NullString{String: reqData.SignatureVersion, Valid: true},
+			AccessKey:                  sql.NullString{String: reqData.AccessKey, Valid: true},
+			SecretKey:                  sql.NullString{String: reqData.SecretKey, Valid: true},
+			HealthCheckIntervalMinutes: toNullInt32(reqData.HealthCheckIntervalMinutes),
+			HealthFailureThreshold:     toNullInt32(reqData.HealthFailureThreshold),
+			HealthSuccessThreshold:     toNullInt32(reqData.HealthSuccessThreshold),
+		},
+	)
+	if err != nil {
+		return respondServiceError(c, http.StatusBadRequest, err)
+	}
+
+	destination, err := h.servs.DestinationsService.GetDestination(
+		ctx, destinationID,
+	)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, newDestinationResponse(destination))
+}
+
+func (h *handlers) deleteDestinationHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	destinationID, err := uuid.Parse(c.Param("destinationID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	err = h.servs.DestinationsService.DeleteDestination(ctx, destinationID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *handlers) testDestinationHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	destinationID, err := uuid.Parse(c.Param("destinationID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	// The result is stored in the database and returned in the response, a
+	// failed test is not an error of the request itself.
+	_ = h.servs.DestinationsService.TestDestinationAndStoreResult(
+		ctx, destinationID,
+	)
+
+	destination, err := h.servs.DestinationsService.GetDestination(
+		ctx, destinationID,
+	)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, newDestinationResponse(destination))
+}
@@ -0,0 +1,279 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/executions"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type executionResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	BackupID     uuid.UUID  `json:"backup_id"`
+	Status       string     `json:"status"`
+	Message      *string    `json:"message"`
+	Path         *string    `json:"path"`
+	FileSize     *int64     `json:"file_size"`
+	IsSuspicious bool       `json:"is_suspicious"`
+	StartedAt    time.Time  `json:"started_at"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+	FinishedAt   *time.Time `json:"finished_at"`
+	DeletedAt    *time.Time `json:"deleted_at"`
+}
+
+func newExecutionResponse(execution dbgen.Execution) executionResponse {
+	return executionResponse{
+		ID:           execution.ID,
+		BackupID:     execution.BackupID,
+		Status:       execution.Status,
+		Message:      nullString(execution.Message),
+		Path:         nullString(execution.Path),
+		FileSize:     nullInt64(execution.FileSize),
+		IsSuspicious: execution.IsSuspicious,
+		StartedAt:    execution.StartedAt,
+		UpdatedAt:    nullTime(execution.UpdatedAt),
+		FinishedAt:   nullTime(execution.FinishedAt),
+		DeletedAt:    nullTime(execution.DeletedAt),
+	}
+}
+
+type listExecutionsQuery struct {
+	DatabaseID    uuid.UUID `query:"database_id"`
+	DestinationID uuid.UUID `query:"destination_id"`
+	BackupID      uuid.UUID `query:"backup_id"`
+}
+
+func (h *handlers) listExecutionsHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params, err := bindPagination(c)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	var queryData listExecutionsQuery
+	if err := c.Bind(&queryData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	pagination, items, err := h.servs.ExecutionsService.PaginateExecutions(
+		ctx, executions.PaginateExecutionsParams{
+			Page:  params.Page,
+			Limit: params.Limit,
+			DatabaseFilter: uuid.NullUUID{
+				UUID: queryData.DatabaseID, Valid: queryData.DatabaseID != uuid.Nil,
+			},
+			DestinationFilter: uuid.NullUUID{
+				UUID: queryData.DestinationID, Valid: queryData.DestinationID != uuid.Nil,
+			},
+			BackupFilter: uuid.NullUUID{
+				UUID: queryData.BackupID, Valid: queryData.BackupID != uuid.Nil,
+			},
+		},
+	)
+	if err != nil {
+		return respondError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, paginatedResponse[executionResponse]{
+		Pagination: pagination,
+		Items: mapItems(
+			items, func(item dbgen.ExecutionsServicePaginateExecutionsRow) executionResponse {
+				return newExecutionResponse(dbgen.Execution{
+					ID:           item.ID,
+					BackupID:     item.BackupID,
+					Status:       item.Status,
+					Message:      item.Message,
+					Path:         item.Path,
+					StartedAt:    item.StartedAt,
+					UpdatedAt:    item.UpdatedAt,
+					FinishedAt:   item.FinishedAt,
+					DeletedAt:    item.DeletedAt,
+					FileSize:     item.FileSize,
+					IsSuspicious: item.IsSuspicious,
+				})
+			},
+		),
+	})
+}
+
+func (h *handlers) getExecutionHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	executionID, err := uuid.Parse(c.Param("executionID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	execution, err := h.servs.ExecutionsService.GetExecution(ctx, executionID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, newExecutionResponse(dbgen.Execution{
+		ID:           execution.ID,
+		BackupID:     execution.BackupID,
+		Status:       execution.Status,
+		Message:      execution.Message,
+		Path:         execution.Path,
+		StartedAt:    execution.StartedAt,
+		UpdatedAt:    execution.UpdatedAt,
+		FinishedAt:   execution.FinishedAt,
+		DeletedAt:    execution.DeletedAt,
+		FileSize:     execution.FileSize,
+		IsSuspicious: execution.IsSuspicious,
+	}))
+}
+
+func (h *handlers) deleteExecutionHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	executionID, err := uuid.Parse(c.Param("executionID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	err = h.servs.ExecutionsService.SoftDeleteExecution(ctx, executionID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+type executionDownloadLinksResponse struct {
+	IsLocal bool     `json:"is_local"`
+	Links   []string `json:"links"`
+}
+
+// getExecutionDownloadLinksHandler returns one download link per part of the
+// execution file. Files stored in S3 get pre-signed links while local files
+// get links to the download endpoint of this API.
+func (h *handlers) getExecutionDownloadLinksHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	executionID, err := uuid.Parse(c.Param("executionID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	isLocal, links, err := h.servs.ExecutionsService.GetAllExecutionLinksOrPaths(
+		ctx, executionID,
+	)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	if isLocal {
+		for i := range links {
+			links[i] = pathutil.BuildPath(fmt.Sprintf(
+				"/api/v1/executions/%s/download?part=%d", executionID, i+1,
+			))
+		}
+	}
+
+	return c.JSON(http.StatusOK, executionDownloadLinksResponse{
+		IsLocal: isLocal,
+		Links:   links,
+	})
+}
+
+type downloadExecutionQuery struct {
+	Part int `query:"part" validate:"min=1"`
+}
+
+// downloadExecutionHandler serves a single part of the execution file, the
+// first one by default. Local files are served directly and S3 files are
+// redirected to their pre-signed link.
+func (h *handlers) downloadExecutionHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	executionID, err := uuid.Parse(c.Param("executionID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	queryData := downloadExecutionQuery{Part: 1}
+	if err := c.Bind(&queryData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+	if err := validate.Struct(&queryData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	isLocal, links, err := h.servs.ExecutionsService.GetAllExecutionLinksOrPaths(
+		ctx, executionID,
+	)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+	if queryData.Part > len(links) {
+		return respondError(
+			c, http.StatusNotFound, fmt.Errorf("part %d not found", queryData.Part),
+		)
+	}
+
+	link := links[queryData.Part-1]
+	if isLocal {
+		return c.Attachment(link, filepath.Base(link))
+	}
+	return c.Redirect(http.StatusFound, link)
+}
+
+// restoreExecutionRequest requires either an existing database or a
+// connection string to restore the execution into, but not both.
+type restoreExecutionRequest struct {
+	DatabaseID *uuid.UUID `json:"database_id" validate:"required_without=ConnString,excluded_with=ConnString"`
+	ConnString string     `json:"conn_string"`
+}
+
+func (h *handlers) restoreExecutionHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	executionID, err := uuid.Parse(c.Param("executionID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	var reqData restoreExecutionRequest
+	if err := c.Bind(&reqData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+	if err := validate.Struct(&reqData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	execution, err := h.servs.ExecutionsService.GetExecution(ctx, executionID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	if reqData.ConnString != "" {
+		err := h.servs.DatabasesService.TestDatabase(
+			ctx, execution.DatabasePgVersion, reqData.ConnString,
+		)
+		if err != nil {
+			return respondError(c, http.StatusBadRequest, err)
+		}
+	}
+
+	// WithoutCancel keeps the role of the user in the context
+	go func() {
+		ctx := context.WithoutCancel(ctx)
+		_ = h.servs.RestorationsService.RunRestoration(
+			ctx, executionID, toNullUUID(reqData.DatabaseID), reqData.ConnString,
+		)
+	}()
+
+	return c.JSON(http.StatusAccepted, messageResponse{
+		Message: "restoration started",
+	})
+}
@@ -1,62 +1,172 @@
 package api
 
 import (
+	"context"
 	"net/http"
+	"slices"
 
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
+type healthQuery struct {
+	IncludeDatabases    bool `query:"databases"`
+	IncludeDestinations bool `query:"destinations"`
+	IncludeBackups      bool `query:"backups"`
+}
+
+type healthDetailsQuery struct {
+	IncludeDatabases    bool `query:"databases"`
+	IncludeDestinations bool `query:"destinations"`
+	IncludeBackups      bool `query:"backups"`
+	IncludeWorkspaces   bool `query:"workspaces"`
+}
+
+type staleBackupResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type workspaceHealthResponse struct {
+	ID                  uuid.UUID `json:"id"`
+	Name                string    `json:"name"`
+	DatabasesHealthy    bool      `json:"databases_healthy"`
+	DestinationsHealthy bool      `json:"destinations_healthy"`
+	BackupsHealthy      bool      `json:"backups_healthy"`
+}
+
+// healthHandler is public, so it only returns whether the resources of the
+// instance are healthy and never which ones are not.
 func (h *handlers) healthHandler(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	var queryData struct {
-		IncludeDatabases    bool `query:"databases"`
-		IncludeDestinations bool `query:"destinations"`
+	var queryData healthQuery
+	if err := c.Bind(&queryData); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
 	}
+
+	response, _, err := h.checkHealth(ctx, queryData)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// healthDetailsHandler is like healthHandler but requires an API token and
+// only checks the workspace of the request. It also lists the stale backups
+// and the health of every workspace of the request, limited to the ones the
+// owner of the token is a member of.
+func (h *handlers) healthDetailsHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	reqCtx := reqctx.GetCtx(c)
+
+	var queryData healthDetailsQuery
 	if err := c.Bind(&queryData); err != nil {
 		return c.String(http.StatusBadRequest, err.Error())
 	}
 
-	databasesHealthy, destinationsHealthy := true, true
+	response, staleBackups, err := h.checkHealth(ctx, healthQuery{
+		IncludeDatabases:    queryData.IncludeDatabases,
+		IncludeDestinations: queryData.IncludeDestinations,
+		IncludeBackups:      queryData.IncludeBackups,
+	})
+	if err != nil {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+	if queryData.IncludeBackups {
+		response["stale_backups"] = staleBackups
+	}
+
+	if queryData.IncludeWorkspaces {
+		summary, err := h.servs.WorkspacesService.GetWorkspacesSummary(ctx)
+		if err != nil {
+			return c.String(http.StatusInternalServerError, err.Error())
+		}
+
+		workspacesHealth := []workspaceHealthResponse{}
+		for _, ws := range summary {
+			isMember := slices.ContainsFunc(
+				reqCtx.Workspaces, func(uw workspaces.UserWorkspace) bool {
+					return uw.ID == ws.ID
+				},
+			)
+			if !isMember {
+				continue
+			}
+
+			workspacesHealth = append(workspacesHealth, workspaceHealthResponse{
+				ID:                  ws.ID,
+				Name:                ws.Name,
+				DatabasesHealthy:    ws.UnhealthyDatabases == 0,
+				DestinationsHealthy: ws.UnhealthyDestinations == 0,
+				BackupsHealthy:      ws.StaleBackups == 0,
+			})
+		}
+		response["workspaces"] = workspacesHealth
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// checkHealth returns the health of the requested resources and the stale
+// backups, if the backups are requested.
+func (h *handlers) checkHealth(
+	ctx context.Context, queryData healthQuery,
+) (map[string]any, []staleBackupResponse, error) {
+	response := map[string]any{
+		"server_healthy": true,
+	}
+	staleBackups := []staleBackupResponse{}
 
 	if queryData.IncludeDatabases {
 		databases, err := h.servs.DatabasesService.GetAllDatabases(ctx)
 		if err != nil {
-			return c.String(http.StatusInternalServerError, err.Error())
+			return nil, nil, err
 		}
 
+		databasesHealthy := true
 		for _, db := range databases {
 			if db.TestOk.Valid && !db.TestOk.Bool {
 				databasesHealthy = false
 				break
 			}
 		}
-
+		response["databases_healthy"] = databasesHealthy
 	}
 
 	if queryData.IncludeDestinations {
 		destinations, err := h.servs.DestinationsService.GetAllDestinations(ctx)
 		if err != nil {
-			return c.String(http.StatusInternalServerError, err.Error())
+			return nil, nil, err
 		}
 
+		destinationsHealthy := true
 		for _, dest := range destinations {
 			if dest.TestOk.Valid && !dest.TestOk.Bool {
 				destinationsHealthy = false
 				break
 			}
 		}
+		response["destinations_healthy"] = destinationsHealthy
 	}
 
-	response := map[string]any{
-		"server_healthy": true,
-	}
-	if queryData.IncludeDatabases {
-		response["databases_healthy"] = databasesHealthy
-	}
-	if queryData.IncludeDestinations {
-		response["destinations_healthy"] = destinationsHealthy
+	if queryData.IncludeBackups {
+		backups, err := h.servs.BackupsService.GetStaleBackups(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, backup := range backups {
+			staleBackups = append(staleBackups, staleBackupResponse{
+				ID:   backup.ID,
+				Name: backup.Name,
+			})
+		}
+		response["backups_healthy"] = len(staleBackups) == 0
 	}
 
-	return c.JSON(http.StatusOK, response)
+	return response, staleBackups, nil
 }
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/health"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type healthPeriodResponse struct {
+	Name         string  `json:"name"`
+	Checks       int64   `json:"checks"`
+	Uptime       float64 `json:"uptime"`
+	AvgLatencyMs int64   `json:"avg_latency_ms"`
+	MaxLatencyMs int64   `json:"max_latency_ms"`
+	Changes      int64   `json:"changes"`
+}
+
+type healthCheckResponse struct {
+	Ok        bool      `json:"ok"`
+	Error     *string   `json:"error"`
+	LatencyMs int32     `json:"latency_ms"`
+	Changed   bool      `json:"changed"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type healthReportResponse struct {
+	Periods []healthPeriodResponse `json:"periods"`
+	Checks  []healthCheckResponse  `json:"checks"`
+}
+
+func newHealthReportResponse(report health.Report) healthReportResponse {
+	return healthReportResponse{
+		Periods: mapItems(
+			report.Periods, func(period health.Period) healthPeriodResponse {
+				return healthPeriodResponse{
+					Name:         period.Name,
+					Checks:       period.Checks,
+					Uptime:       period.Uptime,
+					AvgLatencyMs: period.AvgLatency.Milliseconds(),
+					MaxLatencyMs: period.MaxLatency.Milliseconds(),
+					Changes:      period.Changes,
+				}
+			},
+		),
+		Checks: mapItems(
+			report.Checks, func(check dbgen.HealthCheck) healthCheckResponse {
+				return healthCheckResponse{
+					Ok:        check.Ok,
+					Error:     nullString(check.Error),
+					LatencyMs: check.LatencyMs,
+					Changed:   check.Changed,
+					CreatedAt: check.CreatedAt,
+				}
+			},
+		),
+	}
+}
+
+func (h *handlers) getDatabaseHealthHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	databaseID, err := uuid.Parse(c.Param("databaseID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	// Getting the database first checks it is in the workspace of the token.
+	if _, err := h.servs.DatabasesService.GetDatabase(ctx, databaseID); err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	report, err := h.servs.HealthService.GetReport(
+		ctx, uuid.NullUUID{UUID: databaseID, Valid: true}, uuid.NullUUID{},
+	)
+	if err != nil {
+		return respondError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, newHealthReportResponse(report))
+}
+
+func (h *handlers) getDestinationHealthHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	destinationID, err := uuid.Parse(c.Param("destinationID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	// Getting the destination first checks it is in the workspace of the
+	// token.
+	_, err = h.servs.DestinationsService.GetDestination(ctx, destinationID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	report, err := h.servs.HealthService.GetReport(
+		ctx, uuid.NullUUID{}, uuid.NullUUID{UUID: destinationID, Valid: true},
+	)
+	if err != nil {
+		return respondError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, newHealthReportResponse(report))
+}
@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *handlers) metricsHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	token := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+	if !h.servs.MetricsService.IsValidToken(token) {
+		return c.String(http.StatusUnauthorized, "invalid metrics token")
+	}
+
+	metrics, err := h.servs.MetricsService.GatherMetrics(ctx)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	return c.Blob(
+		http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(metrics),
+	)
+}
@@ -0,0 +1,554 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// openAPIOperation describes one route of the REST API. The OpenAPI document
+// is generated from these descriptions and the Go types the handlers use to
+// bind requests and encode responses, so both can't drift apart.
+type openAPIOperation struct {
+	Method  string
+	Path    string // Echo path relative to /api/v1, e.g. /backups/:backupID
+	Tag     string
+	Summary string
+	// Public operations don't require an API token.
+	Public bool
+	// Query is a struct whose query tags define the query parameters.
+	Query any
+	// Request is the value decoded from the JSON request body.
+	Request any
+	// Response is the value encoded in the successful response body, it is
+	// ignored when ResponseContentType is not JSON.
+	Response            any
+	ResponseStatus      int
+	ResponseContentType string
+}
+
+var openAPIOperations = []openAPIOperation{
+	{
+		Method: http.MethodGet, Path: "/health", Tag: "Health", Public: true,
+		Summary:  "Get the health of the server and optionally its resources",
+		Query:    healthQuery{},
+		Response: map[string]any{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodGet, Path: "/health/details", Tag: "Health",
+		Summary:  "Get the health of the resources of the workspace and the stale backups",
+		Query:    healthDetailsQuery{},
+		Response: map[string]any{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodGet, Path: "/metrics", Tag: "Health", Public: true,
+		Summary:             "Get the Prometheus metrics",
+		ResponseStatus:      http.StatusOK,
+		ResponseContentType: "text/plain",
+	},
+	{
+		Method: http.MethodGet, Path: "/openapi.json", Tag: "Health", Public: true,
+		Summary:  "Get this OpenAPI document",
+		Response: map[string]any{}, ResponseStatus: http.StatusOK,
+	},
+
+	{
+		Method: http.MethodGet, Path: "/databases", Tag: "Databases",
+		Summary:  "List databases",
+		Query:    paginationQuery{},
+		Response: paginatedResponse[databaseResponse]{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodPost, Path: "/databases", Tag: "Databases",
+		Summary:  "Create a database",
+		Request:  databaseRequest{},
+		Response: databaseResponse{}, ResponseStatus: http.StatusCreated,
+	},
+	{
+		Method: http.MethodGet, Path: "/databases/:databaseID", Tag: "Databases",
+		Summary:  "Get a database",
+		Response: databaseResponse{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodPut, Path: "/databases/:databaseID", Tag: "Databases",
+		Summary:  "Update a database",
+		Request:  databaseRequest{},
+		Response: databaseResponse{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodDelete, Path: "/databases/:databaseID", Tag: "Databases",
+		Summary:        "Delete a database",
+		ResponseStatus: http.StatusNoContent,
+	},
+	{
+		Method: http.MethodPost, Path: "/databases/:databaseID/test", Tag: "Databases",
+		Summary:  "Test the connection to a database and store the result",
+		Response: databaseResponse{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodGet, Path: "/databases/:databaseID/health", Tag: "Databases",
+		Summary:  "Get the uptime, latency and latest health checks of a database",
+		Response: healthReportResponse{}, ResponseStatus: http.StatusOK,
+	},
+
+	{
+		Method: http.MethodGet, Path: "/destinations", Tag: "Destinations",
+		Summary:  "List destinations",
+		Query:    paginationQuery{},
+		Response: paginatedResponse[destinationResponse]{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodPost, Path: "/destinations", Tag: "Destinations",
+		Summary:  "Create a destination",
+		Request:  destinationRequest{},
+		Response: destinationResponse{}, ResponseStatus: http.StatusCreated,
+	},
+	{
+		Method: http.MethodGet, Path: "/destinations/:destinationID", Tag: "Destinations",
+		Summary:  "Get a destination",
+		Response: destinationResponse{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodPut, Path: "/destinations/:destinationID", Tag: "Destinations",
+		Summary:  "Update a destination",
+		Request:  destinationRequest{},
+		Response: destinationResponse{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodDelete, Path: "/destinations/:destinationID", Tag: "Destinations",
+		Summary:        "Delete a destination",
+		ResponseStatus: http.StatusNoContent,
+	},
+	{
+		Method: http.MethodPost, Path: "/destinations/:destinationID/test", Tag: "Destinations",
+		Summary:  "Test the connection to a destination and store the result",
+		Response: destinationResponse{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodGet, Path: "/destinations/:destinationID/health", Tag: "Destinations",
+		Summary:  "Get the uptime, latency and latest health checks of a destination",
+		Response: healthReportResponse{}, ResponseStatus: http.StatusOK,
+	},
+
+	{
+		Method: http.MethodGet, Path: "/backups", Tag: "Backups",
+		Summary:  "List backups",
+		Query:    paginationQuery{},
+		Response: paginatedResponse[backupResponse]{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodPost, Path: "/backups", Tag: "Backups",
+		Summary:  "Create a backup",
+		Request:  createBackupRequest{},
+		Response: backupResponse{}, ResponseStatus: http.StatusCreated,
+	},
+	{
+		Method: http.MethodGet, Path: "/backups/:backupID", Tag: "Backups",
+		Summary:  "Get a backup",
+		Response: backupResponse{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodPut, Path: "/backups/:backupID", Tag: "Backups",
+		Summary:  "Update a backup",
+		Request:  backupRequest{},
+		Response: backupResponse{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodDelete, Path: "/backups/:backupID", Tag: "Backups",
+		Summary:        "Delete a backup",
+		ResponseStatus: http.StatusNoContent,
+	},
+	{
+		Method: http.MethodPost, Path: "/backups/:backupID/run", Tag: "Backups",
+		Summary:  "Start an execution of a backup in the background",
+		Response: messageResponse{}, ResponseStatus: http.StatusAccepted,
+	},
+	{
+		Method: http.MethodPost, Path: "/backups/:backupID/duplicate", Tag: "Backups",
+		Summary:  "Duplicate a backup",
+		Response: backupResponse{}, ResponseStatus: http.StatusCreated,
+	},
+	{
+		Method: http.MethodPost, Path: "/backups/:backupID/toggle-active", Tag: "Backups",
+		Summary:  "Activate or deactivate a backup",
+		Response: backupResponse{}, ResponseStatus: http.StatusOK,
+	},
+
+	{
+		Method: http.MethodGet, Path: "/executions", Tag: "Executions",
+		Summary: "List executions",
+		Query: struct {
+			paginationQuery
+			listExecutionsQuery
+		}{},
+		Response: paginatedResponse[executionResponse]{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodGet, Path: "/executions/:executionID", Tag: "Executions",
+		Summary:  "Get an execution",
+		Response: executionResponse{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodDelete, Path: "/executions/:executionID", Tag: "Executions",
+		Summary:        "Delete an execution and its files",
+		ResponseStatus: http.StatusNoContent,
+	},
+	{
+		Method: http.MethodGet, Path: "/executions/:executionID/download-links", Tag: "Executions",
+		Summary:  "Get one download link per part of the execution file",
+		Response: executionDownloadLinksResponse{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodGet, Path: "/executions/:executionID/download", Tag: "Executions",
+		Summary:             "Download a part of the execution file, S3 files are redirected",
+		Query:               downloadExecutionQuery{},
+		ResponseStatus:      http.StatusOK,
+		ResponseContentType: "application/octet-stream",
+	},
+	{
+		Method: http.MethodPost, Path: "/executions/:executionID/restore", Tag: "Executions",
+		Summary:  "Start a restoration of an execution in the background",
+		Request:  restoreExecutionRequest{},
+		Response: messageResponse{}, ResponseStatus: http.StatusAccepted,
+	},
+
+	{
+		Method: http.MethodGet, Path: "/restorations", Tag: "Restorations",
+		Summary: "List restorations",
+		Query: struct {
+			paginationQuery
+			listRestorationsQuery
+		}{},
+		Response: paginatedResponse[restorationResponse]{}, ResponseStatus: http.StatusOK,
+	},
+
+	{
+		Method: http.MethodGet, Path: "/webhooks", Tag: "Webhooks",
+		Summary:  "List webhooks",
+		Query:    paginationQuery{},
+		Response: paginatedResponse[webhookResponse]{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodPost, Path: "/webhooks", Tag: "Webhooks",
+		Summary:  "Create a webhook",
+		Request:  webhookRequest{},
+		Response: webhookResponse{}, ResponseStatus: http.StatusCreated,
+	},
+	{
+		Method: http.MethodGet, Path: "/webhooks/:webhookID", Tag: "Webhooks",
+		Summary:  "Get a webhook",
+		Response: webhookResponse{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodPut, Path: "/webhooks/:webhookID", Tag: "Webhooks",
+		Summary:  "Update a webhook",
+		Request:  webhookRequest{},
+		Response: webhookResponse{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodDelete, Path: "/webhooks/:webhookID", Tag: "Webhooks",
+		Summary:        "Delete a webhook",
+		ResponseStatus: http.StatusNoContent,
+	},
+	{
+		Method: http.MethodPost, Path: "/webhooks/:webhookID/duplicate", Tag: "Webhooks",
+		Summary:  "Duplicate a webhook",
+		Response: webhookResponse{}, ResponseStatus: http.StatusCreated,
+	},
+	{
+		Method: http.MethodPost, Path: "/webhooks/:webhookID/test", Tag: "Webhooks",
+		Summary:  "Send a test message with a sample event and wait for the result",
+		Response: messageResponse{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodGet, Path: "/webhooks/:webhookID/executions", Tag: "Webhooks",
+		Summary:  "List the executions of a webhook",
+		Query:    paginationQuery{},
+		Response: paginatedResponse[webhookExecutionResponse]{}, ResponseStatus: http.StatusOK,
+	},
+
+	{
+		Method: http.MethodGet, Path: "/config/diff", Tag: "Configuration",
+		Summary:  "Show the changes needed to match the configuration file",
+		Response: configPlanResponse{}, ResponseStatus: http.StatusOK,
+	},
+	{
+		Method: http.MethodPost, Path: "/config/reload", Tag: "Configuration",
+		Summary:  "Reconcile the configuration file and return the applied changes",
+		Response: configPlanResponse{}, ResponseStatus: http.StatusOK,
+	},
+}
+
+// openAPISpec is generated only once because the operations never change
+// while the server is running.
+var openAPISpec = sync.OnceValue(func() map[string]any {
+	return buildOpenAPISpec(openAPIOperations)
+})
+
+func (h *handlers) openAPIHandler(c echo.Context) error {
+	return c.JSON(http.StatusOK, openAPISpec())
+}
+
+// openAPIPath converts an Echo path like /backups/:backupID into an OpenAPI
+// path like /backups/{backupID}.
+func openAPIPath(echoPath string) string {
+	parts := strings.Split(echoPath, "/")
+	for i, part := range parts {
+		if name, ok := strings.CutPrefix(part, ":"); ok {
+			parts[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+func buildOpenAPISpec(operations []openAPIOperation) map[string]any {
+	errorContent := map[string]any{
+		"application/json": map[string]any{
+			"schema": openAPISchema(reflect.TypeOf(errorResponse{})),
+		},
+	}
+
+	paths := map[string]any{}
+	for _, op := range operations {
+		path := openAPIPath(op.Path)
+		if _, ok := paths[path]; !ok {
+			paths[path] = map[string]any{}
+		}
+
+		parameters := []any{}
+		for _, part := range strings.Split(op.Path, "/") {
+			if name, ok := strings.CutPrefix(part, ":"); ok {
+				parameters = append(parameters, map[string]any{
+					"name":     name,
+					"in":       "path",
+					"required": true,
+					"schema":   map[string]any{"type": "string", "format": "uuid"},
+				})
+			}
+		}
+		if op.Query != nil {
+			parameters = append(parameters, openAPIQueryParameters(
+				reflect.TypeOf(op.Query),
+			)...)
+		}
+		if !op.Public {
+			parameters = append(parameters, map[string]any{
+				"name":        "X-Workspace-ID",
+				"in":          "header",
+				"description": "The workspace to work on, see the README",
+				"schema":      map[string]any{"type": "string", "format": "uuid"},
+			})
+		}
+
+		success := map[string]any{"description": http.StatusText(op.ResponseStatus)}
+		switch {
+		case op.ResponseContentType != "":
+			success["content"] = map[string]any{
+				op.ResponseContentType: map[string]any{
+					"schema": map[string]any{"type": "string"},
+				},
+			}
+		case op.Response != nil:
+			success["content"] = map[string]any{
+				"application/json": map[string]any{
+					"schema": openAPISchema(reflect.TypeOf(op.Response)),
+				},
+			}
+		}
+
+		responses := map[string]any{
+			strconv.Itoa(op.ResponseStatus): success,
+		}
+		if !op.Public {
+			for _, status := range []int{
+				http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden,
+				http.StatusNotFound, http.StatusInternalServerError,
+			} {
+				responses[strconv.Itoa(status)] = map[string]any{
+					"description": http.StatusText(status),
+					"content":     errorContent,
+				}
+			}
+		}
+
+		operation := map[string]any{
+			"operationId": openAPIOperationID(op),
+			"summary":     op.Summary,
+			"tags":        []string{op.Tag},
+			"parameters":  parameters,
+			"responses":   responses,
+		}
+		if op.Request != nil {
+			operation["requestBody"] = map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{
+						"schema": openAPISchema(reflect.TypeOf(op.Request)),
+					},
+				},
+			}
+		}
+		if op.Public {
+			operation["security"] = []any{}
+		}
+
+		paths[path].(map[string]any)[strings.ToLower(op.Method)] = operation
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "PG Back Web API",
+			"version": "1",
+		},
+		"servers": []any{
+			map[string]any{"url": "/api/v1"},
+		},
+		"security": []any{
+			map[string]any{"bearerAuth": []string{}},
+		},
+		"components": map[string]any{
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{
+					"type":   "http",
+					"scheme": "bearer",
+				},
+			},
+		},
+		"paths": paths,
+	}
+}
+
+// openAPIOperationID builds a unique id from the method and path, e.g.
+// POST /backups/:backupID/run becomes post_backups_backupID_run.
+func openAPIOperationID(op openAPIOperation) string {
+	id := strings.ToLower(op.Method)
+	for _, part := range strings.Split(op.Path, "/") {
+		part = strings.TrimPrefix(part, ":")
+		part = strings.NewReplacer("-", "_", ".", "_").Replace(part)
+		if part != "" {
+			id += "_" + part
+		}
+	}
+	return id
+}
+
+// openAPIStructFields returns the fields of a struct flattening the embedded
+// ones, the same way encoding/json and the Echo binder do.
+func openAPIStructFields(t reflect.Type) []reflect.StructField {
+	fields := []reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, openAPIStructFields(field.Type)...)
+			continue
+		}
+		if field.IsExported() {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func openAPIQueryParameters(t reflect.Type) []any {
+	parameters := []any{}
+	for _, field := range openAPIStructFields(t) {
+		name := field.Tag.Get("query")
+		if name == "" {
+			continue
+		}
+		parameters = append(parameters, map[string]any{
+			"name":   name,
+			"in":     "query",
+			"schema": openAPIFieldSchema(field),
+		})
+	}
+	return parameters
+}
+
+// openAPIFieldSchema returns the schema of a struct field adding the
+// constraints of its validate tag.
+func openAPIFieldSchema(field reflect.StructField) map[string]any {
+	schema := openAPISchema(field.Type)
+
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		name, value, _ := strings.Cut(rule, "=")
+		switch name {
+		case "oneof":
+			schema["enum"] = strings.Fields(value)
+		case "min", "max":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			key := map[string]string{"min": "minimum", "max": "maximum"}[name]
+			if schema["type"] == "array" {
+				key = map[string]string{"min": "minItems", "max": "maxItems"}[name]
+			}
+			schema[key] = n
+		case "url":
+			schema["format"] = "uri"
+		}
+	}
+
+	return schema
+}
+
+func openAPISchema(t reflect.Type) map[string]any {
+	switch t {
+	case reflect.TypeOf(time.Time{}):
+		return map[string]any{"type": "string", "format": "date-time"}
+	case reflect.TypeOf(uuid.UUID{}):
+		return map[string]any{"type": "string", "format": "uuid"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := openAPISchema(t.Elem())
+		schema["nullable"] = true
+		return schema
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": openAPISchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": true}
+	case reflect.Struct:
+		properties := map[string]any{}
+		required := []string{}
+		for _, field := range openAPIStructFields(t) {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			properties[name] = openAPIFieldSchema(field)
+			for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+				if rule == "required" {
+					required = append(required, name)
+				}
+			}
+		}
+
+		schema := map[string]any{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	}
+
+	return map[string]any{}
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAPISpecCoversAllRoutes(t *testing.T) {
+	e := echo.New()
+	MountRouter(e.Group("/api"), nil, nil)
+
+	paths := openAPISpec()["paths"].(map[string]any)
+
+	registered := map[string]bool{}
+	for _, route := range e.Routes() {
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+		path, ok := strings.CutPrefix(route.Path, "/api/v1")
+		if !ok {
+			continue
+		}
+		path = openAPIPath(path)
+		method := strings.ToLower(route.Method)
+		registered[method+" "+path] = true
+
+		operations, ok := paths[path].(map[string]any)
+		if assert.True(t, ok, "path %s is missing in the OpenAPI spec", path) {
+			assert.Contains(
+				t, operations, method,
+				"operation %s %s is missing in the OpenAPI spec", route.Method, path,
+			)
+		}
+	}
+
+	for path, operations := range paths {
+		for method := range operations.(map[string]any) {
+			assert.True(
+				t, registered[method+" "+path],
+				"operation %s %s is in the OpenAPI spec but not registered",
+				method, path,
+			)
+		}
+	}
+}
+
+func TestOpenAPISpecIsValidJSON(t *testing.T) {
+	b, err := json.Marshal(openAPISpec())
+	assert.NoError(t, err)
+	assert.True(t, json.Valid(b))
+}
+
+func TestOpenAPIPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		echoPath string
+		want     string
+	}{
+		{"no params", "/backups", "/backups"},
+		{"one param", "/backups/:backupID", "/backups/{backupID}"},
+		{"nested param", "/backups/:backupID/run", "/backups/{backupID}/run"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, openAPIPath(tt.echoPath))
+		})
+	}
+}
+
+func TestOpenAPISchema(t *testing.T) {
+	schema := openAPISchema(reflect.TypeOf(createBackupRequest{}))
+	properties := schema["properties"].(map[string]any)
+
+	assert.Equal(t, "object", schema["type"])
+	assert.Contains(t, properties, "database_id", "own fields are included")
+	assert.Contains(t, properties, "cron_expression", "embedded fields are flattened")
+	assert.Contains(t, schema["required"], "database_id")
+	assert.Contains(t, schema["required"], "name")
+	assert.NotContains(t, schema["required"], "destination_id")
+
+	destinationID := properties["destination_id"].(map[string]any)
+	assert.Equal(t, "string", destinationID["type"])
+	assert.Equal(t, "uuid", destinationID["format"])
+	assert.Equal(t, true, destinationID["nullable"])
+
+	rpoHours := properties["rpo_hours"].(map[string]any)
+	assert.Equal(t, "integer", rpoHours["type"])
+	assert.Equal(t, 1, rpoHours["minimum"])
+	assert.Equal(t, 8760, rpoHours["maximum"])
+
+	method := openAPISchema(reflect.TypeOf(webhookRequest{}))["properties"].(map[string]any)["method"]
+	assert.Equal(t, []string{"GET", "POST", "PUT", "PATCH", "DELETE"}, method.(map[string]any)["enum"])
+}
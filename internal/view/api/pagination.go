@@ -0,0 +1,54 @@
+package api
+
+import (
+	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/labstack/echo/v4"
+)
+
+const defaultPaginationLimit = 20
+
+// paginatedResponse is the body of every REST API list response.
+type paginatedResponse[T any] struct {
+	Pagination paginateutil.PaginateResponse `json:"pagination"`
+	Items      []T                           `json:"items"`
+}
+
+type paginationQuery struct {
+	Page  int `query:"page" validate:"omitempty,min=1"`
+	Limit int `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// bindPagination reads the page and limit query params, applying the
+// defaults when they are not set.
+func bindPagination(c echo.Context) (paginateutil.PaginateParams, error) {
+	var queryData paginationQuery
+	if err := echo.QueryParamsBinder(c).
+		Int("page", &queryData.Page).
+		Int("limit", &queryData.Limit).
+		BindError(); err != nil {
+		return paginateutil.PaginateParams{}, err
+	}
+	if err := validate.Struct(&queryData); err != nil {
+		return paginateutil.PaginateParams{}, err
+	}
+
+	params := paginateutil.PaginateParams{
+		Page:  max(queryData.Page, 1),
+		Limit: queryData.Limit,
+	}
+	if params.Limit == 0 {
+		params.Limit = defaultPaginationLimit
+	}
+
+	return params, nil
+}
+
+// mapItems converts a slice of database rows into a slice of responses.
+func mapItems[T any, R any](items []T, fn func(T) R) []R {
+	res := make([]R, 0, len(items))
+	for _, item := range items {
+		res = append(res, fn(item))
+	}
+	return res
+}
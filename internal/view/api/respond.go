@@ -0,0 +1,34 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// errorResponse is the body of every failed REST API response.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// respondError responds with the given status and error message.
+func respondError(c echo.Context, status int, err error) error {
+	return c.JSON(status, errorResponse{Error: err.Error()})
+}
+
+// respondServiceError responds with a not found status when the error comes
+// from a missing row, otherwise it responds with the given status.
+func respondServiceError(c echo.Context, status int, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return c.JSON(http.StatusNotFound, errorResponse{Error: "resource not found"})
+	}
+	return respondError(c, status, err)
+}
+
+// messageResponse is the body of REST API responses that only carry a
+// message, like the ones of asynchronous actions.
+type messageResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/restorations"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type restorationResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	ExecutionID uuid.UUID  `json:"execution_id"`
+	DatabaseID  *uuid.UUID `json:"database_id"`
+	Status      string     `json:"status"`
+	Message     *string    `json:"message"`
+	StartedAt   time.Time  `json:"started_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	FinishedAt  *time.Time `json:"finished_at"`
+}
+
+type listRestorationsQuery struct {
+	ExecutionID uuid.UUID `query:"execution_id"`
+	DatabaseID  uuid.UUID `query:"database_id"`
+}
+
+func (h *handlers) listRestorationsHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params, err := bindPagination(c)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	var queryData listRestorationsQuery
+	if err := c.Bind(&queryData); err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	pagination, items, err := h.servs.RestorationsService.PaginateRestorations(
+		ctx, restorations.PaginateRestorationsParams{
+			Page:  params.Page,
+			Limit: params.Limit,
+			ExecutionFilter: uuid.NullUUID{
+				UUID: queryData.ExecutionID, Valid: queryData.ExecutionID != uuid.Nil,
+			},
+			DatabaseFilter: uuid.NullUUID{
+				UUID: queryData.DatabaseID, Valid: queryData.DatabaseID != uuid.Nil,
+			},
+		},
+	)
+	if err != nil {
+		return respondError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, paginatedResponse[restorationResponse]{
+		Pagination: pagination,
+		Items: mapItems(
+			items, func(item dbgen.RestorationsServicePaginateRestorationsRow) restorationResponse {
+				return restorationResponse{
+					ID:          item.ID,
+					ExecutionID: item.ExecutionID,
+					DatabaseID:  nullUUID(item.DatabaseID),
+					Status:      item.Status,
+					Message:     nullString(item.Message),
+					StartedAt:   item.StartedAt,
+					UpdatedAt:   nullTime(item.UpdatedAt),
+					FinishedAt:  nullTime(item.FinishedAt),
+				}
+			},
+		),
+	})
+}
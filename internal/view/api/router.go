@@ -2,6 +2,7 @@ package api
 
 import (
 	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/eduardolat/pgbackweb/internal/view/middleware"
 	"github.com/labstack/echo/v4"
 )
@@ -19,4 +20,68 @@ func MountRouter(
 		servs: servs,
 	}
 	v1.GET("/health", h.healthHandler)
+	v1.GET("/metrics", h.metricsHandler)
+	v1.GET("/openapi.json", h.openAPIHandler)
+
+	authed := v1.Group("", mids.RequireAPIToken)
+	read := mids.RequirePermission(users.PermissionRead)
+	readSecrets := mids.RequirePermission(users.PermissionReadSecrets)
+	run := mids.RequirePermission(users.PermissionRun)
+	manage := mids.RequirePermission(users.PermissionManage)
+	manageInstance := mids.RequirePermission(users.PermissionManageInstance)
+
+	authed.GET("/health/details", h.healthDetailsHandler, read)
+
+	databases := authed.Group("/databases")
+	databases.GET("", h.listDatabasesHandler)
+	databases.POST("", h.createDatabaseHandler, manage)
+	databases.GET("/:databaseID", h.getDatabaseHandler)
+	databases.PUT("/:databaseID", h.updateDatabaseHandler, manage)
+	databases.DELETE("/:databaseID", h.deleteDatabaseHandler, manage)
+	databases.POST("/:databaseID/test", h.testDatabaseHandler, run)
+	databases.GET("/:databaseID/health", h.getDatabaseHealthHandler)
+
+	destinations := authed.Group("/destinations")
+	destinations.GET("", h.listDestinationsHandler)
+	destinations.POST("", h.createDestinationHandler, manage)
+	destinations.GET("/:destinationID", h.getDestinationHandler)
+	destinations.PUT("/:destinationID", h.updateDestinationHandler, manage)
+	destinations.DELETE("/:destinationID", h.deleteDestinationHandler, manage)
+	destinations.POST("/:destinationID/test", h.testDestinationHandler, run)
+	destinations.GET("/:destinationID/health", h.getDestinationHealthHandler)
+
+	backups := authed.Group("/backups")
+	backups.GET("", h.listBackupsHandler)
+	backups.POST("", h.createBackupHandler, manage)
+	backups.GET("/:backupID", h.getBackupHandler)
+	backups.PUT("/:backupID", h.updateBackupHandler, manage)
+	backups.DELETE("/:backupID", h.deleteBackupHandler, manage)
+	backups.POST("/:backupID/run", h.runBackupHandler, run)
+	backups.POST("/:backupID/duplicate", h.duplicateBackupHandler, manage)
+	backups.POST("/:backupID/toggle-active", h.toggleBackupActiveHandler, manage)
+
+	executions := authed.Group("/executions")
+	executions.GET("", h.listExecutionsHandler)
+	executions.GET("/:executionID", h.getExecutionHandler)
+	executions.DELETE("/:executionID", h.deleteExecutionHandler, manage)
+	executions.GET("/:executionID/download-links", h.getExecutionDownloadLinksHandler, readSecrets)
+	executions.GET("/:executionID/download", h.downloadExecutionHandler, readSecrets)
+	executions.POST("/:executionID/restore", h.restoreExecutionHandler, run)
+
+	restorations := authed.Group("/restorations")
+	restorations.GET("", h.listRestorationsHandler)
+
+	webhooks := authed.Group("/webhooks")
+	webhooks.GET("", h.listWebhooksHandler)
+	webhooks.POST("", h.createWebhookHandler, manage)
+	webhooks.GET("/:webhookID", h.getWebhookHandler)
+	webhooks.PUT("/:webhookID", h.updateWebhookHandler, manage)
+	webhooks.DELETE("/:webhookID", h.deleteWebhookHandler, manage)
+	webhooks.POST("/:webhookID/duplicate", h.duplicateWebhookHandler, manage)
+	webhooks.POST("/:webhookID/test", h.testWebhookHandler, run)
+	webhooks.GET("/:webhookID/executions", h.listWebhookExecutionsHandler)
+
+	cfg := authed.Group("/config")
+	cfg.GET("/diff", h.diffConfigHandler, manageInstance)
+	cfg.POST("/reload", h.reloadConfigHandler, manageInstance)
 }
@@ -0,0 +1,362 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type webhookResponse struct {
+	ID               uuid.UUID   `json:"id"`
+	Name             string      `json:"name"`
+	IsActive         bool        `json:"is_active"`
+	EventType        string      `json:"event_type"`
+	TargetIds        []uuid.UUID `json:"target_ids"`
+	Channel          string      `json:"channel"`
+	Url              string      `json:"url"`
+	Method           string      `json:"method"`
+	Headers          *string     `json:"headers"`
+	Body             *string     `json:"body"`
+	TimeoutSeconds   int32       `json:"timeout_seconds"`
+	TLSSkipVerify    bool        `json:"tls_skip_verify"`
+	CACertificate    *string     `json:"ca_certificate"`
+	ProxyURL         *string     `json:"proxy_url"`
+	HasSigningSecret bool        `json:"has_signing_secret"`
+	IsProvisioned    bool        `json:"is_provisioned"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        *time.Time  `json:"updated_at"`
+}
+
+// newWebhookResponse returns the webhook without its URL, headers and proxy
+// URL when the role of ctx can't read secrets, because they can hold the
+// tokens of the chat channels and the credentials of the endpoints.
+func newWebhookResponse(
+	ctx context.Context, webhook dbgen.Webhook,
+) webhookResponse {
+	response := webhookResponse{
+		ID:               webhook.ID,
+		Name:             webhook.Name,
+		IsActive:         webhook.IsActive,
+		EventType:        webhook.EventType,
+		TargetIds:        webhook.TargetIds,
+		Channel:          webhook.Channel,
+		Url:              webhook.Url,
+		Method:           webhook.Method,
+		Headers:          nullString(webhook.Headers),
+		Body:             nullString(webhook.Body),
+		TimeoutSeconds:   webhook.TimeoutSeconds,
+		TLSSkipVerify:    webhook.TlsSkipVerify,
+		CACertificate:    nullString(webhook.CaCertificate),
+		ProxyURL:         nullString(webhook.ProxyUrl),
+		HasSigningSecret: webhook.SigningSecret != nil,
+		IsProvisioned:    webhook.IsProvisioned,
+		CreatedAt:        webhook.CreatedAt,
+		UpdatedAt:        nullTime(webhook.UpdatedAt),
+	}
+
+	if !users.Can(ctx, users.PermissionReadSecrets) {
+		response.Url = ""
+		response.Headers = nil
+		response.ProxyURL = nil
+	}
+
+	return response
+}
+
+type webhookExecutionResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	WebhookID   uuid.UUID  `json:"webhook_id"`
+	DeliveryID  *uuid.UUID `json:"delivery_id"`
+	Attempt     int32      `json:"attempt"`
+	Error       *string    `json:"error"`
+	ReqMethod   *string    `json:"req_method"`
+	ReqHeaders  *string    `json:"req_headers"`
+	ReqBody     *string    `json:"req_body"`
+	ResStatus   *int16     `json:"res_status"`
+	ResHeaders  *string    `json:"res_headers"`
+	ResBody     *string    `json:"res_body"`
+	ResDuration *int32     `json:"res_duration"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type webhookRequest struct {
+	Name      string      `json:"name" validate:"required"`
+	EventType string      `json:"event_type" validate:"required"`
+	TargetIds []uuid.UUID `json:"target_ids" validate:"required,gt=0"`
+	IsActive  bool        `json:"is_active"`
+	// Channel defaults to webhook, the chat channels ignore method and body.
+	Channel string `json:"channel" validate:"omitempty,oneof=webhook slack discord teams telegram email"`
+	Url     string `json:"url" validate:"required"`
+	// Method defaults to POST.
+	Method  string `json:"method" validate:"omitempty,oneof=GET POST PUT PATCH DELETE"`
+	Headers string `json:"headers" validate:"omitempty,json"`
+	Body    string `json:"body" validate:"omitempty"`
+	// TimeoutSeconds defaults to 30, the CA certificate is PEM encoded and
+	// the proxy URL is an http, https or socks5 URL.
+	TimeoutSeconds int32  `json:"timeout_seconds" validate:"omitempty,min=1,max=300"`
+	TLSSkipVerify  bool   `json:"tls_skip_verify"`
+	CACertificate  string `json:"ca_certificate"`
+	ProxyURL       string `json:"proxy_url"`
+	// SigningSecret is write only, on update nil keeps the current secret and
+	// an empty string removes it.
+	SigningSecret *string `json:"signing_secret"`
+}
+
+// bindWebhookRequest binds and validates the webhook sent in the request
+// body, including its event type.
+func bindWebhookRequest(c echo.Context) (webhookRequest, error) {
+	var reqData webhookRequest
+	if err := c.Bind(&reqData); err != nil {
+		return reqData, err
+	}
+	if err := validate.Struct(&reqData); err != nil {
+		return reqData, err
+	}
+	if _, ok := webhooks.FullEventTypes[reqData.EventType]; !ok {
+		return reqData, fmt.Errorf("invalid event type %s", reqData.EventType)
+	}
+	if reqData.Channel == "" {
+		reqData.Channel = webhooks.ChannelWebhook.Value.Key
+	}
+	if reqData.Method == "" {
+		reqData.Method = http.MethodPost
+	}
+	if reqData.TimeoutSeconds == 0 {
+		reqData.TimeoutSeconds = webhooks.DefaultTimeoutSeconds
+	}
+	return reqData, nil
+}
+
+func (h *handlers) listWebhooksHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	params, err := bindPagination(c)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	pagination, items, err := h.servs.WebhooksService.PaginateWebhooks(
+		ctx, webhooks.PaginateWebhooksParams{
+			Page:  params.Page,
+			Limit: params.Limit,
+		},
+	)
+	if err != nil {
+		return respondError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, paginatedResponse[webhookResponse]{
+		Pagination: pagination,
+		Items: mapItems(items, func(item dbgen.Webhook) webhookResponse {
+			return newWebhookResponse(ctx, item)
+		}),
+	})
+}
+
+func (h *handlers) getWebhookHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	webhookID, err := uuid.Parse(c.Param("webhookID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	webhook, err := h.servs.WebhooksService.GetWebhook(ctx, webhookID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, newWebhookResponse(ctx, webhook))
+}
+
+func (h *handlers) createWebhookHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	reqData, err := bindWebhookRequest(c)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	webhook, err := h.servs.WebhooksService.CreateWebhook(
+		ctx, dbgen.WebhooksServiceCreateWebhookParams{
+			Name:           reqData.Name,
+			EventType:      reqData.EventType,
+			TargetIds:      reqData.TargetIds,
+			IsActive:       reqData.IsActive,
+			Channel:        reqData.Channel,
+			Url:            reqData.Url,
+			Method:         reqData.Method,
+			Headers:        sql.NullString{String: reqData.Headers, Valid: true},
+			Body:           sql.NullString{String: reqData.Body, Valid: true},
+			TimeoutSeconds: reqData.TimeoutSeconds,
+			TlsSkipVerify:  reqData.TLSSkipVerify,
+			CaCertificate:  sql.NullString{String: reqData.CACertificate, Valid: true},
+			ProxyUrl:       sql.NullString{String: reqData.ProxyURL, Valid: true},
+			SigningSecret:  toNullString(reqData.SigningSecret),
+		},
+	)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	return c.JSON(http.StatusCreated, newWebhookResponse(ctx, webhook))
+}
+
+func (h *handlers) updateWebhookHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	webhookID, err := uuid.Parse(c.Param("webhookID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	reqData, err := bindWebhookRequest(c)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	webhook, err := h.servs.WebhooksService.UpdateWebhook(
+		ctx, dbgen.WebhooksServiceUpdateWebhookParams{
+			WebhookID: webhookID,
+			Name:      sql.NullString{String: reqData.Name, Valid: true},
+			EventType: sql.NullString{String: reqData.EventType, Valid: true},
+			TargetIds: reqData.TargetIds,
+			IsActive:  sql.NullBool{Bool: reqData.IsActive, Valid: true},
+			Channel:   sql.NullString{String: reqData.Channel, Valid: true},
+			Url:       sql.NullString{String: reqData.Url, Valid: true},
+			Method:    sql.NullString{String: reqData.Method, Valid: true},
+			Headers:   sql.NullString{String: reqData.Headers, Valid: true},
+			Body:      sql.NullString{String: reqData.Body, Valid: true},
+			TimeoutSeconds: sql.NullInt32{
+				Int32: reqData.TimeoutSeconds, Valid: true,
+			},
+			TlsSkipVerify: sql.NullBool{Bool: reqData.TLSSkipVerify, Valid: true},
+			CaCertificate: sql.NullString{String: reqData.CACertificate, Valid: true},
+			ProxyUrl:      sql.NullString{String: reqData.ProxyURL, Valid: true},
+			SigningSecret: toNullString(reqData.SigningSecret),
+		},
+	)
+	if err != nil {
+		return respondServiceError(c, http.StatusBadRequest, err)
+	}
+
+	return c.JSON(http.StatusOK, newWebhookResponse(ctx, webhook))
+}
+
+func (h *handlers) deleteWebhookHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	webhookID, err := uuid.Parse(c.Param("webhookID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	err = h.servs.WebhooksService.DeleteWebhook(ctx, webhookID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *handlers) duplicateWebhookHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	webhookID, err := uuid.Parse(c.Param("webhookID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	webhook, err := h.servs.WebhooksService.DuplicateWebhook(ctx, webhookID)
+	if err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusCreated, newWebhookResponse(ctx, webhook))
+}
+
+func (h *handlers) testWebhookHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	webhookID, err := uuid.Parse(c.Param("webhookID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	if _, err := h.servs.WebhooksService.GetWebhook(ctx, webhookID); err != nil {
+		return respondServiceError(c, http.StatusInternalServerError, err)
+	}
+
+	err = h.servs.WebhooksService.SendTestMessage(ctx, webhookID)
+	if err != nil {
+		return respondError(c, http.StatusBadGateway, err)
+	}
+
+	return c.JSON(http.StatusOK, messageResponse{
+		Message: "test message sent",
+	})
+}
+
+func (h *handlers) listWebhookExecutionsHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	webhookID, err := uuid.Parse(c.Param("webhookID"))
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	params, err := bindPagination(c)
+	if err != nil {
+		return respondError(c, http.StatusBadRequest, err)
+	}
+
+	pagination, items, err := h.servs.WebhooksService.PaginateWebhookExecutions(
+		ctx, webhooks.PaginateWebhookExecutionsParams{
+			WebhookID: webhookID,
+			Page:      params.Page,
+			Limit:     params.Limit,
+		},
+	)
+	if err != nil {
+		return respondError(c, http.StatusInternalServerError, err)
+	}
+
+	canReadSecrets := users.Can(ctx, users.PermissionReadSecrets)
+	return c.JSON(http.StatusOK, paginatedResponse[webhookExecutionResponse]{
+		Pagination: pagination,
+		Items: mapItems(
+			items, func(item dbgen.WebhookExecution) webhookExecutionResponse {
+				// The headers of the request are the ones of the webhook.
+				reqHeaders := nullString(item.ReqHeaders)
+				if !canReadSecrets {
+					reqHeaders = nil
+				}
+
+				return webhookExecutionResponse{
+					ID:          item.ID,
+					WebhookID:   item.WebhookID,
+					DeliveryID:  nullUUID(item.DeliveryID),
+					Attempt:     item.Attempt,
+					Error:       nullString(item.Error),
+					ReqMethod:   nullString(item.ReqMethod),
+					ReqHeaders:  reqHeaders,
+					ReqBody:     nullString(item.ReqBody),
+					ResStatus:   nullInt16(item.ResStatus),
+					ResHeaders:  nullString(item.ResHeaders),
+					ResBody:     nullString(item.ResBody),
+					ResDuration: nullInt32(item.ResDuration),
+					CreatedAt:   item.CreatedAt,
+				}
+			},
+		),
+	})
+}
@@ -5,7 +5,9 @@ import (
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
 	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
 	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	htmx "github.com/nodxdev/nodxgo-htmx"
 )
@@ -16,6 +18,10 @@ func (m *Middleware) InjectReqctx(next echo.HandlerFunc) echo.HandlerFunc {
 			IsHTMXBoosted: htmx.ServerGetIsBoosted(c.Request().Header),
 		}
 
+		c.SetRequest(c.Request().WithContext(audit.WithActor(
+			c.Request().Context(), auditActor(c, nil),
+		)))
+
 		found, user, err := m.servs.AuthService.GetUserFromSessionCookie(c)
 		if err != nil {
 			logger.Error("failed to get user from session cookie", logger.KV{
@@ -30,15 +36,52 @@ func (m *Middleware) InjectReqctx(next echo.HandlerFunc) echo.HandlerFunc {
 			reqCtx.IsAuthed = true
 			reqCtx.SessionID = user.SessionID
 			reqCtx.User = dbgen.User{
-				ID:        user.ID,
-				Name:      user.Name,
-				Email:     user.Email,
-				CreatedAt: user.CreatedAt,
-				UpdatedAt: user.UpdatedAt,
+				ID:            user.ID,
+				Name:          user.Name,
+				Email:         user.Email,
+				Role:          user.Role,
+				TotpEnabledAt: user.TotpEnabledAt,
+				CreatedAt:     user.CreatedAt,
+				UpdatedAt:     user.UpdatedAt,
+			}
+			reqCtx.TwoFactorSetupRequired = user.RequireTwoFactor &&
+				!user.TotpEnabledAt.Valid
+
+			ctx := c.Request().Context()
+			access, err := m.servs.WorkspacesService.ResolveAccess(
+				ctx, reqCtx.User, m.servs.WorkspacesService.GetWorkspaceFromCookie(c),
+			)
+			if err != nil {
+				logger.Error("failed to resolve the workspace access", logger.KV{
+					"ip":    c.RealIP(),
+					"ua":    c.Request().UserAgent(),
+					"error": err,
+				})
+				return c.String(http.StatusInternalServerError, "Internal server error")
 			}
+
+			reqCtx.WorkspaceID = access.WorkspaceID
+			reqCtx.WorkspaceRole = access.Role
+			reqCtx.Workspaces = access.Workspaces
+			ctx = audit.WithActor(ctx, auditActor(c, &reqCtx.User))
+			c.SetRequest(c.Request().WithContext(access.Context(ctx, reqCtx.User)))
 		}
 
 		reqctx.SetCtx(c, reqCtx)
 		return next(c)
 	}
 }
+
+// auditActor returns the actor recorded in the audit log for the request,
+// user is nil before the user is known.
+func auditActor(c echo.Context, user *dbgen.User) audit.Actor {
+	actor := audit.Actor{
+		IP:        c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
+	}
+	if user != nil {
+		actor.UserID = uuid.NullUUID{UUID: user.ID, Valid: true}
+		actor.UserEmail = user.Email
+	}
+	return actor
+}
@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/service/auth"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const workspaceHeader = "X-Workspace-ID"
+
+// RequireAPIToken authenticates the request using the API token sent in the
+// "Authorization: Bearer <token>" header and injects the user into the
+// request context.
+//
+// Tokens with the read scope are only allowed to perform GET requests, the
+// role of the user still applies to tokens with the write scope.
+//
+// The workspace is selected with the "X-Workspace-ID" header, without it
+// admins work on all the workspaces and the other users on their first one.
+func (m *Middleware) RequireAPIToken(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		authHeader := c.Request().Header.Get("Authorization")
+		token, ok := strings.CutPrefix(authHeader, "Bearer ")
+		if !ok || token == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]string{
+				"error": "missing API token",
+			})
+		}
+
+		found, user, err := m.servs.AuthService.GetUserByAPIToken(ctx, token)
+		if err != nil {
+			logger.Error("failed to get user from API token", logger.KV{
+				"ip":    c.RealIP(),
+				"ua":    c.Request().UserAgent(),
+				"error": err,
+			})
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "internal server error",
+			})
+		}
+		if !found {
+			return c.JSON(http.StatusUnauthorized, map[string]string{
+				"error": "invalid or expired API token",
+			})
+		}
+
+		// Tokens can't be used to skip the two-factor authentication setup.
+		if user.RequireTwoFactor && !user.TotpEnabledAt.Valid {
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "two-factor authentication must be set up in the dashboard before using the API",
+			})
+		}
+
+		method := c.Request().Method
+		isReadOnly := method == http.MethodGet || method == http.MethodHead
+		if !isReadOnly && user.ApiTokenScope != auth.APITokenScopeWrite {
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "the API token does not have the write scope",
+			})
+		}
+
+		reqUser := dbgen.User{
+			ID:        user.ID,
+			Name:      user.Name,
+			Email:     user.Email,
+			Role:      user.Role,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+		}
+
+		var requestedWorkspace uuid.NullUUID
+		if header := c.Request().Header.Get(workspaceHeader); header != "" {
+			workspaceID, err := uuid.Parse(header)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{
+					"error": "invalid " + workspaceHeader + " header",
+				})
+			}
+			requestedWorkspace = uuid.NullUUID{UUID: workspaceID, Valid: true}
+		}
+
+		access, err := m.servs.WorkspacesService.ResolveAccess(
+			ctx, reqUser, requestedWorkspace,
+		)
+		if err != nil {
+			logger.Error("failed to resolve the workspace access", logger.KV{
+				"ip":    c.RealIP(),
+				"ua":    c.Request().UserAgent(),
+				"error": err,
+			})
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "internal server error",
+			})
+		}
+		if requestedWorkspace.Valid && access.WorkspaceID != requestedWorkspace {
+			return c.JSON(http.StatusForbidden, map[string]string{
+				"error": "you are not a member of the requested workspace",
+			})
+		}
+
+		reqctx.SetCtx(c, reqctx.Ctx{
+			IsAuthed:      true,
+			APITokenID:    user.ApiTokenID,
+			APITokenScope: user.ApiTokenScope,
+			User:          reqUser,
+			WorkspaceID:   access.WorkspaceID,
+			WorkspaceRole: access.Role,
+			Workspaces:    access.Workspaces,
+		})
+		ctx = audit.WithActor(ctx, auditActor(c, &reqUser))
+		c.SetRequest(c.Request().WithContext(access.Context(ctx, reqUser)))
+		return next(c)
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/eduardolat/pgbackweb/internal/logger"
 	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
@@ -16,6 +17,11 @@ func (m *Middleware) RequireAuth(next echo.HandlerFunc) echo.HandlerFunc {
 		reqCtx := reqctx.GetCtx(c)
 
 		if reqCtx.IsAuthed {
+			if reqCtx.TwoFactorSetupRequired && !allowedWithoutTwoFactor(c) {
+				redirectPath := pathutil.BuildPath("/dashboard/profile")
+				htmx.ServerSetRedirect(c.Response().Header(), redirectPath)
+				return c.Redirect(http.StatusFound, redirectPath)
+			}
 			return next(c)
 		}
 
@@ -40,3 +46,23 @@ func (m *Middleware) RequireAuth(next echo.HandlerFunc) echo.HandlerFunc {
 		return c.Redirect(http.StatusFound, redirectPath)
 	}
 }
+
+// allowedWithoutTwoFactor reports whether the request can be done by users
+// that have to set up two-factor authentication before using the dashboard,
+// which are the profile page, the routes that set it up and the logout.
+func allowedWithoutTwoFactor(c echo.Context) bool {
+	route := c.Request().Method + " " + c.Request().URL.Path
+	for _, allowed := range []string{
+		"GET /dashboard/profile",
+		"POST /dashboard/profile/two-factor/setup",
+		"POST /dashboard/profile/two-factor/enable",
+		"POST /auth/logout",
+		"POST /auth/logout-all",
+	} {
+		method, path, _ := strings.Cut(allowed, " ")
+		if route == method+" "+pathutil.BuildPath(path) {
+			return true
+		}
+	}
+	return false
+}
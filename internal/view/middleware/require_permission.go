@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+// RequirePermission rejects the request when the roles of the authenticated
+// user don't have the given permission. It must run after InjectReqctx or
+// RequireAPIToken.
+func (m *Middleware) RequirePermission(
+	permission users.Permission,
+) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			reqCtx := reqctx.GetCtx(c)
+			ctx := c.Request().Context()
+			if reqCtx.IsAuthed && users.Can(ctx, permission) {
+				return next(c)
+			}
+
+			msg := users.ErrForbidden.Error()
+			if reqCtx.APITokenID != uuid.Nil {
+				return c.JSON(http.StatusForbidden, map[string]string{"error": msg})
+			}
+			if htmx.ServerGetIsHtmxRequest(c.Request().Header) {
+				return respondhtmx.ToastError(c, msg)
+			}
+			return c.String(http.StatusForbidden, msg)
+		}
+	}
+}
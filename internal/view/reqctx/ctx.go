@@ -2,6 +2,7 @@ package reqctx
 
 import (
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
@@ -15,7 +16,19 @@ type Ctx struct {
 	IsHTMXBoosted bool
 	IsAuthed      bool
 	SessionID     uuid.UUID
+	APITokenID    uuid.UUID
+	APITokenScope string
 	User          dbgen.User
+
+	// WorkspaceID is the workspace the request works on, null when an admin
+	// works on all the workspaces.
+	WorkspaceID   uuid.NullUUID
+	WorkspaceRole string
+	Workspaces    []workspaces.UserWorkspace
+
+	// TwoFactorSetupRequired is true when two-factor authentication is
+	// required for everyone and the user hasn't set it up yet.
+	TwoFactorSetupRequired bool
 }
 
 // SetCtx inserts values into the Echo request context.
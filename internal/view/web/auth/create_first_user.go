@@ -5,6 +5,7 @@ import (
 
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
 	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
 	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
 	"github.com/eduardolat/pgbackweb/internal/validate"
@@ -20,6 +21,10 @@ import (
 func (h *handlers) createFirstUserPageHandler(c echo.Context) error {
 	ctx := c.Request().Context()
 
+	if !h.servs.AuthService.PasswordLoginEnabled() {
+		return c.Redirect(http.StatusFound, pathutil.BuildPath("/auth/login"))
+	}
+
 	usersQty, err := h.servs.UsersService.GetUsersQty(ctx)
 	if err != nil {
 		logger.Error("failed to get users qty", logger.KV{
@@ -116,6 +121,10 @@ func createFirstUserPage() nodx.Node {
 func (h *handlers) createFirstUserHandler(c echo.Context) error {
 	ctx := c.Request().Context()
 
+	if !h.servs.AuthService.PasswordLoginEnabled() {
+		return respondhtmx.ToastError(c, "The password login is disabled")
+	}
+
 	var formData struct {
 		Name                 string `form:"name" validate:"required"`
 		Email                string `form:"email" validate:"required,email"`
@@ -129,10 +138,19 @@ func (h *handlers) createFirstUserHandler(c echo.Context) error {
 		return respondhtmx.ToastError(c, err.Error())
 	}
 
-	_, err := h.servs.UsersService.CreateUser(ctx, dbgen.UsersServiceCreateUserParams{
+	usersQty, err := h.servs.UsersService.GetUsersQty(ctx)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if usersQty > 0 {
+		return respondhtmx.ToastError(c, "The first user has already been created")
+	}
+
+	_, err = h.servs.UsersService.CreateUser(ctx, dbgen.UsersServiceCreateUserParams{
 		Name:     formData.Name,
 		Email:    formData.Email,
 		Password: formData.Password,
+		Role:     users.RoleAdmin,
 	})
 	if err != nil {
 		return respondhtmx.ToastError(c, err.Error())
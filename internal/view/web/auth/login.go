@@ -28,18 +28,53 @@ func (h *handlers) loginPageHandler(c echo.Context) error {
 		})
 		return c.String(http.StatusInternalServerError, "Internal server error")
 	}
-	if usersQty == 0 {
+	// Without password login the first user is created by the OpenID Connect
+	// login, so there is no need to create it here.
+	if usersQty == 0 && h.servs.AuthService.PasswordLoginEnabled() {
 		return c.Redirect(http.StatusFound, pathutil.BuildPath("/auth/create-first-user"))
 	}
 
-	return echoutil.RenderNodx(c, http.StatusOK, loginPage())
+	return echoutil.RenderNodx(c, http.StatusOK, loginPage(loginPageParams{
+		PasswordLogin:    h.servs.AuthService.PasswordLoginEnabled(),
+		OIDCLogin:        h.servs.AuthService.OIDCEnabled(),
+		OIDCProviderName: h.servs.AuthService.OIDCProviderName(),
+	}))
 }
 
-func loginPage() nodx.Node {
+type loginPageParams struct {
+	PasswordLogin    bool
+	OIDCLogin        bool
+	OIDCProviderName string
+	Error            string
+}
+
+func loginPage(params loginPageParams) nodx.Node {
 	content := []nodx.Node{
 		component.H1Text("Login"),
 
-		nodx.FormEl(
+		nodx.If(params.Error != "", nodx.Div(
+			nodx.Role("alert"),
+			nodx.Class("alert alert-error mt-4"),
+			lucide.CircleX(),
+			component.SpanText(params.Error),
+		)),
+
+		nodx.If(params.OIDCLogin, nodx.Div(
+			nodx.Class("mt-4"),
+			nodx.A(
+				nodx.Class("btn btn-primary w-full"),
+				nodx.Href(pathutil.BuildPath("/auth/oidc/login")),
+				lucide.KeyRound(),
+				component.SpanText("Login with "+params.OIDCProviderName),
+			),
+		)),
+
+		nodx.If(params.OIDCLogin && params.PasswordLogin, nodx.Div(
+			nodx.Class("divider"),
+			component.SpanText("or"),
+		)),
+
+		nodx.If(params.PasswordLogin, nodx.FormEl(
 			htmx.HxPost(pathutil.BuildPath("/auth/login")),
 			htmx.HxDisabledELT("find button"),
 			nodx.Class("mt-4 space-y-2"),
@@ -75,7 +110,7 @@ func loginPage() nodx.Node {
 					lucide.LogIn(),
 				),
 			),
-		),
+		)),
 	}
 
 	return layout.Auth(layout.AuthParams{
@@ -98,7 +133,7 @@ func (h *handlers) loginHandler(c echo.Context) error {
 		return respondhtmx.ToastError(c, err.Error())
 	}
 
-	session, err := h.servs.AuthService.Login(
+	result, err := h.servs.AuthService.Login(
 		ctx, formData.Email, formData.Password, c.RealIP(), c.Request().UserAgent(),
 	)
 	if err != nil {
@@ -111,6 +146,11 @@ func (h *handlers) loginHandler(c echo.Context) error {
 		return respondhtmx.ToastError(c, "Login failed")
 	}
 
-	h.servs.AuthService.SetSessionCookie(c, session.DecryptedToken)
+	if result.ChallengeToken != "" {
+		h.servs.AuthService.SetLoginChallengeCookie(c, result.ChallengeToken)
+		return respondhtmx.Redirect(c, pathutil.BuildPath("/auth/two-factor"))
+	}
+
+	h.servs.AuthService.SetSessionCookie(c, result.Session.DecryptedToken)
 	return respondhtmx.Redirect(c, pathutil.BuildPath("/dashboard"))
 }
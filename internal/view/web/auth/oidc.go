@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/labstack/echo/v4"
+)
+
+// oidcRedirectURL returns the configured callback URL or builds it from the
+// request when it's not configured.
+func (h *handlers) oidcRedirectURL(c echo.Context) string {
+	if redirectURL := h.servs.AuthService.OIDCRedirectURL(); redirectURL != "" {
+		return redirectURL
+	}
+	return c.Scheme() + "://" + c.Request().Host + pathutil.BuildPath("/auth/oidc/callback")
+}
+
+func (h *handlers) oidcLoginHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if !h.servs.AuthService.OIDCEnabled() {
+		return c.Redirect(http.StatusFound, pathutil.BuildPath("/auth/login"))
+	}
+
+	authURL, state, err := h.servs.AuthService.StartOIDCLogin(
+		ctx, h.oidcRedirectURL(c),
+	)
+	if err != nil {
+		logger.Error("failed to start the oidc login", logger.KV{
+			"ip":    c.RealIP(),
+			"ua":    c.Request().UserAgent(),
+			"error": err,
+		})
+		return h.oidcLoginFailed(c)
+	}
+
+	if err := h.servs.AuthService.SetOIDCStateCookie(c, state); err != nil {
+		return c.String(http.StatusInternalServerError, "Internal server error")
+	}
+
+	return c.Redirect(http.StatusFound, authURL)
+}
+
+func (h *handlers) oidcCallbackHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if !h.servs.AuthService.OIDCEnabled() {
+		return c.Redirect(http.StatusFound, pathutil.BuildPath("/auth/login"))
+	}
+
+	state, err := h.servs.AuthService.PopOIDCStateCookie(c)
+	if err != nil {
+		logger.Error("oidc login failed", logger.KV{
+			"ip":  c.RealIP(),
+			"ua":  c.Request().UserAgent(),
+			"err": err,
+		})
+		return h.oidcLoginFailed(c)
+	}
+
+	if providerErr := c.QueryParam("error"); providerErr != "" {
+		logger.Error("oidc login failed", logger.KV{
+			"ip":          c.RealIP(),
+			"ua":          c.Request().UserAgent(),
+			"err":         providerErr,
+			"description": c.QueryParam("error_description"),
+		})
+		return h.oidcLoginFailed(c)
+	}
+
+	if c.QueryParam("state") != state.State {
+		logger.Error("oidc login failed", logger.KV{
+			"ip":  c.RealIP(),
+			"ua":  c.Request().UserAgent(),
+			"err": "state mismatch",
+		})
+		return h.oidcLoginFailed(c)
+	}
+
+	result, err := h.servs.AuthService.OIDCLogin(
+		ctx, c.QueryParam("code"), h.oidcRedirectURL(c), state,
+		c.RealIP(), c.Request().UserAgent(),
+	)
+	if err != nil {
+		logger.Error("oidc login failed", logger.KV{
+			"ip":  c.RealIP(),
+			"ua":  c.Request().UserAgent(),
+			"err": err,
+		})
+		return h.oidcLoginFailed(c)
+	}
+
+	if result.ChallengeToken != "" {
+		h.servs.AuthService.SetLoginChallengeCookie(c, result.ChallengeToken)
+		return c.Redirect(http.StatusFound, pathutil.BuildPath("/auth/two-factor"))
+	}
+
+	h.servs.AuthService.SetSessionCookie(c, result.Session.DecryptedToken)
+	return c.Redirect(http.StatusFound, pathutil.BuildPath("/dashboard"))
+}
+
+func (h *handlers) oidcLoginFailed(c echo.Context) error {
+	return echoutil.RenderNodx(c, http.StatusUnauthorized, loginPage(loginPageParams{
+		PasswordLogin:    h.servs.AuthService.PasswordLoginEnabled(),
+		OIDCLogin:        h.servs.AuthService.OIDCEnabled(),
+		OIDCProviderName: h.servs.AuthService.OIDCProviderName(),
+		Error:            "Login with " + h.servs.AuthService.OIDCProviderName() + " failed",
+	}))
+}
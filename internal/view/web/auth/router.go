@@ -29,6 +29,18 @@ func MountRouter(
 		Period: 10 * time.Second,
 	}))
 
+	requireNoAuth.GET("/two-factor", h.twoFactorPageHandler)
+	requireNoAuth.POST("/two-factor", h.twoFactorHandler, mids.RateLimit(middleware.RateLimitConfig{
+		Limit:  5,
+		Period: 10 * time.Second,
+	}))
+
+	requireNoAuth.GET("/oidc/login", h.oidcLoginHandler)
+	requireNoAuth.GET("/oidc/callback", h.oidcCallbackHandler, mids.RateLimit(middleware.RateLimitConfig{
+		Limit:  5,
+		Period: 10 * time.Second,
+	}))
+
 	requireAuth.POST("/logout", h.logoutHandler)
 	requireAuth.POST("/logout-all", h.logoutAllSessionsHandler)
 }
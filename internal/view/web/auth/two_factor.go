@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/auth"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/layout"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) twoFactorPageHandler(c echo.Context) error {
+	if h.servs.AuthService.GetLoginChallengeCookie(c) == "" {
+		return c.Redirect(http.StatusFound, pathutil.BuildPath("/auth/login"))
+	}
+
+	return echoutil.RenderNodx(c, http.StatusOK, twoFactorPage())
+}
+
+func twoFactorPage() nodx.Node {
+	content := []nodx.Node{
+		component.H1Text("Two-factor authentication"),
+		component.PText(
+			"Enter the code of your authenticator app, or one of your recovery codes.",
+		),
+
+		nodx.FormEl(
+			htmx.HxPost(pathutil.BuildPath("/auth/two-factor")),
+			htmx.HxDisabledELT("find button"),
+			nodx.Class("mt-4 space-y-2"),
+
+			component.InputControl(component.InputControlParams{
+				Name:         "code",
+				Label:        "Code",
+				Placeholder:  "123456",
+				Required:     true,
+				Type:         component.InputTypeText,
+				AutoComplete: "one-time-code",
+				Children: []nodx.Node{
+					nodx.Autofocus(""),
+					nodx.Maxlength("20"),
+				},
+			}),
+
+			nodx.Div(
+				nodx.Class("pt-2 flex justify-between items-center space-x-2"),
+				nodx.A(
+					nodx.Class("link"),
+					nodx.Href(pathutil.BuildPath("/auth/login")),
+					component.SpanText("Back to login"),
+				),
+				nodx.Div(
+					nodx.Class("flex items-center space-x-2"),
+					component.HxLoadingMd(),
+					nodx.Button(
+						nodx.Class("btn btn-primary"),
+						nodx.Type("submit"),
+						component.SpanText("Verify"),
+						lucide.ShieldCheck(),
+					),
+				),
+			),
+		),
+	}
+
+	return layout.Auth(layout.AuthParams{
+		Title: "Two-factor authentication",
+		Body:  content,
+	})
+}
+
+func (h *handlers) twoFactorHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var formData struct {
+		Code string `form:"code" validate:"required,max=20"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	challengeToken := h.servs.AuthService.GetLoginChallengeCookie(c)
+	if challengeToken == "" {
+		return respondhtmx.AlertWithRedirect(
+			c, auth.ErrLoginChallengeExpired.Error(), pathutil.BuildPath("/auth/login"),
+		)
+	}
+
+	session, err := h.servs.AuthService.VerifyTwoFactorLogin(
+		ctx, challengeToken, formData.Code, c.RealIP(), c.Request().UserAgent(),
+	)
+	if err != nil {
+		logger.Error("two-factor login failed", logger.KV{
+			"ip":  c.RealIP(),
+			"ua":  c.Request().UserAgent(),
+			"err": err,
+		})
+		if errors.Is(err, auth.ErrLoginChallengeExpired) {
+			h.servs.AuthService.ClearLoginChallengeCookie(c)
+			return respondhtmx.AlertWithRedirect(
+				c, err.Error(), pathutil.BuildPath("/auth/login"),
+			)
+		}
+		return respondhtmx.ToastError(c, "Invalid code")
+	}
+
+	h.servs.AuthService.ClearLoginChallengeCookie(c)
+	h.servs.AuthService.SetSessionCookie(c, session.DecryptedToken)
+	return respondhtmx.Redirect(c, pathutil.BuildPath("/dashboard"))
+}
@@ -35,6 +35,7 @@ var (
 	InputTypeNumber   = inputType{"number"}
 	InputTypeTel      = inputType{"tel"}
 	InputTypeUrl      = inputType{"url"}
+	InputTypeDate     = inputType{"date"}
 
 	bgBase100 = bgBase{"bg-base-100"}
 	bgBase200 = bgBase{"bg-base-200"}
@@ -0,0 +1,68 @@
+package component
+
+import (
+	"fmt"
+
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// HealthCheckControls renders the inputs of the health check settings of a
+// database or destination with the given values.
+func HealthCheckControls(
+	intervalMinutes, failureThreshold, successThreshold int32,
+) nodx.Node {
+	return nodx.Div(
+		nodx.Class("pt-4"),
+		nodx.Div(
+			nodx.Class("flex justify-start items-center space-x-1"),
+			H2Text("Health checks"),
+			HelpButtonModal(HelpButtonModalParams{
+				ModalTitle: "Health checks",
+				Children: []nodx.Node{
+					PText(`
+						The connection is tested automatically at the check interval. The
+						health only changes after the given number of checks in a row
+						fail or succeed, so a single network blip doesn't flip it and
+						doesn't run the healthy and unhealthy webhooks.
+					`),
+				},
+			}),
+		),
+		nodx.Div(
+			nodx.Class("mt-2 grid grid-cols-3 gap-2"),
+			InputControl(InputControlParams{
+				Name:     "health_check_interval_minutes",
+				Label:    "Check interval (minutes)",
+				Required: true,
+				Type:     InputTypeNumber,
+				Children: []nodx.Node{
+					nodx.Min("1"),
+					nodx.Max("1440"),
+					nodx.Value(fmt.Sprintf("%d", intervalMinutes)),
+				},
+			}),
+			InputControl(InputControlParams{
+				Name:     "health_failure_threshold",
+				Label:    "Failures to be unhealthy",
+				Required: true,
+				Type:     InputTypeNumber,
+				Children: []nodx.Node{
+					nodx.Min("1"),
+					nodx.Max("100"),
+					nodx.Value(fmt.Sprintf("%d", failureThreshold)),
+				},
+			}),
+			InputControl(InputControlParams{
+				Name:     "health_success_threshold",
+				Label:    "Successes to be healthy",
+				Required: true,
+				Type:     InputTypeNumber,
+				Children: []nodx.Node{
+					nodx.Min("1"),
+					nodx.Max("100"),
+					nodx.Value(fmt.Sprintf("%d", successThreshold)),
+				},
+			}),
+		),
+	)
+}
@@ -0,0 +1,162 @@
+package component
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/health"
+	"github.com/eduardolat/pgbackweb/internal/util/timeutil"
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// HealthReport renders the uptime, latency and flapping of a database or
+// destination with its latest health checks.
+func HealthReport(report health.Report) nodx.Node {
+	return nodx.Div(
+		nodx.Class("space-y-4"),
+		nodx.Div(
+			nodx.Class("grid grid-cols-1 lg:grid-cols-3 gap-4"),
+			nodx.Map(report.Periods, healthPeriodCard),
+		),
+		healthLatencyChart(report.Checks),
+		healthChecksTable(report.Checks),
+	)
+}
+
+func healthPeriodCard(period health.Period) nodx.Node {
+	uptime := "No checks"
+	if period.Checks > 0 {
+		uptime = fmt.Sprintf("%.2f%%", period.Uptime)
+	}
+
+	row := func(name string, value nodx.Node) nodx.Node {
+		return nodx.Tr(nodx.Th(SpanText(name)), nodx.Td(value))
+	}
+
+	return CardBoxSimple(
+		H3Text(period.Name),
+		nodx.P(
+			nodx.Class("text-3xl font-bold mt-2"),
+			nodx.TitleAttr("Percentage of the checks that succeeded"),
+			nodx.Text(uptime),
+		),
+		nodx.Table(
+			nodx.Class("table table-sm mt-2"),
+			row("Checks", SpanText(fmt.Sprintf("%d", period.Checks))),
+			row("Avg latency", SpanText(period.AvgLatency.String())),
+			row("Max latency", SpanText(period.MaxLatency.String())),
+			row("Health changes", nodx.Div(
+				nodx.Class("flex items-center space-x-2"),
+				SpanText(fmt.Sprintf("%d", period.Changes)),
+				nodx.If(
+					period.Flapping(),
+					nodx.SpanEl(
+						nodx.Class("badge badge-warning"),
+						nodx.TitleAttr("The health changed too often in the period"),
+						nodx.Text("Flapping"),
+					),
+				),
+			)),
+		),
+	)
+}
+
+// healthLatencyChart renders a bar for the latency of every check, from the
+// oldest to the newest, in red for the failed ones.
+func healthLatencyChart(checks []dbgen.HealthCheck) nodx.Node {
+	if len(checks) == 0 {
+		return nodx.Group()
+	}
+
+	maxLatency := int32(1)
+	for _, check := range checks {
+		maxLatency = max(maxLatency, check.LatencyMs)
+	}
+
+	oldestFirst := slices.Clone(checks)
+	slices.Reverse(oldestFirst)
+
+	return CardBoxSimple(
+		H3Text("Latency of the latest checks"),
+		nodx.Div(
+			nodx.Class("mt-4 flex items-end h-32 gap-px"),
+			nodx.Map(oldestFirst, func(check dbgen.HealthCheck) nodx.Node {
+				height := max(check.LatencyMs*100/maxLatency, 2)
+				title := fmt.Sprintf(
+					"%s - %s",
+					check.CreatedAt.Local().Format(timeutil.LayoutYYYYMMDDHHMMSSPretty),
+					healthCheckLatency(check),
+				)
+				if check.Error.Valid && check.Error.String != "" {
+					title += " - " + check.Error.String
+				}
+
+				return nodx.Div(
+					nodx.ClassMap{
+						"flex-1 rounded-t-sm": true,
+						"bg-success":          check.Ok,
+						"bg-error":            !check.Ok,
+					},
+					nodx.StyleAttr(fmt.Sprintf("height: %d%%;", height)),
+					nodx.TitleAttr(title),
+				)
+			}),
+		),
+	)
+}
+
+func healthChecksTable(checks []dbgen.HealthCheck) nodx.Node {
+	if len(checks) == 0 {
+		return CardBoxSimple(PText("There are no health checks yet."))
+	}
+
+	return CardBoxSimple(
+		H3Text("Latest checks"),
+		nodx.Div(
+			nodx.Class("overflow-x-auto mt-2"),
+			nodx.Table(
+				nodx.Class("table table-sm"),
+				nodx.Thead(nodx.Tr(
+					nodx.Th(SpanText("Tested at")),
+					nodx.Th(SpanText("Result")),
+					nodx.Th(SpanText("Latency")),
+					nodx.Th(SpanText("Error")),
+				)),
+				nodx.Tbody(nodx.Map(checks, func(check dbgen.HealthCheck) nodx.Node {
+					result := "success"
+					if !check.Ok {
+						result = "failed"
+					}
+
+					return nodx.Tr(
+						nodx.Td(SpanText(
+							check.CreatedAt.Local().Format(timeutil.LayoutYYYYMMDDHHMMSSPretty),
+						)),
+						nodx.Td(
+							nodx.Class("space-x-1"),
+							StatusBadge(result),
+							nodx.If(
+								check.Changed,
+								nodx.SpanEl(
+									nodx.Class("badge badge-outline"),
+									nodx.Text("Health changed"),
+								),
+							),
+						),
+						nodx.Td(SpanText(healthCheckLatency(check))),
+						nodx.Td(
+							nodx.Class("break-all"),
+							SpanText(check.Error.String),
+						),
+					)
+				})),
+			),
+		),
+	)
+}
+
+func healthCheckLatency(check dbgen.HealthCheck) string {
+	return (time.Duration(check.LatencyMs) * time.Millisecond).String()
+}
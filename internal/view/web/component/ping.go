@@ -99,7 +99,7 @@ func HealthStatusPing(
 						nodx.If(
 							testError.Valid && testError.String != "",
 							nodx.Tr(
-								nodx.Th(SpanText("Error")),
+								nodx.Th(SpanText("Last error")),
 								nodx.Td(
 									nodx.Class("break-all"),
 									SpanText(testError.String),
@@ -119,9 +119,11 @@ func HealthStatusPing(
 							nodx.Td(
 								nodx.Colspan("2"),
 								PText(`
-									The health check runs automatically every 10 minutes, when
-									PG Back Web starts, and when you click the "Test connection"
-									button.
+									The health check runs automatically at the check interval
+									set for it, and when you click the "Test connection"
+									button. The status only changes after the number of failed
+									or successful checks in a row set for it, the error is the
+									one of the last check.
 								`),
 							),
 						),
@@ -0,0 +1,18 @@
+package component
+
+import (
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+// ProvisionedBadge marks the entities managed by the configuration file,
+// they are read-only in the UI.
+func ProvisionedBadge(isProvisioned bool) nodx.Node {
+	return nodx.If(
+		isProvisioned,
+		nodx.SpanEl(
+			nodx.Class("badge badge-neutral"),
+			nodx.TitleAttr("Managed by the configuration file, edit the file to change it"),
+			nodx.Text("config"),
+		),
+	)
+}
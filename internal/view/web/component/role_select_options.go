@@ -0,0 +1,18 @@
+package component
+
+import (
+	"strings"
+
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+func RoleSelectOptions(selectedRole string) nodx.Node {
+	return nodx.Map(users.Roles, func(role string) nodx.Node {
+		return nodx.Option(
+			nodx.Value(role),
+			nodx.Text(strings.ToUpper(role[:1])+role[1:]),
+			nodx.If(role == selectedRole, nodx.Selected("")),
+		)
+	})
+}
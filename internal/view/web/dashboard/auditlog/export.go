@@ -0,0 +1,55 @@
+package auditlog
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/labstack/echo/v4"
+)
+
+type exportQueryData struct {
+	filterQueryData
+	Format string `query:"format" validate:"required,oneof=csv json"`
+}
+
+// exportHandler is opened as a regular link, not with htmx, so the browser
+// downloads the export as a file.
+func (h *handlers) exportHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var queryData exportQueryData
+	if err := c.Bind(&queryData); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	if err := validate.Struct(&queryData); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	// The export is written to a buffer first so a failure can still be
+	// returned as an error instead of a truncated file.
+	var buf bytes.Buffer
+	err := h.servs.AuditService.ExportAuditLog(
+		ctx, &buf, queryData.Format, queryData.filter(),
+	)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	contentType := "text/csv"
+	if queryData.Format == audit.ExportFormatJSON {
+		contentType = echo.MIMEApplicationJSON
+	}
+
+	fileName := fmt.Sprintf(
+		"pgbackweb-audit-log-%s.%s",
+		time.Now().Format("20060102-150405"), queryData.Format,
+	)
+	c.Response().Header().Set(
+		echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName),
+	)
+	return c.Blob(http.StatusOK, contentType, buf.Bytes())
+}
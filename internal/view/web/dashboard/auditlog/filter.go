@@ -0,0 +1,61 @@
+package auditlog
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+)
+
+const dateLayout = "2006-01-02"
+
+type filterQueryData struct {
+	Search     string `query:"search"`
+	EntityType string `query:"entity_type"`
+	Action     string `query:"action"`
+	From       string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To         string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// filter returns the filter of the audit service, the dates are days in the
+// local time zone and both are included.
+func (q filterQueryData) filter() audit.Filter {
+	filter := audit.Filter{
+		Search:     q.Search,
+		EntityType: q.EntityType,
+		Action:     q.Action,
+	}
+	if from, err := time.ParseInLocation(dateLayout, q.From, time.Local); err == nil {
+		filter.From = from
+	}
+	if to, err := time.ParseInLocation(dateLayout, q.To, time.Local); err == nil {
+		filter.To = to.AddDate(0, 0, 1)
+	}
+	return filter
+}
+
+// url returns the path with the filter as query params, extra params are
+// added as they are.
+func (q filterQueryData) url(path string, extra url.Values) string {
+	values := url.Values{}
+	for key, value := range map[string]string{
+		"search":      q.Search,
+		"entity_type": q.EntityType,
+		"action":      q.Action,
+		"from":        q.From,
+		"to":          q.To,
+	} {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	for key, value := range extra {
+		values[key] = value
+	}
+
+	if len(values) == 0 {
+		return pathutil.BuildPath(path)
+	}
+	return pathutil.BuildPath(path + "?" + values.Encode())
+}
@@ -0,0 +1,160 @@
+package auditlog
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/layout"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) indexPageHandler(c echo.Context) error {
+	reqCtx := reqctx.GetCtx(c)
+
+	var queryData filterQueryData
+	if err := c.Bind(&queryData); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	if err := validate.Struct(&queryData); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	return echoutil.RenderNodx(c, http.StatusOK, indexPage(reqCtx, queryData))
+}
+
+func indexPage(reqCtx reqctx.Ctx, queryData filterQueryData) nodx.Node {
+	content := []nodx.Node{
+		component.H1Text("Audit log"),
+		component.PText(
+			"Every change and login, the secrets are never recorded.",
+		),
+
+		component.CardBox(component.CardBoxParams{
+			Class:    "mt-4",
+			Children: []nodx.Node{filterForm(queryData)},
+		}),
+
+		component.CardBox(component.CardBoxParams{
+			Class: "mt-4",
+			Children: []nodx.Node{
+				nodx.Div(
+					nodx.Class("overflow-x-auto"),
+					nodx.Table(
+						nodx.Class("table"),
+						nodx.Thead(
+							nodx.Tr(
+								nodx.Th(component.SpanText("Date")),
+								nodx.Th(component.SpanText("User")),
+								nodx.Th(component.SpanText("Action")),
+								nodx.Th(component.SpanText("Entity")),
+								nodx.Th(component.SpanText("Changes")),
+							),
+						),
+						nodx.Tbody(
+							component.SkeletonTr(8),
+							htmx.HxGet(queryData.url(
+								"/dashboard/audit-log/list", url.Values{"page": {"1"}},
+							)),
+							htmx.HxTrigger("load"),
+						),
+					),
+				),
+			},
+		}),
+	}
+
+	return layout.Dashboard(reqCtx, layout.DashboardParams{
+		Title: "Audit log",
+		Body:  content,
+	})
+}
+
+func filterForm(queryData filterQueryData) nodx.Node {
+	selectOptions := func(values []string, selected, allLabel string) []nodx.Node {
+		options := []nodx.Node{nodx.Option(nodx.Value(""), nodx.Text(allLabel))}
+		for _, value := range values {
+			options = append(options, nodx.Option(
+				nodx.Value(value),
+				nodx.Text(value),
+				nodx.If(value == selected, nodx.Selected("")),
+			))
+		}
+		return options
+	}
+
+	exportButton := func(format, label string) nodx.Node {
+		return nodx.A(
+			nodx.Class("btn btn-outline"),
+			nodx.Href(queryData.url(
+				"/dashboard/audit-log/export", url.Values{"format": {format}},
+			)),
+			component.SpanText(label),
+			lucide.Download(),
+		)
+	}
+
+	return nodx.FormEl(
+		nodx.Method("get"),
+		nodx.Action(pathutil.BuildPath("/dashboard/audit-log")),
+
+		nodx.Div(
+			nodx.Class("grid grid-cols-1 md:grid-cols-5 gap-2"),
+			component.InputControl(component.InputControlParams{
+				Name:        "search",
+				Label:       "Search",
+				Placeholder: "Email, name, ID or IP",
+				Children:    []nodx.Node{nodx.Value(queryData.Search)},
+			}),
+			component.SelectControl(component.SelectControlParams{
+				Name:  "entity_type",
+				Label: "Entity",
+				Children: selectOptions(
+					audit.EntityTypes, queryData.EntityType, "All entities",
+				),
+			}),
+			component.SelectControl(component.SelectControlParams{
+				Name:     "action",
+				Label:    "Action",
+				Children: selectOptions(audit.Actions, queryData.Action, "All actions"),
+			}),
+			component.InputControl(component.InputControlParams{
+				Name:     "from",
+				Label:    "From",
+				Type:     component.InputTypeDate,
+				Children: []nodx.Node{nodx.Value(queryData.From)},
+			}),
+			component.InputControl(component.InputControlParams{
+				Name:     "to",
+				Label:    "To",
+				Type:     component.InputTypeDate,
+				Children: []nodx.Node{nodx.Value(queryData.To)},
+			}),
+		),
+
+		nodx.Div(
+			nodx.Class("flex flex-wrap justify-end items-center gap-2 pt-4"),
+			exportButton(audit.ExportFormatCSV, "Export CSV"),
+			exportButton(audit.ExportFormatJSON, "Export JSON"),
+			nodx.A(
+				nodx.Class("btn btn-ghost"),
+				nodx.Href(pathutil.BuildPath("/dashboard/audit-log")),
+				component.SpanText("Clear"),
+			),
+			nodx.Button(
+				nodx.Class("btn btn-primary"),
+				nodx.Type("submit"),
+				component.SpanText("Filter"),
+				lucide.Search(),
+			),
+		),
+	)
+}
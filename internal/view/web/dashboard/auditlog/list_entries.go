@@ -0,0 +1,186 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/audit"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
+	"github.com/eduardolat/pgbackweb/internal/util/timeutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+type listQueryData struct {
+	filterQueryData
+	Page int `query:"page" validate:"required,min=1"`
+}
+
+func (h *handlers) listEntriesHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var queryData listQueryData
+	if err := c.Bind(&queryData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&queryData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	pagination, entries, err := h.servs.AuditService.PaginateAuditLog(
+		ctx, audit.PaginateAuditLogParams{
+			Filter: queryData.filter(),
+			Page:   queryData.Page,
+			Limit:  30,
+		},
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return echoutil.RenderNodx(
+		c, http.StatusOK, listEntries(queryData, pagination, entries),
+	)
+}
+
+func listEntries(
+	queryData listQueryData,
+	pagination paginateutil.PaginateResponse,
+	entries []dbgen.AuditLog,
+) nodx.Node {
+	if len(entries) < 1 {
+		return component.EmptyResultsTr(component.EmptyResultsParams{
+			Title:    "No audit log entries found",
+			Subtitle: "Try changing the filters",
+		})
+	}
+
+	trs := []nodx.Node{}
+	for _, entry := range entries {
+		trs = append(trs, nodx.Tr(
+			nodx.Td(
+				nodx.Class("text-nowrap"),
+				component.SpanText(
+					entry.CreatedAt.Local().Format(timeutil.LayoutYYYYMMDDHHMMSSPretty),
+				),
+			),
+			nodx.Td(entryActor(entry)),
+			nodx.Td(nodx.SpanEl(
+				nodx.Class("badge badge-neutral text-nowrap"),
+				nodx.Text(entry.Action),
+			)),
+			nodx.Td(entryEntity(entry)),
+			nodx.Td(entryChanges(entry.Diff)),
+		))
+	}
+
+	if pagination.HasNextPage {
+		trs = append(trs, nodx.Tr(
+			htmx.HxGet(queryData.url("/dashboard/audit-log/list", url.Values{
+				"page": {strconv.Itoa(pagination.NextPage)},
+			})),
+			htmx.HxTrigger("intersect once"),
+			htmx.HxSwap("afterend"),
+		))
+	}
+
+	return component.RenderableGroup(trs)
+}
+
+func entryActor(entry dbgen.AuditLog) nodx.Node {
+	user := "System"
+	if entry.UserEmail.Valid {
+		user = entry.UserEmail.String
+	} else if !entry.UserID.Valid && entry.Ip.Valid {
+		user = "Anonymous"
+	}
+
+	return nodx.Div(
+		nodx.Class("flex flex-col"),
+		component.SpanText(user),
+		nodx.If(
+			entry.Ip.Valid,
+			nodx.SpanEl(
+				nodx.Class("text-xs opacity-70"),
+				nodx.TitleAttr(entry.UserAgent.String),
+				nodx.Text(entry.Ip.String),
+			),
+		),
+	)
+}
+
+func entryEntity(entry dbgen.AuditLog) nodx.Node {
+	return nodx.Div(
+		nodx.Class("flex flex-col"),
+		component.SpanText(entry.EntityType),
+		nodx.If(
+			entry.EntityName.Valid,
+			nodx.SpanEl(nodx.Class("text-xs"), nodx.Text(entry.EntityName.String)),
+		),
+		nodx.If(
+			entry.EntityID.Valid,
+			nodx.SpanEl(
+				nodx.Class("text-xs opacity-70 font-mono"),
+				nodx.Text(entry.EntityID.UUID.String()),
+			),
+		),
+	)
+}
+
+func entryChanges(diff json.RawMessage) nodx.Node {
+	changes := map[string]audit.Change{}
+	if err := json.Unmarshal(diff, &changes); err != nil || len(changes) == 0 {
+		return nodx.Group()
+	}
+
+	fields := make([]string, 0, len(changes))
+	for field := range changes {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+
+	return nodx.Details(
+		nodx.SummaryEl(
+			nodx.Class("cursor-pointer text-nowrap"),
+			nodx.Textf("%d changed fields", len(fields)),
+		),
+		nodx.Ul(
+			nodx.Class("mt-2 space-y-1 text-xs"),
+			nodx.Map(fields, func(field string) nodx.Node {
+				change := changes[field]
+				return nodx.Li(
+					nodx.Class("break-all"),
+					nodx.B(nodx.Text(field)),
+					nodx.Text(": "),
+					nodx.CodeEl(nodx.Text(changeValue(change.Before))),
+					nodx.Text(" → "),
+					nodx.CodeEl(nodx.Text(changeValue(change.After))),
+				)
+			}),
+		),
+	)
+}
+
+func changeValue(value any) string {
+	if value == nil {
+		return "empty"
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
@@ -0,0 +1,30 @@
+package auditlog
+
+import (
+	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/view/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+type handlers struct {
+	servs *service.Service
+}
+
+func newHandlers(servs *service.Service) *handlers {
+	return &handlers{servs: servs}
+}
+
+func MountRouter(
+	parent *echo.Group, mids *middleware.Middleware, servs *service.Service,
+) {
+	h := newHandlers(servs)
+
+	// The audit service doesn't check the role of the user, so every route
+	// has to require the permission here.
+	parent.Use(mids.RequirePermission(users.PermissionManageInstance))
+
+	parent.GET("", h.indexPageHandler)
+	parent.GET("/list", h.listEntriesHandler)
+	parent.GET("/export", h.exportHandler)
+}
@@ -0,0 +1,285 @@
+package backupgroups
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/staticdata"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/google/uuid"
+	nodx "github.com/nodxdev/nodxgo"
+	alpine "github.com/nodxdev/nodxgo-alpine"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+// parseBackupIDs parses the ordered backup ids sent by the group form.
+func parseBackupIDs(values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid backup id %q: %w", value, err)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("the group must have at least one backup")
+	}
+	return ids, nil
+}
+
+func createAndUpdateBackupGroupForm(
+	backups []dbgen.Backup, group ...dbgen.BackupGroup,
+) nodx.Node {
+	shouldPrefill, pickedGroup := false, dbgen.BackupGroup{}
+	if len(group) > 0 {
+		shouldPrefill = true
+		pickedGroup = group[0]
+	}
+
+	pickedTimeZone := time.Now().Location().String()
+	if shouldPrefill {
+		pickedTimeZone = pickedGroup.TimeZone
+	}
+
+	type option struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	options := make([]option, 0, len(backups))
+	exists := make(map[uuid.UUID]bool, len(backups))
+	for _, backup := range backups {
+		options = append(options, option{ID: backup.ID.String(), Name: backup.Name})
+		exists[backup.ID] = true
+	}
+
+	// The backups deleted since the group was saved are left out.
+	selected := []string{}
+	for _, id := range pickedGroup.BackupIds {
+		if exists[id] {
+			selected = append(selected, id.String())
+		}
+	}
+
+	optionsJSON, _ := json.Marshal(options)
+	selectedJSON, _ := json.Marshal(selected)
+
+	return nodx.Div(
+		nodx.Class("space-y-2"),
+
+		alpine.XData(`{
+			backups: `+string(optionsJSON)+`,
+			selected: `+string(selectedJSON)+`,
+			toAdd: "",
+			backupName(id) {
+				const backup = this.backups.find((b) => b.id === id);
+				return backup ? backup.name : id;
+			},
+			add() {
+				if (this.toAdd && !this.selected.includes(this.toAdd)) {
+					this.selected.push(this.toAdd);
+				}
+				this.toAdd = "";
+			},
+			move(index, offset) {
+				const to = index + offset;
+				if (to < 0 || to >= this.selected.length) return;
+				const [id] = this.selected.splice(index, 1);
+				this.selected.splice(to, 0, id);
+			},
+			remove(index) {
+				this.selected.splice(index, 1);
+			},
+		}`),
+
+		component.InputControl(component.InputControlParams{
+			Name:        "name",
+			Label:       "Name",
+			Placeholder: "Nightly backups",
+			Required:    true,
+			Type:        component.InputTypeText,
+			Children: []nodx.Node{
+				nodx.If(shouldPrefill, nodx.Value(pickedGroup.Name)),
+			},
+		}),
+
+		component.InputControl(component.InputControlParams{
+			Name:               "cron_expression",
+			Label:              "Cron expression",
+			Placeholder:        "0 2 * * *",
+			Required:           true,
+			Type:               component.InputTypeText,
+			HelpText:           "When the group starts, e.g. every night at 2:00.",
+			Pattern:            `^\S+\s+\S+\s+\S+\s+\S+\s+\S+$`,
+			HelpButtonChildren: cronExpressionHelp(),
+			Children: []nodx.Node{
+				nodx.If(shouldPrefill, nodx.Value(pickedGroup.CronExpression)),
+			},
+		}),
+
+		component.SelectControl(component.SelectControlParams{
+			Name:        "time_zone",
+			Label:       "Time zone",
+			Required:    true,
+			Placeholder: "Select a time zone",
+			HelpText:    "The time zone in which the cron expression is evaluated.",
+			Children: []nodx.Node{
+				nodx.Map(
+					staticdata.Timezones,
+					func(tz staticdata.Timezone) nodx.Node {
+						return nodx.Option(
+							nodx.Value(tz.TzCode),
+							nodx.Text(tz.Label),
+							nodx.If(tz.TzCode == pickedTimeZone, nodx.Selected("")),
+						)
+					},
+				),
+			},
+		}),
+
+		component.SelectControl(component.SelectControlParams{
+			Name:     "is_active",
+			Label:    "Activate group",
+			Required: true,
+			Children: []nodx.Node{
+				nodx.Option(
+					nodx.Value("true"), nodx.Text("Yes"),
+					nodx.If(!shouldPrefill, nodx.Selected("")),
+					nodx.If(shouldPrefill && pickedGroup.IsActive, nodx.Selected("")),
+				),
+				nodx.Option(
+					nodx.Value("false"), nodx.Text("No"),
+					nodx.If(shouldPrefill && !pickedGroup.IsActive, nodx.Selected("")),
+				),
+			},
+		}),
+
+		// A plain select, the backups are added to the ordered list below and
+		// sent as hidden inputs.
+		nodx.Div(
+			nodx.Class("form-control w-full"),
+			nodx.Div(
+				nodx.Class("label flex justify-start items-center space-x-1"),
+				component.SpanText("Backups"),
+				lucide.Asterisk(nodx.Class("text-error")),
+				component.HelpButtonModal(component.HelpButtonModalParams{
+					ModalTitle: "Backups",
+					Children:   backupsHelp(),
+				}),
+			),
+			nodx.Div(
+				nodx.Class("flex items-center space-x-2"),
+				nodx.Select(
+					nodx.Class("select select-bordered w-full"),
+					alpine.XModel("toAdd"),
+					nodx.Option(
+						nodx.Value(""),
+						nodx.Text("Select a backup to add"),
+					),
+					nodx.Map(
+						backups,
+						func(backup dbgen.Backup) nodx.Node {
+							return nodx.Option(
+								nodx.Value(backup.ID.String()),
+								nodx.Text(backup.Name),
+								alpine.XBind("disabled", "selected.includes($el.value)"),
+							)
+						},
+					),
+				),
+				nodx.Button(
+					nodx.Type("button"),
+					nodx.Class("btn btn-neutral"),
+					alpine.XOn("click", "add()"),
+					component.SpanText("Add"),
+					lucide.Plus(),
+				),
+			),
+			nodx.LabelEl(
+				nodx.Class("label"),
+				component.SpanText("The backups run in this order, the group stops at the first one that fails."),
+			),
+		),
+
+		nodx.Ol(
+			nodx.Class("space-y-1"),
+			alpine.Template(
+				alpine.XFor("(id, index) in selected"),
+				alpine.XBind("key", "id"),
+				nodx.Li(
+					nodx.Class("flex items-center justify-between bg-base-200 rounded-btn px-3 py-1"),
+					nodx.Input(
+						nodx.Type("hidden"),
+						nodx.Name("backup_ids"),
+						alpine.XBind("value", "id"),
+					),
+					nodx.SpanEl(alpine.XText("(index + 1) + '. ' + backupName(id)")),
+					nodx.Div(
+						nodx.Class("flex items-center"),
+						nodx.Button(
+							nodx.Type("button"),
+							nodx.Class("btn btn-ghost btn-xs btn-square"),
+							nodx.TitleAttr("Move up"),
+							alpine.XOn("click", "move(index, -1)"),
+							lucide.ArrowUp(),
+						),
+						nodx.Button(
+							nodx.Type("button"),
+							nodx.Class("btn btn-ghost btn-xs btn-square"),
+							nodx.TitleAttr("Move down"),
+							alpine.XOn("click", "move(index, 1)"),
+							lucide.ArrowDown(),
+						),
+						nodx.Button(
+							nodx.Type("button"),
+							nodx.Class("btn btn-ghost btn-xs btn-square"),
+							nodx.TitleAttr("Remove"),
+							alpine.XOn("click", "remove(index)"),
+							lucide.X(),
+						),
+					),
+				),
+			),
+		),
+	)
+}
+
+func cronExpressionHelp() []nodx.Node {
+	return []nodx.Node{
+		component.PText(`
+			A cron expression has five fields representing the minute, hour, day
+			of the month, month, and day of the week. For example 0 2 * * * starts
+			the group every day at 2:00.
+		`),
+
+		nodx.Div(
+			nodx.Class("mt-4 flex justify-end items-center space-x-1"),
+			nodx.A(
+				nodx.Href("https://crontab.guru/examples.html"),
+				nodx.Target("_blank"),
+				nodx.Class("btn btn-ghost"),
+				component.SpanText("Examples & common expressions"),
+				lucide.ExternalLink(),
+			),
+		),
+	}
+}
+
+func backupsHelp() []nodx.Node {
+	return []nodx.Node{
+		component.PText(`
+			The backups of the group run one after the other, each one starts
+			when the previous one finishes successfully. If a backup fails, the
+			rest of the group is skipped until the next run. Inactive backups are
+			skipped.
+		`),
+		component.PText(`
+			While the group is active, its backups don't run on their own cron
+			expression. The backups that run after a backup of the group still
+			run when that backup succeeds.
+		`),
+	}
+}
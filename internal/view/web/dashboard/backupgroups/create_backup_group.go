@@ -0,0 +1,117 @@
+package backupgroups
+
+import (
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+type createBackupGroupDTO struct {
+	Name           string   `form:"name" validate:"required"`
+	CronExpression string   `form:"cron_expression" validate:"required"`
+	TimeZone       string   `form:"time_zone" validate:"required"`
+	IsActive       string   `form:"is_active" validate:"required,oneof=true false"`
+	BackupIDs      []string `form:"backup_ids"`
+}
+
+func (h *handlers) createBackupGroupHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var formData createBackupGroupDTO
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	backupIDs, err := parseBackupIDs(formData.BackupIDs)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	_, err = h.servs.BackupsService.CreateBackupGroup(
+		ctx, dbgen.BackupsServiceCreateBackupGroupParams{
+			Name:           formData.Name,
+			IsActive:       formData.IsActive == "true",
+			CronExpression: formData.CronExpression,
+			TimeZone:       formData.TimeZone,
+			BackupIds:      backupIDs,
+		},
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.Redirect(c, pathutil.BuildPath("/dashboard/backup-groups"))
+}
+
+func (h *handlers) createBackupGroupFormHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	backups, err := h.servs.BackupsService.GetAllBackups(ctx)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return echoutil.RenderNodx(c, http.StatusOK, createBackupGroupForm(backups))
+}
+
+func createBackupGroupForm(backups []dbgen.Backup) nodx.Node {
+	return nodx.FormEl(
+		htmx.HxPost(pathutil.BuildPath("/dashboard/backup-groups/create")),
+		htmx.HxDisabledELT("find button[type='submit']"),
+		nodx.Class("space-y-2"),
+
+		createAndUpdateBackupGroupForm(backups),
+
+		nodx.Div(
+			nodx.Class("flex justify-end items-center space-x-2 pt-2"),
+			component.HxLoadingMd(),
+			nodx.Button(
+				nodx.Class("btn btn-primary"),
+				nodx.Type("submit"),
+				component.SpanText("Save"),
+				lucide.Save(),
+			),
+		),
+	)
+}
+
+func createBackupGroupButton() nodx.Node {
+	mo := component.Modal(component.ModalParams{
+		Size:  component.SizeMd,
+		Title: "Create backup group",
+		Content: []nodx.Node{
+			nodx.Div(
+				htmx.HxGet(pathutil.BuildPath("/dashboard/backup-groups/create")),
+				htmx.HxSwap("outerHTML"),
+				htmx.HxTrigger("intersect once"),
+				nodx.Class("p-10 flex justify-center"),
+				component.HxLoadingMd(),
+			),
+		},
+	})
+
+	button := nodx.Button(
+		mo.OpenerAttr,
+		nodx.Class("btn btn-primary"),
+		component.SpanText("Create backup group"),
+		lucide.Plus(),
+	)
+
+	return nodx.Div(
+		nodx.Class("inline-block"),
+		mo.HTML,
+		button,
+	)
+}
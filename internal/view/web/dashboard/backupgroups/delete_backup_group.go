@@ -0,0 +1,38 @@
+package backupgroups
+
+import (
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) deleteBackupGroupHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	groupID, err := uuid.Parse(c.Param("groupID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	if err = h.servs.BackupsService.DeleteBackupGroup(ctx, groupID); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.Refresh(c)
+}
+
+func deleteBackupGroupButton(groupID uuid.UUID) nodx.Node {
+	return component.OptionsDropdownButton(
+		htmx.HxDelete(pathutil.BuildPath(fmt.Sprintf("/dashboard/backup-groups/%s", groupID))),
+		htmx.HxConfirm("Are you sure you want to delete this backup group? Its backups will go back to their own schedule."),
+		lucide.Trash(),
+		component.SpanText("Delete group"),
+	)
+}
@@ -0,0 +1,134 @@
+package backupgroups
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+type editBackupGroupDTO struct {
+	Name           string   `form:"name" validate:"required"`
+	CronExpression string   `form:"cron_expression" validate:"required"`
+	TimeZone       string   `form:"time_zone" validate:"required"`
+	IsActive       string   `form:"is_active" validate:"required,oneof=true false"`
+	BackupIDs      []string `form:"backup_ids"`
+}
+
+func (h *handlers) editBackupGroupHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	groupID, err := uuid.Parse(c.Param("groupID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	var formData editBackupGroupDTO
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	backupIDs, err := parseBackupIDs(formData.BackupIDs)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	_, err = h.servs.BackupsService.UpdateBackupGroup(
+		ctx, dbgen.BackupsServiceUpdateBackupGroupParams{
+			GroupID:        groupID,
+			Name:           sql.NullString{String: formData.Name, Valid: true},
+			IsActive:       sql.NullBool{Bool: formData.IsActive == "true", Valid: true},
+			CronExpression: sql.NullString{String: formData.CronExpression, Valid: true},
+			TimeZone:       sql.NullString{String: formData.TimeZone, Valid: true},
+			BackupIds:      backupIDs,
+		},
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.AlertWithRefresh(c, "Backup group updated")
+}
+
+func (h *handlers) editBackupGroupFormHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	groupID, err := uuid.Parse(c.Param("groupID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	group, err := h.servs.BackupsService.GetBackupGroup(ctx, groupID)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	backups, err := h.servs.BackupsService.GetAllBackups(ctx)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return echoutil.RenderNodx(
+		c, http.StatusOK, editBackupGroupForm(group, backups),
+	)
+}
+
+func editBackupGroupForm(
+	group dbgen.BackupGroup, backups []dbgen.Backup,
+) nodx.Node {
+	return nodx.FormEl(
+		htmx.HxPost(pathutil.BuildPath(fmt.Sprintf("/dashboard/backup-groups/%s/edit", group.ID))),
+		htmx.HxDisabledELT("find button[type='submit']"),
+		nodx.Class("space-y-2"),
+
+		createAndUpdateBackupGroupForm(backups, group),
+
+		nodx.Div(
+			nodx.Class("flex justify-end items-center space-x-2 pt-2"),
+			component.HxLoadingMd(),
+			nodx.Button(
+				nodx.Class("btn btn-primary"),
+				nodx.Type("submit"),
+				component.SpanText("Save"),
+				lucide.Save(),
+			),
+		),
+	)
+}
+
+func editBackupGroupButton(groupID uuid.UUID) nodx.Node {
+	mo := component.Modal(component.ModalParams{
+		Size:  component.SizeMd,
+		Title: "Edit backup group",
+		Content: []nodx.Node{
+			nodx.Div(
+				htmx.HxGet(pathutil.BuildPath(fmt.Sprintf("/dashboard/backup-groups/%s/edit", groupID))),
+				htmx.HxSwap("outerHTML"),
+				htmx.HxTrigger("intersect once"),
+				nodx.Class("p-10 flex justify-center"),
+				component.HxLoadingMd(),
+			),
+		},
+	})
+
+	return nodx.Div(
+		mo.HTML,
+		component.OptionsDropdownButton(
+			mo.OpenerAttr,
+			lucide.Pencil(),
+			component.SpanText("Edit group"),
+		),
+	)
+}
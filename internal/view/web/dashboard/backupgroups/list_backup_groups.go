@@ -0,0 +1,106 @@
+package backupgroups
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/backups"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/util/strutil"
+	"github.com/eduardolat/pgbackweb/internal/util/timeutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func (h *handlers) listBackupGroupsHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var formData struct {
+		Page int `query:"page" validate:"required,min=1"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	pagination, groups, err := h.servs.BackupsService.PaginateBackupGroups(
+		ctx, backups.PaginateBackupGroupsParams{
+			Page:  formData.Page,
+			Limit: 20,
+		},
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return echoutil.RenderNodx(
+		c, http.StatusOK, listBackupGroups(pagination, groups),
+	)
+}
+
+func listBackupGroups(
+	pagination paginateutil.PaginateResponse,
+	groups []dbgen.BackupsServicePaginateBackupGroupsRow,
+) nodx.Node {
+	if len(groups) < 1 {
+		return component.EmptyResultsTr(component.EmptyResultsParams{
+			Title:    "No backup groups found",
+			Subtitle: "Create a group to run several backups in order",
+		})
+	}
+
+	trs := []nodx.Node{}
+	for _, group := range groups {
+		trs = append(trs, nodx.Tr(
+			nodx.Td(component.OptionsDropdown(
+				runBackupGroupButton(group.ID),
+				nodx.If(!group.IsProvisioned, editBackupGroupButton(group.ID)),
+				nodx.If(!group.IsProvisioned, deleteBackupGroupButton(group.ID)),
+			)),
+			nodx.Td(
+				nodx.Div(
+					nodx.Class("flex items-center space-x-2"),
+					component.IsActivePing(group.IsActive),
+					component.SpanText(group.Name),
+					component.ProvisionedBadge(group.IsProvisioned),
+				),
+			),
+			nodx.Td(
+				nodx.Class("font-mono"),
+				nodx.Div(
+					nodx.Class("flex flex-col items-start text-xs"),
+					component.SpanText(group.CronExpression),
+					component.SpanText(group.TimeZone),
+				),
+			),
+			nodx.Td(component.SpanText(strings.Join(group.BackupNames, " → "))),
+			nodx.Td(component.SpanText(
+				group.CreatedAt.Local().Format(timeutil.LayoutYYYYMMDDHHMMSSPretty),
+			)),
+		))
+	}
+
+	if pagination.HasNextPage {
+		trs = append(trs, nodx.Tr(
+			htmx.HxGet(func() string {
+				url := pathutil.BuildPath("/dashboard/backup-groups/list")
+				url = strutil.AddQueryParamToUrl(url, "page", fmt.Sprintf("%d", pagination.NextPage))
+				return url
+			}()),
+			htmx.HxTrigger("intersect once"),
+			htmx.HxSwap("afterend"),
+		))
+	}
+
+	return component.RenderableGroup(trs)
+}
@@ -0,0 +1,34 @@
+package backupgroups
+
+import (
+	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/view/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+type handlers struct {
+	servs *service.Service
+}
+
+func newHandlers(servs *service.Service) *handlers {
+	return &handlers{servs: servs}
+}
+
+func MountRouter(
+	parent *echo.Group, mids *middleware.Middleware, servs *service.Service,
+) {
+	h := newHandlers(servs)
+
+	run := mids.RequirePermission(users.PermissionRun)
+	manage := mids.RequirePermission(users.PermissionManage)
+
+	parent.GET("", h.indexPageHandler)
+	parent.GET("/list", h.listBackupGroupsHandler)
+	parent.GET("/create", h.createBackupGroupFormHandler, manage)
+	parent.POST("/create", h.createBackupGroupHandler, manage)
+	parent.GET("/:groupID/edit", h.editBackupGroupFormHandler, manage)
+	parent.POST("/:groupID/edit", h.editBackupGroupHandler, manage)
+	parent.POST("/:groupID/run", h.runBackupGroupHandler, run)
+	parent.DELETE("/:groupID", h.deleteBackupGroupHandler, manage)
+}
@@ -0,0 +1,42 @@
+package backupgroups
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) runBackupGroupHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	groupID, err := uuid.Parse(c.Param("groupID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	// WithoutCancel keeps the role of the user in the context
+	go func() {
+		_ = h.servs.BackupsService.RunBackupGroup(
+			context.WithoutCancel(ctx), groupID,
+		)
+	}()
+
+	return respondhtmx.ToastSuccess(c, "Backup group started, check the backup executions for more details")
+}
+
+func runBackupGroupButton(groupID uuid.UUID) nodx.Node {
+	return component.OptionsDropdownButton(
+		htmx.HxPost(pathutil.BuildPath(fmt.Sprintf("/dashboard/backup-groups/%s/run", groupID))),
+		htmx.HxDisabledELT("this"),
+		lucide.Zap(),
+		component.SpanText("Run group now"),
+	)
+}
@@ -5,7 +5,9 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
 	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/google/uuid"
 	nodx "github.com/nodxdev/nodxgo"
 	lucide "github.com/nodxdev/nodxgo-lucide"
 )
@@ -36,6 +38,68 @@ func parseNullInt16(s string) sql.NullInt16 {
 	return sql.NullInt16{Int16: int16(v), Valid: true}
 }
 
+// parseNullUUID parses an optional form string into a uuid.NullUUID.
+// Empty string returns a null (invalid) result.
+func parseNullUUID(s string) uuid.NullUUID {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: id, Valid: true}
+}
+
+// runAfterSelect lets the backup run after another backup of the workspace
+// instead of on its own schedule, the backup itself is left out.
+func runAfterSelect(
+	backups []dbgen.Backup, backupID uuid.UUID, runAfterID uuid.NullUUID,
+) nodx.Node {
+	return component.SelectControl(component.SelectControlParams{
+		Name:               "run_after_backup_id",
+		Label:              "Run after",
+		Required:           false,
+		HelpText:           "Run after this backup succeeds instead of on the schedule",
+		HelpButtonChildren: runAfterHelp(),
+		Children: []nodx.Node{
+			nodx.Option(
+				nodx.Value(""),
+				nodx.Text("None, use the schedule"),
+				nodx.If(!runAfterID.Valid, nodx.Selected("")),
+			),
+			nodx.Map(
+				backups,
+				func(backup dbgen.Backup) nodx.Node {
+					if backup.ID == backupID {
+						return nil
+					}
+					return nodx.Option(
+						nodx.Value(backup.ID.String()),
+						nodx.Text(backup.Name),
+						nodx.If(
+							runAfterID.Valid && runAfterID.UUID == backup.ID,
+							nodx.Selected(""),
+						),
+					)
+				},
+			),
+		},
+	})
+}
+
+func runAfterHelp() []nodx.Node {
+	return []nodx.Node{
+		component.PText(`
+			A backup that runs after another backup is started every time the
+			other backup succeeds, and its own cron expression is not used. The
+			backups that run after the same backup run one after the other, in
+			the order they were created.
+		`),
+		component.PText(`
+			To run several backups in order on a single schedule, use a backup
+			group instead.
+		`),
+	}
+}
+
 func localBackupsHelp() []nodx.Node {
 	return []nodx.Node{
 		component.H3Text("Local backups"),
@@ -181,6 +245,58 @@ func retentionDaysHelp() []nodx.Node {
 	}
 }
 
+func rpoHoursHelp() []nodx.Node {
+	return []nodx.Node{
+		nodx.Div(
+			nodx.Class("space-y-2"),
+
+			component.PText(`
+				The RPO (recovery point objective) is the maximum number of hours this
+				backup can go without a successful execution. For example, a daily
+				backup can use 26 hours to leave some margin.
+			`),
+
+			component.PText(`
+				Every 10 minutes the latest successful execution is checked. If it is
+				older than the RPO, the backup is marked as stale and the "Backup stale"
+				webhooks are triggered. This catches problems that never produce a
+				failed execution, like a wrong cron expression, a deactivated backup or
+				the server being down.
+			`),
+
+			component.PText(`
+				Leave it empty to disable the check.
+			`),
+		),
+	}
+}
+
+func sizeDeviationThresholdHelp() []nodx.Node {
+	return []nodx.Node{
+		nodx.Div(
+			nodx.Class("space-y-2"),
+
+			component.PText(`
+				After every successful execution its file size is compared with the
+				average size of the last 10 executions of the same backup. If the
+				difference is greater than this percentage, the execution is marked as
+				suspicious and the "Execution suspicious" webhooks are triggered.
+			`),
+
+			component.PText(`
+				A dump that suddenly shrinks usually means something went wrong, like an
+				empty table, a wrong database or a permissions issue, even when pg_dump
+				reports success. At least 3 previous executions are needed before an
+				execution can be flagged.
+			`),
+
+			component.PText(`
+				If you set the threshold to 0, the check is disabled.
+			`),
+		),
+	}
+}
+
 func pgDumpOptionsHelp() []nodx.Node {
 	return []nodx.Node{
 		nodx.Div(
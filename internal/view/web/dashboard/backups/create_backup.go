@@ -40,6 +40,9 @@ func (h *handlers) createBackupHandler(c echo.Context) error {
 		OptNoComments    string    `form:"opt_no_comments" validate:"required,oneof=true false"`
 		MaxPartSizeMb    string    `form:"max_part_size_mb"`
 		CompressionLevel string    `form:"compression_level"`
+		SizeDeviation    int16     `form:"size_deviation_threshold" validate:"min=0,max=1000"`
+		RpoHours         string    `form:"rpo_hours"`
+		RunAfterBackupID string    `form:"run_after_backup_id"`
 	}
 	if err := c.Bind(&formData); err != nil {
 		return respondhtmx.ToastError(c, err.Error())
@@ -54,21 +57,24 @@ func (h *handlers) createBackupHandler(c echo.Context) error {
 			DestinationID: uuid.NullUUID{
 				Valid: formData.IsLocal == "false", UUID: formData.DestinationID,
 			},
-			IsLocal:        formData.IsLocal == "true",
-			Name:           formData.Name,
-			CronExpression: formData.CronExpression,
-			TimeZone:       formData.TimeZone,
-			IsActive:       formData.IsActive == "true",
-			DestDir:        formData.DestDir,
-			RetentionDays:  formData.RetentionDays,
-			OptDataOnly:    formData.OptDataOnly == "true",
-			OptSchemaOnly:  formData.OptSchemaOnly == "true",
-			OptClean:       formData.OptClean == "true",
-			OptIfExists:    formData.OptIfExists == "true",
-			OptCreate:      formData.OptCreate == "true",
-			OptNoComments:  formData.OptNoComments == "true",
-			MaxPartSizeMb:  parseNullInt32(formData.MaxPartSizeMb),
-			CompressionLevel: parseNullInt16(formData.CompressionLevel),
+			IsLocal:                formData.IsLocal == "true",
+			Name:                   formData.Name,
+			CronExpression:         formData.CronExpression,
+			TimeZone:               formData.TimeZone,
+			IsActive:               formData.IsActive == "true",
+			DestDir:                formData.DestDir,
+			RetentionDays:          formData.RetentionDays,
+			OptDataOnly:            formData.OptDataOnly == "true",
+			OptSchemaOnly:          formData.OptSchemaOnly == "true",
+			OptClean:               formData.OptClean == "true",
+			OptIfExists:            formData.OptIfExists == "true",
+			OptCreate:              formData.OptCreate == "true",
+			OptNoComments:          formData.OptNoComments == "true",
+			MaxPartSizeMb:          parseNullInt32(formData.MaxPartSizeMb),
+			CompressionLevel:       parseNullInt16(formData.CompressionLevel),
+			SizeDeviationThreshold: formData.SizeDeviation,
+			RpoHours:               parseNullInt16(formData.RpoHours),
+			RunAfterBackupID:       parseNullUUID(formData.RunAfterBackupID),
 		},
 	)
 	if err != nil {
@@ -91,14 +97,20 @@ func (h *handlers) createBackupFormHandler(c echo.Context) error {
 		return respondhtmx.ToastError(c, err.Error())
 	}
 
+	backups, err := h.servs.BackupsService.GetAllBackups(ctx)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
 	return echoutil.RenderNodx(
-		c, http.StatusOK, createBackupForm(databases, destinations),
+		c, http.StatusOK, createBackupForm(databases, destinations, backups),
 	)
 }
 
 func createBackupForm(
 	databases []dbgen.DatabasesServiceGetAllDatabasesRow,
 	destinations []dbgen.DestinationsServiceGetAllDestinationsRow,
+	backups []dbgen.Backup,
 ) nodx.Node {
 	yesNoOptions := func() nodx.Node {
 		return nodx.Group(
@@ -228,6 +240,22 @@ func createBackupForm(
 			},
 		}),
 
+		component.InputControl(component.InputControlParams{
+			Name:               "rpo_hours",
+			Label:              "RPO (hours)",
+			Placeholder:        "Leave empty to disable",
+			Required:           false,
+			Type:               component.InputTypeNumber,
+			HelpText:           "Maximum hours allowed without a successful execution",
+			HelpButtonChildren: rpoHoursHelp(),
+			Children: []nodx.Node{
+				nodx.Min("1"),
+				nodx.Max("8760"),
+			},
+		}),
+
+		runAfterSelect(backups, uuid.Nil, uuid.NullUUID{}),
+
 		component.SelectControl(component.SelectControlParams{
 			Name:     "is_active",
 			Label:    "Activate backup",
@@ -271,6 +299,20 @@ func createBackupForm(
 						nodx.Max("10000"),
 					},
 				}),
+				component.InputControl(component.InputControlParams{
+					Name:               "size_deviation_threshold",
+					Label:              "Size deviation threshold (%)",
+					Placeholder:        "0",
+					Required:           false,
+					Type:               component.InputTypeNumber,
+					HelpText:           "Flag executions whose size deviates more than this. 0 disables it",
+					HelpButtonChildren: sizeDeviationThresholdHelp(),
+					Children: []nodx.Node{
+						nodx.Min("0"),
+						nodx.Max("1000"),
+						nodx.Value("0"),
+					},
+				}),
 			),
 		),
 
@@ -46,6 +46,9 @@ func (h *handlers) editBackupHandler(c echo.Context) error {
 		DestinationID    uuid.UUID `form:"destination_id" validate:"omitempty,uuid"`
 		MaxPartSizeMb    string    `form:"max_part_size_mb"`
 		CompressionLevel string    `form:"compression_level"`
+		SizeDeviation    int16     `form:"size_deviation_threshold" validate:"min=0,max=1000"`
+		RpoHours         string    `form:"rpo_hours"`
+		RunAfterBackupID string    `form:"run_after_backup_id"`
 	}
 	if err := c.Bind(&formData); err != nil {
 		return respondhtmx.ToastError(c, err.Error())
@@ -75,6 +78,11 @@ func (h *handlers) editBackupHandler(c echo.Context) error {
 			},
 			MaxPartSizeMb:    parseNullInt32(formData.MaxPartSizeMb),
 			CompressionLevel: parseNullInt16(formData.CompressionLevel),
+			SizeDeviationThreshold: sql.NullInt16{
+				Int16: formData.SizeDeviation, Valid: true,
+			},
+			RpoHours:         parseNullInt16(formData.RpoHours),
+			RunAfterBackupID: parseNullUUID(formData.RunAfterBackupID),
 		},
 	)
 	if err != nil {
@@ -102,8 +110,13 @@ func (h *handlers) getEditBackupFormHandler(c echo.Context) error {
 		return respondhtmx.ToastError(c, err.Error())
 	}
 
+	backups, err := h.servs.BackupsService.GetAllBackups(ctx)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
 	return echoutil.RenderNodx(
-		c, http.StatusOK, editBackupForm(backup, destinations),
+		c, http.StatusOK, editBackupForm(backup, destinations, backups),
 	)
 }
 
@@ -137,6 +150,7 @@ func editBackupButton(backup dbgen.BackupsServicePaginateBackupsRow) nodx.Node {
 func editBackupForm(
 	backup dbgen.Backup,
 	destinations []dbgen.DestinationsServiceGetAllDestinationsRow,
+	backups []dbgen.Backup,
 ) nodx.Node {
 	yesNoOptions := func(value bool) nodx.Node {
 		return nodx.Group(
@@ -159,278 +173,312 @@ func editBackupForm(
 		nodx.Class("space-y-2 text-base"),
 
 		alpine.XData(`{
-					is_local: ` + fmt.Sprintf("%v", backup.IsLocal) + `,
+					is_local: `+fmt.Sprintf("%v", backup.IsLocal)+`,
 				}`),
 
-				component.InputControl(component.InputControlParams{
-					Name:        "name",
-					Label:       "Name",
-					Placeholder: "My backup",
-					Required:    true,
-					Type:        component.InputTypeText,
-					Children: []nodx.Node{
-						nodx.Value(backup.Name),
+		component.InputControl(component.InputControlParams{
+			Name:        "name",
+			Label:       "Name",
+			Placeholder: "My backup",
+			Required:    true,
+			Type:        component.InputTypeText,
+			Children: []nodx.Node{
+				nodx.Value(backup.Name),
+			},
+		}),
+
+		component.InputControl(component.InputControlParams{
+			Name:        "cron_expression",
+			Label:       "Cron expression",
+			Placeholder: "* * * * *",
+			Required:    true,
+			Type:        component.InputTypeText,
+			HelpText:    "The cron expression to schedule the backup",
+			Pattern:     `^\S+\s+\S+\s+\S+\s+\S+\s+\S+$`,
+			Children: []nodx.Node{
+				nodx.Value(backup.CronExpression),
+			},
+			HelpButtonChildren: cronExpressionHelp(),
+		}),
+
+		component.SelectControl(component.SelectControlParams{
+			Name:        "time_zone",
+			Label:       "Time zone",
+			Required:    true,
+			Placeholder: "Select a time zone",
+			Children: []nodx.Node{
+				nodx.Map(
+					staticdata.Timezones,
+					func(tz staticdata.Timezone) nodx.Node {
+						return nodx.Option(
+							nodx.Value(tz.TzCode),
+							nodx.Text(tz.Label),
+							nodx.If(
+								tz.TzCode == backup.TimeZone,
+								nodx.Selected(""),
+							),
+						)
 					},
-				}),
+				),
+			},
+			HelpButtonChildren: timezoneFilenamesHelp(),
+		}),
+
+		component.InputControl(component.InputControlParams{
+			Name:               "dest_dir",
+			Label:              "Destination directory",
+			Placeholder:        "/path/to/backup",
+			Required:           true,
+			Type:               component.InputTypeText,
+			HelpText:           "Relative to the base directory of the destination",
+			HelpButtonChildren: destinationDirectoryHelp(),
+			Pattern:            `^\/\S*[^\/]$`,
+			Children: []nodx.Node{
+				nodx.Value(backup.DestDir),
+			},
+		}),
+
+		component.InputControl(component.InputControlParams{
+			Name:               "retention_days",
+			Label:              "Retention days",
+			Placeholder:        "30",
+			Required:           true,
+			Type:               component.InputTypeNumber,
+			Pattern:            "[0-9]+",
+			HelpButtonChildren: retentionDaysHelp(),
+			Children: []nodx.Node{
+				nodx.Min("0"),
+				nodx.Max("36500"),
+				nodx.Value(fmt.Sprintf("%d", backup.RetentionDays)),
+			},
+		}),
+
+		component.InputControl(component.InputControlParams{
+			Name:               "rpo_hours",
+			Label:              "RPO (hours)",
+			Placeholder:        "Leave empty to disable",
+			Required:           false,
+			Type:               component.InputTypeNumber,
+			HelpText:           "Maximum hours allowed without a successful execution",
+			HelpButtonChildren: rpoHoursHelp(),
+			Children: []nodx.Node{
+				nodx.Min("1"),
+				nodx.Max("8760"),
+				nodx.If(
+					backup.RpoHours.Valid,
+					nodx.Value(fmt.Sprintf("%d", backup.RpoHours.Int16)),
+				),
+			},
+		}),
 
-				component.InputControl(component.InputControlParams{
-					Name:        "cron_expression",
-					Label:       "Cron expression",
-					Placeholder: "* * * * *",
-					Required:    true,
-					Type:        component.InputTypeText,
-					HelpText:    "The cron expression to schedule the backup",
-					Pattern:     `^\S+\s+\S+\s+\S+\s+\S+\s+\S+$`,
-					Children: []nodx.Node{
-						nodx.Value(backup.CronExpression),
-					},
-					HelpButtonChildren: cronExpressionHelp(),
-				}),
+		runAfterSelect(backups, backup.ID, backup.RunAfterBackupID),
+
+		component.SelectControl(component.SelectControlParams{
+			Name:     "is_active",
+			Label:    "Activate backup",
+			Required: true,
+			Children: []nodx.Node{
+				yesNoOptions(backup.IsActive),
+			},
+		}),
+
+		component.SelectControl(component.SelectControlParams{
+			Name:     "is_local",
+			Label:    "Local backup",
+			Required: true,
+			Children: []nodx.Node{
+				alpine.XModel("is_local"),
+				nodx.Option(
+					nodx.Value("true"),
+					nodx.Text("Yes"),
+					nodx.If(backup.IsLocal, nodx.Selected("")),
+				),
+				nodx.Option(
+					nodx.Value("false"),
+					nodx.Text("No"),
+					nodx.If(!backup.IsLocal, nodx.Selected("")),
+				),
+			},
+			HelpButtonChildren: localBackupsHelp(),
+		}),
+
+		alpine.Template(
+			alpine.XIf("is_local == 'false'"),
+			component.SelectControl(component.SelectControlParams{
+				Name:        "destination_id",
+				Label:       "Destination",
+				Required:    true,
+				Placeholder: "Select a destination",
+				Children: []nodx.Node{
+					nodx.Map(
+						destinations,
+						func(dest dbgen.DestinationsServiceGetAllDestinationsRow) nodx.Node {
+							return nodx.Option(
+								nodx.Value(dest.ID.String()),
+								nodx.Text(dest.Name),
+								nodx.If(
+									backup.DestinationID.Valid && backup.DestinationID.UUID == dest.ID,
+									nodx.Selected(""),
+								),
+							)
+						},
+					),
+				},
+			}),
+		),
 
+		nodx.Div(
+			nodx.Class("pt-4"),
+			nodx.Div(
+				nodx.Class("flex justify-start items-center space-x-1"),
+				component.H2Text("File management"),
+			),
+			nodx.Div(
+				nodx.Class("mt-2 grid grid-cols-2 gap-2"),
 				component.SelectControl(component.SelectControlParams{
-					Name:        "time_zone",
-					Label:       "Time zone",
-					Required:    true,
-					Placeholder: "Select a time zone",
+					Name:     "compression_level",
+					Label:    "Compression level",
+					Required: false,
+					HelpText: "ZIP compression level. Default is best compression",
 					Children: []nodx.Node{
-						nodx.Map(
-							staticdata.Timezones,
-							func(tz staticdata.Timezone) nodx.Node {
-								return nodx.Option(
-									nodx.Value(tz.TzCode),
-									nodx.Text(tz.Label),
-									nodx.If(
-										tz.TzCode == backup.TimeZone,
-										nodx.Selected(""),
-									),
-								)
-							},
+						nodx.Option(
+							nodx.Value(""),
+							nodx.Text("Default (best)"),
+							nodx.If(!backup.CompressionLevel.Valid, nodx.Selected("")),
+						),
+						nodx.Option(
+							nodx.Value("9"),
+							nodx.Text("Best (9)"),
+							nodx.If(backup.CompressionLevel.Valid && backup.CompressionLevel.Int16 == 9, nodx.Selected("")),
+						),
+						nodx.Option(
+							nodx.Value("6"),
+							nodx.Text("Balanced (6)"),
+							nodx.If(backup.CompressionLevel.Valid && backup.CompressionLevel.Int16 == 6, nodx.Selected("")),
+						),
+						nodx.Option(
+							nodx.Value("1"),
+							nodx.Text("Fastest (1)"),
+							nodx.If(backup.CompressionLevel.Valid && backup.CompressionLevel.Int16 == 1, nodx.Selected("")),
+						),
+						nodx.Option(
+							nodx.Value("0"),
+							nodx.Text("None (store only)"),
+							nodx.If(backup.CompressionLevel.Valid && backup.CompressionLevel.Int16 == 0, nodx.Selected("")),
 						),
 					},
-					HelpButtonChildren: timezoneFilenamesHelp(),
 				}),
-
 				component.InputControl(component.InputControlParams{
-					Name:               "dest_dir",
-					Label:              "Destination directory",
-					Placeholder:        "/path/to/backup",
-					Required:           true,
-					Type:               component.InputTypeText,
-					HelpText:           "Relative to the base directory of the destination",
-					HelpButtonChildren: destinationDirectoryHelp(),
-					Pattern:            `^\/\S*[^\/]$`,
+					Name:        "max_part_size_mb",
+					Label:       "Max part size (MB)",
+					Placeholder: "Leave empty for single file",
+					Required:    false,
+					Type:        component.InputTypeNumber,
+					HelpText:    "Split backup into parts of this size. Leave empty to keep as a single file",
 					Children: []nodx.Node{
-						nodx.Value(backup.DestDir),
+						nodx.Min("1"),
+						nodx.Max("10000"),
+						nodx.If(
+							backup.MaxPartSizeMb.Valid,
+							nodx.Value(strconv.Itoa(int(backup.MaxPartSizeMb.Int32))),
+						),
 					},
 				}),
-
 				component.InputControl(component.InputControlParams{
-					Name:               "retention_days",
-					Label:              "Retention days",
-					Placeholder:        "30",
-					Required:           true,
+					Name:               "size_deviation_threshold",
+					Label:              "Size deviation threshold (%)",
+					Placeholder:        "0",
+					Required:           false,
 					Type:               component.InputTypeNumber,
-					Pattern:            "[0-9]+",
-					HelpButtonChildren: retentionDaysHelp(),
+					HelpText:           "Flag executions whose size deviates more than this. 0 disables it",
+					HelpButtonChildren: sizeDeviationThresholdHelp(),
 					Children: []nodx.Node{
 						nodx.Min("0"),
-						nodx.Max("36500"),
-						nodx.Value(fmt.Sprintf("%d", backup.RetentionDays)),
+						nodx.Max("1000"),
+						nodx.Value(strconv.Itoa(int(backup.SizeDeviationThreshold))),
 					},
 				}),
+			),
+		),
 
+		nodx.Div(
+			nodx.Class("pt-4"),
+			nodx.Div(
+				nodx.Class("flex justify-start items-center space-x-1"),
+				component.H2Text("Options"),
+				component.HelpButtonModal(component.HelpButtonModalParams{
+					ModalTitle: "Backup options",
+					Children:   pgDumpOptionsHelp(),
+				}),
+			),
+
+			nodx.Div(
+				nodx.Class("mt-2 grid grid-cols-2 gap-2"),
 				component.SelectControl(component.SelectControlParams{
-					Name:     "is_active",
-					Label:    "Activate backup",
+					Name:     "opt_data_only",
+					Label:    "--data-only",
 					Required: true,
 					Children: []nodx.Node{
-						yesNoOptions(backup.IsActive),
+						yesNoOptions(backup.OptDataOnly),
 					},
 				}),
 
 				component.SelectControl(component.SelectControlParams{
-					Name:     "is_local",
-					Label:    "Local backup",
+					Name:     "opt_schema_only",
+					Label:    "--schema-only",
 					Required: true,
 					Children: []nodx.Node{
-						alpine.XModel("is_local"),
-						nodx.Option(
-							nodx.Value("true"),
-							nodx.Text("Yes"),
-							nodx.If(backup.IsLocal, nodx.Selected("")),
-						),
-						nodx.Option(
-							nodx.Value("false"),
-							nodx.Text("No"),
-							nodx.If(!backup.IsLocal, nodx.Selected("")),
-						),
+						yesNoOptions(backup.OptSchemaOnly),
 					},
-					HelpButtonChildren: localBackupsHelp(),
 				}),
 
-				alpine.Template(
-					alpine.XIf("is_local == 'false'"),
-					component.SelectControl(component.SelectControlParams{
-						Name:        "destination_id",
-						Label:       "Destination",
-						Required:    true,
-						Placeholder: "Select a destination",
-						Children: []nodx.Node{
-							nodx.Map(
-								destinations,
-								func(dest dbgen.DestinationsServiceGetAllDestinationsRow) nodx.Node {
-									return nodx.Option(
-										nodx.Value(dest.ID.String()),
-										nodx.Text(dest.Name),
-										nodx.If(
-											backup.DestinationID.Valid && backup.DestinationID.UUID == dest.ID,
-											nodx.Selected(""),
-										),
-									)
-								},
-							),
-						},
-					}),
-				),
+				component.SelectControl(component.SelectControlParams{
+					Name:     "opt_clean",
+					Label:    "--clean",
+					Required: true,
+					Children: []nodx.Node{
+						yesNoOptions(backup.OptClean),
+					},
+				}),
 
-				nodx.Div(
-					nodx.Class("pt-4"),
-					nodx.Div(
-						nodx.Class("flex justify-start items-center space-x-1"),
-						component.H2Text("File management"),
-					),
-					nodx.Div(
-						nodx.Class("mt-2 grid grid-cols-2 gap-2"),
-						component.SelectControl(component.SelectControlParams{
-							Name:     "compression_level",
-							Label:    "Compression level",
-							Required: false,
-							HelpText: "ZIP compression level. Default is best compression",
-							Children: []nodx.Node{
-								nodx.Option(
-									nodx.Value(""),
-									nodx.Text("Default (best)"),
-									nodx.If(!backup.CompressionLevel.Valid, nodx.Selected("")),
-								),
-								nodx.Option(
-									nodx.Value("9"),
-									nodx.Text("Best (9)"),
-									nodx.If(backup.CompressionLevel.Valid && backup.CompressionLevel.Int16 == 9, nodx.Selected("")),
-								),
-								nodx.Option(
-									nodx.Value("6"),
-									nodx.Text("Balanced (6)"),
-									nodx.If(backup.CompressionLevel.Valid && backup.CompressionLevel.Int16 == 6, nodx.Selected("")),
-								),
-								nodx.Option(
-									nodx.Value("1"),
-									nodx.Text("Fastest (1)"),
-									nodx.If(backup.CompressionLevel.Valid && backup.CompressionLevel.Int16 == 1, nodx.Selected("")),
-								),
-								nodx.Option(
-									nodx.Value("0"),
-									nodx.Text("None (store only)"),
-									nodx.If(backup.CompressionLevel.Valid && backup.CompressionLevel.Int16 == 0, nodx.Selected("")),
-								),
-							},
-						}),
-						component.InputControl(component.InputControlParams{
-							Name:        "max_part_size_mb",
-							Label:       "Max part size (MB)",
-							Placeholder: "Leave empty for single file",
-							Required:    false,
-							Type:        component.InputTypeNumber,
-							HelpText:    "Split backup into parts of this size. Leave empty to keep as a single file",
-							Children: []nodx.Node{
-								nodx.Min("1"),
-								nodx.Max("10000"),
-								nodx.If(
-									backup.MaxPartSizeMb.Valid,
-									nodx.Value(strconv.Itoa(int(backup.MaxPartSizeMb.Int32))),
-								),
-							},
-						}),
-					),
-				),
+				component.SelectControl(component.SelectControlParams{
+					Name:     "opt_if_exists",
+					Label:    "--if-exists",
+					Required: true,
+					Children: []nodx.Node{
+						yesNoOptions(backup.OptIfExists),
+					},
+				}),
 
-				nodx.Div(
-					nodx.Class("pt-4"),
-					nodx.Div(
-						nodx.Class("flex justify-start items-center space-x-1"),
-						component.H2Text("Options"),
-						component.HelpButtonModal(component.HelpButtonModalParams{
-							ModalTitle: "Backup options",
-							Children:   pgDumpOptionsHelp(),
-						}),
-					),
+				component.SelectControl(component.SelectControlParams{
+					Name:     "opt_create",
+					Label:    "--create",
+					Required: true,
+					Children: []nodx.Node{
+						yesNoOptions(backup.OptCreate),
+					},
+				}),
 
-					nodx.Div(
-						nodx.Class("mt-2 grid grid-cols-2 gap-2"),
-						component.SelectControl(component.SelectControlParams{
-							Name:     "opt_data_only",
-							Label:    "--data-only",
-							Required: true,
-							Children: []nodx.Node{
-								yesNoOptions(backup.OptDataOnly),
-							},
-						}),
-
-						component.SelectControl(component.SelectControlParams{
-							Name:     "opt_schema_only",
-							Label:    "--schema-only",
-							Required: true,
-							Children: []nodx.Node{
-								yesNoOptions(backup.OptSchemaOnly),
-							},
-						}),
-
-						component.SelectControl(component.SelectControlParams{
-							Name:     "opt_clean",
-							Label:    "--clean",
-							Required: true,
-							Children: []nodx.Node{
-								yesNoOptions(backup.OptClean),
-							},
-						}),
-
-						component.SelectControl(component.SelectControlParams{
-							Name:     "opt_if_exists",
-							Label:    "--if-exists",
-							Required: true,
-							Children: []nodx.Node{
-								yesNoOptions(backup.OptIfExists),
-							},
-						}),
-
-						component.SelectControl(component.SelectControlParams{
-							Name:     "opt_create",
-							Label:    "--create",
-							Required: true,
-							Children: []nodx.Node{
-								yesNoOptions(backup.OptCreate),
-							},
-						}),
-
-						component.SelectControl(component.SelectControlParams{
-							Name:     "opt_no_comments",
-							Label:    "--no-comments",
-							Required: true,
-							Children: []nodx.Node{
-								yesNoOptions(backup.OptNoComments),
-							},
-						}),
-					),
-				),
+				component.SelectControl(component.SelectControlParams{
+					Name:     "opt_no_comments",
+					Label:    "--no-comments",
+					Required: true,
+					Children: []nodx.Node{
+						yesNoOptions(backup.OptNoComments),
+					},
+				}),
+			),
+		),
 
-				nodx.Div(
-					nodx.Class("flex justify-end items-center space-x-2 pt-2"),
-					component.HxLoadingMd(),
-					nodx.Button(
-						nodx.Class("btn btn-primary"),
-						nodx.Type("submit"),
-						component.SpanText("Save"),
-						lucide.Save(),
-					),
-				),
+		nodx.Div(
+			nodx.Class("flex justify-end items-center space-x-2 pt-2"),
+			component.HxLoadingMd(),
+			nodx.Button(
+				nodx.Class("btn btn-primary"),
+				nodx.Type("submit"),
+				component.SpanText("Save"),
+				lucide.Save(),
+			),
+		),
 	)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/labstack/echo/v4"
 	nodx "github.com/nodxdev/nodxgo"
 	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
 )
 
 func (h *handlers) indexPageHandler(c echo.Context) error {
@@ -23,7 +24,16 @@ func indexPage(reqCtx reqctx.Ctx) nodx.Node {
 		nodx.Div(
 			nodx.Class("flex justify-between items-start"),
 			component.H1Text("Backup tasks"),
-			createBackupButton(),
+			nodx.Div(
+				nodx.Class("flex items-center space-x-2"),
+				nodx.A(
+					nodx.Class("btn btn-neutral"),
+					nodx.Href(pathutil.BuildPath("/dashboard/backup-groups")),
+					component.SpanText("Backup groups"),
+					lucide.Layers(),
+				),
+				createBackupButton(),
+			),
 		),
 		component.CardBox(component.CardBoxParams{
 			Class: "mt-4",
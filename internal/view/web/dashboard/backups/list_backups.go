@@ -79,15 +79,24 @@ func listBackups(
 					component.SpanText("Show executions"),
 				),
 				manualRunbutton(backup.ID),
-				editBackupButton(backup),
+				nodx.If(!backup.IsProvisioned, editBackupButton(backup)),
 				duplicateBackupButton(backup.ID),
-				deleteBackupButton(backup.ID),
+				nodx.If(!backup.IsProvisioned, deleteBackupButton(backup.ID)),
 			)),
 			nodx.Td(
 				nodx.Div(
 					nodx.Class("flex items-center space-x-2"),
 					component.IsActivePing(backup.IsActive),
 					component.SpanText(backup.Name),
+					component.ProvisionedBadge(backup.IsProvisioned),
+					nodx.If(
+						backup.IsStale,
+						nodx.SpanEl(
+							nodx.Class("badge badge-warning"),
+							nodx.TitleAttr("No successful execution within the RPO"),
+							nodx.Text("stale"),
+						),
+					),
 				),
 			),
 			nodx.Td(component.SpanText(backup.DatabaseName)),
@@ -95,11 +104,21 @@ func listBackups(
 				backup.IsLocal, backup.DestinationName,
 			)),
 			nodx.Td(
-				nodx.Class("font-mono"),
-				nodx.Div(
-					nodx.Class("flex flex-col items-start text-xs"),
-					component.SpanText(backup.CronExpression),
-					component.SpanText(backup.TimeZone),
+				nodx.If(
+					backup.RunAfterBackupID.Valid,
+					nodx.Div(
+						nodx.Class("flex items-center space-x-1 text-xs"),
+						lucide.ArrowRightFromLine(nodx.Class("size-4")),
+						component.SpanText("After "+backup.RunAfterBackupName.String),
+					),
+				),
+				nodx.If(
+					!backup.RunAfterBackupID.Valid,
+					nodx.Div(
+						nodx.Class("flex flex-col items-start text-xs font-mono"),
+						component.SpanText(backup.CronExpression),
+						component.SpanText(backup.TimeZone),
+					),
 				),
 			),
 			nodx.Td(
@@ -15,13 +15,18 @@ import (
 )
 
 func (h *handlers) manualRunHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
 	backupID, err := uuid.Parse(c.Param("backupID"))
 	if err != nil {
 		return respondhtmx.ToastError(c, err.Error())
 	}
 
+	// WithoutCancel keeps the role of the user in the context
 	go func() {
-		_ = h.servs.ExecutionsService.RunExecution(context.Background(), backupID)
+		_ = h.servs.ExecutionsService.RunExecution(
+			context.WithoutCancel(ctx), backupID,
+		)
 	}()
 
 	return respondhtmx.ToastSuccess(c, "Backup started, check the backup executions for more details")
@@ -2,6 +2,7 @@ package backups
 
 import (
 	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/eduardolat/pgbackweb/internal/view/middleware"
 	"github.com/labstack/echo/v4"
 )
@@ -19,13 +20,16 @@ func MountRouter(
 ) {
 	h := newHandlers(servs)
 
+	run := mids.RequirePermission(users.PermissionRun)
+	manage := mids.RequirePermission(users.PermissionManage)
+
 	parent.GET("", h.indexPageHandler)
 	parent.GET("/list", h.listBackupsHandler)
-	parent.GET("/create-form", h.createBackupFormHandler)
-	parent.POST("", h.createBackupHandler)
-	parent.DELETE("/:backupID", h.deleteBackupHandler)
-	parent.GET("/:backupID/edit-form", h.getEditBackupFormHandler)
-	parent.POST("/:backupID/edit", h.editBackupHandler)
-	parent.POST("/:backupID/run", h.manualRunHandler)
-	parent.POST("/:backupID/duplicate", h.duplicateBackupHandler)
+	parent.GET("/create-form", h.createBackupFormHandler, manage)
+	parent.POST("", h.createBackupHandler, manage)
+	parent.DELETE("/:backupID", h.deleteBackupHandler, manage)
+	parent.GET("/:backupID/edit-form", h.getEditBackupFormHandler, manage)
+	parent.POST("/:backupID/edit", h.editBackupHandler, manage)
+	parent.POST("/:backupID/run", h.manualRunHandler, run)
+	parent.POST("/:backupID/duplicate", h.duplicateBackupHandler, manage)
 }
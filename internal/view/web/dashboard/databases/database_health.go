@@ -0,0 +1,81 @@
+package databases
+
+import (
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/health"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/layout"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) databaseHealthPageHandler(c echo.Context) error {
+	reqCtx := reqctx.GetCtx(c)
+	ctx := c.Request().Context()
+
+	databaseID, err := uuid.Parse(c.Param("databaseID"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	database, err := h.servs.DatabasesService.GetDatabase(ctx, databaseID)
+	if err != nil {
+		logger.Error("failed to get database", logger.KV{"err": err})
+		return c.String(http.StatusInternalServerError, "failed to get database")
+	}
+
+	report, err := h.servs.HealthService.GetReport(
+		ctx, uuid.NullUUID{UUID: databaseID, Valid: true}, uuid.NullUUID{},
+	)
+	if err != nil {
+		logger.Error("failed to get database health", logger.KV{"err": err})
+		return c.String(
+			http.StatusInternalServerError, "failed to get database health",
+		)
+	}
+
+	return echoutil.RenderNodx(
+		c, http.StatusOK, databaseHealthPage(reqCtx, database, report),
+	)
+}
+
+func databaseHealthPage(
+	reqCtx reqctx.Ctx, database dbgen.DatabasesServiceGetDatabaseRow,
+	report health.Report,
+) nodx.Node {
+	content := []nodx.Node{
+		nodx.Div(
+			nodx.Class("flex justify-between items-start"),
+			nodx.Div(
+				nodx.Class("flex items-center space-x-2"),
+				component.HealthStatusPing(
+					database.TestOk, database.TestError, database.LastTestAt,
+				),
+				component.H1Text("Health of "+database.Name),
+			),
+			nodx.A(
+				nodx.Href(pathutil.BuildPath("/dashboard/databases")),
+				nodx.Class("btn btn-ghost"),
+				lucide.ArrowLeft(),
+				component.SpanText("Back to databases"),
+			),
+		),
+		nodx.Div(
+			nodx.Class("mt-4"),
+			component.HealthReport(report),
+		),
+	}
+
+	return layout.Dashboard(reqCtx, layout.DashboardParams{
+		Title: "Health of " + database.Name,
+		Body:  content,
+	})
+}
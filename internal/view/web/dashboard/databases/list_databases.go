@@ -72,14 +72,21 @@ func listDatabases(
 						lucide.List(),
 						component.SpanText("Show executions"),
 					),
-					editDatabaseButton(database),
+					component.OptionsDropdownA(
+						nodx.Href(pathutil.BuildPath(
+							fmt.Sprintf("/dashboard/databases/%s/health", database.ID),
+						)),
+						lucide.Activity(),
+						component.SpanText("Show health"),
+					),
+					nodx.If(!database.IsProvisioned, editDatabaseButton(database)),
 					component.OptionsDropdownButton(
 						htmx.HxPost(pathutil.BuildPath(fmt.Sprintf("/dashboard/databases/%s/test", database.ID))),
 						htmx.HxDisabledELT("this"),
 						lucide.DatabaseZap(),
 						component.SpanText("Test connection"),
 					),
-					deleteDatabaseButton(database.ID),
+					nodx.If(!database.IsProvisioned, deleteDatabaseButton(database.ID)),
 				),
 			)),
 			nodx.Td(
@@ -89,12 +96,16 @@ func listDatabases(
 						database.TestOk, database.TestError, database.LastTestAt,
 					),
 					component.SpanText(database.Name),
+					component.ProvisionedBadge(database.IsProvisioned),
 				),
 			),
 			nodx.Td(component.SpanText("PostgreSQL "+database.PgVersion)),
 			nodx.Td(
 				nodx.Class("space-x-1"),
-				component.CopyButtonSm(database.DecryptedConnectionString),
+				nodx.If(
+					database.DecryptedConnectionString != "",
+					component.CopyButtonSm(database.DecryptedConnectionString),
+				),
 				component.SpanText("****************"),
 			),
 			nodx.Td(component.SpanText(
@@ -2,6 +2,7 @@ package databases
 
 import (
 	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/eduardolat/pgbackweb/internal/view/middleware"
 	"github.com/labstack/echo/v4"
 )
@@ -19,11 +20,15 @@ func MountRouter(
 ) {
 	h := newHandlers(servs)
 
+	run := mids.RequirePermission(users.PermissionRun)
+	manage := mids.RequirePermission(users.PermissionManage)
+
 	parent.GET("", h.indexPageHandler)
 	parent.GET("/list", h.listDatabasesHandler)
-	parent.POST("", h.createDatabaseHandler)
-	parent.POST("/test", h.testDatabaseHandler)
-	parent.DELETE("/:databaseID", h.deleteDatabaseHandler)
-	parent.POST("/:databaseID/edit", h.editDatabaseHandler)
-	parent.POST("/:databaseID/test", h.testExistingDatabaseHandler)
+	parent.GET("/:databaseID/health", h.databaseHealthPageHandler)
+	parent.POST("", h.createDatabaseHandler, manage)
+	parent.POST("/test", h.testDatabaseHandler, run)
+	parent.DELETE("/:databaseID", h.deleteDatabaseHandler, manage)
+	parent.POST("/:databaseID/edit", h.editDatabaseHandler, manage)
+	parent.POST("/:databaseID/test", h.testExistingDatabaseHandler, run)
 }
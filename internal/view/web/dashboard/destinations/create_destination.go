@@ -1,7 +1,10 @@
 package destinations
 
 import (
+	"database/sql"
+
 	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/health"
 	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
 	"github.com/eduardolat/pgbackweb/internal/validate"
 	"github.com/eduardolat/pgbackweb/internal/view/web/component"
@@ -21,6 +24,10 @@ type createDestinationDTO struct {
 	Endpoint         string `form:"endpoint" validate:"required"`
 	ForcePathStyle   string `form:"force_path_style" validate:"required,oneof=true false"`
 	SignatureVersion string `form:"signature_version" validate:"required,oneof=v2 v4"`
+
+	HealthCheckIntervalMinutes int32 `form:"health_check_interval_minutes" validate:"required,min=1,max=1440"`
+	HealthFailureThreshold     int32 `form:"health_failure_threshold" validate:"required,min=1,max=100"`
+	HealthSuccessThreshold     int32 `form:"health_success_threshold" validate:"required,min=1,max=100"`
 }
 
 func (h *handlers) createDestinationHandler(c echo.Context) error {
@@ -44,6 +51,15 @@ func (h *handlers) createDestinationHandler(c echo.Context) error {
 			BucketName: formData.BucketName,
 			ForcePathStyle: formData.ForcePathStyle == "true",
 			SignatureVersion: formData.SignatureVersion,
+			HealthCheckIntervalMinutes: sql.NullInt32{
+				Int32: formData.HealthCheckIntervalMinutes, Valid: true,
+			},
+			HealthFailureThreshold: sql.NullInt32{
+				Int32: formData.HealthFailureThreshold, Valid: true,
+			},
+			HealthSuccessThreshold: sql.NullInt32{
+				Int32: formData.HealthSuccessThreshold, Valid: true,
+			},
 		},
 	)
 	if err != nil {
@@ -111,7 +127,7 @@ func createDestinationButton() nodx.Node {
 					Placeholder: "Access key",
 					Required:    true,
 					Type:        component.InputTypeText,
-					HelpText:    "It will be stored securely using PGP encryption.",
+					HelpText:    "It will be stored securely using PGP encryption. It can also be a reference to an external secret like env://NAME, file:///path or vault://mount/path#field, resolved every time it is used.",
 				}),
 
 				component.InputControl(component.InputControlParams{
@@ -120,7 +136,7 @@ func createDestinationButton() nodx.Node {
 					Placeholder: "Secret key",
 					Required:    true,
 					Type:        component.InputTypeText,
-					HelpText:    "It will be stored securely using PGP encryption.",
+					HelpText:    "It will be stored securely using PGP encryption. It can also be a reference to an external secret like env://NAME, file:///path or vault://mount/path#field, resolved every time it is used.",
 				}),
 
 				component.SelectControl(component.SelectControlParams{
@@ -144,6 +160,11 @@ func createDestinationButton() nodx.Node {
 						nodx.Option(nodx.Value("true"), nodx.Text("Yes")),
 					},
 				}),
+
+				component.HealthCheckControls(
+					health.DefaultCheckIntervalMinutes, health.DefaultFailureThreshold,
+					health.DefaultSuccessThreshold,
+				),
 			),
 
 			nodx.Div(
@@ -0,0 +1,83 @@
+package destinations
+
+import (
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/health"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/layout"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) destinationHealthPageHandler(c echo.Context) error {
+	reqCtx := reqctx.GetCtx(c)
+	ctx := c.Request().Context()
+
+	destinationID, err := uuid.Parse(c.Param("destinationID"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	destination, err := h.servs.DestinationsService.GetDestination(
+		ctx, destinationID,
+	)
+	if err != nil {
+		logger.Error("failed to get destination", logger.KV{"err": err})
+		return c.String(http.StatusInternalServerError, "failed to get destination")
+	}
+
+	report, err := h.servs.HealthService.GetReport(
+		ctx, uuid.NullUUID{}, uuid.NullUUID{UUID: destinationID, Valid: true},
+	)
+	if err != nil {
+		logger.Error("failed to get destination health", logger.KV{"err": err})
+		return c.String(
+			http.StatusInternalServerError, "failed to get destination health",
+		)
+	}
+
+	return echoutil.RenderNodx(
+		c, http.StatusOK, destinationHealthPage(reqCtx, destination, report),
+	)
+}
+
+func destinationHealthPage(
+	reqCtx reqctx.Ctx, destination dbgen.DestinationsServiceGetDestinationRow,
+	report health.Report,
+) nodx.Node {
+	content := []nodx.Node{
+		nodx.Div(
+			nodx.Class("flex justify-between items-start"),
+			nodx.Div(
+				nodx.Class("flex items-center space-x-2"),
+				component.HealthStatusPing(
+					destination.TestOk, destination.TestError, destination.LastTestAt,
+				),
+				component.H1Text("Health of "+destination.Name),
+			),
+			nodx.A(
+				nodx.Href(pathutil.BuildPath("/dashboard/destinations")),
+				nodx.Class("btn btn-ghost"),
+				lucide.ArrowLeft(),
+				component.SpanText("Back to destinations"),
+			),
+		),
+		nodx.Div(
+			nodx.Class("mt-4"),
+			component.HealthReport(report),
+		),
+	}
+
+	return layout.Dashboard(reqCtx, layout.DashboardParams{
+		Title: "Health of " + destination.Name,
+		Body:  content,
+	})
+}
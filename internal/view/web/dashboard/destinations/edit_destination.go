@@ -42,6 +42,15 @@ func (h *handlers) editDestinationHandler(c echo.Context) error {
 			SignatureVersion: sql.NullString{String: formData.SignatureVersion, Valid: true},
 			AccessKey:  sql.NullString{String: formData.AccessKey, Valid: true},
 			SecretKey:  sql.NullString{String: formData.SecretKey, Valid: true},
+			HealthCheckIntervalMinutes: sql.NullInt32{
+				Int32: formData.HealthCheckIntervalMinutes, Valid: true,
+			},
+			HealthFailureThreshold: sql.NullInt32{
+				Int32: formData.HealthFailureThreshold, Valid: true,
+			},
+			HealthSuccessThreshold: sql.NullInt32{
+				Int32: formData.HealthSuccessThreshold, Valid: true,
+			},
 		},
 	)
 	if err != nil {
@@ -139,7 +148,7 @@ func editDestinationButton(
 					Placeholder: "Access key",
 					Required:    true,
 					Type:        component.InputTypeText,
-					HelpText:    "It will be stored securely using PGP encryption.",
+					HelpText:    "It will be stored securely using PGP encryption. It can also be a reference to an external secret like env://NAME, file:///path or vault://mount/path#field, resolved every time it is used.",
 					Children: []nodx.Node{
 						nodx.Value(destination.DecryptedAccessKey),
 					},
@@ -151,7 +160,7 @@ func editDestinationButton(
 					Placeholder: "Secret key",
 					Required:    true,
 					Type:        component.InputTypeText,
-					HelpText:    "It will be stored securely using PGP encryption.",
+					HelpText:    "It will be stored securely using PGP encryption. It can also be a reference to an external secret like env://NAME, file:///path or vault://mount/path#field, resolved every time it is used.",
 					Children: []nodx.Node{
 						nodx.Value(destination.DecryptedSecretKey),
 					},
@@ -167,6 +176,11 @@ func editDestinationButton(
 						nodx.Option(nodx.Value("true"), nodx.Text("Yes"), nodx.If(destination.ForcePathStyle, nodx.Selected(""))),
 					},
 				}),
+
+				component.HealthCheckControls(
+					destination.HealthCheckIntervalMinutes, destination.HealthFailureThreshold,
+					destination.HealthSuccessThreshold,
+				),
 			),
 
 			nodx.Div(
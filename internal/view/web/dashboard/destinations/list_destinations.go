@@ -70,14 +70,21 @@ func listDestinations(
 					lucide.List(),
 					component.SpanText("Show executions"),
 				),
-				editDestinationButton(destination),
+				component.OptionsDropdownA(
+					nodx.Href(pathutil.BuildPath(
+						fmt.Sprintf("/dashboard/destinations/%s/health", destination.ID),
+					)),
+					lucide.Activity(),
+					component.SpanText("Show health"),
+				),
+				nodx.If(!destination.IsProvisioned, editDestinationButton(destination)),
 				component.OptionsDropdownButton(
 					htmx.HxPost(pathutil.BuildPath(fmt.Sprintf("/dashboard/destinations/%s/test", destination.ID))),
 					htmx.HxDisabledELT("this"),
 					lucide.PlugZap(),
 					component.SpanText("Test connection"),
 				),
-				deleteDestinationButton(destination.ID),
+				nodx.If(!destination.IsProvisioned, deleteDestinationButton(destination.ID)),
 			)),
 			nodx.Td(
 				nodx.Div(
@@ -86,6 +93,7 @@ func listDestinations(
 						destination.TestOk, destination.TestError, destination.LastTestAt,
 					),
 					component.SpanText(destination.Name),
+					component.ProvisionedBadge(destination.IsProvisioned),
 				),
 			),
 			nodx.Td(
@@ -114,14 +122,20 @@ func listDestinations(
 			nodx.Td(
 				nodx.Div(
 					nodx.Class("flex items-center space-x-1"),
-					component.CopyButtonSm(destination.DecryptedAccessKey),
+					nodx.If(
+						destination.DecryptedAccessKey != "",
+						component.CopyButtonSm(destination.DecryptedAccessKey),
+					),
 					component.SpanText("**********"),
 				),
 			),
 			nodx.Td(
 				nodx.Div(
 					nodx.Class("flex items-center space-x-1"),
-					component.CopyButtonSm(destination.DecryptedSecretKey),
+					nodx.If(
+						destination.DecryptedSecretKey != "",
+						component.CopyButtonSm(destination.DecryptedSecretKey),
+					),
 					component.SpanText("**********"),
 				),
 			),
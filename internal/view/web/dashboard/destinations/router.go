@@ -2,6 +2,7 @@ package destinations
 
 import (
 	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/eduardolat/pgbackweb/internal/view/middleware"
 	"github.com/labstack/echo/v4"
 )
@@ -19,11 +20,15 @@ func MountRouter(
 ) {
 	h := newHandlers(servs)
 
+	run := mids.RequirePermission(users.PermissionRun)
+	manage := mids.RequirePermission(users.PermissionManage)
+
 	parent.GET("", h.indexPageHandler)
 	parent.GET("/list", h.listDestinationsHandler)
-	parent.POST("", h.createDestinationHandler)
-	parent.POST("/test", h.testDestinationHandler)
-	parent.DELETE("/:destinationID", h.deleteDestinationHandler)
-	parent.POST("/:destinationID/edit", h.editDestinationHandler)
-	parent.POST("/:destinationID/test", h.testExistingDestinationHandler)
+	parent.GET("/:destinationID/health", h.destinationHealthPageHandler)
+	parent.POST("", h.createDestinationHandler, manage)
+	parent.POST("/test", h.testDestinationHandler, run)
+	parent.DELETE("/:destinationID", h.deleteDestinationHandler, manage)
+	parent.POST("/:destinationID/edit", h.editDestinationHandler, manage)
+	parent.POST("/:destinationID/test", h.testExistingDestinationHandler, run)
 }
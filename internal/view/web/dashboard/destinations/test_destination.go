@@ -8,6 +8,8 @@ import (
 )
 
 func (h *handlers) testDestinationHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
 	var formData createDestinationDTO
 	if err := c.Bind(&formData); err != nil {
 		return respondhtmx.ToastError(c, err.Error())
@@ -17,7 +19,7 @@ func (h *handlers) testDestinationHandler(c echo.Context) error {
 	}
 
 	err := h.servs.DestinationsService.TestDestination(
-		formData.AccessKey, formData.SecretKey, formData.Region, formData.Endpoint,
+		ctx, formData.AccessKey, formData.SecretKey, formData.Region, formData.Endpoint,
 		formData.BucketName, formData.ForcePathStyle == "true",
 		formData.SignatureVersion,
 	)
@@ -144,7 +144,7 @@ func listExecutions(
 				showExecutionButton(execution),
 				restoreExecutionButton(execution),
 			)),
-			nodx.Td(component.StatusBadge(execution.Status)),
+			nodx.Td(executionStatusBadges(execution.Status, execution.IsSuspicious)),
 			nodx.Td(component.SpanText(execution.BackupName)),
 			nodx.Td(component.SpanText(execution.DatabaseName)),
 			nodx.Td(component.PrettyDestinationName(
@@ -209,3 +209,20 @@ func listExecutions(
 
 	return component.RenderableGroup(trs)
 }
+
+// executionStatusBadges renders the status badge of an execution followed by
+// a suspicious badge when its file size deviated from the backup history.
+func executionStatusBadges(status string, isSuspicious bool) nodx.Node {
+	return nodx.Div(
+		nodx.Class("flex items-center gap-1"),
+		component.StatusBadge(status),
+		nodx.If(
+			isSuspicious,
+			nodx.SpanEl(
+				nodx.Class("badge badge-warning"),
+				nodx.TitleAttr("The file size deviates from the recent executions"),
+				nodx.Text("suspicious"),
+			),
+		),
+	)
+}
@@ -62,8 +62,9 @@ func (h *handlers) restoreExecutionHandler(c echo.Context) error {
 		}
 	}
 
+	// WithoutCancel keeps the role of the user in the context
 	go func() {
-		ctx := context.Background()
+		ctx := context.WithoutCancel(ctx)
 		_ = h.servs.RestorationsService.RunRestoration(
 			ctx,
 			formData.ExecutionID,
@@ -2,6 +2,7 @@ package executions
 
 import (
 	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/eduardolat/pgbackweb/internal/view/middleware"
 	"github.com/labstack/echo/v4"
 )
@@ -19,10 +20,14 @@ func MountRouter(
 ) {
 	h := newHandlers(servs)
 
+	readSecrets := mids.RequirePermission(users.PermissionReadSecrets)
+	run := mids.RequirePermission(users.PermissionRun)
+	manage := mids.RequirePermission(users.PermissionManage)
+
 	parent.GET("", h.indexPageHandler)
 	parent.GET("/list", h.listExecutionsHandler)
-	parent.GET("/:executionID/download", h.downloadExecutionHandler)
-	parent.DELETE("/:executionID", h.deleteExecutionHandler)
-	parent.GET("/:executionID/restore-form", h.restoreExecutionFormHandler)
-	parent.POST("/:executionID/restore", h.restoreExecutionHandler)
+	parent.GET("/:executionID/download", h.downloadExecutionHandler, readSecrets)
+	parent.DELETE("/:executionID", h.deleteExecutionHandler, manage)
+	parent.GET("/:executionID/restore-form", h.restoreExecutionFormHandler, run)
+	parent.POST("/:executionID/restore", h.restoreExecutionHandler, run)
 }
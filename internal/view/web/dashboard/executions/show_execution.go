@@ -122,7 +122,7 @@ func showExecutionButton(
 					),
 					nodx.Tr(
 						nodx.Th(component.SpanText("Status")),
-						nodx.Td(component.StatusBadge(execution.Status)),
+						nodx.Td(executionStatusBadges(execution.Status, execution.IsSuspicious)),
 					),
 					nodx.Tr(
 						nodx.Th(component.SpanText("Database")),
@@ -0,0 +1,206 @@
+package profile
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/auth"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/util/timeutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) createAPITokenHandler(c echo.Context) error {
+	reqCtx := reqctx.GetCtx(c)
+	ctx := c.Request().Context()
+
+	var formData struct {
+		Name          string `form:"name" validate:"required"`
+		Scope         string `form:"scope" validate:"required,oneof=read write"`
+		ExpiresInDays int    `form:"expires_in_days" validate:"min=0"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	expiresAt := sql.NullTime{}
+	if formData.ExpiresInDays > 0 {
+		expiresAt = sql.NullTime{
+			Time:  time.Now().AddDate(0, 0, formData.ExpiresInDays),
+			Valid: true,
+		}
+	}
+
+	_, token, err := h.servs.AuthService.CreateAPIToken(
+		ctx, reqCtx.User.ID, formData.Name, formData.Scope, expiresAt,
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.AlertWithRefresh(c, fmt.Sprintf(
+		"API token created, copy it now because it will not be shown again:\n\n%s",
+		token,
+	))
+}
+
+func (h *handlers) deleteAPITokenHandler(c echo.Context) error {
+	reqCtx := reqctx.GetCtx(c)
+	ctx := c.Request().Context()
+
+	apiTokenID, err := uuid.Parse(c.Param("apiTokenID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	err = h.servs.AuthService.DeleteAPIToken(ctx, reqCtx.User.ID, apiTokenID)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.Refresh(c)
+}
+
+func apiTokensCard(apiTokens []dbgen.ApiToken) nodx.Node {
+	formatNullTime := func(t sql.NullTime, fallback string) string {
+		if !t.Valid {
+			return fallback
+		}
+		return t.Time.Local().Format(timeutil.LayoutYYYYMMDDHHMMSSPretty)
+	}
+
+	return component.CardBox(component.CardBoxParams{
+		Children: []nodx.Node{
+			nodx.FormEl(
+				htmx.HxPost(pathutil.BuildPath("/dashboard/profile/api-tokens")),
+				htmx.HxDisabledELT("find button"),
+				nodx.Class("space-y-2"),
+
+				component.H2Text("API tokens"),
+				component.PText(
+					"API tokens authenticate requests to the REST API under /api/v1 "+
+						"using the Authorization: Bearer <token> header. Tokens with "+
+						"the read scope can only perform GET requests.",
+				),
+
+				nodx.Div(
+					nodx.Class("grid grid-cols-3 gap-2"),
+
+					component.InputControl(component.InputControlParams{
+						Name:        "name",
+						Label:       "Name",
+						Placeholder: "My script",
+						Required:    true,
+						Type:        component.InputTypeText,
+					}),
+
+					component.SelectControl(component.SelectControlParams{
+						Name:     "scope",
+						Label:    "Scope",
+						Required: true,
+						Children: []nodx.Node{
+							nodx.Option(
+								nodx.Value(auth.APITokenScopeRead), nodx.Text("Read"),
+								nodx.Selected(""),
+							),
+							nodx.Option(
+								nodx.Value(auth.APITokenScopeWrite), nodx.Text("Read and write"),
+							),
+						},
+					}),
+
+					component.SelectControl(component.SelectControlParams{
+						Name:     "expires_in_days",
+						Label:    "Expiration",
+						Required: true,
+						Children: []nodx.Node{
+							nodx.Option(nodx.Value("30"), nodx.Text("30 days"), nodx.Selected("")),
+							nodx.Option(nodx.Value("90"), nodx.Text("90 days")),
+							nodx.Option(nodx.Value("365"), nodx.Text("1 year")),
+							nodx.Option(nodx.Value("0"), nodx.Text("Never")),
+						},
+					}),
+				),
+
+				nodx.Div(
+					nodx.Class("flex justify-end items-center space-x-2 pt-2"),
+					component.HxLoadingMd(),
+					nodx.Button(
+						nodx.Class("btn btn-primary"),
+						nodx.Type("submit"),
+						component.SpanText("Create token"),
+						lucide.KeyRound(),
+					),
+				),
+			),
+
+			nodx.Div(nodx.Class("divider")),
+
+			nodx.Div(
+				nodx.Class("overflow-x-auto"),
+				nodx.Table(
+					nodx.Class("table"),
+					nodx.Thead(
+						nodx.Tr(
+							nodx.Th(component.SpanText("Name")),
+							nodx.Th(component.SpanText("Token")),
+							nodx.Th(component.SpanText("Scope")),
+							nodx.Th(component.SpanText("Expires at")),
+							nodx.Th(component.SpanText("Last used at")),
+							nodx.Th(),
+						),
+					),
+					nodx.Tbody(
+						nodx.If(
+							len(apiTokens) < 1,
+							nodx.Tr(
+								nodx.Td(
+									nodx.Colspan("6"),
+									component.SpanText("No API tokens found"),
+								),
+							),
+						),
+						nodx.Map(apiTokens, func(apiToken dbgen.ApiToken) nodx.Node {
+							return nodx.Tr(
+								nodx.Td(component.SpanText(apiToken.Name)),
+								nodx.Td(component.SpanText(apiToken.TokenPrefix+"...")),
+								nodx.Td(component.SpanText(apiToken.Scope)),
+								nodx.Td(component.SpanText(
+									formatNullTime(apiToken.ExpiresAt, "Never"),
+								)),
+								nodx.Td(component.SpanText(
+									formatNullTime(apiToken.LastUsedAt, "Never"),
+								)),
+								nodx.Td(
+									nodx.Button(
+										htmx.HxDelete(pathutil.BuildPath(
+											"/dashboard/profile/api-tokens/"+apiToken.ID.String(),
+										)),
+										htmx.HxConfirm("Are you sure you want to delete this API token?"),
+										htmx.HxDisabledELT("this"),
+										nodx.Class("btn btn-error btn-sm btn-square btn-ghost"),
+										nodx.TitleAttr("Delete API token"),
+										lucide.Trash(),
+									),
+								),
+							)
+						}),
+					),
+				),
+			),
+		},
+	})
+}
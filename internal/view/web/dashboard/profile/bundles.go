@@ -0,0 +1,216 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/service/bundles"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+// exportBundleHandler is submitted as a regular form, not with htmx, so the
+// browser downloads the bundle as a file.
+func (h *handlers) exportBundleHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var formData struct {
+		Passphrase        string `form:"passphrase" validate:"required"`
+		IncludeExecutions string `form:"include_executions" validate:"required,oneof=true false"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	bundle, err := h.servs.BundlesService.ExportBundle(
+		ctx, formData.Passphrase, formData.IncludeExecutions == "true",
+	)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	content, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+
+	fileName := fmt.Sprintf(
+		"pgbackweb-bundle-%s.json", time.Now().Format("20060102-150405"),
+	)
+	c.Response().Header().Set(
+		echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName),
+	)
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, content)
+}
+
+func (h *handlers) importBundleHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var formData struct {
+		Passphrase string `form:"passphrase" validate:"required"`
+		Strategy   string `form:"strategy" validate:"required,oneof=skip overwrite rename"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	fileHeader, err := c.FormFile("bundle")
+	if err != nil {
+		return respondhtmx.ToastError(c, "the bundle file is required")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	defer file.Close()
+
+	bundle, err := bundles.ParseBundle(file)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	result, err := h.servs.BundlesService.ImportBundle(
+		ctx, bundle, formData.Passphrase,
+		bundles.ConflictStrategy(formData.Strategy),
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.AlertWithRefresh(c, "Bundle imported:\n\n"+result.String())
+}
+
+func bundlesCard() nodx.Node {
+	return component.CardBox(component.CardBoxParams{
+		Children: []nodx.Node{
+			component.H2Text("Export and import"),
+			component.PText(
+				"Move the databases, destinations, backups and webhooks to another " +
+					"PG Back Web instance. The secrets of the bundle are encrypted " +
+					"with the passphrase, keep it to import the bundle.",
+			),
+
+			nodx.Div(
+				nodx.Class("mt-2 grid grid-cols-2 gap-4"),
+
+				nodx.FormEl(
+					nodx.Method("post"),
+					nodx.Action(pathutil.BuildPath("/dashboard/profile/bundle/export")),
+					nodx.Class("space-y-2"),
+
+					component.H3Text("Export"),
+
+					component.InputControl(component.InputControlParams{
+						Name:         "passphrase",
+						Label:        "Passphrase",
+						Placeholder:  "At least 8 characters",
+						Required:     true,
+						Type:         component.InputTypePassword,
+						AutoComplete: "new-password",
+					}),
+
+					component.SelectControl(component.SelectControlParams{
+						Name:     "include_executions",
+						Label:    "Include executions",
+						Required: true,
+						HelpText: "References to the files of the successful executions, " +
+							"the files themselves are not copied",
+						Children: []nodx.Node{
+							nodx.Option(nodx.Value("false"), nodx.Text("No"), nodx.Selected("")),
+							nodx.Option(nodx.Value("true"), nodx.Text("Yes")),
+						},
+					}),
+
+					nodx.Div(
+						nodx.Class("flex justify-end items-center pt-2"),
+						nodx.Button(
+							nodx.Class("btn btn-primary"),
+							nodx.Type("submit"),
+							component.SpanText("Export bundle"),
+							lucide.Download(),
+						),
+					),
+				),
+
+				nodx.FormEl(
+					htmx.HxPost(pathutil.BuildPath("/dashboard/profile/bundle/import")),
+					htmx.HxEncoding("multipart/form-data"),
+					htmx.HxDisabledELT("find button"),
+					htmx.HxConfirm("Are you sure you want to import this bundle?"),
+					nodx.Class("space-y-2"),
+
+					component.H3Text("Import"),
+
+					nodx.Div(
+						nodx.Class("form-control w-full"),
+						nodx.Div(
+							nodx.Class("label"),
+							component.SpanText("Bundle file"),
+						),
+						nodx.Input(
+							nodx.Class("file-input file-input-bordered w-full"),
+							nodx.Type("file"),
+							nodx.Name("bundle"),
+							nodx.Accept(".json,application/json"),
+							nodx.Required(""),
+						),
+					),
+
+					component.InputControl(component.InputControlParams{
+						Name:         "passphrase",
+						Label:        "Passphrase",
+						Required:     true,
+						Type:         component.InputTypePassword,
+						AutoComplete: "off",
+					}),
+
+					component.SelectControl(component.SelectControlParams{
+						Name:     "strategy",
+						Label:    "When the name already exists",
+						Required: true,
+						Children: []nodx.Node{
+							nodx.Option(
+								nodx.Value(string(bundles.ConflictSkip)),
+								nodx.Text("Skip and keep the existing one"),
+								nodx.Selected(""),
+							),
+							nodx.Option(
+								nodx.Value(string(bundles.ConflictOverwrite)),
+								nodx.Text("Overwrite the existing one"),
+							),
+							nodx.Option(
+								nodx.Value(string(bundles.ConflictRename)),
+								nodx.Text("Import with a new name"),
+							),
+						},
+					}),
+
+					nodx.Div(
+						nodx.Class("flex justify-end items-center space-x-2 pt-2"),
+						component.HxLoadingMd(),
+						nodx.Button(
+							nodx.Class("btn btn-primary"),
+							nodx.Type("submit"),
+							component.SpanText("Import bundle"),
+							lucide.Upload(),
+						),
+					),
+				),
+			),
+		},
+	})
+}
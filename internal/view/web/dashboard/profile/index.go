@@ -23,19 +23,66 @@ func (h *handlers) indexPageHandler(c echo.Context) error {
 		return c.String(http.StatusInternalServerError, "failed to get user sessions")
 	}
 
+	apiTokens, err := h.servs.AuthService.GetUserAPITokens(ctx, reqCtx.User.ID)
+	if err != nil {
+		logger.Error("failed to get user API tokens", logger.KV{"err": err})
+		return c.String(http.StatusInternalServerError, "failed to get user API tokens")
+	}
+
+	twoFactorRequired, err := h.servs.AuthService.IsTwoFactorRequired(ctx)
+	if err != nil {
+		logger.Error("failed to get two-factor requirement", logger.KV{"err": err})
+		return c.String(http.StatusInternalServerError, "failed to get two-factor requirement")
+	}
+
+	recoveryCodesLeft, err := h.servs.AuthService.GetUnusedRecoveryCodesQty(
+		ctx, reqCtx.User.ID,
+	)
+	if err != nil {
+		logger.Error("failed to get recovery codes", logger.KV{"err": err})
+		return c.String(http.StatusInternalServerError, "failed to get recovery codes")
+	}
+
 	return echoutil.RenderNodx(
-		c, http.StatusOK, indexPage(reqCtx, sessions),
+		c, http.StatusOK, indexPage(
+			reqCtx, sessions, apiTokens, twoFactorRequired, recoveryCodesLeft,
+		),
 	)
 }
 
-func indexPage(reqCtx reqctx.Ctx, sessions []dbgen.Session) nodx.Node {
+func indexPage(
+	reqCtx reqctx.Ctx, sessions []dbgen.Session, apiTokens []dbgen.ApiToken,
+	twoFactorRequired bool, recoveryCodesLeft int64,
+) nodx.Node {
 	content := []nodx.Node{
 		component.H1Text("Profile"),
 
 		nodx.Div(
 			nodx.Class("mt-4 grid grid-cols-2 gap-4"),
-			nodx.Div(updateUserForm(reqCtx.User)),
+			nodx.If(
+				!reqCtx.TwoFactorSetupRequired,
+				nodx.Div(updateUserForm(reqCtx.User)),
+			),
 			nodx.Div(closeAllSessionsForm(sessions)),
+			nodx.Div(
+				nodx.Class("col-span-2"),
+				twoFactorCard(reqCtx.User, twoFactorRequired, recoveryCodesLeft),
+			),
+			// Until two-factor authentication is set up only its card is usable.
+			nodx.If(
+				!reqCtx.TwoFactorSetupRequired,
+				nodx.Div(
+					nodx.Class("col-span-2"),
+					apiTokensCard(apiTokens),
+				),
+			),
+			nodx.If(
+				!reqCtx.TwoFactorSetupRequired,
+				nodx.Div(
+					nodx.Class("col-span-2"),
+					bundlesCard(),
+				),
+			),
 		),
 	}
 
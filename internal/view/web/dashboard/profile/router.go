@@ -2,6 +2,7 @@ package profile
 
 import (
 	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/eduardolat/pgbackweb/internal/view/middleware"
 	"github.com/labstack/echo/v4"
 )
@@ -19,6 +20,16 @@ func MountRouter(
 ) {
 	h := newHandlers(servs)
 
+	manageInstance := mids.RequirePermission(users.PermissionManageInstance)
+
 	parent.GET("", h.indexPageHandler)
 	parent.POST("", h.updateUserHandler)
+	parent.POST("/api-tokens", h.createAPITokenHandler)
+	parent.DELETE("/api-tokens/:apiTokenID", h.deleteAPITokenHandler)
+	parent.POST("/two-factor/setup", h.setupTwoFactorHandler)
+	parent.POST("/two-factor/enable", h.enableTwoFactorHandler)
+	parent.POST("/two-factor/disable", h.disableTwoFactorHandler)
+	parent.POST("/two-factor/recovery-codes", h.regenerateRecoveryCodesHandler)
+	parent.POST("/bundle/export", h.exportBundleHandler, manageInstance)
+	parent.POST("/bundle/import", h.importBundleHandler, manageInstance)
 }
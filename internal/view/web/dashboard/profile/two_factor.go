@@ -0,0 +1,233 @@
+package profile
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/util/timeutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) setupTwoFactorHandler(c echo.Context) error {
+	reqCtx := reqctx.GetCtx(c)
+	ctx := c.Request().Context()
+
+	setup, err := h.servs.AuthService.StartTOTPSetup(ctx, reqCtx.User)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return echoutil.RenderNodx(c, http.StatusOK, nodx.Div(
+		nodx.Class("space-y-2"),
+		component.PText(
+			"Scan the QR code with your authenticator app, or enter the secret "+
+				"manually, and confirm with the code it shows.",
+		),
+		nodx.Div(
+			nodx.Class("flex items-center space-x-4"),
+			nodx.Img(
+				nodx.Class("rounded bg-white p-2"),
+				nodx.Src(setup.QRCode),
+				nodx.Alt("TOTP QR code"),
+				nodx.Width("192"),
+				nodx.Height("192"),
+			),
+			nodx.Div(
+				nodx.Class("flex items-center space-x-2"),
+				nodx.CodeEl(nodx.Class("break-all"), nodx.Text(setup.Secret)),
+				component.CopyButtonSm(setup.Secret),
+			),
+		),
+		nodx.FormEl(
+			htmx.HxPost(pathutil.BuildPath("/dashboard/profile/two-factor/enable")),
+			htmx.HxDisabledELT("find button"),
+			nodx.Class("flex items-end space-x-2"),
+			component.InputControl(component.InputControlParams{
+				Name:         "code",
+				Label:        "Code",
+				Placeholder:  "123456",
+				Required:     true,
+				Type:         component.InputTypeText,
+				AutoComplete: "one-time-code",
+				Children: []nodx.Node{
+					nodx.Maxlength("20"),
+				},
+			}),
+			nodx.Button(
+				nodx.Class("btn btn-primary"),
+				nodx.Type("submit"),
+				component.SpanText("Enable"),
+				lucide.ShieldCheck(),
+			),
+		),
+	))
+}
+
+func (h *handlers) enableTwoFactorHandler(c echo.Context) error {
+	reqCtx := reqctx.GetCtx(c)
+	ctx := c.Request().Context()
+
+	code, err := bindTwoFactorCode(c)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	recoveryCodes, err := h.servs.AuthService.EnableTOTP(ctx, reqCtx.User.ID, code)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.AlertWithRefresh(c, recoveryCodesMessage(
+		"Two-factor authentication enabled.", recoveryCodes,
+	))
+}
+
+func (h *handlers) disableTwoFactorHandler(c echo.Context) error {
+	reqCtx := reqctx.GetCtx(c)
+	ctx := c.Request().Context()
+
+	code, err := bindTwoFactorCode(c)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	if err := h.servs.AuthService.DisableTOTP(ctx, reqCtx.User.ID, code); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.AlertWithRefresh(c, "Two-factor authentication disabled")
+}
+
+func (h *handlers) regenerateRecoveryCodesHandler(c echo.Context) error {
+	reqCtx := reqctx.GetCtx(c)
+	ctx := c.Request().Context()
+
+	code, err := bindTwoFactorCode(c)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	recoveryCodes, err := h.servs.AuthService.RegenerateRecoveryCodes(
+		ctx, reqCtx.User.ID, code,
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.AlertWithRefresh(c, recoveryCodesMessage(
+		"New recovery codes generated, the previous ones no longer work.",
+		recoveryCodes,
+	))
+}
+
+func bindTwoFactorCode(c echo.Context) (string, error) {
+	var formData struct {
+		Code string `form:"code" validate:"required,max=20"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return "", err
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return "", err
+	}
+	return formData.Code, nil
+}
+
+func recoveryCodesMessage(message string, recoveryCodes []string) string {
+	return fmt.Sprintf(
+		"%s Save these recovery codes in a safe place, each one can be used "+
+			"once to log in without your authenticator app and they will not "+
+			"be shown again:\n\n%s",
+		message, strings.Join(recoveryCodes, "\n"),
+	)
+}
+
+func twoFactorCard(
+	user dbgen.User, required bool, recoveryCodesLeft int64,
+) nodx.Node {
+	if !user.TotpEnabledAt.Valid {
+		return component.CardBox(component.CardBoxParams{
+			Children: []nodx.Node{
+				component.H2Text("Two-factor authentication"),
+				component.PText(
+					"Protect your account with a code of an authenticator app in " +
+						"addition to your password.",
+				),
+				nodx.If(required, nodx.Div(
+					nodx.Role("alert"),
+					nodx.Class("alert alert-warning mt-2"),
+					lucide.TriangleAlert(),
+					component.SpanText(
+						"Two-factor authentication is required by the administrators, "+
+							"set it up to continue using PG Back Web.",
+					),
+				)),
+				nodx.Div(
+					nodx.Id("two-factor-setup"),
+					nodx.Class("mt-2"),
+					nodx.Button(
+						htmx.HxPost(pathutil.BuildPath("/dashboard/profile/two-factor/setup")),
+						htmx.HxTarget("#two-factor-setup"),
+						htmx.HxDisabledELT("this"),
+						nodx.Class("btn btn-primary"),
+						component.SpanText("Set up two-factor authentication"),
+						lucide.ShieldCheck(),
+					),
+				),
+			},
+		})
+	}
+
+	return component.CardBox(component.CardBoxParams{
+		Children: []nodx.Node{
+			component.H2Text("Two-factor authentication"),
+			component.PText(fmt.Sprintf(
+				"Enabled since %s, you have %d recovery codes left.",
+				user.TotpEnabledAt.Time.Local().Format(timeutil.LayoutYYYYMMDDHHMMSSPretty),
+				recoveryCodesLeft,
+			)),
+			nodx.FormEl(
+				htmx.HxDisabledELT("find button"),
+				nodx.Class("mt-2 flex items-end space-x-2"),
+				component.InputControl(component.InputControlParams{
+					Name:         "code",
+					Label:        "Code",
+					Placeholder:  "123456",
+					Required:     true,
+					Type:         component.InputTypeText,
+					AutoComplete: "one-time-code",
+					Children: []nodx.Node{
+						nodx.Maxlength("20"),
+					},
+				}),
+				nodx.Button(
+					htmx.HxPost(pathutil.BuildPath("/dashboard/profile/two-factor/recovery-codes")),
+					nodx.Class("btn btn-neutral"),
+					nodx.Type("submit"),
+					nodx.TitleAttr("Requires a code of your authenticator app"),
+					component.SpanText("New recovery codes"),
+					lucide.RefreshCw(),
+				),
+				nodx.If(!required, nodx.Button(
+					htmx.HxPost(pathutil.BuildPath("/dashboard/profile/two-factor/disable")),
+					htmx.HxConfirm("Are you sure you want to disable two-factor authentication?"),
+					nodx.Class("btn btn-error"),
+					nodx.Type("submit"),
+					component.SpanText("Disable"),
+					lucide.ShieldOff(),
+				)),
+			),
+		},
+	})
+}
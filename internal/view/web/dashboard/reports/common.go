@@ -0,0 +1,206 @@
+package reports
+
+import (
+	"time"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/reports"
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
+	"github.com/eduardolat/pgbackweb/internal/staticdata"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	nodx "github.com/nodxdev/nodxgo"
+	alpine "github.com/nodxdev/nodxgo-alpine"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func createAndUpdateReportForm(report ...dbgen.Report) nodx.Node {
+	shouldPrefill, pickedReport := false, dbgen.Report{}
+	if len(report) > 0 {
+		shouldPrefill = true
+		pickedReport = report[0]
+	}
+
+	pickedPeriod := reports.PeriodDaily.Value.Key
+	pickedChannel := webhooks.ChannelWebhook.Value.Key
+	pickedTimeZone := time.Now().Location().String()
+	if shouldPrefill {
+		pickedPeriod = pickedReport.Period
+		pickedChannel = pickedReport.Channel
+		pickedTimeZone = pickedReport.TimeZone
+	}
+
+	return nodx.Div(
+		nodx.Class("space-y-2"),
+
+		alpine.XData(`{
+			channel: "`+pickedChannel+`",
+			urlPlaceholders: {
+				webhook: "https://example.com/report",
+				email: "mailto:ops@example.com,management@example.com",
+			},
+		}`),
+
+		component.InputControl(component.InputControlParams{
+			Name:        "name",
+			Label:       "Name",
+			Placeholder: "Weekly report",
+			Required:    true,
+			Type:        component.InputTypeText,
+			Children: []nodx.Node{
+				nodx.If(shouldPrefill, nodx.Value(pickedReport.Name)),
+			},
+		}),
+
+		component.SelectControl(component.SelectControlParams{
+			Name:     "period",
+			Label:    "Period",
+			Required: true,
+			HelpText: "How far back the report looks, the last day or the last 7 days.",
+			Children: []nodx.Node{
+				nodx.Map(
+					[]string{
+						reports.PeriodDaily.Value.Key,
+						reports.PeriodWeekly.Value.Key,
+					},
+					func(key string) nodx.Node {
+						return nodx.Option(
+							nodx.Value(key),
+							nodx.Text(reports.FullPeriods[key]),
+							nodx.If(key == pickedPeriod, nodx.Selected("")),
+						)
+					},
+				),
+			},
+		}),
+
+		component.InputControl(component.InputControlParams{
+			Name:               "cron_expression",
+			Label:              "Cron expression",
+			Placeholder:        "0 8 * * 1",
+			Required:           true,
+			Type:               component.InputTypeText,
+			HelpText:           "When the report is sent, e.g. every monday at 8:00.",
+			Pattern:            `^\S+\s+\S+\s+\S+\s+\S+\s+\S+$`,
+			HelpButtonChildren: cronExpressionHelp(),
+			Children: []nodx.Node{
+				nodx.If(shouldPrefill, nodx.Value(pickedReport.CronExpression)),
+			},
+		}),
+
+		component.SelectControl(component.SelectControlParams{
+			Name:        "time_zone",
+			Label:       "Time zone",
+			Required:    true,
+			Placeholder: "Select a time zone",
+			HelpText:    "The time zone in which the cron expression is evaluated.",
+			Children: []nodx.Node{
+				nodx.Map(
+					staticdata.Timezones,
+					func(tz staticdata.Timezone) nodx.Node {
+						return nodx.Option(
+							nodx.Value(tz.TzCode),
+							nodx.Text(tz.Label),
+							nodx.If(tz.TzCode == pickedTimeZone, nodx.Selected("")),
+						)
+					},
+				),
+			},
+		}),
+
+		component.SelectControl(component.SelectControlParams{
+			Name:     "is_active",
+			Label:    "Activate report",
+			Required: true,
+			Children: []nodx.Node{
+				nodx.Option(
+					nodx.Value("true"), nodx.Text("Yes"),
+					nodx.If(!shouldPrefill, nodx.Selected("")),
+					nodx.If(shouldPrefill && pickedReport.IsActive, nodx.Selected("")),
+				),
+				nodx.Option(
+					nodx.Value("false"), nodx.Text("No"),
+					nodx.If(shouldPrefill && !pickedReport.IsActive, nodx.Selected("")),
+				),
+			},
+		}),
+
+		component.SelectControl(component.SelectControlParams{
+			Name:               "channel",
+			Label:              "Channel",
+			Required:           true,
+			HelpButtonChildren: channelHelp(),
+			Children: []nodx.Node{
+				alpine.XModel("channel"),
+				nodx.Map(
+					reports.Channels,
+					func(key string) nodx.Node {
+						return nodx.Option(
+							nodx.Value(key),
+							nodx.Text(webhooks.FullChannels[key]),
+							nodx.If(key == pickedChannel, nodx.Selected("")),
+						)
+					},
+				),
+			},
+		}),
+
+		component.InputControl(component.InputControlParams{
+			Name:     "url",
+			Label:    "URL",
+			Required: true,
+			Type:     component.InputTypeText,
+			Children: []nodx.Node{
+				alpine.XBind("placeholder", "urlPlaceholders[channel]"),
+				nodx.If(shouldPrefill, nodx.Value(pickedReport.Url)),
+			},
+		}),
+	)
+}
+
+func cronExpressionHelp() []nodx.Node {
+	return []nodx.Node{
+		component.PText(`
+			A cron expression has five fields representing the minute, hour, day
+			of the month, month, and day of the week. For example 0 8 * * * sends
+			the report every day at 8:00 and 0 8 * * 1 every monday at 8:00.
+		`),
+
+		nodx.Div(
+			nodx.Class("mt-4 flex justify-end items-center space-x-1"),
+			nodx.A(
+				nodx.Href("https://crontab.guru/examples.html"),
+				nodx.Target("_blank"),
+				nodx.Class("btn btn-ghost"),
+				component.SpanText("Examples & common expressions"),
+				lucide.ExternalLink(),
+			),
+		),
+	}
+}
+
+func channelHelp() []nodx.Node {
+	return []nodx.Node{
+		component.H3Text("Channels"),
+		nodx.Div(
+			nodx.Class("space-y-2 mt-2"),
+			component.CardBoxSimple(
+				component.H4Text("Webhook"),
+				component.PText(`
+					The report is sent as a JSON POST request to the URL, with the
+					totals, the executions of every backup, the largest growth, the
+					stale backups, the unhealthy databases and destinations and the
+					restorations of the period.
+				`),
+			),
+			component.CardBoxSimple(
+				component.H4Text("Email"),
+				component.PText(`
+					mailto: followed by the recipients separated by commas, e.g.
+					mailto:ops@example.com,management@example.com. The email is
+					sent through the SMTP server of the email settings of the
+					webhooks, with an HTML and a text version of the report.
+				`),
+			),
+		),
+	}
+}
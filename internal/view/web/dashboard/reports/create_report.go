@@ -0,0 +1,109 @@
+package reports
+
+import (
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+type createReportDTO struct {
+	Name           string `form:"name" validate:"required"`
+	Period         string `form:"period" validate:"required,oneof=daily weekly"`
+	CronExpression string `form:"cron_expression" validate:"required"`
+	TimeZone       string `form:"time_zone" validate:"required"`
+	IsActive       string `form:"is_active" validate:"required,oneof=true false"`
+	Channel        string `form:"channel" validate:"required,oneof=webhook email"`
+	Url            string `form:"url" validate:"required"`
+}
+
+func (h *handlers) createReportHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var formData createReportDTO
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	_, err := h.servs.ReportsService.CreateReport(
+		ctx, dbgen.ReportsServiceCreateReportParams{
+			Name:           formData.Name,
+			IsActive:       formData.IsActive == "true",
+			Period:         formData.Period,
+			CronExpression: formData.CronExpression,
+			TimeZone:       formData.TimeZone,
+			Channel:        formData.Channel,
+			Url:            formData.Url,
+		},
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.Redirect(c, pathutil.BuildPath("/dashboard/reports"))
+}
+
+func (h *handlers) createReportFormHandler(c echo.Context) error {
+	return echoutil.RenderNodx(c, http.StatusOK, createReportForm())
+}
+
+func createReportForm() nodx.Node {
+	return nodx.FormEl(
+		htmx.HxPost(pathutil.BuildPath("/dashboard/reports/create")),
+		htmx.HxDisabledELT("find button[type='submit']"),
+		nodx.Class("space-y-2"),
+
+		createAndUpdateReportForm(),
+
+		nodx.Div(
+			nodx.Class("flex justify-end items-center space-x-2 pt-2"),
+			component.HxLoadingMd(),
+			nodx.Button(
+				nodx.Class("btn btn-primary"),
+				nodx.Type("submit"),
+				component.SpanText("Save"),
+				lucide.Save(),
+			),
+		),
+	)
+}
+
+func createReportButton() nodx.Node {
+	mo := component.Modal(component.ModalParams{
+		Size:  component.SizeMd,
+		Title: "Create report",
+		Content: []nodx.Node{
+			nodx.Div(
+				htmx.HxGet(pathutil.BuildPath("/dashboard/reports/create")),
+				htmx.HxSwap("outerHTML"),
+				htmx.HxTrigger("intersect once"),
+				nodx.Class("p-10 flex justify-center"),
+				component.HxLoadingMd(),
+			),
+		},
+	})
+
+	button := nodx.Button(
+		mo.OpenerAttr,
+		nodx.Class("btn btn-primary"),
+		component.SpanText("Create report"),
+		lucide.Plus(),
+	)
+
+	return nodx.Div(
+		nodx.Class("inline-block"),
+		mo.HTML,
+		button,
+	)
+}
@@ -0,0 +1,38 @@
+package reports
+
+import (
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) deleteReportHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	reportID, err := uuid.Parse(c.Param("reportID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	if err = h.servs.ReportsService.DeleteReport(ctx, reportID); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.Refresh(c)
+}
+
+func deleteReportButton(reportID uuid.UUID) nodx.Node {
+	return component.OptionsDropdownButton(
+		htmx.HxDelete(pathutil.BuildPath(fmt.Sprintf("/dashboard/reports/%s", reportID))),
+		htmx.HxConfirm("Are you sure you want to delete this report?"),
+		lucide.Trash(),
+		component.SpanText("Delete report"),
+	)
+}
@@ -0,0 +1,124 @@
+package reports
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+type editReportDTO struct {
+	Name           string `form:"name" validate:"required"`
+	Period         string `form:"period" validate:"required,oneof=daily weekly"`
+	CronExpression string `form:"cron_expression" validate:"required"`
+	TimeZone       string `form:"time_zone" validate:"required"`
+	IsActive       string `form:"is_active" validate:"required,oneof=true false"`
+	Channel        string `form:"channel" validate:"required,oneof=webhook email"`
+	Url            string `form:"url" validate:"required"`
+}
+
+func (h *handlers) editReportHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	reportID, err := uuid.Parse(c.Param("reportID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	var formData editReportDTO
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	_, err = h.servs.ReportsService.UpdateReport(
+		ctx, dbgen.ReportsServiceUpdateReportParams{
+			ReportID:       reportID,
+			Name:           sql.NullString{String: formData.Name, Valid: true},
+			IsActive:       sql.NullBool{Bool: formData.IsActive == "true", Valid: true},
+			Period:         sql.NullString{String: formData.Period, Valid: true},
+			CronExpression: sql.NullString{String: formData.CronExpression, Valid: true},
+			TimeZone:       sql.NullString{String: formData.TimeZone, Valid: true},
+			Channel:        sql.NullString{String: formData.Channel, Valid: true},
+			Url:            sql.NullString{String: formData.Url, Valid: true},
+		},
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.AlertWithRefresh(c, "Report updated")
+}
+
+func (h *handlers) editReportFormHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	reportID, err := uuid.Parse(c.Param("reportID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	report, err := h.servs.ReportsService.GetReport(ctx, reportID)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return echoutil.RenderNodx(c, http.StatusOK, editReportForm(report))
+}
+
+func editReportForm(report dbgen.Report) nodx.Node {
+	return nodx.FormEl(
+		htmx.HxPost(pathutil.BuildPath(fmt.Sprintf("/dashboard/reports/%s/edit", report.ID))),
+		htmx.HxDisabledELT("find button[type='submit']"),
+		nodx.Class("space-y-2"),
+
+		createAndUpdateReportForm(report),
+
+		nodx.Div(
+			nodx.Class("flex justify-end items-center space-x-2 pt-2"),
+			component.HxLoadingMd(),
+			nodx.Button(
+				nodx.Class("btn btn-primary"),
+				nodx.Type("submit"),
+				component.SpanText("Save"),
+				lucide.Save(),
+			),
+		),
+	)
+}
+
+func editReportButton(reportID uuid.UUID) nodx.Node {
+	mo := component.Modal(component.ModalParams{
+		Size:  component.SizeMd,
+		Title: "Edit report",
+		Content: []nodx.Node{
+			nodx.Div(
+				htmx.HxGet(pathutil.BuildPath(fmt.Sprintf("/dashboard/reports/%s/edit", reportID))),
+				htmx.HxSwap("outerHTML"),
+				htmx.HxTrigger("intersect once"),
+				nodx.Class("p-10 flex justify-center"),
+				component.HxLoadingMd(),
+			),
+		},
+	})
+
+	return nodx.Div(
+		mo.HTML,
+		component.OptionsDropdownButton(
+			mo.OpenerAttr,
+			lucide.Pencil(),
+			component.SpanText("Edit report"),
+		),
+	)
+}
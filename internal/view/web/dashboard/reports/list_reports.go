@@ -0,0 +1,128 @@
+package reports
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/reports"
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/util/strutil"
+	"github.com/eduardolat/pgbackweb/internal/util/timeutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func (h *handlers) listReportsHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var formData struct {
+		Page int `query:"page" validate:"required,min=1"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	pagination, reps, err := h.servs.ReportsService.PaginateReports(
+		ctx, reports.PaginateReportsParams{
+			Page:  formData.Page,
+			Limit: 20,
+		},
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return echoutil.RenderNodx(
+		c, http.StatusOK, listReports(pagination, reps),
+	)
+}
+
+func listReports(
+	pagination paginateutil.PaginateResponse,
+	reps []dbgen.Report,
+) nodx.Node {
+	if len(reps) < 1 {
+		return component.EmptyResultsTr(component.EmptyResultsParams{
+			Title:    "No reports found",
+			Subtitle: "Create a report to receive a digest of the backup activity",
+		})
+	}
+
+	trs := []nodx.Node{}
+	for _, report := range reps {
+		trs = append(trs, nodx.Tr(
+			nodx.Td(component.OptionsDropdown(
+				sendReportButton(report.ID),
+				nodx.If(!report.IsProvisioned, editReportButton(report.ID)),
+				nodx.If(!report.IsProvisioned, deleteReportButton(report.ID)),
+			)),
+			nodx.Td(
+				nodx.Div(
+					nodx.Class("flex items-center space-x-2"),
+					component.IsActivePing(report.IsActive),
+					component.SpanText(report.Name),
+					component.ProvisionedBadge(report.IsProvisioned),
+				),
+			),
+			nodx.Td(component.SpanText(reports.FullPeriods[report.Period])),
+			nodx.Td(component.SpanText(
+				fmt.Sprintf("%s (%s)", report.CronExpression, report.TimeZone),
+			)),
+			nodx.Td(component.SpanText(webhooks.FullChannels[report.Channel])),
+			nodx.Td(lastSent(report)),
+			nodx.Td(component.SpanText(
+				report.CreatedAt.Local().Format(timeutil.LayoutYYYYMMDDHHMMSSPretty),
+			)),
+		))
+	}
+
+	if pagination.HasNextPage {
+		trs = append(trs, nodx.Tr(
+			htmx.HxGet(func() string {
+				url := pathutil.BuildPath("/dashboard/reports/list")
+				url = strutil.AddQueryParamToUrl(url, "page", fmt.Sprintf("%d", pagination.NextPage))
+				return url
+			}()),
+			htmx.HxTrigger("intersect once"),
+			htmx.HxSwap("afterend"),
+		))
+	}
+
+	return component.RenderableGroup(trs)
+}
+
+// lastSent shows when the report was last sent and whether it failed, with
+// the error in a tooltip.
+func lastSent(report dbgen.Report) nodx.Node {
+	if !report.LastSentAt.Valid {
+		return component.SpanText("Never")
+	}
+
+	status := "success"
+	if report.LastError.Valid {
+		status = "failed"
+	}
+
+	return nodx.Div(
+		nodx.Class("flex items-center space-x-2"),
+		nodx.Div(
+			nodx.ClassMap{"tooltip tooltip-right": report.LastError.Valid},
+			nodx.If(report.LastError.Valid, nodx.Data("tip", report.LastError.String)),
+			component.StatusBadge(status),
+		),
+		component.SpanText(
+			report.LastSentAt.Time.Local().Format(timeutil.LayoutYYYYMMDDHHMMSSPretty),
+		),
+	)
+}
@@ -0,0 +1,34 @@
+package reports
+
+import (
+	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/view/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+type handlers struct {
+	servs *service.Service
+}
+
+func newHandlers(servs *service.Service) *handlers {
+	return &handlers{servs: servs}
+}
+
+func MountRouter(
+	parent *echo.Group, mids *middleware.Middleware, servs *service.Service,
+) {
+	h := newHandlers(servs)
+
+	run := mids.RequirePermission(users.PermissionRun)
+	manage := mids.RequirePermission(users.PermissionManage)
+
+	parent.GET("", h.indexPageHandler)
+	parent.GET("/list", h.listReportsHandler)
+	parent.GET("/create", h.createReportFormHandler, manage)
+	parent.POST("/create", h.createReportHandler, manage)
+	parent.GET("/:reportID/edit", h.editReportFormHandler, manage)
+	parent.POST("/:reportID/edit", h.editReportHandler, manage)
+	parent.POST("/:reportID/send", h.sendReportHandler, run)
+	parent.DELETE("/:reportID", h.deleteReportHandler, manage)
+}
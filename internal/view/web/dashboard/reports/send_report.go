@@ -0,0 +1,38 @@
+package reports
+
+import (
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) sendReportHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	reportID, err := uuid.Parse(c.Param("reportID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	if err := h.servs.ReportsService.SendReport(ctx, reportID); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.AlertWithRefresh(c, "Report sent")
+}
+
+func sendReportButton(reportID uuid.UUID) nodx.Node {
+	return component.OptionsDropdownButton(
+		htmx.HxPost(pathutil.BuildPath(fmt.Sprintf("/dashboard/reports/%s/send", reportID))),
+		htmx.HxDisabledELT("this"),
+		lucide.Send(),
+		component.SpanText("Send now"),
+	)
+}
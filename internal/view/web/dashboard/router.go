@@ -4,14 +4,19 @@ import (
 	"github.com/eduardolat/pgbackweb/internal/service"
 	"github.com/eduardolat/pgbackweb/internal/view/middleware"
 	"github.com/eduardolat/pgbackweb/internal/view/web/dashboard/about"
+	"github.com/eduardolat/pgbackweb/internal/view/web/dashboard/auditlog"
+	"github.com/eduardolat/pgbackweb/internal/view/web/dashboard/backupgroups"
 	"github.com/eduardolat/pgbackweb/internal/view/web/dashboard/backups"
 	"github.com/eduardolat/pgbackweb/internal/view/web/dashboard/databases"
 	"github.com/eduardolat/pgbackweb/internal/view/web/dashboard/destinations"
 	"github.com/eduardolat/pgbackweb/internal/view/web/dashboard/executions"
 	"github.com/eduardolat/pgbackweb/internal/view/web/dashboard/profile"
+	"github.com/eduardolat/pgbackweb/internal/view/web/dashboard/reports"
 	"github.com/eduardolat/pgbackweb/internal/view/web/dashboard/restorations"
 	"github.com/eduardolat/pgbackweb/internal/view/web/dashboard/summary"
+	"github.com/eduardolat/pgbackweb/internal/view/web/dashboard/users"
 	"github.com/eduardolat/pgbackweb/internal/view/web/dashboard/webhooks"
+	"github.com/eduardolat/pgbackweb/internal/view/web/dashboard/workspaces"
 	"github.com/labstack/echo/v4"
 )
 
@@ -19,14 +24,21 @@ func MountRouter(
 	parent *echo.Group, mids *middleware.Middleware, servs *service.Service,
 ) {
 	parent.GET("/health-button", healthButtonHandler(servs))
+	parent.GET("/workspace-switcher", workspaceSwitcherHandler)
+	parent.POST("/workspace", selectWorkspaceHandler(servs))
 
 	summary.MountRouter(parent.Group(""), mids, servs)
 	databases.MountRouter(parent.Group("/databases"), mids, servs)
 	destinations.MountRouter(parent.Group("/destinations"), mids, servs)
 	backups.MountRouter(parent.Group("/backups"), mids, servs)
+	backupgroups.MountRouter(parent.Group("/backup-groups"), mids, servs)
 	executions.MountRouter(parent.Group("/executions"), mids, servs)
 	restorations.MountRouter(parent.Group("/restorations"), mids, servs)
 	webhooks.MountRouter(parent.Group("/webhooks"), mids, servs)
+	reports.MountRouter(parent.Group("/reports"), mids, servs)
+	users.MountRouter(parent.Group("/users"), mids, servs)
+	workspaces.MountRouter(parent.Group("/workspaces"), mids, servs)
+	auditlog.MountRouter(parent.Group("/audit-log"), mids, servs)
 	profile.MountRouter(parent.Group("/profile"), mids, servs)
 	about.MountRouter(parent.Group("/about"), mids, servs)
 }
@@ -38,12 +38,20 @@ func (h *handlers) indexPageHandler(c echo.Context) error {
 	if err != nil {
 		return c.String(http.StatusInternalServerError, err.Error())
 	}
+	staleBackups, err := h.servs.BackupsService.GetStaleBackups(ctx)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+	workspacesQty, err := h.servs.WorkspacesService.GetWorkspacesSummary(ctx)
+	if err != nil {
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
 
 	return echoutil.RenderNodx(
 		c, http.StatusOK,
 		indexPage(
 			reqCtx, databasesQty, destinationsQty, backupsQty, executionsQty,
-			restorationsQty,
+			restorationsQty, staleBackups, workspacesQty,
 		),
 	)
 }
@@ -55,6 +63,8 @@ func indexPage(
 	backupsQty dbgen.BackupsServiceGetBackupsQtyRow,
 	executionsQty dbgen.ExecutionsServiceGetExecutionsQtyRow,
 	restorationsQty dbgen.RestorationsServiceGetRestorationsQtyRow,
+	staleBackups []dbgen.Backup,
+	workspacesQty []dbgen.WorkspacesServiceGetWorkspacesSummaryRow,
 ) nodx.Node {
 	type ChartData struct {
 		Label    string
@@ -157,6 +167,8 @@ func indexPage(
 		nodx.Div(
 			component.H1Text("Summary"),
 		),
+		staleBackupsAlert(staleBackups),
+		workspacesSummary(workspacesQty),
 		nodx.Div(
 			nodx.Class("mt-4 flex justify-start flex-wrap gap-4"),
 
@@ -172,11 +184,16 @@ func indexPage(
 				Data:     []int32{destinationsQty.Healthy, destinationsQty.Unhealthy},
 				BgColors: []string{greenColor, redColor},
 			}),
+			// The stale backups are active too, they are left out of the active
+			// slice so every backup is counted once.
 			countCard("Backup tasks", backupsQty.All, ChartData{
-				Label:    "Quantity",
-				Labels:   []string{"Active", "Inactive"},
-				Data:     []int32{backupsQty.Active, backupsQty.Inactive},
-				BgColors: []string{greenColor, redColor},
+				Label:  "Quantity",
+				Labels: []string{"Active", "Stale", "Inactive"},
+				Data: []int32{
+					backupsQty.Active - backupsQty.Stale, backupsQty.Stale,
+					backupsQty.Inactive,
+				},
+				BgColors: []string{greenColor, yellowColor, redColor},
 			}),
 			countCard("Executions", executionsQty.All, ChartData{
 				Label:  "Status",
@@ -0,0 +1,49 @@
+package summary
+
+import (
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	nodx "github.com/nodxdev/nodxgo"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func staleBackupsAlert(staleBackups []dbgen.Backup) nodx.Node {
+	return nodx.If(len(staleBackups) > 0, nodx.Div(
+		nodx.Class("mt-4"),
+		nodx.Div(
+			nodx.Role("alert"),
+			nodx.Class("alert alert-warning"),
+			lucide.TriangleAlert(),
+			nodx.Div(
+				nodx.P(
+					component.BText(fmt.Sprintf(
+						"%d backup task(s) without a successful execution within their RPO",
+						len(staleBackups),
+					)),
+				),
+				nodx.Ul(
+					nodx.Class("list-disc list-inside"),
+					nodx.Map(
+						staleBackups,
+						func(backup dbgen.Backup) nodx.Node {
+							return nodx.Li(
+								nodx.A(
+									nodx.Class("link"),
+									nodx.Href(pathutil.BuildPath(
+										"/dashboard/executions?backup="+backup.ID.String(),
+									)),
+									nodx.Text(fmt.Sprintf(
+										"%s (RPO %dh)", backup.Name, backup.RpoHours.Int16,
+									)),
+								),
+							)
+						},
+					),
+				),
+			),
+		),
+	))
+}
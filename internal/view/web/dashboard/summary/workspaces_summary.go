@@ -0,0 +1,62 @@
+package summary
+
+import (
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+func workspacesSummary(
+	summary []dbgen.WorkspacesServiceGetWorkspacesSummaryRow,
+) nodx.Node {
+	countCell := func(all, failing int64) nodx.Node {
+		return nodx.Td(
+			component.SpanText(fmt.Sprintf("%d", all)),
+			nodx.If(
+				failing > 0,
+				nodx.SpanEl(
+					nodx.Class("badge badge-error ml-2"),
+					nodx.Textf("%d", failing),
+				),
+			),
+		)
+	}
+
+	return nodx.If(len(summary) > 1, nodx.Div(
+		nodx.Class("mt-4"),
+		component.CardBox(component.CardBoxParams{
+			Children: []nodx.Node{
+				component.H2Text("Workspaces"),
+				nodx.Div(
+					nodx.Class("overflow-x-auto"),
+					nodx.Table(
+						nodx.Class("table text-nowrap"),
+						nodx.Thead(
+							nodx.Tr(
+								nodx.Th(component.SpanText("Workspace")),
+								nodx.Th(component.SpanText("Databases (unhealthy)")),
+								nodx.Th(component.SpanText("Destinations (unhealthy)")),
+								nodx.Th(component.SpanText("Backup tasks (stale)")),
+							),
+						),
+						nodx.Tbody(
+							nodx.Map(
+								summary,
+								func(ws dbgen.WorkspacesServiceGetWorkspacesSummaryRow) nodx.Node {
+									return nodx.Tr(
+										nodx.Td(component.SpanText(ws.Name)),
+										countCell(ws.Databases, ws.UnhealthyDatabases),
+										countCell(ws.Destinations, ws.UnhealthyDestinations),
+										countCell(ws.Backups, ws.StaleBackups),
+									)
+								},
+							),
+						),
+					),
+				),
+			},
+		}),
+	))
+}
@@ -0,0 +1,57 @@
+package users
+
+import (
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func (h *handlers) changeUserRoleHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	reqCtx := reqctx.GetCtx(c)
+
+	userID, err := uuid.Parse(c.Param("userID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if userID == reqCtx.User.ID {
+		return respondhtmx.ToastError(c, "You can't change your own role")
+	}
+
+	var formData struct {
+		Role string `form:"role" validate:"required,oneof=admin operator viewer"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	_, err = h.servs.UsersService.ChangeUserRole(ctx, userID, formData.Role)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.ToastSuccess(c, "Role updated")
+}
+
+func roleSelect(user dbgen.User, disabled bool) nodx.Node {
+	return nodx.Select(
+		htmx.HxPost(pathutil.BuildPath(fmt.Sprintf("/dashboard/users/%s/role", user.ID))),
+		htmx.HxTrigger("change"),
+		nodx.Class("select select-bordered select-sm"),
+		nodx.Name("role"),
+		nodx.If(disabled, nodx.Disabled("")),
+		component.RoleSelectOptions(user.Role),
+	)
+}
@@ -0,0 +1,43 @@
+package users
+
+import (
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) deleteUserHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	reqCtx := reqctx.GetCtx(c)
+
+	userID, err := uuid.Parse(c.Param("userID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if userID == reqCtx.User.ID {
+		return respondhtmx.ToastError(c, "You can't delete your own user")
+	}
+
+	if err = h.servs.UsersService.DeleteUser(ctx, userID); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.Refresh(c)
+}
+
+func deleteUserButton(userID uuid.UUID) nodx.Node {
+	return component.OptionsDropdownButton(
+		htmx.HxDelete(pathutil.BuildPath(fmt.Sprintf("/dashboard/users/%s", userID))),
+		htmx.HxConfirm("Are you sure you want to delete this user?"),
+		lucide.Trash(),
+		component.SpanText("Delete user"),
+	)
+}
@@ -0,0 +1,127 @@
+package users
+
+import (
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/timeutil"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/layout"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+func (h *handlers) indexPageHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	reqCtx := reqctx.GetCtx(c)
+
+	allUsers, err := h.servs.UsersService.GetAllUsers(ctx)
+	if err != nil {
+		logger.Error("failed to get users", logger.KV{"err": err})
+		return c.String(http.StatusInternalServerError, "failed to get users")
+	}
+
+	allWorkspaces, err := h.servs.WorkspacesService.GetAllWorkspaces(ctx)
+	if err != nil {
+		logger.Error("failed to get workspaces", logger.KV{"err": err})
+		return c.String(http.StatusInternalServerError, "failed to get workspaces")
+	}
+
+	twoFactorRequired, err := h.servs.AuthService.IsTwoFactorRequired(ctx)
+	if err != nil {
+		logger.Error("failed to get two-factor requirement", logger.KV{"err": err})
+		return c.String(http.StatusInternalServerError, "failed to get two-factor requirement")
+	}
+
+	return echoutil.RenderNodx(
+		c, http.StatusOK, indexPage(
+			reqCtx, allUsers, allWorkspaces, twoFactorRequired,
+		),
+	)
+}
+
+func indexPage(
+	reqCtx reqctx.Ctx, allUsers []dbgen.User, allWorkspaces []dbgen.Workspace,
+	twoFactorRequired bool,
+) nodx.Node {
+	content := []nodx.Node{
+		component.H1Text("Users"),
+
+		nodx.Div(
+			nodx.Class("mt-4 space-y-4"),
+			inviteUserCard(allWorkspaces),
+			requireTwoFactorCard(twoFactorRequired),
+
+			component.CardBox(component.CardBoxParams{
+				Children: []nodx.Node{
+					nodx.Div(
+						nodx.Class("overflow-x-auto"),
+						nodx.Table(
+							nodx.Class("table text-nowrap"),
+							nodx.Thead(
+								nodx.Tr(
+									nodx.Th(nodx.Class("w-1")),
+									nodx.Th(component.SpanText("Name")),
+									nodx.Th(component.SpanText("Email")),
+									nodx.Th(component.SpanText("Role")),
+									nodx.Th(component.SpanText("Status")),
+									nodx.Th(component.SpanText("Two-factor")),
+									nodx.Th(component.SpanText("Created at")),
+								),
+							),
+							nodx.Tbody(
+								nodx.Map(allUsers, func(user dbgen.User) nodx.Node {
+									return userRow(reqCtx, user)
+								}),
+							),
+						),
+					),
+				},
+			}),
+		),
+	}
+
+	return layout.Dashboard(reqCtx, layout.DashboardParams{
+		Title: "Users",
+		Body:  content,
+	})
+}
+
+func userRow(reqCtx reqctx.Ctx, user dbgen.User) nodx.Node {
+	isCurrentUser := user.ID == reqCtx.User.ID
+
+	status := "Active"
+	if user.DisabledAt.Valid {
+		status = "Disabled"
+	}
+
+	twoFactor := "Disabled"
+	if user.TotpEnabledAt.Valid {
+		twoFactor = "Enabled"
+	}
+
+	return nodx.Tr(
+		nodx.Td(
+			nodx.If(
+				!isCurrentUser,
+				component.OptionsDropdown(
+					nodx.If(!user.DisabledAt.Valid, disableUserButton(user.ID)),
+					nodx.If(user.DisabledAt.Valid, enableUserButton(user.ID)),
+					nodx.If(user.TotpEnabledAt.Valid, resetTwoFactorButton(user.ID)),
+					deleteUserButton(user.ID),
+				),
+			),
+		),
+		nodx.Td(component.SpanText(user.Name)),
+		nodx.Td(component.SpanText(user.Email)),
+		nodx.Td(roleSelect(user, isCurrentUser)),
+		nodx.Td(component.SpanText(status)),
+		nodx.Td(component.SpanText(twoFactor)),
+		nodx.Td(component.SpanText(
+			user.CreatedAt.Local().Format(timeutil.LayoutYYYYMMDDHHMMSSPretty),
+		)),
+	)
+}
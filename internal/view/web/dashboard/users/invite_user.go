@@ -0,0 +1,131 @@
+package users
+
+import (
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) inviteUserHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var formData struct {
+		Name        string    `form:"name" validate:"required"`
+		Email       string    `form:"email" validate:"required,email"`
+		Role        string    `form:"role" validate:"required,oneof=admin operator viewer"`
+		WorkspaceID uuid.UUID `form:"workspace_id" validate:"omitempty,uuid"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	user, password, err := h.servs.UsersService.InviteUser(
+		ctx, formData.Name, formData.Email, formData.Role,
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	// Admins act as admins in every workspace, the other users only see the
+	// workspaces they are members of.
+	if formData.Role != users.RoleAdmin && formData.WorkspaceID != uuid.Nil {
+		_, err = h.servs.WorkspacesService.SetMember(
+			ctx, formData.WorkspaceID, user.ID, formData.Role,
+		)
+		if err != nil {
+			return respondhtmx.ToastError(c, err.Error())
+		}
+	}
+
+	return respondhtmx.AlertWithRefresh(c, fmt.Sprintf(
+		"User invited, share this temporary password with %s because it will "+
+			"not be shown again, it can be changed from the profile page:\n\n%s",
+		user.Email, password,
+	))
+}
+
+func inviteUserCard(allWorkspaces []dbgen.Workspace) nodx.Node {
+	return component.CardBox(component.CardBoxParams{
+		Children: []nodx.Node{
+			nodx.FormEl(
+				htmx.HxPost(pathutil.BuildPath("/dashboard/users")),
+				htmx.HxDisabledELT("find button"),
+				nodx.Class("space-y-2"),
+
+				component.H2Text("Invite user"),
+				component.PText(
+					"Admins can do everything, operators can also run backups, "+
+						"restorations and tests, and viewers have read-only access "+
+						"without the connection strings and keys. Operators and "+
+						"viewers are added to the selected workspace with that role.",
+				),
+
+				nodx.Div(
+					nodx.Class("grid grid-cols-4 gap-2"),
+
+					component.InputControl(component.InputControlParams{
+						Name:        "name",
+						Label:       "Name",
+						Placeholder: "John Doe",
+						Required:    true,
+						Type:        component.InputTypeText,
+					}),
+
+					component.InputControl(component.InputControlParams{
+						Name:         "email",
+						Label:        "Email",
+						Placeholder:  "john@example.com",
+						Required:     true,
+						Type:         component.InputTypeEmail,
+						AutoComplete: "off",
+					}),
+
+					component.SelectControl(component.SelectControlParams{
+						Name:     "role",
+						Label:    "Role",
+						Required: true,
+						Children: []nodx.Node{component.RoleSelectOptions(users.RoleViewer)},
+					}),
+
+					component.SelectControl(component.SelectControlParams{
+						Name:  "workspace_id",
+						Label: "Workspace",
+						Children: []nodx.Node{
+							nodx.Map(allWorkspaces, func(ws dbgen.Workspace) nodx.Node {
+								return nodx.Option(
+									nodx.Value(ws.ID.String()),
+									nodx.Text(ws.Name),
+									nodx.If(ws.IsDefault, nodx.Selected("")),
+								)
+							}),
+						},
+					}),
+				),
+
+				nodx.Div(
+					nodx.Class("flex justify-end items-center space-x-2 pt-2"),
+					component.HxLoadingMd(),
+					nodx.Button(
+						nodx.Class("btn btn-primary"),
+						nodx.Type("submit"),
+						component.SpanText("Invite user"),
+						lucide.UserPlus(),
+					),
+				),
+			),
+		},
+	})
+}
@@ -0,0 +1,33 @@
+package users
+
+import (
+	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/view/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+type handlers struct {
+	servs *service.Service
+}
+
+func newHandlers(servs *service.Service) *handlers {
+	return &handlers{servs: servs}
+}
+
+func MountRouter(
+	parent *echo.Group, mids *middleware.Middleware, servs *service.Service,
+) {
+	h := newHandlers(servs)
+
+	parent.Use(mids.RequirePermission(users.PermissionManageInstance))
+
+	parent.GET("", h.indexPageHandler)
+	parent.POST("", h.inviteUserHandler)
+	parent.POST("/two-factor", h.requireTwoFactorHandler)
+	parent.POST("/:userID/role", h.changeUserRoleHandler)
+	parent.POST("/:userID/disable", h.disableUserHandler)
+	parent.POST("/:userID/enable", h.enableUserHandler)
+	parent.POST("/:userID/two-factor/reset", h.resetTwoFactorHandler)
+	parent.DELETE("/:userID", h.deleteUserHandler)
+}
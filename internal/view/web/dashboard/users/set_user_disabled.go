@@ -0,0 +1,60 @@
+package users
+
+import (
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) disableUserHandler(c echo.Context) error {
+	return h.setUserDisabled(c, true)
+}
+
+func (h *handlers) enableUserHandler(c echo.Context) error {
+	return h.setUserDisabled(c, false)
+}
+
+func (h *handlers) setUserDisabled(c echo.Context, disabled bool) error {
+	ctx := c.Request().Context()
+	reqCtx := reqctx.GetCtx(c)
+
+	userID, err := uuid.Parse(c.Param("userID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if userID == reqCtx.User.ID {
+		return respondhtmx.ToastError(c, "You can't disable your own user")
+	}
+
+	_, err = h.servs.UsersService.SetUserDisabled(ctx, userID, disabled)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.Refresh(c)
+}
+
+func disableUserButton(userID uuid.UUID) nodx.Node {
+	return component.OptionsDropdownButton(
+		htmx.HxPost(pathutil.BuildPath(fmt.Sprintf("/dashboard/users/%s/disable", userID))),
+		htmx.HxConfirm("Are you sure you want to disable this user? Their sessions will be closed."),
+		lucide.UserX(),
+		component.SpanText("Disable user"),
+	)
+}
+
+func enableUserButton(userID uuid.UUID) nodx.Node {
+	return component.OptionsDropdownButton(
+		htmx.HxPost(pathutil.BuildPath(fmt.Sprintf("/dashboard/users/%s/enable", userID))),
+		lucide.UserCheck(),
+		component.SpanText("Enable user"),
+	)
+}
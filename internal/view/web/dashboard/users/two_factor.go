@@ -0,0 +1,90 @@
+package users
+
+import (
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) resetTwoFactorHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := uuid.Parse(c.Param("userID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	_, err = h.servs.UsersService.ResetTwoFactor(ctx, userID)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.Refresh(c)
+}
+
+func (h *handlers) requireTwoFactorHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var formData struct {
+		Required bool `form:"required"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	err := h.servs.UsersService.SetRequireTwoFactor(ctx, formData.Required)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.Refresh(c)
+}
+
+func resetTwoFactorButton(userID uuid.UUID) nodx.Node {
+	return component.OptionsDropdownButton(
+		htmx.HxPost(pathutil.BuildPath(fmt.Sprintf("/dashboard/users/%s/two-factor/reset", userID))),
+		htmx.HxConfirm("Are you sure you want to reset the two-factor authentication of this user? They will be able to log in with their password only until they set it up again."),
+		lucide.ShieldOff(),
+		component.SpanText("Reset two-factor authentication"),
+	)
+}
+
+func requireTwoFactorCard(required bool) nodx.Node {
+	description := "Users can choose whether to set up two-factor authentication " +
+		"from their profile page."
+	buttonText := "Require for everyone"
+	if required {
+		description = "Two-factor authentication is required for everyone, users " +
+			"without it have to set it up before using the dashboard."
+		buttonText = "Stop requiring"
+	}
+
+	return component.CardBox(component.CardBoxParams{
+		Children: []nodx.Node{
+			component.H2Text("Two-factor authentication"),
+			nodx.Div(
+				nodx.Class("flex justify-between items-center space-x-2"),
+				component.PText(description),
+				nodx.Button(
+					htmx.HxPost(pathutil.BuildPath("/dashboard/users/two-factor")),
+					htmx.HxVals(fmt.Sprintf(`{"required": %t}`, !required)),
+					htmx.HxDisabledELT("this"),
+					nodx.Class("btn btn-neutral"),
+					component.SpanText(buttonText),
+					lucide.ShieldCheck(),
+				),
+			),
+		},
+	})
+}
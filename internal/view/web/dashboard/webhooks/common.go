@@ -120,12 +120,20 @@ func createAndUpdateWebhookForm(
 	})
 
 	eventTypeSelects := map[string]nodx.Node{
-		webhooks.EventTypeDatabaseHealthy.Value.Key:      databaseSelect,
-		webhooks.EventTypeDatabaseUnhealthy.Value.Key:    databaseSelect,
-		webhooks.EventTypeDestinationHealthy.Value.Key:   destinationSelect,
-		webhooks.EventTypeDestinationUnhealthy.Value.Key: destinationSelect,
-		webhooks.EventTypeExecutionSuccess.Value.Key:     backupSelect,
-		webhooks.EventTypeExecutionFailed.Value.Key:      backupSelect,
+		webhooks.EventTypeDatabaseHealthy.Value.Key:             databaseSelect,
+		webhooks.EventTypeDatabaseUnhealthy.Value.Key:           databaseSelect,
+		webhooks.EventTypeRestorationStarted.Value.Key:          databaseSelect,
+		webhooks.EventTypeRestorationSuccess.Value.Key:          databaseSelect,
+		webhooks.EventTypeRestorationFailed.Value.Key:           databaseSelect,
+		webhooks.EventTypeDestinationHealthy.Value.Key:          destinationSelect,
+		webhooks.EventTypeDestinationUnhealthy.Value.Key:        destinationSelect,
+		webhooks.EventTypeDestinationUploadSlow.Value.Key:       destinationSelect,
+		webhooks.EventTypeExecutionStarted.Value.Key:            backupSelect,
+		webhooks.EventTypeExecutionSuccess.Value.Key:            backupSelect,
+		webhooks.EventTypeExecutionFailed.Value.Key:             backupSelect,
+		webhooks.EventTypeExecutionSuspicious.Value.Key:         backupSelect,
+		webhooks.EventTypeExecutionDeletedByRetention.Value.Key: backupSelect,
+		webhooks.EventTypeBackupStale.Value.Key:                 backupSelect,
 	}
 
 	targetIdsSelect := []nodx.Node{}
@@ -136,6 +144,11 @@ func createAndUpdateWebhookForm(
 		))
 	}
 
+	pickedChannel := webhooks.ChannelWebhook.Value.Key
+	if shouldPrefill {
+		pickedChannel = pickedWebhook.Channel
+	}
+
 	pickedTargetIds := ""
 	if len(pickedWebhook.TargetIds) > 0 {
 		for _, tid := range pickedWebhook.TargetIds {
@@ -149,6 +162,15 @@ func createAndUpdateWebhookForm(
 		alpine.XData(`{
 			eventType: "`+pickedWebhook.EventType+`",
 			targetIds: [`+pickedTargetIds+`],
+			channel: "`+pickedChannel+`",
+			urlPlaceholders: {
+				webhook: "https://example.com/webhook",
+				slack: "https://hooks.slack.com/services/T000/B000/XXXX",
+				discord: "https://discord.com/api/webhooks/000/XXXX",
+				teams: "https://prod-00.westus.logic.azure.com/workflows/...",
+				telegram: "https://api.telegram.org/bot<token>/sendMessage?chat_id=<chat id>",
+				email: "mailto:ops@example.com,oncall@example.com",
+			},
 
 			isEventType(eventType) {
 				return this.eventType === eventType
@@ -256,6 +278,25 @@ func createAndUpdateWebhookForm(
 							This event will be triggered when a backup execution fails.
 						`),
 					),
+
+					component.CardBoxSimple(
+						component.H4Text("Execution suspicious"),
+						component.PText(`
+							This event will be triggered when a backup execution is
+							successful but its file size deviates from the recent
+							executions of the same backup more than the configured
+							threshold.
+						`),
+					),
+
+					component.CardBoxSimple(
+						component.H4Text("Backup stale"),
+						component.PText(`
+							This event will be triggered when a backup with a RPO
+							configured has not had a successful execution within it.
+							It is triggered once until the backup succeeds again.
+						`),
+					),
 				),
 			},
 			Children: []nodx.Node{
@@ -283,70 +324,209 @@ func createAndUpdateWebhookForm(
 			},
 		}),
 
-		component.InputControl(component.InputControlParams{
-			Name:        "url",
-			Label:       "URL",
-			Placeholder: "https://example.com/webhook",
-			Required:    true,
-			Type:        component.InputTypeUrl,
+		component.SelectControl(component.SelectControlParams{
+			Name:               "channel",
+			Label:              "Channel",
+			Required:           true,
+			HelpButtonChildren: channelHelp(),
 			Children: []nodx.Node{
-				nodx.If(shouldPrefill, nodx.Value(pickedWebhook.Url)),
+				alpine.XModel("channel"),
+				nodx.Map(
+					[]string{
+						webhooks.ChannelWebhook.Value.Key,
+						webhooks.ChannelSlack.Value.Key,
+						webhooks.ChannelDiscord.Value.Key,
+						webhooks.ChannelTeams.Value.Key,
+						webhooks.ChannelTelegram.Value.Key,
+						webhooks.ChannelEmail.Value.Key,
+					},
+					func(key string) nodx.Node {
+						return nodx.Option(
+							nodx.Value(key),
+							nodx.Text(webhooks.FullChannels[key]),
+							nodx.If(key == pickedChannel, nodx.Selected("")),
+						)
+					},
+				),
 			},
 		}),
 
-		component.SelectControl(component.SelectControlParams{
-			Name:     "method",
-			Label:    "Method",
+		component.InputControl(component.InputControlParams{
+			Name:     "url",
+			Label:    "URL",
 			Required: true,
+			Type:     component.InputTypeText,
+			HelpText: "It can use the template variables described in the body.",
 			Children: []nodx.Node{
-				nodx.Option(
-					nodx.Value("POST"),
-					nodx.Text("POST"),
-					nodx.If(!shouldPrefill, nodx.Selected("")),
+				alpine.XBind("placeholder", "urlPlaceholders[channel]"),
+				nodx.If(shouldPrefill, nodx.Value(pickedWebhook.Url)),
+			},
+		}),
+
+		nodx.Div(
+			alpine.XShow("channel === 'webhook'"),
+			component.SelectControl(component.SelectControlParams{
+				Name:     "method",
+				Label:    "Method",
+				Required: true,
+				Children: []nodx.Node{
+					nodx.Map(
+						webhooks.Methods,
+						func(method string) nodx.Node {
+							picked := method == "POST"
+							if shouldPrefill {
+								picked = method == pickedWebhook.Method
+							}
+							return nodx.Option(
+								nodx.Value(method),
+								nodx.Text(method),
+								nodx.If(picked, nodx.Selected("")),
+							)
+						},
+					),
+				},
+			}),
+		),
+
+		nodx.Div(
+			alpine.XShow("channel !== 'email'"),
+			component.TextareaControl(component.TextareaControlParams{
+				Name:        "headers",
+				Label:       "Headers",
+				Placeholder: `{ "Authorization": "Bearer my-token" }`,
+				HelpText:    `By default it will send a { "Content-Type": "application/json" } header. The values can use the template variables described in the body.`,
+				Children: []nodx.Node{
+					alpine.XRef("headersTextarea"),
+					alpine.XOn("click.outside", "formatHeadersTextarea()"),
+					alpine.XOn("input", "autoGrowHeadersTextarea()"),
 					nodx.If(
-						shouldPrefill && pickedWebhook.Method == "POST",
-						nodx.Selected(""),
+						shouldPrefill, nodx.Text(pickedWebhook.Headers.String),
 					),
-				),
-				nodx.Option(
-					nodx.Value("GET"),
-					nodx.Text("GET"),
+				},
+			}),
+		),
+
+		nodx.Div(
+			alpine.XShow("channel === 'webhook'"),
+			component.TextareaControl(component.TextareaControlParams{
+				Name:               "body",
+				Label:              "Body",
+				Placeholder:        `{ "key": "value" }`,
+				HelpText:           `By default it will send an empty json object {}.`,
+				HelpButtonChildren: templateHelp(),
+				Children: []nodx.Node{
+					alpine.XRef("bodyTextarea"),
+					alpine.XOn("click.outside", "formatBodyTextarea()"),
+					alpine.XOn("input", "autoGrowBodyTextarea()"),
 					nodx.If(
-						shouldPrefill && pickedWebhook.Method == "GET",
-						nodx.Selected(""),
+						shouldPrefill, nodx.Text(pickedWebhook.Body.String),
 					),
-				),
-			},
-		}),
+				},
+			}),
+		),
 
-		component.TextareaControl(component.TextareaControlParams{
-			Name:        "headers",
-			Label:       "Headers",
-			Placeholder: `{ "Authorization": "Bearer my-token" }`,
-			HelpText:    `By default it will send a { "Content-Type": "application/json" } header.`,
-			Children: []nodx.Node{
-				alpine.XRef("headersTextarea"),
-				alpine.XOn("click.outside", "formatHeadersTextarea()"),
-				alpine.XOn("input", "autoGrowHeadersTextarea()"),
-				nodx.If(
-					shouldPrefill, nodx.Text(pickedWebhook.Headers.String),
-				),
-			},
-		}),
+		nodx.Div(
+			alpine.XShow("channel !== 'email'"),
+			httpSettingsControls(shouldPrefill, pickedWebhook),
+		),
 
-		component.TextareaControl(component.TextareaControlParams{
-			Name:        "body",
-			Label:       "Body",
-			Placeholder: `{ "key": "value" }`,
-			HelpText:    `By default it will send an empty json object {}.`,
-			Children: []nodx.Node{
-				alpine.XRef("bodyTextarea"),
-				alpine.XOn("click.outside", "formatBodyTextarea()"),
-				alpine.XOn("input", "autoGrowBodyTextarea()"),
-				nodx.If(
-					shouldPrefill, nodx.Text(pickedWebhook.Body.String),
-				),
-			},
-		}),
+		nodx.Div(
+			alpine.XShow("channel !== 'email'"),
+			signingSecretControls(shouldPrefill && pickedWebhook.SigningSecret != nil),
+		),
+
+		nodx.Div(
+			nodx.Class("space-y-2"),
+			previewWebhookButton(),
+			nodx.Div(),
+		),
 	)
 }
+
+func channelHelp() []nodx.Node {
+	return []nodx.Node{
+		component.H3Text("Channels"),
+		component.PText(`
+			The webhook channel sends the body template to any URL. The chat
+			channels send a formatted message with the status color, the target,
+			the error, the size and the duration of the execution and a link to
+			the dashboard, they always use POST and ignore the body.
+		`),
+		nodx.Div(
+			nodx.Class("space-y-2 mt-2"),
+			component.CardBoxSimple(
+				component.H4Text("Slack"),
+				component.PText("The URL of an incoming webhook of a Slack app."),
+			),
+			component.CardBoxSimple(
+				component.H4Text("Discord"),
+				component.PText("The URL of a webhook created in the integrations of a channel."),
+			),
+			component.CardBoxSimple(
+				component.H4Text("Microsoft Teams"),
+				component.PText(`
+					The URL of a Teams workflow started by a webhook request, the
+					message is sent as an Adaptive Card.
+				`),
+			),
+			component.CardBoxSimple(
+				component.H4Text("Telegram"),
+				component.PText(`
+					https://api.telegram.org/bot<token>/sendMessage?chat_id=<chat id>
+					with the token of your bot and the ID of the chat to send the
+					messages to.
+				`),
+			),
+			component.CardBoxSimple(
+				component.H4Text("Email"),
+				component.PText(`
+					mailto: followed by the recipients separated by commas, e.g.
+					mailto:ops@example.com,oncall@example.com. The email is sent
+					through the SMTP server of the email settings, with an HTML and
+					a text version of the details.
+				`),
+			),
+		),
+	}
+}
+
+func templateHelp() []nodx.Node {
+	variables := [][2]string{
+		{".Type", "Event type key, e.g. execution_failed"},
+		{".TypeName", "Event type name, e.g. Execution failed"},
+		{".TargetID", "ID of the database, destination or backup"},
+		{".TargetName", "Name of the database, destination or backup"},
+		{".ExecutionID", "ID of the execution, empty for other events"},
+		{".RestorationID", "ID of the restoration, empty for other events"},
+		{".Status", "Execution or restoration status, or healthy, unhealthy or stale"},
+		{".Message", "Execution or restoration message, or health check error"},
+		{".FileSize", "Size of the backup file in bytes"},
+		{".StartedAt", "When the execution or restoration started"},
+		{".FinishedAt", "When the execution or restoration finished"},
+		{".Duration", "How long the execution or restoration took"},
+		{".DashboardURL", "Link to the dashboard, needs PBW_PUBLIC_URL"},
+		{".Time", "When the event happened"},
+	}
+
+	return []nodx.Node{
+		component.H3Text("Templates"),
+		component.PText(`
+			The URL, the header values and the body are Go text/template
+			templates rendered with the event that runs the webhook. Escape the
+			values in the body with json so it stays valid JSON, e.g.
+			{ "text": {{ json .TargetName }} }. The templates are checked with a
+			sample event when the webhook is saved.
+		`),
+		nodx.Table(
+			nodx.Class("table table-sm"),
+			nodx.Tbody(
+				nodx.Map(variables, func(variable [2]string) nodx.Node {
+					return nodx.Tr(
+						nodx.Td(nodx.CodeEl(nodx.Text(variable[0]))),
+						nodx.Td(component.SpanText(variable[1])),
+					)
+				}),
+			),
+		),
+	}
+}
@@ -18,14 +18,21 @@ import (
 )
 
 type createWebhookDTO struct {
-	Name      string      `form:"name" validate:"required"`
-	EventType string      `form:"event_type" validate:"required"`
-	TargetIds []uuid.UUID `form:"target_ids" validate:"required,gt=0"`
-	IsActive  string      `form:"is_active" validate:"required,oneof=true false"`
-	Url       string      `form:"url" validate:"required,url"`
-	Method    string      `form:"method" validate:"required,oneof=GET POST"`
-	Headers   string      `form:"headers" validate:"omitempty,json"`
-	Body      string      `form:"body" validate:"omitempty,json"`
+	Name           string      `form:"name" validate:"required"`
+	EventType      string      `form:"event_type" validate:"required"`
+	TargetIds      []uuid.UUID `form:"target_ids" validate:"required,gt=0"`
+	IsActive       string      `form:"is_active" validate:"required,oneof=true false"`
+	Channel        string      `form:"channel" validate:"required,oneof=webhook slack discord teams telegram email"`
+	Url            string      `form:"url" validate:"required"`
+	Method         string      `form:"method" validate:"required,oneof=GET POST PUT PATCH DELETE"`
+	Headers        string      `form:"headers" validate:"omitempty,json"`
+	Body           string      `form:"body" validate:"omitempty"`
+	TimeoutSeconds int32       `form:"timeout_seconds" validate:"required,min=1,max=300"`
+	TLSSkipVerify  string      `form:"tls_skip_verify" validate:"required,oneof=true false"`
+	CACertificate  string      `form:"ca_certificate"`
+	ProxyURL       string      `form:"proxy_url"`
+	SignRequests   string      `form:"sign_requests" validate:"required,oneof=true false"`
+	SigningSecret  string      `form:"signing_secret"`
 }
 
 func (h *handlers) createWebhookHandler(c echo.Context) error {
@@ -41,14 +48,22 @@ func (h *handlers) createWebhookHandler(c echo.Context) error {
 
 	_, err := h.servs.WebhooksService.CreateWebhook(
 		ctx, dbgen.WebhooksServiceCreateWebhookParams{
-			Name:      formData.Name,
-			EventType: formData.EventType,
-			TargetIds: formData.TargetIds,
-			IsActive:  formData.IsActive == "true",
-			Url:       formData.Url,
-			Method:    formData.Method,
-			Headers:   sql.NullString{String: formData.Headers, Valid: true},
-			Body:      sql.NullString{String: formData.Body, Valid: true},
+			Name:           formData.Name,
+			EventType:      formData.EventType,
+			TargetIds:      formData.TargetIds,
+			IsActive:       formData.IsActive == "true",
+			Channel:        formData.Channel,
+			Url:            formData.Url,
+			Method:         formData.Method,
+			Headers:        sql.NullString{String: formData.Headers, Valid: true},
+			Body:           sql.NullString{String: formData.Body, Valid: true},
+			TimeoutSeconds: formData.TimeoutSeconds,
+			TlsSkipVerify:  formData.TLSSkipVerify == "true",
+			CaCertificate:  sql.NullString{String: formData.CACertificate, Valid: true},
+			ProxyUrl:       sql.NullString{String: formData.ProxyURL, Valid: true},
+			SigningSecret: signingSecretParam(
+				formData.SignRequests, formData.SigningSecret,
+			),
 		},
 	)
 	if err != nil {
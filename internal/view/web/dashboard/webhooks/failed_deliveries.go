@@ -0,0 +1,203 @@
+package webhooks
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/paginateutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/util/strutil"
+	"github.com/eduardolat/pgbackweb/internal/util/timeutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/layout"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) failedDeliveriesPageHandler(c echo.Context) error {
+	reqCtx := reqctx.GetCtx(c)
+	return echoutil.RenderNodx(
+		c, http.StatusOK, failedDeliveriesPage(reqCtx),
+	)
+}
+
+func failedDeliveriesPage(reqCtx reqctx.Ctx) nodx.Node {
+	content := []nodx.Node{
+		nodx.Div(
+			nodx.Class("flex justify-between items-start"),
+			component.H1Text("Failed webhook deliveries"),
+			nodx.A(
+				nodx.Href(pathutil.BuildPath("/dashboard/webhooks")),
+				nodx.Class("btn btn-ghost"),
+				lucide.ArrowLeft(),
+				component.SpanText("Back to webhooks"),
+			),
+		),
+		component.PText(
+			"The events that couldn't be delivered after all the attempts, " +
+				"the attempts are listed in the webhook executions.",
+		),
+
+		component.CardBox(component.CardBoxParams{
+			Class: "mt-4",
+			Children: []nodx.Node{
+				nodx.Div(
+					nodx.Class("overflow-x-auto"),
+					nodx.Table(
+						nodx.Class("table text-nowrap"),
+						nodx.Thead(
+							nodx.Tr(
+								nodx.Th(nodx.Class("w-1")),
+								nodx.Th(component.SpanText("Webhook")),
+								nodx.Th(component.SpanText("Event type")),
+								nodx.Th(component.SpanText("Attempts")),
+								nodx.Th(component.SpanText("Last error")),
+								nodx.Th(component.SpanText("Created at")),
+								nodx.Th(component.SpanText("Failed at")),
+							),
+						),
+						nodx.Tbody(
+							component.SkeletonTr(8),
+							htmx.HxGet(pathutil.BuildPath(
+								"/dashboard/webhooks/failed-deliveries/list?page=1",
+							)),
+							htmx.HxTrigger("load"),
+						),
+					),
+				),
+			},
+		}),
+	}
+
+	return layout.Dashboard(reqCtx, layout.DashboardParams{
+		Title: "Failed webhook deliveries",
+		Body:  content,
+	})
+}
+
+func (h *handlers) listFailedDeliveriesHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var formData struct {
+		Page int `query:"page" validate:"required,min=1"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	pagination, deliveries, err := h.servs.WebhooksService.PaginateFailedDeliveries(
+		ctx, webhooks.PaginateFailedDeliveriesParams{
+			Page:  formData.Page,
+			Limit: 20,
+		},
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return echoutil.RenderNodx(
+		c, http.StatusOK, listFailedDeliveries(pagination, deliveries),
+	)
+}
+
+func listFailedDeliveries(
+	pagination paginateutil.PaginateResponse,
+	deliveries []dbgen.WebhooksServicePaginateFailedDeliveriesRow,
+) nodx.Node {
+	if len(deliveries) < 1 {
+		return component.EmptyResultsTr(component.EmptyResultsParams{
+			Title:    "No failed deliveries found",
+			Subtitle: "All the webhook events were delivered",
+		})
+	}
+
+	trs := []nodx.Node{}
+	for _, delivery := range deliveries {
+		eventType := delivery.WebhookEventType
+		if name, ok := webhooks.FullEventTypes[eventType]; ok {
+			eventType = name
+		}
+
+		trs = append(trs, nodx.Tr(
+			nodx.Td(component.OptionsDropdown(
+				webhookExecutionsButton(delivery.WebhookID),
+				redeliverButton(delivery.ID),
+			)),
+			nodx.Td(component.SpanText(delivery.WebhookName)),
+			nodx.Td(component.SpanText(eventType)),
+			nodx.Td(component.SpanText(fmt.Sprintf("%d", delivery.Attempts))),
+			nodx.Td(
+				nodx.Class("max-w-sm truncate"),
+				nodx.TitleAttr(delivery.LastError.String),
+				component.SpanText(delivery.LastError.String),
+			),
+			nodx.Td(component.SpanText(
+				delivery.CreatedAt.Local().Format(timeutil.LayoutYYYYMMDDHHMMSSPretty),
+			)),
+			nodx.Td(component.SpanText(
+				delivery.UpdatedAt.Time.Local().Format(timeutil.LayoutYYYYMMDDHHMMSSPretty),
+			)),
+		))
+	}
+
+	if pagination.HasNextPage {
+		trs = append(trs, nodx.Tr(
+			htmx.HxGet(func() string {
+				url := pathutil.BuildPath("/dashboard/webhooks/failed-deliveries/list")
+				url = strutil.AddQueryParamToUrl(url, "page", fmt.Sprintf("%d", pagination.NextPage))
+				return url
+			}()),
+			htmx.HxTrigger("intersect once"),
+			htmx.HxSwap("afterend"),
+		))
+	}
+
+	return component.RenderableGroup(trs)
+}
+
+func (h *handlers) redeliverHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	deliveryID, err := uuid.Parse(c.Param("deliveryID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	if err := h.servs.WebhooksService.RedeliverWebhook(ctx, deliveryID); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.ToastSuccess(c, "Redelivering webhook, check the webhook executions for more details")
+}
+
+func redeliverButton(deliveryID uuid.UUID) nodx.Node {
+	return component.OptionsDropdownButton(
+		htmx.HxPost(pathutil.BuildPath(fmt.Sprintf(
+			"/dashboard/webhooks/failed-deliveries/%s/redeliver", deliveryID,
+		))),
+		htmx.HxDisabledELT("this"),
+		lucide.RefreshCw(),
+		component.SpanText("Redeliver"),
+	)
+}
+
+func failedDeliveriesButton() nodx.Node {
+	return nodx.A(
+		nodx.Href(pathutil.BuildPath("/dashboard/webhooks/failed-deliveries")),
+		nodx.Class("btn btn-ghost"),
+		component.SpanText("Failed deliveries"),
+		lucide.TriangleAlert(),
+	)
+}
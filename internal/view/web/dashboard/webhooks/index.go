@@ -3,6 +3,7 @@ package webhooks
 import (
 	"net/http"
 
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
 	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
 	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
@@ -25,7 +26,15 @@ func indexPage(reqCtx reqctx.Ctx) nodx.Node {
 		nodx.Div(
 			nodx.Class("flex justify-between items-start"),
 			component.H1Text("Webhooks"),
-			createWebhookButton(),
+			nodx.Div(
+				nodx.Class("flex items-center space-x-2"),
+				nodx.If(
+					users.HasPermission(reqCtx.User.Role, users.PermissionManageInstance),
+					smtpSettingsButton(),
+				),
+				failedDeliveriesButton(),
+				createWebhookButton(),
+			),
 		),
 
 		component.CardBox(component.CardBoxParams{
@@ -40,6 +49,7 @@ func indexPage(reqCtx reqctx.Ctx) nodx.Node {
 								nodx.Th(nodx.Class("w-1")),
 								nodx.Th(component.SpanText("Name")),
 								nodx.Th(component.SpanText("Event type")),
+								nodx.Th(component.SpanText("Channel")),
 								nodx.Th(component.SpanText("Targets")),
 								nodx.Th(component.SpanText("Created at")),
 							),
@@ -64,15 +64,16 @@ func listWebhooks(
 			nodx.Td(component.OptionsDropdown(
 				webhookExecutionsButton(whook.ID),
 				runWebhookButton(whook.ID),
-				editWebhookButton(whook.ID),
+				nodx.If(!whook.IsProvisioned, editWebhookButton(whook.ID)),
 				duplicateWebhookButton(whook.ID),
-				deleteWebhookButton(whook.ID),
+				nodx.If(!whook.IsProvisioned, deleteWebhookButton(whook.ID)),
 			)),
 			nodx.Td(
 				nodx.Div(
 					nodx.Class("flex items-center space-x-2"),
 					component.IsActivePing(whook.IsActive),
 					component.SpanText(whook.Name),
+					component.ProvisionedBadge(whook.IsProvisioned),
 				),
 			),
 			nodx.Td(component.SpanText(
@@ -83,6 +84,7 @@ func listWebhooks(
 					return whook.EventType
 				}(),
 			)),
+			nodx.Td(component.SpanText(webhooks.FullChannels[whook.Channel])),
 			nodx.Td(component.SpanText(fmt.Sprintf("%d", len(whook.TargetIds)))),
 			nodx.Td(component.SpanText(
 				whook.CreatedAt.Local().Format(timeutil.LayoutYYYYMMDDHHMMSSPretty),
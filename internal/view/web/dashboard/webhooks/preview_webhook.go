@@ -0,0 +1,113 @@
+package webhooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+type previewWebhookDTO struct {
+	EventType string `form:"event_type" validate:"required"`
+	Channel   string `form:"channel" validate:"required"`
+	Url       string `form:"url" validate:"required"`
+	Method    string `form:"method" validate:"required,oneof=GET POST PUT PATCH DELETE"`
+	Headers   string `form:"headers" validate:"omitempty,json"`
+	Body      string `form:"body"`
+}
+
+// previewWebhookHandler renders the request of the webhook form for a
+// sample event, without sending it.
+func (h *handlers) previewWebhookHandler(c echo.Context) error {
+	var formData previewWebhookDTO
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	err := webhooks.ValidateTemplates(
+		formData.Channel, formData.EventType, formData.Url, formData.Headers,
+		formData.Body,
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	req, err := webhooks.RenderChannelRequest(
+		formData.Channel, formData.Url, formData.Headers, formData.Body,
+		webhooks.SampleEvent(formData.EventType),
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	method := formData.Method
+	if webhooks.IsChatChannel(formData.Channel) {
+		method = http.MethodPost
+	}
+	if formData.Channel == webhooks.ChannelEmail.Value.Key {
+		method = ""
+	}
+
+	return echoutil.RenderNodx(
+		c, http.StatusOK, previewWebhook(method, req),
+	)
+}
+
+func previewWebhook(method string, req webhooks.Request) nodx.Node {
+	headers, _ := json.MarshalIndent(req.Headers, "", "  ")
+
+	body := []byte(req.Body)
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, body, "", "  "); err == nil {
+		body = indented.Bytes()
+	}
+
+	block := func(title, content string) nodx.Node {
+		return nodx.Div(
+			component.H4Text(title),
+			nodx.Pre(
+				nodx.Class("bg-base-200 rounded-btn p-2 text-xs whitespace-pre-wrap break-all"),
+				nodx.Text(content),
+			),
+		)
+	}
+
+	return component.CardBoxSimple(
+		nodx.Div(
+			nodx.Class("space-y-2"),
+			component.PText("Request rendered with a sample event, it was not sent."),
+			block("URL", strings.TrimSpace(method+" "+req.URL)),
+			block("Headers", string(headers)),
+			block("Body", string(body)),
+		),
+	)
+}
+
+// previewWebhookButton renders the request of the enclosing form in the div
+// that follows the button.
+func previewWebhookButton() nodx.Node {
+	return nodx.Button(
+		nodx.Class("btn btn-ghost"),
+		nodx.Type("button"),
+		htmx.HxPost(pathutil.BuildPath("/dashboard/webhooks/preview")),
+		htmx.HxInclude("closest form"),
+		htmx.HxTarget("next div"),
+		htmx.HxDisabledELT("this"),
+		component.SpanText("Preview with sample event"),
+		lucide.Eye(),
+	)
+}
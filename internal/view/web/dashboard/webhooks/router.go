@@ -2,6 +2,7 @@ package webhooks
 
 import (
 	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/eduardolat/pgbackweb/internal/view/middleware"
 	"github.com/labstack/echo/v4"
 )
@@ -19,14 +20,25 @@ func MountRouter(
 ) {
 	h := newHandlers(servs)
 
+	run := mids.RequirePermission(users.PermissionRun)
+	manage := mids.RequirePermission(users.PermissionManage)
+	manageInstance := mids.RequirePermission(users.PermissionManageInstance)
+
 	parent.GET("", h.indexPageHandler)
 	parent.GET("/list", h.listWebhooksHandler)
-	parent.GET("/create", h.createWebhookFormHandler)
-	parent.POST("/create", h.createWebhookHandler)
-	parent.GET("/:webhookID/edit", h.editWebhookFormHandler)
-	parent.POST("/:webhookID/edit", h.editWebhookHandler)
-	parent.POST("/:webhookID/run", h.runWebhookHandler)
-	parent.POST("/:webhookID/duplicate", h.duplicateWebhookHandler)
+	parent.GET("/failed-deliveries", h.failedDeliveriesPageHandler)
+	parent.GET("/failed-deliveries/list", h.listFailedDeliveriesHandler)
+	parent.POST("/failed-deliveries/:deliveryID/redeliver", h.redeliverHandler, run)
+	parent.GET("/smtp", h.smtpSettingsPageHandler, manageInstance)
+	parent.POST("/smtp", h.updateSMTPSettingsHandler, manageInstance)
+	parent.POST("/smtp/test", h.sendTestEmailHandler, manageInstance)
+	parent.GET("/create", h.createWebhookFormHandler, manage)
+	parent.POST("/create", h.createWebhookHandler, manage)
+	parent.GET("/:webhookID/edit", h.editWebhookFormHandler, manage)
+	parent.POST("/:webhookID/edit", h.editWebhookHandler, manage)
+	parent.POST("/preview", h.previewWebhookHandler, manage)
+	parent.POST("/:webhookID/run", h.runWebhookHandler, run)
+	parent.POST("/:webhookID/duplicate", h.duplicateWebhookHandler, manage)
 	parent.GET("/:webhookID/executions", h.paginateWebhookExecutionsHandler)
-	parent.DELETE("/:webhookID", h.deleteWebhookHandler)
+	parent.DELETE("/:webhookID", h.deleteWebhookHandler, manage)
 }
@@ -1,10 +1,8 @@
 package webhooks
 
 import (
-	"context"
 	"fmt"
 
-	"github.com/eduardolat/pgbackweb/internal/logger"
 	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
 	"github.com/eduardolat/pgbackweb/internal/view/web/component"
 	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
@@ -16,31 +14,20 @@ import (
 )
 
 func (h *handlers) runWebhookHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
 	webhookID, err := uuid.Parse(c.Param("webhookID"))
 	if err != nil {
 		return respondhtmx.ToastError(c, err.Error())
 	}
 
-	go func() {
-		ctx := context.Background()
-		webhook, err := h.servs.WebhooksService.GetWebhook(ctx, webhookID)
-		if err != nil {
-			logger.Error("error getting webhook", logger.KV{
-				"webhook_id": webhookID.String(),
-				"error":      err.Error(),
-			})
-			return
-		}
-		err = h.servs.WebhooksService.SendWebhookRequest(ctx, webhook)
-		if err != nil {
-			logger.Error("error sending webhook request", logger.KV{
-				"webhook_id": webhook.ID,
-				"error":      err.Error(),
-			})
-		}
-	}()
+	// Manual runs have no real event, they send a sample one marked as test.
+	err = h.servs.WebhooksService.SendTestMessage(ctx, webhookID)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
 
-	return respondhtmx.ToastSuccess(c, "Running webhook, check the webhook executions for more details")
+	return respondhtmx.ToastSuccess(c, "Test message sent, check the webhook executions for more details")
 }
 
 func runWebhookButton(webhookID uuid.UUID) nodx.Node {
@@ -48,6 +35,6 @@ func runWebhookButton(webhookID uuid.UUID) nodx.Node {
 		htmx.HxPost(pathutil.BuildPath(fmt.Sprintf("/dashboard/webhooks/%s/run", webhookID))),
 		htmx.HxDisabledELT("this"),
 		lucide.Zap(),
-		component.SpanText("Run webhook now"),
+		component.SpanText("Send test message"),
 	)
 }
@@ -0,0 +1,166 @@
+package webhooks
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/service/webhooks"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	nodx "github.com/nodxdev/nodxgo"
+	alpine "github.com/nodxdev/nodxgo-alpine"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+// signingSecretParam returns the signing secret to store from the form. An
+// empty secret keeps the current one and not signing the requests removes
+// it, the same way an empty string does in the update query.
+func signingSecretParam(signRequests, secret string) sql.NullString {
+	if signRequests != "true" {
+		return sql.NullString{String: "", Valid: true}
+	}
+	return sql.NullString{String: secret, Valid: secret != ""}
+}
+
+func signingSecretControls(hasSecret bool) nodx.Node {
+	placeholder := "whsec_..."
+	if hasSecret {
+		placeholder = "Leave empty to keep the current secret"
+	}
+
+	return nodx.Div(
+		nodx.Class("space-y-2"),
+		alpine.XData(fmt.Sprintf(`{
+			signRequests: "%t",
+			hasSecret: %t,
+
+			generateSigningSecret() {
+				const bytes = crypto.getRandomValues(new Uint8Array(32))
+				$refs.signingSecretInput.value = "whsec_" + Array.from(
+					bytes, (b) => b.toString(16).padStart(2, "0")
+				).join("")
+			}
+		}`, hasSecret, hasSecret)),
+
+		component.SelectControl(component.SelectControlParams{
+			Name:               "sign_requests",
+			Label:              "Sign requests",
+			Required:           true,
+			HelpButtonChildren: signatureHelp(),
+			Children: []nodx.Node{
+				alpine.XModel("signRequests"),
+				nodx.Option(
+					nodx.Value("false"), nodx.Text("No"),
+					nodx.If(!hasSecret, nodx.Selected("")),
+				),
+				nodx.Option(
+					nodx.Value("true"), nodx.Text("Yes"),
+					nodx.If(hasSecret, nodx.Selected("")),
+				),
+			},
+		}),
+
+		alpine.Template(
+			alpine.XIf("signRequests === 'true'"),
+			nodx.Div(
+				component.InputControl(component.InputControlParams{
+					Name:         "signing_secret",
+					Label:        "Signing secret",
+					Placeholder:  placeholder,
+					Type:         component.InputTypeText,
+					AutoComplete: "off",
+					HelpText: "Keep a copy, it is stored encrypted and can't be " +
+						"seen again.",
+					Children: []nodx.Node{
+						alpine.XRef("signingSecretInput"),
+						alpine.XBind("required", "!hasSecret"),
+					},
+				}),
+				nodx.Div(
+					nodx.Class("flex justify-end"),
+					nodx.Button(
+						nodx.Class("btn btn-sm btn-ghost"),
+						nodx.Type("button"),
+						alpine.XOn("click", "generateSigningSecret()"),
+						component.SpanText("Generate secret"),
+						lucide.KeyRound(),
+					),
+				),
+			),
+		),
+	)
+}
+
+func signatureHelp() []nodx.Node {
+	snippet := func(language, code string) nodx.Node {
+		return nodx.Div(
+			component.H4Text(language),
+			nodx.Pre(
+				nodx.Class("bg-base-200 rounded-btn p-2 text-xs overflow-x-auto"),
+				nodx.Text(code),
+			),
+		)
+	}
+
+	return []nodx.Node{
+		component.H3Text("Signed requests"),
+		component.PText(fmt.Sprintf(`
+			The requests of a webhook with a signing secret include the %s
+			header, e.g. t=1735700700,v1=5257a8...ce6e. t is the Unix timestamp
+			of the request and v1 the hex encoded HMAC-SHA256 of the timestamp,
+			a dot and the raw body, with the signing secret as the key. Compute
+			it on the receiver, compare it in constant time and reject the old
+			timestamps to prevent replays.
+		`, webhooks.SignatureHeader)),
+
+		nodx.Div(
+			nodx.Class("space-y-2 mt-2"),
+			snippet("Node.js", `const crypto = require("crypto");
+
+function verify(secret, header, body) {
+  const parts = Object.fromEntries(header.split(",").map((p) => p.split("=")));
+  const expected = crypto
+    .createHmac("sha256", secret)
+    .update(parts.t + "." + body)
+    .digest("hex");
+  const age = Math.abs(Date.now() / 1000 - Number(parts.t));
+  return (
+    age < 300 &&
+    expected.length === parts.v1?.length &&
+    crypto.timingSafeEqual(Buffer.from(expected), Buffer.from(parts.v1))
+  );
+}`),
+			snippet("Python", `import hashlib, hmac, time
+
+def verify(secret: str, header: str, body: bytes) -> bool:
+    parts = dict(p.split("=", 1) for p in header.split(","))
+    expected = hmac.new(
+        secret.encode(), parts["t"].encode() + b"." + body, hashlib.sha256
+    ).hexdigest()
+    age = abs(time.time() - int(parts["t"]))
+    return age < 300 and hmac.compare_digest(expected, parts.get("v1", ""))`),
+			snippet("Go", `func verify(secret, header string, body []byte) bool {
+	var t, v1 string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			t = value
+		case "v1":
+			v1 = value
+		}
+	}
+
+	timestamp, err := strconv.ParseInt(t, 10, 64)
+	if err != nil || time.Since(time.Unix(timestamp, 0)).Abs() > 5*time.Minute {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t + "."))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(v1))
+}`),
+		),
+	}
+}
@@ -0,0 +1,285 @@
+package webhooks
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/integration/email"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/layout"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) smtpSettingsPageHandler(c echo.Context) error {
+	reqCtx := reqctx.GetCtx(c)
+	ctx := c.Request().Context()
+
+	settings, err := h.servs.WebhooksService.GetSMTPSettings(ctx)
+	if err != nil {
+		logger.Error("failed to get SMTP settings", logger.KV{"err": err})
+		return c.String(http.StatusInternalServerError, "failed to get SMTP settings")
+	}
+
+	return echoutil.RenderNodx(
+		c, http.StatusOK, smtpSettingsPage(reqCtx, settings),
+	)
+}
+
+func smtpSettingsPage(
+	reqCtx reqctx.Ctx, settings dbgen.WebhooksServiceGetSMTPSettingsRow,
+) nodx.Node {
+	content := []nodx.Node{
+		nodx.Div(
+			nodx.Class("flex justify-between items-start"),
+			component.H1Text("Email settings"),
+			nodx.A(
+				nodx.Href(pathutil.BuildPath("/dashboard/webhooks")),
+				nodx.Class("btn btn-ghost"),
+				lucide.ArrowLeft(),
+				component.SpanText("Back to webhooks"),
+			),
+		),
+		component.PText(
+			"The SMTP server the webhooks with the email channel send the " +
+				"emails through.",
+		),
+
+		nodx.Div(
+			nodx.Class("mt-4 grid grid-cols-1 lg:grid-cols-2 gap-4 items-start"),
+			smtpSettingsForm(settings),
+			sendTestEmailForm(),
+		),
+	}
+
+	return layout.Dashboard(reqCtx, layout.DashboardParams{
+		Title: "Email settings",
+		Body:  content,
+	})
+}
+
+func (h *handlers) updateSMTPSettingsHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var formData struct {
+		Host     string `form:"host" validate:"required"`
+		Port     int32  `form:"port" validate:"required,min=1,max=65535"`
+		TLSMode  string `form:"tls_mode" validate:"required,oneof=none starttls tls"`
+		Username string `form:"username"`
+		Password string `form:"password"`
+		From     string `form:"from" validate:"required"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	// An empty password keeps the current one, without username it is
+	// removed because the emails are sent without auth.
+	password := sql.NullString{
+		String: formData.Password, Valid: formData.Password != "",
+	}
+	if formData.Username == "" {
+		password = sql.NullString{String: "", Valid: true}
+	}
+
+	err := h.servs.WebhooksService.UpdateSMTPSettings(
+		ctx, dbgen.WebhooksServiceUpdateSMTPSettingsParams{
+			SmtpHost:     formData.Host,
+			SmtpPort:     formData.Port,
+			SmtpTlsMode:  formData.TLSMode,
+			SmtpUsername: formData.Username,
+			SmtpPassword: password,
+			SmtpFrom:     formData.From,
+		},
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.ToastSuccess(c, "Email settings saved")
+}
+
+func smtpSettingsForm(settings dbgen.WebhooksServiceGetSMTPSettingsRow) nodx.Node {
+	tlsModes := [][2]string{
+		{email.TLSModeStartTLS, "STARTTLS (usually port 587)"},
+		{email.TLSModeTLS, "TLS (usually port 465)"},
+		{email.TLSModeNone, "None (usually port 25)"},
+	}
+
+	passwordHelp := "Leave empty if the server doesn't need auth"
+	if settings.DecryptedSmtpPassword != "" {
+		passwordHelp = "Leave empty to keep the current password"
+	}
+
+	return component.CardBox(component.CardBoxParams{
+		Children: []nodx.Node{
+			nodx.FormEl(
+				htmx.HxPost(pathutil.BuildPath("/dashboard/webhooks/smtp")),
+				htmx.HxDisabledELT("find button"),
+				nodx.Class("space-y-2"),
+
+				component.H2Text("SMTP server"),
+
+				component.InputControl(component.InputControlParams{
+					Name:        "host",
+					Label:       "Host",
+					Placeholder: "smtp.example.com",
+					Required:    true,
+					Type:        component.InputTypeText,
+					Children: []nodx.Node{
+						nodx.Value(settings.SmtpHost),
+					},
+				}),
+
+				component.InputControl(component.InputControlParams{
+					Name:        "port",
+					Label:       "Port",
+					Placeholder: "587",
+					Required:    true,
+					Type:        component.InputTypeNumber,
+					Children: []nodx.Node{
+						nodx.Value(fmt.Sprintf("%d", settings.SmtpPort)),
+					},
+				}),
+
+				component.SelectControl(component.SelectControlParams{
+					Name:     "tls_mode",
+					Label:    "TLS mode",
+					Required: true,
+					Children: []nodx.Node{
+						nodx.Map(tlsModes, func(mode [2]string) nodx.Node {
+							return nodx.Option(
+								nodx.Value(mode[0]),
+								nodx.Text(mode[1]),
+								nodx.If(mode[0] == settings.SmtpTlsMode, nodx.Selected("")),
+							)
+						}),
+					},
+				}),
+
+				component.InputControl(component.InputControlParams{
+					Name:         "username",
+					Label:        "Username",
+					Placeholder:  "Username",
+					AutoComplete: "off",
+					Type:         component.InputTypeText,
+					HelpText:     "Leave empty if the server doesn't need auth",
+					Children: []nodx.Node{
+						nodx.Value(settings.SmtpUsername),
+					},
+				}),
+
+				component.InputControl(component.InputControlParams{
+					Name:         "password",
+					Label:        "Password",
+					Placeholder:  "Password",
+					AutoComplete: "new-password",
+					Type:         component.InputTypePassword,
+					HelpText:     passwordHelp,
+				}),
+
+				component.InputControl(component.InputControlParams{
+					Name:        "from",
+					Label:       "From",
+					Placeholder: "PG Back Web <pgbackweb@example.com>",
+					Required:    true,
+					Type:        component.InputTypeText,
+					HelpText:    "The sender of the emails",
+					Children: []nodx.Node{
+						nodx.Value(settings.SmtpFrom),
+					},
+				}),
+
+				nodx.Div(
+					nodx.Class("flex justify-end items-center space-x-2 pt-2"),
+					component.HxLoadingMd(),
+					nodx.Button(
+						nodx.Class("btn btn-primary"),
+						nodx.Type("submit"),
+						component.SpanText("Save settings"),
+						lucide.Save(),
+					),
+				),
+			),
+		},
+	})
+}
+
+func (h *handlers) sendTestEmailHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var formData struct {
+		To string `form:"to" validate:"required,email"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	if err := h.servs.WebhooksService.SendTestEmail(ctx, formData.To); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.ToastSuccess(c, "Test email sent to "+formData.To)
+}
+
+func sendTestEmailForm() nodx.Node {
+	return component.CardBox(component.CardBoxParams{
+		Children: []nodx.Node{
+			nodx.FormEl(
+				htmx.HxPost(pathutil.BuildPath("/dashboard/webhooks/smtp/test")),
+				htmx.HxDisabledELT("find button"),
+				nodx.Class("space-y-2"),
+
+				component.H2Text("Send test email"),
+				component.PText(
+					"Sends an email with a sample failed execution using the "+
+						"saved settings.",
+				),
+
+				component.InputControl(component.InputControlParams{
+					Name:        "to",
+					Label:       "To",
+					Placeholder: "you@example.com",
+					Required:    true,
+					Type:        component.InputTypeEmail,
+				}),
+
+				nodx.Div(
+					nodx.Class("flex justify-end items-center space-x-2 pt-2"),
+					component.HxLoadingMd(),
+					nodx.Button(
+						nodx.Class("btn btn-neutral"),
+						nodx.Type("submit"),
+						component.SpanText("Send test email"),
+						lucide.Send(),
+					),
+				),
+			),
+		},
+	})
+}
+
+func smtpSettingsButton() nodx.Node {
+	return nodx.A(
+		nodx.Href(pathutil.BuildPath("/dashboard/webhooks/smtp")),
+		nodx.Class("btn btn-ghost"),
+		component.SpanText("Email settings"),
+		lucide.Mail(),
+	)
+}
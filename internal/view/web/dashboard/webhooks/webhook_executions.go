@@ -77,7 +77,8 @@ func webhookExecutionsList(
 			nodx.Td(
 				webhookExecutionDetailsButton(exec, duration),
 			),
-			nodx.Td(component.SpanText(fmt.Sprintf("%d", exec.ResStatus.Int16))),
+			nodx.Td(component.SpanText(executionStatus(exec))),
+			nodx.Td(component.SpanText(fmt.Sprintf("%d", exec.Attempt))),
 			nodx.Td(component.SpanText(exec.ReqMethod.String)),
 			nodx.Td(component.SpanText(duration.String())),
 			nodx.Td(component.SpanText(
@@ -102,6 +103,19 @@ func webhookExecutionsList(
 	return component.RenderableGroup(trs)
 }
 
+// executionStatus returns the response status of the execution, or a dash
+// if the request failed before getting a response.
+func executionStatus(exec dbgen.WebhookExecution) string {
+	// The emails have no response status.
+	if !exec.ResStatus.Valid && !exec.ReqMethod.Valid && !exec.Error.Valid {
+		return "Sent"
+	}
+	if !exec.ResStatus.Valid {
+		return "-"
+	}
+	return fmt.Sprintf("%d", exec.ResStatus.Int16)
+}
+
 func webhookExecutionDetailsButton(
 	exec dbgen.WebhookExecution,
 	duration time.Duration,
@@ -146,6 +160,20 @@ func webhookExecutionDetailsButton(
 							exec.CreatedAt.Local().Format(timeutil.LayoutYYYYMMDDHHMMSSPretty),
 						)),
 					),
+					nodx.Tr(
+						nodx.Th(component.SpanText("Attempt")),
+						nodx.Td(component.SpanText(fmt.Sprintf("%d", exec.Attempt))),
+					),
+					nodx.If(
+						exec.Error.Valid,
+						nodx.Tr(
+							nodx.Th(component.SpanText("Error")),
+							nodx.Td(
+								nodx.Class("text-error whitespace-pre-wrap break-all"),
+								component.SpanText(exec.Error.String),
+							),
+						),
+					),
 				),
 
 				nodx.Table(
@@ -194,9 +222,7 @@ func webhookExecutionDetailsButton(
 					),
 					nodx.Tr(
 						nodx.Th(component.SpanText("Status")),
-						nodx.Td(component.SpanText(
-							fmt.Sprintf("%d", exec.ResStatus.Int16),
-						)),
+						nodx.Td(component.SpanText(executionStatus(exec))),
 					),
 					nodx.Tr(
 						nodx.Th(component.SpanText("Duration")),
@@ -252,6 +278,7 @@ func webhookExecutionsButton(webhookID uuid.UUID) nodx.Node {
 					nodx.Tr(
 						nodx.Th(nodx.Class("w-1")),
 						nodx.Th(component.SpanText("Status")),
+						nodx.Th(component.SpanText("Attempt")),
 						nodx.Th(component.SpanText("Method")),
 						nodx.Th(component.SpanText("Duration")),
 						nodx.Th(component.SpanText("Date")),
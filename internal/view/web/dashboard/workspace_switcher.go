@@ -0,0 +1,80 @@
+package dashboard
+
+import (
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/service/workspaces"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+)
+
+func workspaceSwitcherHandler(c echo.Context) error {
+	reqCtx := reqctx.GetCtx(c)
+	return echoutil.RenderNodx(c, http.StatusOK, workspaceSwitcher(reqCtx))
+}
+
+func workspaceSwitcher(reqCtx reqctx.Ctx) nodx.Node {
+	isAdmin := reqCtx.User.Role == users.RoleAdmin
+	if !isAdmin && len(reqCtx.Workspaces) < 2 {
+		return nodx.Group()
+	}
+
+	return nodx.Select(
+		nodx.Class("select select-bordered select-sm"),
+		nodx.Name("workspace_id"),
+		nodx.TitleAttr("Workspace"),
+		htmx.HxPost(pathutil.BuildPath("/dashboard/workspace")),
+		htmx.HxTrigger("change"),
+		nodx.If(
+			isAdmin,
+			nodx.Option(
+				nodx.Value(""),
+				nodx.If(!reqCtx.WorkspaceID.Valid, nodx.Selected("")),
+				nodx.Text("All workspaces"),
+			),
+		),
+		nodx.Map(
+			reqCtx.Workspaces,
+			func(ws workspaces.UserWorkspace) nodx.Node {
+				isSelected := reqCtx.WorkspaceID.Valid &&
+					reqCtx.WorkspaceID.UUID == ws.ID
+				return nodx.Option(
+					nodx.Value(ws.ID.String()),
+					nodx.If(isSelected, nodx.Selected("")),
+					nodx.Text(ws.Name),
+				)
+			},
+		),
+	)
+}
+
+func selectWorkspaceHandler(servs *service.Service) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var formData struct {
+			WorkspaceID string `form:"workspace_id"`
+		}
+		if err := c.Bind(&formData); err != nil {
+			return respondhtmx.ToastError(c, err.Error())
+		}
+
+		var workspaceID uuid.NullUUID
+		if formData.WorkspaceID != "" {
+			id, err := uuid.Parse(formData.WorkspaceID)
+			if err != nil {
+				return respondhtmx.ToastError(c, err.Error())
+			}
+			workspaceID = uuid.NullUUID{UUID: id, Valid: true}
+		}
+
+		servs.WorkspacesService.SetWorkspaceCookie(c, workspaceID)
+		return respondhtmx.Refresh(c)
+	}
+}
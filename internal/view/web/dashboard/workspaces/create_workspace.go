@@ -0,0 +1,60 @@
+package workspaces
+
+import (
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) createWorkspaceHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var formData struct {
+		Name string `form:"name" validate:"required"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	_, err := h.servs.WorkspacesService.CreateWorkspace(ctx, formData.Name)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.Refresh(c)
+}
+
+func createWorkspaceCard() nodx.Node {
+	return component.CardBox(component.CardBoxParams{
+		Children: []nodx.Node{
+			nodx.FormEl(
+				htmx.HxPost(pathutil.BuildPath("/dashboard/workspaces")),
+				htmx.HxDisabledELT("find button"),
+				nodx.Class("flex items-end space-x-2"),
+
+				component.InputControl(component.InputControlParams{
+					Name:        "name",
+					Label:       "New workspace",
+					Placeholder: "My team",
+					Required:    true,
+					Type:        component.InputTypeText,
+				}),
+
+				nodx.Button(
+					nodx.Class("btn btn-primary"),
+					nodx.Type("submit"),
+					component.SpanText("Create workspace"),
+					lucide.Plus(),
+				),
+			),
+		},
+	})
+}
@@ -0,0 +1,42 @@
+package workspaces
+
+import (
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) deleteWorkspaceHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	workspaceID, err := uuid.Parse(c.Param("workspaceID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	err = h.servs.WorkspacesService.DeleteWorkspace(ctx, workspaceID)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.Refresh(c)
+}
+
+func deleteWorkspaceButton(workspaceID uuid.UUID) nodx.Node {
+	return nodx.Button(
+		htmx.HxDelete(pathutil.BuildPath(
+			fmt.Sprintf("/dashboard/workspaces/%s", workspaceID),
+		)),
+		htmx.HxConfirm("Are you sure you want to delete this workspace?"),
+		nodx.Class("btn btn-error btn-outline"),
+		component.SpanText("Delete workspace"),
+		lucide.Trash(),
+	)
+}
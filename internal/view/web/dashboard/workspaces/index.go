@@ -0,0 +1,106 @@
+package workspaces
+
+import (
+	"net/http"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/logger"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/util/echoutil"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/layout"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+)
+
+type workspaceWithMembers struct {
+	Workspace dbgen.Workspace
+	Members   []dbgen.WorkspacesServiceGetMembersRow
+}
+
+func (h *handlers) indexPageHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+	reqCtx := reqctx.GetCtx(c)
+
+	allWorkspaces, err := h.servs.WorkspacesService.GetAllWorkspaces(ctx)
+	if err != nil {
+		logger.Error("failed to get workspaces", logger.KV{"err": err})
+		return c.String(http.StatusInternalServerError, "failed to get workspaces")
+	}
+
+	items := make([]workspaceWithMembers, 0, len(allWorkspaces))
+	for _, ws := range allWorkspaces {
+		members, err := h.servs.WorkspacesService.GetMembers(ctx, ws.ID)
+		if err != nil {
+			logger.Error("failed to get workspace members", logger.KV{"err": err})
+			return c.String(http.StatusInternalServerError, "failed to get workspace members")
+		}
+		items = append(items, workspaceWithMembers{Workspace: ws, Members: members})
+	}
+
+	allUsers, err := h.servs.UsersService.GetAllUsers(ctx)
+	if err != nil {
+		logger.Error("failed to get users", logger.KV{"err": err})
+		return c.String(http.StatusInternalServerError, "failed to get users")
+	}
+
+	// Admins act as admins in every workspace, so only the other users can be
+	// added as members.
+	var candidates []dbgen.User
+	for _, user := range allUsers {
+		if user.Role != users.RoleAdmin {
+			candidates = append(candidates, user)
+		}
+	}
+
+	return echoutil.RenderNodx(c, http.StatusOK, indexPage(reqCtx, items, candidates))
+}
+
+func indexPage(
+	reqCtx reqctx.Ctx, items []workspaceWithMembers, candidates []dbgen.User,
+) nodx.Node {
+	content := []nodx.Node{
+		component.H1Text("Workspaces"),
+		component.PText(
+			"Workspaces own the databases, destinations, backups and webhooks. " +
+				"Members only see the workspaces they belong to and act with the " +
+				"role of their membership, admins see every workspace.",
+		),
+
+		nodx.Div(
+			nodx.Class("mt-4 space-y-4"),
+			createWorkspaceCard(),
+			nodx.Map(items, func(item workspaceWithMembers) nodx.Node {
+				return workspaceCard(item, candidates)
+			}),
+		),
+	}
+
+	return layout.Dashboard(reqCtx, layout.DashboardParams{
+		Title: "Workspaces",
+		Body:  content,
+	})
+}
+
+func workspaceCard(
+	item workspaceWithMembers, candidates []dbgen.User,
+) nodx.Node {
+	return component.CardBox(component.CardBoxParams{
+		Children: []nodx.Node{
+			nodx.Div(
+				nodx.Class("space-y-4"),
+				nodx.Div(
+					nodx.Class("flex justify-between items-end space-x-2"),
+					renameWorkspaceForm(item.Workspace),
+					nodx.If(
+						!item.Workspace.IsDefault,
+						deleteWorkspaceButton(item.Workspace.ID),
+					),
+				),
+				membersTable(item),
+				setMemberForm(item.Workspace.ID, candidates),
+			),
+		},
+	})
+}
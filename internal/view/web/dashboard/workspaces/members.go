@@ -0,0 +1,169 @@
+package workspaces
+
+import (
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) setMemberHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	workspaceID, err := uuid.Parse(c.Param("workspaceID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	var formData struct {
+		UserID uuid.UUID `form:"user_id" validate:"required,uuid"`
+		Role   string    `form:"role" validate:"required,oneof=admin operator viewer"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	_, err = h.servs.WorkspacesService.SetMember(
+		ctx, workspaceID, formData.UserID, formData.Role,
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.Refresh(c)
+}
+
+func (h *handlers) removeMemberHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	workspaceID, err := uuid.Parse(c.Param("workspaceID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	userID, err := uuid.Parse(c.Param("userID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	err = h.servs.WorkspacesService.RemoveMember(ctx, workspaceID, userID)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.Refresh(c)
+}
+
+func membersTable(item workspaceWithMembers) nodx.Node {
+	if len(item.Members) == 0 {
+		return component.PText("This workspace has no members yet.")
+	}
+
+	membersPath := fmt.Sprintf(
+		"/dashboard/workspaces/%s/members", item.Workspace.ID,
+	)
+
+	return nodx.Div(
+		nodx.Class("overflow-x-auto"),
+		nodx.Table(
+			nodx.Class("table text-nowrap"),
+			nodx.Thead(
+				nodx.Tr(
+					nodx.Th(component.SpanText("Name")),
+					nodx.Th(component.SpanText("Email")),
+					nodx.Th(component.SpanText("Role")),
+					nodx.Th(nodx.Class("w-1")),
+				),
+			),
+			nodx.Tbody(
+				nodx.Map(
+					item.Members,
+					func(member dbgen.WorkspacesServiceGetMembersRow) nodx.Node {
+						return nodx.Tr(
+							nodx.Td(component.SpanText(member.UserName)),
+							nodx.Td(component.SpanText(member.UserEmail)),
+							nodx.Td(
+								nodx.FormEl(
+									htmx.HxPost(pathutil.BuildPath(membersPath)),
+									htmx.HxTrigger("change"),
+									nodx.Input(
+										nodx.Type("hidden"),
+										nodx.Name("user_id"),
+										nodx.Value(member.UserID.String()),
+									),
+									nodx.Select(
+										nodx.Class("select select-bordered select-sm"),
+										nodx.Name("role"),
+										component.RoleSelectOptions(member.Role),
+									),
+								),
+							),
+							nodx.Td(
+								nodx.Button(
+									htmx.HxDelete(pathutil.BuildPath(
+										fmt.Sprintf("%s/%s", membersPath, member.UserID),
+									)),
+									htmx.HxConfirm("Are you sure you want to remove this member?"),
+									nodx.Class("btn btn-ghost btn-sm"),
+									component.SpanText("Remove"),
+									lucide.UserMinus(),
+								),
+							),
+						)
+					},
+				),
+			),
+		),
+	)
+}
+
+func setMemberForm(workspaceID uuid.UUID, candidates []dbgen.User) nodx.Node {
+	return nodx.FormEl(
+		htmx.HxPost(pathutil.BuildPath(
+			fmt.Sprintf("/dashboard/workspaces/%s/members", workspaceID),
+		)),
+		htmx.HxDisabledELT("find button"),
+		nodx.Class("flex items-end space-x-2"),
+
+		component.SelectControl(component.SelectControlParams{
+			Name:     "user_id",
+			Label:    "User",
+			Required: true,
+			Children: []nodx.Node{
+				nodx.Map(candidates, func(user dbgen.User) nodx.Node {
+					return nodx.Option(
+						nodx.Value(user.ID.String()),
+						nodx.Textf("%s (%s)", user.Name, user.Email),
+					)
+				}),
+			},
+		}),
+
+		component.SelectControl(component.SelectControlParams{
+			Name:     "role",
+			Label:    "Role",
+			Required: true,
+			Children: []nodx.Node{
+				component.RoleSelectOptions(users.RoleViewer),
+			},
+		}),
+
+		nodx.Button(
+			nodx.Class("btn btn-primary"),
+			nodx.Type("submit"),
+			component.SpanText("Add member"),
+			lucide.UserPlus(),
+		),
+	)
+}
@@ -0,0 +1,76 @@
+package workspaces
+
+import (
+	"fmt"
+
+	"github.com/eduardolat/pgbackweb/internal/database/dbgen"
+	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/validate"
+	"github.com/eduardolat/pgbackweb/internal/view/web/component"
+	"github.com/eduardolat/pgbackweb/internal/view/web/respondhtmx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	nodx "github.com/nodxdev/nodxgo"
+	htmx "github.com/nodxdev/nodxgo-htmx"
+	lucide "github.com/nodxdev/nodxgo-lucide"
+)
+
+func (h *handlers) renameWorkspaceHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	workspaceID, err := uuid.Parse(c.Param("workspaceID"))
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	var formData struct {
+		Name string `form:"name" validate:"required"`
+	}
+	if err := c.Bind(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+	if err := validate.Struct(&formData); err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	_, err = h.servs.WorkspacesService.RenameWorkspace(
+		ctx, workspaceID, formData.Name,
+	)
+	if err != nil {
+		return respondhtmx.ToastError(c, err.Error())
+	}
+
+	return respondhtmx.ToastSuccess(c, "Workspace renamed")
+}
+
+func renameWorkspaceForm(workspace dbgen.Workspace) nodx.Node {
+	label := "Name"
+	if workspace.IsDefault {
+		label = "Name (default workspace)"
+	}
+
+	return nodx.FormEl(
+		htmx.HxPost(pathutil.BuildPath(
+			fmt.Sprintf("/dashboard/workspaces/%s/rename", workspace.ID),
+		)),
+		htmx.HxDisabledELT("find button"),
+		nodx.Class("flex items-end space-x-2"),
+
+		component.InputControl(component.InputControlParams{
+			Name:     "name",
+			Label:    label,
+			Required: true,
+			Type:     component.InputTypeText,
+			Children: []nodx.Node{
+				nodx.Value(workspace.Name),
+			},
+		}),
+
+		nodx.Button(
+			nodx.Class("btn btn-ghost"),
+			nodx.Type("submit"),
+			component.SpanText("Rename"),
+			lucide.Save(),
+		),
+	)
+}
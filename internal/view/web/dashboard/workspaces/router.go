@@ -0,0 +1,31 @@
+package workspaces
+
+import (
+	"github.com/eduardolat/pgbackweb/internal/service"
+	"github.com/eduardolat/pgbackweb/internal/service/users"
+	"github.com/eduardolat/pgbackweb/internal/view/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+type handlers struct {
+	servs *service.Service
+}
+
+func newHandlers(servs *service.Service) *handlers {
+	return &handlers{servs: servs}
+}
+
+func MountRouter(
+	parent *echo.Group, mids *middleware.Middleware, servs *service.Service,
+) {
+	h := newHandlers(servs)
+
+	parent.Use(mids.RequirePermission(users.PermissionManageInstance))
+
+	parent.GET("", h.indexPageHandler)
+	parent.POST("", h.createWorkspaceHandler)
+	parent.POST("/:workspaceID/rename", h.renameWorkspaceHandler)
+	parent.DELETE("/:workspaceID", h.deleteWorkspaceHandler)
+	parent.POST("/:workspaceID/members", h.setMemberHandler)
+	parent.DELETE("/:workspaceID/members/:userID", h.removeMemberHandler)
+}
@@ -27,10 +27,10 @@ func Dashboard(reqCtx reqctx.Ctx, params DashboardParams) nodx.Node {
 			"w-screen h-screen bg-base-200":      true,
 			"flex justify-start overflow-hidden": true,
 		},
-		dashboardAside(),
+		dashboardAside(reqCtx),
 		nodx.Div(
 			nodx.Class("flex-grow overflow-y-auto"),
-			dashboardHeader(),
+			dashboardHeader(reqCtx),
 			nodx.Main(
 				nodx.Id("dashboard-main"),
 				nodx.Class("p-4"),
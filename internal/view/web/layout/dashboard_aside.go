@@ -3,14 +3,16 @@ package layout
 import (
 	"fmt"
 
+	"github.com/eduardolat/pgbackweb/internal/service/users"
 	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
 	nodx "github.com/nodxdev/nodxgo"
 	alpine "github.com/nodxdev/nodxgo-alpine"
 	htmx "github.com/nodxdev/nodxgo-htmx"
 	lucide "github.com/nodxdev/nodxgo-lucide"
 )
 
-func dashboardAside() nodx.Node {
+func dashboardAside(reqCtx reqctx.Ctx) nodx.Node {
 	return nodx.Aside(
 		nodx.Id("dashboard-aside"),
 		nodx.ClassMap{
@@ -92,6 +94,43 @@ func dashboardAside() nodx.Node {
 				false,
 			),
 
+			dashboardAsideItem(
+				lucide.FileChartColumn,
+				"Reports",
+				pathutil.BuildPath("/dashboard/reports"),
+				false,
+			),
+
+			nodx.If(
+				users.HasPermission(reqCtx.User.Role, users.PermissionManageInstance),
+				dashboardAsideItem(
+					lucide.Users,
+					"Users",
+					pathutil.BuildPath("/dashboard/users"),
+					false,
+				),
+			),
+
+			nodx.If(
+				users.HasPermission(reqCtx.User.Role, users.PermissionManageInstance),
+				dashboardAsideItem(
+					lucide.LayoutGrid,
+					"Workspaces",
+					pathutil.BuildPath("/dashboard/workspaces"),
+					false,
+				),
+			),
+
+			nodx.If(
+				users.HasPermission(reqCtx.User.Role, users.PermissionManageInstance),
+				dashboardAsideItem(
+					lucide.ScrollText,
+					"Audit log",
+					pathutil.BuildPath("/dashboard/audit-log"),
+					false,
+				),
+			),
+
 			dashboardAsideItem(
 				lucide.User,
 				"Profile",
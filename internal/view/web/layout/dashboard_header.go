@@ -2,13 +2,14 @@ package layout
 
 import (
 	"github.com/eduardolat/pgbackweb/internal/util/pathutil"
+	"github.com/eduardolat/pgbackweb/internal/view/reqctx"
 	"github.com/eduardolat/pgbackweb/internal/view/web/component"
 	nodx "github.com/nodxdev/nodxgo"
 	htmx "github.com/nodxdev/nodxgo-htmx"
 	lucide "github.com/nodxdev/nodxgo-lucide"
 )
 
-func dashboardHeader() nodx.Node {
+func dashboardHeader(reqCtx reqctx.Ctx) nodx.Node {
 	return nodx.Header(
 		nodx.ClassMap{
 			"sticky top-0 z-50":                 true,
@@ -28,10 +29,21 @@ func dashboardHeader() nodx.Node {
 		),
 		nodx.Div(
 			nodx.Class("flex justify-end items-center space-x-2"),
-			nodx.Div(
-				htmx.HxGet(pathutil.BuildPath("/dashboard/health-button")),
-				htmx.HxSwap("outerHTML"),
-				htmx.HxTrigger("load once"),
+			// They are not available until two-factor authentication is set up.
+			nodx.If(
+				!reqCtx.TwoFactorSetupRequired,
+				nodx.Group(
+					nodx.Div(
+						htmx.HxGet(pathutil.BuildPath("/dashboard/workspace-switcher")),
+						htmx.HxSwap("outerHTML"),
+						htmx.HxTrigger("load once"),
+					),
+					nodx.Div(
+						htmx.HxGet(pathutil.BuildPath("/dashboard/health-button")),
+						htmx.HxSwap("outerHTML"),
+						htmx.HxTrigger("load once"),
+					),
+				),
 			),
 			nodx.A(
 				nodx.Href("https://ufobackup.uforg.dev/r/community"),
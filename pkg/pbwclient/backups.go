@@ -0,0 +1,132 @@
+package pbwclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Backup is a scheduled backup task of a database.
+type Backup struct {
+	ID                     uuid.UUID  `json:"id"`
+	DatabaseID             uuid.UUID  `json:"database_id"`
+	DestinationID          *uuid.UUID `json:"destination_id"`
+	IsLocal                bool       `json:"is_local"`
+	Name                   string     `json:"name"`
+	CronExpression         string     `json:"cron_expression"`
+	TimeZone               string     `json:"time_zone"`
+	IsActive               bool       `json:"is_active"`
+	DestDir                string     `json:"dest_dir"`
+	RetentionDays          int16      `json:"retention_days"`
+	OptDataOnly            bool       `json:"opt_data_only"`
+	OptSchemaOnly          bool       `json:"opt_schema_only"`
+	OptClean               bool       `json:"opt_clean"`
+	OptIfExists            bool       `json:"opt_if_exists"`
+	OptCreate              bool       `json:"opt_create"`
+	OptNoComments          bool       `json:"opt_no_comments"`
+	MaxPartSizeMb          *int32     `json:"max_part_size_mb"`
+	CompressionLevel       *int16     `json:"compression_level"`
+	SizeDeviationThreshold int16      `json:"size_deviation_threshold"`
+	RpoHours               *int16     `json:"rpo_hours"`
+	RunAfterBackupID       *uuid.UUID `json:"run_after_backup_id"`
+	IsStale                bool       `json:"is_stale"`
+	IsProvisioned          bool       `json:"is_provisioned"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              *time.Time `json:"updated_at"`
+}
+
+// BackupInput is the data to update a backup.
+type BackupInput struct {
+	IsLocal                bool       `json:"is_local"`
+	DestinationID          *uuid.UUID `json:"destination_id"`
+	Name                   string     `json:"name"`
+	CronExpression         string     `json:"cron_expression"`
+	TimeZone               string     `json:"time_zone"`
+	IsActive               bool       `json:"is_active"`
+	DestDir                string     `json:"dest_dir"`
+	RetentionDays          int16      `json:"retention_days"`
+	OptDataOnly            bool       `json:"opt_data_only"`
+	OptSchemaOnly          bool       `json:"opt_schema_only"`
+	OptClean               bool       `json:"opt_clean"`
+	OptIfExists            bool       `json:"opt_if_exists"`
+	OptCreate              bool       `json:"opt_create"`
+	OptNoComments          bool       `json:"opt_no_comments"`
+	MaxPartSizeMb          *int32     `json:"max_part_size_mb"`
+	CompressionLevel       *int16     `json:"compression_level"`
+	SizeDeviationThreshold int16      `json:"size_deviation_threshold"`
+	RpoHours               *int16     `json:"rpo_hours"`
+	RunAfterBackupID       *uuid.UUID `json:"run_after_backup_id"`
+}
+
+// CreateBackupInput is the data to create a backup, the database can't be
+// changed once the backup is created.
+type CreateBackupInput struct {
+	DatabaseID uuid.UUID `json:"database_id"`
+	BackupInput
+}
+
+func (c *Client) ListBackups(
+	ctx context.Context, params ListParams,
+) (Page[Backup], error) {
+	var out Page[Backup]
+	err := c.do(ctx, http.MethodGet, "/backups", params.query(), nil, &out)
+	return out, err
+}
+
+func (c *Client) GetBackup(ctx context.Context, backupID uuid.UUID) (Backup, error) {
+	var out Backup
+	err := c.do(ctx, http.MethodGet, "/backups/"+backupID.String(), nil, nil, &out)
+	return out, err
+}
+
+func (c *Client) CreateBackup(
+	ctx context.Context, input CreateBackupInput,
+) (Backup, error) {
+	var out Backup
+	err := c.do(ctx, http.MethodPost, "/backups", nil, input, &out)
+	return out, err
+}
+
+func (c *Client) UpdateBackup(
+	ctx context.Context, backupID uuid.UUID, input BackupInput,
+) (Backup, error) {
+	var out Backup
+	err := c.do(ctx, http.MethodPut, "/backups/"+backupID.String(), nil, input, &out)
+	return out, err
+}
+
+func (c *Client) DeleteBackup(ctx context.Context, backupID uuid.UUID) error {
+	return c.do(ctx, http.MethodDelete, "/backups/"+backupID.String(), nil, nil, nil)
+}
+
+// RunBackup starts an execution of the backup in the background, use
+// ListExecutions to follow its progress.
+func (c *Client) RunBackup(ctx context.Context, backupID uuid.UUID) error {
+	return c.do(
+		ctx, http.MethodPost, "/backups/"+backupID.String()+"/run", nil, nil, nil,
+	)
+}
+
+func (c *Client) DuplicateBackup(
+	ctx context.Context, backupID uuid.UUID,
+) (Backup, error) {
+	var out Backup
+	err := c.do(
+		ctx, http.MethodPost, "/backups/"+backupID.String()+"/duplicate",
+		nil, nil, &out,
+	)
+	return out, err
+}
+
+func (c *Client) ToggleBackupActive(
+	ctx context.Context, backupID uuid.UUID,
+) (Backup, error) {
+	var out Backup
+	err := c.do(
+		ctx, http.MethodPost, "/backups/"+backupID.String()+"/toggle-active",
+		nil, nil, &out,
+	)
+	return out, err
+}
@@ -0,0 +1,138 @@
+// Package pbwclient is a typed Go client for the PG Back Web REST API.
+//
+// The types and methods of this package mirror the OpenAPI document served
+// at /api/v1/openapi.json.
+package pbwclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Client calls the PG Back Web REST API using a personal API token.
+type Client struct {
+	baseURL     string
+	token       string
+	workspaceID uuid.NullUUID
+	httpClient  *http.Client
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used to send the requests, by default
+// http.DefaultClient is used.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithWorkspace sets the workspace the requests work on. Without it admins
+// work on all the workspaces and the other users on their first one.
+func WithWorkspace(workspaceID uuid.UUID) Option {
+	return func(c *Client) {
+		c.workspaceID = uuid.NullUUID{UUID: workspaceID, Valid: true}
+	}
+}
+
+// New creates a new Client.
+//
+// The baseURL is the URL where PG Back Web is served including its path
+// prefix if any, e.g. https://backups.example.com/pgbackweb
+func New(baseURL, token string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Error is returned when the API responds with a non successful status.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("pbwclient: %d %s", e.StatusCode, e.Message)
+}
+
+// Message is the response of the asynchronous actions like running a backup
+// or restoring an execution.
+type Message struct {
+	Message string `json:"message"`
+}
+
+// do sends a request to the given path relative to /api/v1 and decodes the
+// JSON response into out when it is not nil.
+func (c *Client) do(
+	ctx context.Context, method, path string, query url.Values, body, out any,
+) error {
+	u := c.baseURL + "/api/v1" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("pbwclient: error encoding request body: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return fmt.Errorf("pbwclient: error creating request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if c.workspaceID.Valid {
+		req.Header.Set("X-Workspace-ID", c.workspaceID.UUID.String())
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("pbwclient: error sending request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		apiErr := &Error{StatusCode: res.StatusCode}
+		var errBody struct {
+			Error string `json:"error"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&errBody); err == nil {
+			apiErr.Message = errBody.Error
+		}
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(res.StatusCode)
+		}
+		return apiErr
+	}
+
+	if out == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("pbwclient: error decoding response body: %w", err)
+	}
+	return nil
+}
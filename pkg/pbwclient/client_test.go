@@ -0,0 +1,111 @@
+package pbwclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientSendsTokenAndDecodesResponse(t *testing.T) {
+	backupID := uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer pbw_test", r.Header.Get("Authorization"))
+			assert.Equal(t, "/prefix/api/v1/backups", r.URL.Path)
+			assert.Equal(t, "2", r.URL.Query().Get("page"))
+			assert.Equal(t, "50", r.URL.Query().Get("limit"))
+
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"pagination": map[string]any{"current_page": 2},
+				"items":      []any{map[string]any{"id": backupID, "name": "daily"}},
+			})
+		},
+	))
+	defer server.Close()
+
+	c := New(server.URL+"/prefix/", "pbw_test")
+	page, err := c.ListBackups(context.Background(), ListParams{Page: 2, Limit: 50})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, page.Pagination.CurrentPage)
+	if assert.Len(t, page.Items, 1) {
+		assert.Equal(t, backupID, page.Items[0].ID)
+		assert.Equal(t, "daily", page.Items[0].Name)
+	}
+}
+
+func TestClientSendsWorkspace(t *testing.T) {
+	workspaceID := uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, workspaceID.String(), r.Header.Get("X-Workspace-ID"))
+			w.WriteHeader(http.StatusNoContent)
+		},
+	))
+	defer server.Close()
+
+	c := New(server.URL, "pbw_test", WithWorkspace(workspaceID))
+	assert.NoError(t, c.do(context.Background(), http.MethodGet, "/", nil, nil, nil))
+}
+
+func TestClientReturnsAPIErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+	}{
+		{
+			name:        "JSON error",
+			status:      http.StatusNotFound,
+			body:        `{"error":"resource not found"}`,
+			wantMessage: "resource not found",
+		},
+		{
+			name:        "non JSON error",
+			status:      http.StatusBadGateway,
+			body:        "bad gateway",
+			wantMessage: "Bad Gateway",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(tt.status)
+					_, _ = w.Write([]byte(tt.body))
+				},
+			))
+			defer server.Close()
+
+			c := New(server.URL, "pbw_test")
+			err := c.DeleteBackup(context.Background(), uuid.New())
+
+			var apiErr *Error
+			if assert.ErrorAs(t, err, &apiErr) {
+				assert.Equal(t, tt.status, apiErr.StatusCode)
+				assert.Equal(t, tt.wantMessage, apiErr.Message)
+			}
+		})
+	}
+}
+
+func TestRestoreInputOmitsEmptyTarget(t *testing.T) {
+	databaseID := uuid.New()
+
+	b, err := json.Marshal(RestoreInput{DatabaseID: &databaseID})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"database_id":"`+databaseID.String()+`"}`, string(b))
+
+	b, err = json.Marshal(RestoreInput{ConnString: "postgresql://localhost/db"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"conn_string":"postgresql://localhost/db"}`, string(b))
+}
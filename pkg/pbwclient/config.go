@@ -0,0 +1,38 @@
+package pbwclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// ConfigChange is a single operation needed to make the server match its
+// configuration file.
+type ConfigChange struct {
+	// Action is one of create, update, delete or release.
+	Action string `json:"action"`
+	// Kind is one of database, destination, backup or webhook.
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	Fields []string `json:"fields"`
+}
+
+// ConfigPlan is the list of changes of a configuration file reconciliation.
+type ConfigPlan struct {
+	Changes []ConfigChange `json:"changes"`
+}
+
+// DiffConfig returns the changes that a reload of the configuration file
+// would apply without applying them.
+func (c *Client) DiffConfig(ctx context.Context) (ConfigPlan, error) {
+	var out ConfigPlan
+	err := c.do(ctx, http.MethodGet, "/config/diff", nil, nil, &out)
+	return out, err
+}
+
+// ReloadConfig reconciles the configuration file and returns the applied
+// changes.
+func (c *Client) ReloadConfig(ctx context.Context) (ConfigPlan, error) {
+	var out ConfigPlan
+	err := c.do(ctx, http.MethodPost, "/config/reload", nil, nil, &out)
+	return out, err
+}
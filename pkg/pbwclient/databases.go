@@ -0,0 +1,88 @@
+package pbwclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Database is a PostgreSQL database to back up, its connection string is
+// never returned by the API.
+type Database struct {
+	ID            uuid.UUID  `json:"id"`
+	Name          string     `json:"name"`
+	PgVersion     string     `json:"pg_version"`
+	TestOk        *bool      `json:"test_ok"`
+	TestError     *string    `json:"test_error"`
+	LastTestAt    *time.Time `json:"last_test_at"`
+	IsProvisioned bool       `json:"is_provisioned"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+
+	HealthCheckIntervalMinutes int32 `json:"health_check_interval_minutes"`
+	HealthFailureThreshold     int32 `json:"health_failure_threshold"`
+	HealthSuccessThreshold     int32 `json:"health_success_threshold"`
+}
+
+// DatabaseInput is the data to create or update a database.
+type DatabaseInput struct {
+	Name             string `json:"name"`
+	PgVersion        string `json:"pg_version"`
+	ConnectionString string `json:"connection_string"`
+
+	// The health settings keep their current values, or the defaults on
+	// create, when they are nil.
+	HealthCheckIntervalMinutes *int32 `json:"health_check_interval_minutes"`
+	HealthFailureThreshold     *int32 `json:"health_failure_threshold"`
+	HealthSuccessThreshold     *int32 `json:"health_success_threshold"`
+}
+
+func (c *Client) ListDatabases(
+	ctx context.Context, params ListParams,
+) (Page[Database], error) {
+	var out Page[Database]
+	err := c.do(ctx, http.MethodGet, "/databases", params.query(), nil, &out)
+	return out, err
+}
+
+func (c *Client) GetDatabase(
+	ctx context.Context, databaseID uuid.UUID,
+) (Database, error) {
+	var out Database
+	err := c.do(ctx, http.MethodGet, "/databases/"+databaseID.String(), nil, nil, &out)
+	return out, err
+}
+
+func (c *Client) CreateDatabase(
+	ctx context.Context, input DatabaseInput,
+) (Database, error) {
+	var out Database
+	err := c.do(ctx, http.MethodPost, "/databases", nil, input, &out)
+	return out, err
+}
+
+func (c *Client) UpdateDatabase(
+	ctx context.Context, databaseID uuid.UUID, input DatabaseInput,
+) (Database, error) {
+	var out Database
+	err := c.do(ctx, http.MethodPut, "/databases/"+databaseID.String(), nil, input, &out)
+	return out, err
+}
+
+func (c *Client) DeleteDatabase(ctx context.Context, databaseID uuid.UUID) error {
+	return c.do(ctx, http.MethodDelete, "/databases/"+databaseID.String(), nil, nil, nil)
+}
+
+// TestDatabase tests the connection to a database and returns it with the
+// result of the test.
+func (c *Client) TestDatabase(
+	ctx context.Context, databaseID uuid.UUID,
+) (Database, error) {
+	var out Database
+	err := c.do(
+		ctx, http.MethodPost, "/databases/"+databaseID.String()+"/test", nil, nil, &out,
+	)
+	return out, err
+}
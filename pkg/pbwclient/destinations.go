@@ -0,0 +1,106 @@
+package pbwclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Destination is an S3 compatible bucket to store backups, its keys are
+// never returned by the API.
+type Destination struct {
+	ID               uuid.UUID  `json:"id"`
+	Name             string     `json:"name"`
+	BucketName       string     `json:"bucket_name"`
+	Region           string     `json:"region"`
+	Endpoint         string     `json:"endpoint"`
+	ForcePathStyle   bool       `json:"force_path_style"`
+	SignatureVersion string     `json:"signature_version"`
+	TestOk           *bool      `json:"test_ok"`
+	TestError        *string    `json:"test_error"`
+	LastTestAt       *time.Time `json:"last_test_at"`
+	IsProvisioned    bool       `json:"is_provisioned"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
+
+	HealthCheckIntervalMinutes int32 `json:"health_check_interval_minutes"`
+	HealthFailureThreshold     int32 `json:"health_failure_threshold"`
+	HealthSuccessThreshold     int32 `json:"health_success_threshold"`
+}
+
+// DestinationInput is the data to create or update a destination.
+type DestinationInput struct {
+	Name             string `json:"name"`
+	BucketName       string `json:"bucket_name"`
+	AccessKey        string `json:"access_key"`
+	SecretKey        string `json:"secret_key"`
+	Region           string `json:"region"`
+	Endpoint         string `json:"endpoint"`
+	ForcePathStyle   bool   `json:"force_path_style"`
+	SignatureVersion string `json:"signature_version"`
+
+	// The health settings keep their current values, or the defaults on
+	// create, when they are nil.
+	HealthCheckIntervalMinutes *int32 `json:"health_check_interval_minutes"`
+	HealthFailureThreshold     *int32 `json:"health_failure_threshold"`
+	HealthSuccessThreshold     *int32 `json:"health_success_threshold"`
+}
+
+func (c *Client) ListDestinations(
+	ctx context.Context, params ListParams,
+) (Page[Destination], error) {
+	var out Page[Destination]
+	err := c.do(ctx, http.MethodGet, "/destinations", params.query(), nil, &out)
+	return out, err
+}
+
+func (c *Client) GetDestination(
+	ctx context.Context, destinationID uuid.UUID,
+) (Destination, error) {
+	var out Destination
+	err := c.do(
+		ctx, http.MethodGet, "/destinations/"+destinationID.String(), nil, nil, &out,
+	)
+	return out, err
+}
+
+func (c *Client) CreateDestination(
+	ctx context.Context, input DestinationInput,
+) (Destination, error) {
+	var out Destination
+	err := c.do(ctx, http.MethodPost, "/destinations", nil, input, &out)
+	return out, err
+}
+
+func (c *Client) UpdateDestination(
+	ctx context.Context, destinationID uuid.UUID, input DestinationInput,
+) (Destination, error) {
+	var out Destination
+	err := c.do(
+		ctx, http.MethodPut, "/destinations/"+destinationID.String(), nil, input, &out,
+	)
+	return out, err
+}
+
+func (c *Client) DeleteDestination(
+	ctx context.Context, destinationID uuid.UUID,
+) error {
+	return c.do(
+		ctx, http.MethodDelete, "/destinations/"+destinationID.String(), nil, nil, nil,
+	)
+}
+
+// TestDestination tests the connection to a destination and returns it with
+// the result of the test.
+func (c *Client) TestDestination(
+	ctx context.Context, destinationID uuid.UUID,
+) (Destination, error) {
+	var out Destination
+	err := c.do(
+		ctx, http.MethodPost, "/destinations/"+destinationID.String()+"/test",
+		nil, nil, &out,
+	)
+	return out, err
+}
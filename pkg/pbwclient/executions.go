@@ -0,0 +1,106 @@
+package pbwclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Execution is a single run of a backup.
+type Execution struct {
+	ID           uuid.UUID  `json:"id"`
+	BackupID     uuid.UUID  `json:"backup_id"`
+	Status       string     `json:"status"`
+	Message      *string    `json:"message"`
+	Path         *string    `json:"path"`
+	FileSize     *int64     `json:"file_size"`
+	IsSuspicious bool       `json:"is_suspicious"`
+	StartedAt    time.Time  `json:"started_at"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+	FinishedAt   *time.Time `json:"finished_at"`
+	DeletedAt    *time.Time `json:"deleted_at"`
+}
+
+// ListExecutionsParams filters the executions, zero IDs are ignored.
+type ListExecutionsParams struct {
+	ListParams
+	DatabaseID    uuid.UUID
+	DestinationID uuid.UUID
+	BackupID      uuid.UUID
+}
+
+// DownloadLinks are the links to download each part of an execution file.
+type DownloadLinks struct {
+	IsLocal bool     `json:"is_local"`
+	Links   []string `json:"links"`
+}
+
+// RestoreInput is the target of a restoration, either an existing database
+// or a connection string but not both.
+type RestoreInput struct {
+	DatabaseID *uuid.UUID `json:"database_id,omitempty"`
+	ConnString string     `json:"conn_string,omitempty"`
+}
+
+func (c *Client) ListExecutions(
+	ctx context.Context, params ListExecutionsParams,
+) (Page[Execution], error) {
+	query := params.query()
+	if params.DatabaseID != uuid.Nil {
+		query.Set("database_id", params.DatabaseID.String())
+	}
+	if params.DestinationID != uuid.Nil {
+		query.Set("destination_id", params.DestinationID.String())
+	}
+	if params.BackupID != uuid.Nil {
+		query.Set("backup_id", params.BackupID.String())
+	}
+
+	var out Page[Execution]
+	err := c.do(ctx, http.MethodGet, "/executions", query, nil, &out)
+	return out, err
+}
+
+func (c *Client) GetExecution(
+	ctx context.Context, executionID uuid.UUID,
+) (Execution, error) {
+	var out Execution
+	err := c.do(
+		ctx, http.MethodGet, "/executions/"+executionID.String(), nil, nil, &out,
+	)
+	return out, err
+}
+
+// DeleteExecution deletes an execution and its files.
+func (c *Client) DeleteExecution(ctx context.Context, executionID uuid.UUID) error {
+	return c.do(
+		ctx, http.MethodDelete, "/executions/"+executionID.String(), nil, nil, nil,
+	)
+}
+
+// GetExecutionDownloadLinks returns pre-signed links for files stored in S3
+// and links to the download endpoint of the API for local files. The latter
+// require the API token too.
+func (c *Client) GetExecutionDownloadLinks(
+	ctx context.Context, executionID uuid.UUID,
+) (DownloadLinks, error) {
+	var out DownloadLinks
+	err := c.do(
+		ctx, http.MethodGet, "/executions/"+executionID.String()+"/download-links",
+		nil, nil, &out,
+	)
+	return out, err
+}
+
+// RestoreExecution starts a restoration of the execution in the background,
+// use ListRestorations to follow its progress.
+func (c *Client) RestoreExecution(
+	ctx context.Context, executionID uuid.UUID, input RestoreInput,
+) error {
+	return c.do(
+		ctx, http.MethodPost, "/executions/"+executionID.String()+"/restore",
+		nil, input, nil,
+	)
+}
@@ -0,0 +1,57 @@
+package pbwclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HealthReport is the health history of a database or destination.
+type HealthReport struct {
+	Periods []HealthPeriod `json:"periods"`
+	// Checks are the latest health checks, newest first.
+	Checks []HealthCheck `json:"checks"`
+}
+
+// HealthPeriod summarizes the health checks of a period, Uptime is the
+// percentage of the checks that succeeded and Changes how many times the
+// health changed.
+type HealthPeriod struct {
+	Name         string  `json:"name"`
+	Checks       int64   `json:"checks"`
+	Uptime       float64 `json:"uptime"`
+	AvgLatencyMs int64   `json:"avg_latency_ms"`
+	MaxLatencyMs int64   `json:"max_latency_ms"`
+	Changes      int64   `json:"changes"`
+}
+
+// HealthCheck is a health check of a database or destination.
+type HealthCheck struct {
+	Ok        bool      `json:"ok"`
+	Error     *string   `json:"error"`
+	LatencyMs int32     `json:"latency_ms"`
+	Changed   bool      `json:"changed"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (c *Client) GetDatabaseHealth(
+	ctx context.Context, databaseID uuid.UUID,
+) (HealthReport, error) {
+	var out HealthReport
+	err := c.do(
+		ctx, http.MethodGet, "/databases/"+databaseID.String()+"/health", nil, nil, &out,
+	)
+	return out, err
+}
+
+func (c *Client) GetDestinationHealth(
+	ctx context.Context, destinationID uuid.UUID,
+) (HealthReport, error) {
+	var out HealthReport
+	err := c.do(
+		ctx, http.MethodGet, "/destinations/"+destinationID.String()+"/health", nil, nil, &out,
+	)
+	return out, err
+}
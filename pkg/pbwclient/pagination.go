@@ -0,0 +1,42 @@
+package pbwclient
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// ListParams are the pagination params of the list methods. Zero values use
+// the API defaults, page 1 and 20 items per page.
+type ListParams struct {
+	Page  int
+	Limit int
+}
+
+func (p ListParams) query() url.Values {
+	query := url.Values{}
+	if p.Page > 0 {
+		query.Set("page", strconv.Itoa(p.Page))
+	}
+	if p.Limit > 0 {
+		query.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return query
+}
+
+// Pagination describes the page returned by a list method.
+type Pagination struct {
+	TotalItems      int  `json:"total_items"`
+	TotalPages      int  `json:"total_pages"`
+	ItemsPerPage    int  `json:"items_per_page"`
+	PreviousPage    int  `json:"previous_page"`
+	HasPreviousPage bool `json:"has_previous_page"`
+	CurrentPage     int  `json:"current_page"`
+	NextPage        int  `json:"next_page"`
+	HasNextPage     bool `json:"has_next_page"`
+}
+
+// Page is the response of every list method.
+type Page[T any] struct {
+	Pagination Pagination `json:"pagination"`
+	Items      []T        `json:"items"`
+}
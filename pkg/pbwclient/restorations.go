@@ -0,0 +1,44 @@
+package pbwclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Restoration is a single restoration of an execution.
+type Restoration struct {
+	ID          uuid.UUID  `json:"id"`
+	ExecutionID uuid.UUID  `json:"execution_id"`
+	DatabaseID  *uuid.UUID `json:"database_id"`
+	Status      string     `json:"status"`
+	Message     *string    `json:"message"`
+	StartedAt   time.Time  `json:"started_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	FinishedAt  *time.Time `json:"finished_at"`
+}
+
+// ListRestorationsParams filters the restorations, zero IDs are ignored.
+type ListRestorationsParams struct {
+	ListParams
+	ExecutionID uuid.UUID
+	DatabaseID  uuid.UUID
+}
+
+func (c *Client) ListRestorations(
+	ctx context.Context, params ListRestorationsParams,
+) (Page[Restoration], error) {
+	query := params.query()
+	if params.ExecutionID != uuid.Nil {
+		query.Set("execution_id", params.ExecutionID.String())
+	}
+	if params.DatabaseID != uuid.Nil {
+		query.Set("database_id", params.DatabaseID.String())
+	}
+
+	var out Page[Restoration]
+	err := c.do(ctx, http.MethodGet, "/restorations", query, nil, &out)
+	return out, err
+}
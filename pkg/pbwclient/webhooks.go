@@ -0,0 +1,142 @@
+package pbwclient
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook is an HTTP request sent when an event happens to its targets.
+type Webhook struct {
+	ID        uuid.UUID   `json:"id"`
+	Name      string      `json:"name"`
+	IsActive  bool        `json:"is_active"`
+	EventType string      `json:"event_type"`
+	TargetIds []uuid.UUID `json:"target_ids"`
+	Channel   string      `json:"channel"`
+	Url       string      `json:"url"`
+	Method    string      `json:"method"`
+	Headers   *string     `json:"headers"`
+	Body      *string     `json:"body"`
+	// The HTTP client settings the requests are sent with.
+	TimeoutSeconds int32   `json:"timeout_seconds"`
+	TLSSkipVerify  bool    `json:"tls_skip_verify"`
+	CACertificate  *string `json:"ca_certificate"`
+	ProxyURL       *string `json:"proxy_url"`
+	// HasSigningSecret is true when the requests are signed, the secret
+	// itself is never returned.
+	HasSigningSecret bool       `json:"has_signing_secret"`
+	IsProvisioned    bool       `json:"is_provisioned"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        *time.Time `json:"updated_at"`
+}
+
+// WebhookInput is the data to create or update a webhook.
+type WebhookInput struct {
+	Name      string      `json:"name"`
+	EventType string      `json:"event_type"`
+	TargetIds []uuid.UUID `json:"target_ids"`
+	IsActive  bool        `json:"is_active"`
+	// Channel is webhook, slack, discord, teams or telegram, webhook if
+	// empty. Method is GET, POST, PUT, PATCH or DELETE, POST if empty.
+	Channel string `json:"channel,omitempty"`
+	Url     string `json:"url"`
+	Method  string `json:"method,omitempty"`
+	Headers string `json:"headers,omitempty"`
+	Body    string `json:"body,omitempty"`
+	// TimeoutSeconds defaults to 30. CACertificate is a PEM certificate
+	// trusted on top of the system ones, ProxyURL is an http, https or
+	// socks5 URL.
+	TimeoutSeconds int32  `json:"timeout_seconds,omitempty"`
+	TLSSkipVerify  bool   `json:"tls_skip_verify,omitempty"`
+	CACertificate  string `json:"ca_certificate,omitempty"`
+	ProxyURL       string `json:"proxy_url,omitempty"`
+	// SigningSecret signs the requests when set. On update nil keeps the
+	// current secret and an empty string removes it.
+	SigningSecret *string `json:"signing_secret,omitempty"`
+}
+
+// WebhookExecution is a request sent by a webhook and its response.
+type WebhookExecution struct {
+	ID          uuid.UUID  `json:"id"`
+	WebhookID   uuid.UUID  `json:"webhook_id"`
+	DeliveryID  *uuid.UUID `json:"delivery_id"`
+	Attempt     int32      `json:"attempt"`
+	Error       *string    `json:"error"`
+	ReqMethod   *string    `json:"req_method"`
+	ReqHeaders  *string    `json:"req_headers"`
+	ReqBody     *string    `json:"req_body"`
+	ResStatus   *int16     `json:"res_status"`
+	ResHeaders  *string    `json:"res_headers"`
+	ResBody     *string    `json:"res_body"`
+	ResDuration *int32     `json:"res_duration"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (c *Client) ListWebhooks(
+	ctx context.Context, params ListParams,
+) (Page[Webhook], error) {
+	var out Page[Webhook]
+	err := c.do(ctx, http.MethodGet, "/webhooks", params.query(), nil, &out)
+	return out, err
+}
+
+func (c *Client) GetWebhook(
+	ctx context.Context, webhookID uuid.UUID,
+) (Webhook, error) {
+	var out Webhook
+	err := c.do(ctx, http.MethodGet, "/webhooks/"+webhookID.String(), nil, nil, &out)
+	return out, err
+}
+
+func (c *Client) CreateWebhook(
+	ctx context.Context, input WebhookInput,
+) (Webhook, error) {
+	var out Webhook
+	err := c.do(ctx, http.MethodPost, "/webhooks", nil, input, &out)
+	return out, err
+}
+
+func (c *Client) UpdateWebhook(
+	ctx context.Context, webhookID uuid.UUID, input WebhookInput,
+) (Webhook, error) {
+	var out Webhook
+	err := c.do(ctx, http.MethodPut, "/webhooks/"+webhookID.String(), nil, input, &out)
+	return out, err
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, webhookID uuid.UUID) error {
+	return c.do(ctx, http.MethodDelete, "/webhooks/"+webhookID.String(), nil, nil, nil)
+}
+
+func (c *Client) DuplicateWebhook(
+	ctx context.Context, webhookID uuid.UUID,
+) (Webhook, error) {
+	var out Webhook
+	err := c.do(
+		ctx, http.MethodPost, "/webhooks/"+webhookID.String()+"/duplicate",
+		nil, nil, &out,
+	)
+	return out, err
+}
+
+// TestWebhook sends a test message with a sample event and returns an error
+// if the request fails.
+func (c *Client) TestWebhook(ctx context.Context, webhookID uuid.UUID) error {
+	return c.do(
+		ctx, http.MethodPost, "/webhooks/"+webhookID.String()+"/test", nil, nil, nil,
+	)
+}
+
+func (c *Client) ListWebhookExecutions(
+	ctx context.Context, webhookID uuid.UUID, params ListParams,
+) (Page[WebhookExecution], error) {
+	var out Page[WebhookExecution]
+	err := c.do(
+		ctx, http.MethodGet, "/webhooks/"+webhookID.String()+"/executions",
+		params.query(), nil, &out,
+	)
+	return out, err
+}